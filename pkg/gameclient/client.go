@@ -0,0 +1,304 @@
+// Package gameclient 无界面的 Go 客户端 SDK：封装经 Gateway 的登录、建房/入房 ticket 流程，
+// 以及游戏 WebSocket 的 URL 构造、GamePacket 编解码与 target_tick 估算。
+// 机器人、压测与集成测试都基于它实现：
+//
+//	c := gameclient.New("http://127.0.0.1:8080")
+//	if err := c.Login(ctx, "alice", "secret"); err != nil { ... }
+//	room, _ := c.CreateRoom(ctx, gameclient.RoomOptions{MapID: 1, MaxPlayers: 4})
+//	conn, _ := c.Connect(ctx, room, gameclient.ConnectOptions{})
+//	conn.SendMove(1, 0)
+package gameclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	pb "mygame/proto"
+)
+
+// ErrNotLoggedIn 需要登录的接口在登录前被调用
+var ErrNotLoggedIn = errors.New("gameclient: not logged in")
+
+// APIError Gateway 返回的非 2xx 响应
+type APIError struct {
+	Status  int
+	Message string // 响应中的 error 字段，没有时为原始响应体
+	Body    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gateway returned %d: %s", e.Status, e.Message)
+}
+
+// Client 一个玩家的 Gateway 会话，并发安全
+type Client struct {
+	BaseURL    string       // Gateway 地址，如 http://127.0.0.1:8080
+	HTTPClient *http.Client // 为 nil 时使用 http.DefaultClient
+
+	mu           sync.RWMutex
+	uid          int64
+	username     string
+	token        string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+// Room 创建、加入或观战房间后得到的入场信息
+type Room struct {
+	ID         string
+	Ticket     string // 连接游戏 WebSocket 使用的 room token
+	InviteCode string // 仅创建房间时返回
+	Observer   bool   // 观战 ticket
+}
+
+// RoomOptions 建房参数，对应 POST /api/match/create
+type RoomOptions struct {
+	Name       string `json:"room_name"`
+	MapID      int32  `json:"map_id"`
+	MaxPlayers int32  `json:"max_players"`
+	Visibility string `json:"visibility"` // public / private
+	Password   string `json:"password"`
+	Mode       string `json:"mode"` // ffa / tdm
+}
+
+// New 创建未登录的客户端
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// UID 登录后的玩家 ID
+func (c *Client) UID() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.uid
+}
+
+// Username 登录后的用户名
+func (c *Client) Username() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.username
+}
+
+// Token 当前的 access token
+func (c *Client) Token() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// ExpiresAt access token 的过期时间，到期前应调用 Refresh
+func (c *Client) ExpiresAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.expiresAt
+}
+
+// Register 注册账号，返回 uid；不会自动登录
+func (c *Client) Register(ctx context.Context, username, password string) (int64, error) {
+	var resp struct {
+		UID int64 `json:"uid"`
+	}
+	body := map[string]string{"username": username, "password": password}
+	if err := c.do(ctx, http.MethodPost, "/api/auth/register", "", body, &resp); err != nil {
+		return 0, fmt.Errorf("register %s: %w", username, err)
+	}
+	return resp.UID, nil
+}
+
+// Login 登录并保存令牌
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	body := map[string]string{"username": username, "password": password}
+	if err := c.authenticate(ctx, "/api/auth/login", body); err != nil {
+		return fmt.Errorf("login %s: %w", username, err)
+	}
+	return nil
+}
+
+// Refresh 用 refresh token 换取新的令牌对
+func (c *Client) Refresh(ctx context.Context) error {
+	c.mu.RLock()
+	refreshToken := c.refreshToken
+	c.mu.RUnlock()
+	if refreshToken == "" {
+		return ErrNotLoggedIn
+	}
+	body := map[string]string{"refresh_token": refreshToken}
+	if err := c.authenticate(ctx, "/api/auth/refresh", body); err != nil {
+		return fmt.Errorf("refresh token: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) authenticate(ctx context.Context, path string, body interface{}) error {
+	var resp struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		UID          int64  `json:"uid"`
+		Username     string `json:"username"`
+	}
+	if err := c.do(ctx, http.MethodPost, path, "", body, &resp); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.uid = resp.UID
+	c.username = resp.Username
+	c.token = resp.Token
+	c.refreshToken = resp.RefreshToken
+	c.expiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	return nil
+}
+
+// Do 以当前登录身份调用 Gateway 的 JSON 接口，out 为 nil 时忽略响应体
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	token := c.Token()
+	if token == "" {
+		return ErrNotLoggedIn
+	}
+	return c.do(ctx, method, path, token, body, out)
+}
+
+// CreateRoom 创建房间，创建者自动入座
+func (c *Client) CreateRoom(ctx context.Context, opts RoomOptions) (*Room, error) {
+	var resp struct {
+		RoomID     string `json:"room_id"`
+		Ticket     string `json:"ticket"`
+		InviteCode string `json:"invite_code"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/match/create", opts, &resp); err != nil {
+		return nil, fmt.Errorf("create room: %w", err)
+	}
+	return &Room{ID: resp.RoomID, Ticket: resp.Ticket, InviteCode: resp.InviteCode}, nil
+}
+
+// JoinRoom 按房间 ID 加入房间，password 仅私密房间需要
+func (c *Client) JoinRoom(ctx context.Context, roomID, password string) (*Room, error) {
+	return c.join(ctx, map[string]string{"room_id": roomID, "password": password})
+}
+
+// JoinByInvite 按邀请码加入房间
+func (c *Client) JoinByInvite(ctx context.Context, inviteCode string) (*Room, error) {
+	return c.join(ctx, map[string]string{"invite_code": inviteCode})
+}
+
+func (c *Client) join(ctx context.Context, body map[string]string) (*Room, error) {
+	var resp struct {
+		RoomID string `json:"room_id"`
+		Ticket string `json:"ticket"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/match/join", body, &resp); err != nil {
+		return nil, fmt.Errorf("join room: %w", err)
+	}
+	return &Room{ID: resp.RoomID, Ticket: resp.Ticket}, nil
+}
+
+// Observe 以观战者身份进入房间
+func (c *Client) Observe(ctx context.Context, roomID string) (*Room, error) {
+	var resp struct {
+		RoomID string `json:"room_id"`
+		Ticket string `json:"ticket"`
+	}
+	body := map[string]string{"room_id": roomID}
+	if err := c.Do(ctx, http.MethodPost, "/api/match/observe", body, &resp); err != nil {
+		return nil, fmt.Errorf("observe room %s: %w", roomID, err)
+	}
+	return &Room{ID: resp.RoomID, Ticket: resp.Ticket, Observer: true}, nil
+}
+
+// LeaveRoom 离开房间
+func (c *Client) LeaveRoom(ctx context.Context, roomID string) error {
+	body := map[string]string{"room_id": roomID}
+	if err := c.Do(ctx, http.MethodPost, "/api/match/leave", body, nil); err != nil {
+		return fmt.Errorf("leave room %s: %w", roomID, err)
+	}
+	return nil
+}
+
+// StartMatch 房主开局，返回倒计时秒数
+func (c *Client) StartMatch(ctx context.Context, roomID string) (int, error) {
+	var resp struct {
+		CountdownSeconds int `json:"countdown_seconds"`
+	}
+	body := map[string]string{"room_id": roomID}
+	if err := c.Do(ctx, http.MethodPost, "/api/match/start", body, &resp); err != nil {
+		return 0, fmt.Errorf("start match %s: %w", roomID, err)
+	}
+	return resp.CountdownSeconds, nil
+}
+
+// History 最近的对局记录
+func (c *Client) History(ctx context.Context) ([]*pb.MatchRecord, error) {
+	var resp struct {
+		History []*pb.MatchRecord `json:"history"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/user/history", nil, &resp); err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	return resp.History, nil
+}
+
+// do 发送 JSON 请求，token 不为空时携带 Bearer 头
+func (c *Client) do(ctx context.Context, method, path, token string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		apiErr := &APIError{Status: resp.StatusCode, Message: string(data), Body: string(data)}
+		var e struct {
+			Error   string `json:"error"`
+			Details string `json:"details"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error != "" {
+			apiErr.Message = e.Error
+			if e.Details != "" {
+				apiErr.Message += ": " + e.Details
+			}
+		}
+		return apiErr
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
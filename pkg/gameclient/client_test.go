@@ -0,0 +1,186 @@
+package gameclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "mygame/proto"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeGateway 模拟 Gateway：登录、建房与 /ws/game，收到的输入写入 inputs
+type fakeGateway struct {
+	inputs chan *pb.C2SInput
+}
+
+func (g *fakeGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authorized := r.Header.Get("Authorization") == "Bearer access"
+	switch {
+	case r.URL.Path == "/api/auth/login":
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["password"] != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid credentials","details":"wrong password"}`))
+			return
+		}
+		w.Write([]byte(`{"token":"access","refresh_token":"refresh","expires_in":900,"uid":7,"username":"alice"}`))
+	case !authorized:
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("unauthorized"))
+	case r.URL.Path == "/api/match/create":
+		w.Write([]byte(`{"room_id":"r1","ticket":"t1","invite_code":"ABC234"}`))
+	case r.URL.Path == "/ws/game":
+		q := r.URL.Query()
+		if q.Get("room_id") != "r1" || q.Get("token") != "t1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		g.serveGame(w, r)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// serveGame 连接后立即推送一个快照，之后转发客户端的输入
+func (g *fakeGateway) serveGame(w http.ResponseWriter, r *http.Request) {
+	ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	snapshot, _ := proto.Marshal(&pb.GamePacket{Payload: &pb.GamePacket_Snapshot{Snapshot: &pb.S2CSnapshot{
+		Tick:       100,
+		ServerTime: time.Now().UnixMilli(),
+		Players:    []*pb.PlayerState{{Uid: 7}},
+	}}})
+	ws.WriteMessage(websocket.BinaryMessage, snapshot)
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var packet pb.GamePacket
+		if proto.Unmarshal(data, &packet) == nil && packet.GetInput() != nil {
+			g.inputs <- packet.GetInput()
+		}
+	}
+}
+
+func TestClientAPI(t *testing.T) {
+	srv := httptest.NewServer(&fakeGateway{})
+	defer srv.Close()
+	ctx := context.Background()
+
+	c := New(srv.URL + "/")
+	if _, err := c.CreateRoom(ctx, RoomOptions{}); !errors.Is(err, ErrNotLoggedIn) {
+		t.Fatalf("create before login = %v, want ErrNotLoggedIn", err)
+	}
+
+	tests := []struct {
+		name       string
+		password   string
+		wantStatus int
+		wantMsg    string
+	}{
+		{name: "wrong password", password: "guess", wantStatus: http.StatusUnauthorized, wantMsg: "invalid credentials: wrong password"},
+		{name: "login", password: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.Login(ctx, "alice", tt.password)
+			var apiErr *APIError
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.As(err, &apiErr) || apiErr.Status != tt.wantStatus || apiErr.Message != tt.wantMsg {
+				t.Fatalf("login = %v, want %d %q", err, tt.wantStatus, tt.wantMsg)
+			}
+		})
+	}
+	if c.UID() != 7 || c.Username() != "alice" || c.Token() != "access" || time.Until(c.ExpiresAt()) < 14*time.Minute {
+		t.Fatalf("session = %d %s %s %v", c.UID(), c.Username(), c.Token(), c.ExpiresAt())
+	}
+
+	room, err := c.CreateRoom(ctx, RoomOptions{MapID: 1, MaxPlayers: 4})
+	if err != nil || *room != (Room{ID: "r1", Ticket: "t1", InviteCode: "ABC234"}) {
+		t.Fatalf("create = %+v, %v", room, err)
+	}
+	// 非 JSON 的错误响应保留原文
+	var apiErr *APIError
+	if err := c.Do(ctx, http.MethodGet, "/missing", nil, nil); !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("missing path = %v", err)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	gw := &fakeGateway{inputs: make(chan *pb.C2SInput, 1)}
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	c := New(srv.URL)
+	if err := c.Login(ctx, "alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Connect(ctx, &Room{ID: "r1", Ticket: "stale"}, ConnectOptions{}); err == nil {
+		t.Fatal("connected with a stale ticket")
+	}
+
+	closed := make(chan error, 1)
+	conn, err := c.Connect(ctx, &Room{ID: "r1", Ticket: "t1"}, ConnectOptions{
+		TickRate:     10,
+		PingInterval: -1,
+		Handler:      Handler{OnClose: func(err error) { closed <- err }},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1. 收到快照后可以读取自己的状态，时钟以快照 tick 为基准
+	snapshot, err := conn.NextSnapshot(ctx, 0)
+	if err != nil || snapshot.Tick != 100 {
+		t.Fatalf("snapshot = %v, %v", snapshot, err)
+	}
+	if self := conn.Self(); self == nil || self.Uid != 7 {
+		t.Fatalf("self = %v", self)
+	}
+
+	// 2. 输入自动填充时间戳与 target_tick
+	if err := conn.SendMove(1, 0); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case input := <-gw.inputs:
+		if input.Move.GetDx() != 1 || input.Timestamp == 0 || input.TargetTick < 99 {
+			t.Fatalf("input = %v", input)
+		}
+	case <-ctx.Done():
+		t.Fatal("input not received")
+	}
+	if s := conn.Stats(); s.Snapshots != 1 || s.PacketsOut != 1 {
+		t.Fatalf("stats = %+v", s)
+	}
+
+	// 3. 主动关闭后等待者立即返回
+	conn.Close()
+	if err := <-closed; !errors.Is(err, ErrConnClosed) {
+		t.Fatalf("close reason = %v", err)
+	}
+	if _, err := conn.WaitEvent(ctx, pb.GameEvent_GAME_OVER); err == nil {
+		t.Fatal("WaitEvent returned without an event on a closed connection")
+	}
+}
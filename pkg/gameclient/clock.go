@@ -0,0 +1,118 @@
+package gameclient
+
+import (
+	"sync"
+	"time"
+)
+
+// 与 game-service 的 tick 参数一致
+const (
+	DefaultTickRate   = 64 // Hz
+	DelayCompensation = 2  // 服务端在 CurrentTick - 2 执行输入
+)
+
+// Clock 根据收到的快照与 ping 往返时间估算服务端的时钟与 tick。
+// 服务端只接受 target_tick 落在执行 tick 前后 2 tick 内的输入，
+// 因此 target_tick 需要按到达服务端时的 tick 计算，而不是直接使用最近一次快照的 tick
+type Clock struct {
+	tickDuration time.Duration
+
+	mu      sync.RWMutex
+	tick    int64         // 最近快照的 tick
+	tickAt  time.Time     // 收到该快照的本地时间
+	offset  time.Duration // 服务端时间 - 本地时间（平滑后）
+	rtt     time.Duration // 往返时间（平滑后）
+	samples int
+}
+
+// NewClock tickRate <= 0 时使用 DefaultTickRate
+func NewClock(tickRate int) *Clock {
+	if tickRate <= 0 {
+		tickRate = DefaultTickRate
+	}
+	return &Clock{tickDuration: time.Second / time.Duration(tickRate)}
+}
+
+// TickDuration 每个 tick 的时长
+func (c *Clock) TickDuration() time.Duration {
+	return c.tickDuration
+}
+
+// ObserveSnapshot 记录一次快照；tick 不大于已记录值的快照（如连接时的初始包）只用于校时
+func (c *Clock) ObserveSnapshot(tick, serverTimeMs int64, receivedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 1. 服务端时间偏移：快照在路上走了约半个 RTT
+	if serverTimeMs > 0 {
+		sample := time.UnixMilli(serverTimeMs).Add(c.rtt / 2).Sub(receivedAt)
+		if c.samples == 0 {
+			c.offset = sample
+		} else {
+			c.offset += (sample - c.offset) / 8
+		}
+		c.samples++
+	}
+
+	// 2. tick 基准
+	if tick > c.tick {
+		c.tick = tick
+		c.tickAt = receivedAt
+	}
+}
+
+// ObserveRTT 记录一次 ping 往返时间
+func (c *Clock) ObserveRTT(rtt time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rtt == 0 {
+		c.rtt = rtt
+		return
+	}
+	c.rtt += (rtt - c.rtt) / 8
+}
+
+// RTT 平滑后的往返时间，尚未测量时为 0
+func (c *Clock) RTT() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.rtt
+}
+
+// ServerTime 估算的服务端当前时间
+func (c *Clock) ServerTime() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// Tick 估算的服务端当前 tick，尚未收到快照时为 0
+func (c *Clock) Tick() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tickAtTime(time.Now())
+}
+
+// TargetTick 现在发送的输入应携带的 target_tick：
+// 输入在到达服务端后的下一个 tick 执行，减去服务端的固定延迟补偿
+func (c *Clock) TargetTick() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.tick == 0 {
+		return 0
+	}
+	target := c.tickAtTime(time.Now().Add(c.rtt/2)) + 1 - DelayCompensation
+	if target < 1 {
+		target = 1
+	}
+	return target
+}
+
+// tickAtTime 快照 tick 加上此后经过的时间，快照本身在路上走了约半个 RTT
+func (c *Clock) tickAtTime(t time.Time) int64 {
+	if c.tick == 0 {
+		return 0
+	}
+	elapsed := t.Sub(c.tickAt) + c.rtt/2
+	return c.tick + int64(elapsed/c.tickDuration)
+}
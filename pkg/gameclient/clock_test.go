@@ -0,0 +1,61 @@
+package gameclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockTargetTick(t *testing.T) {
+	// 10Hz，每个 tick 100ms；快照均在 50ms 前收到，避开 tick 边界
+	tests := []struct {
+		name       string
+		ticks      []int64
+		rtt        time.Duration
+		wantTick   int64
+		wantTarget int64
+	}{
+		{name: "no snapshot yet", wantTick: 0, wantTarget: 0},
+		{name: "fresh snapshot", ticks: []int64{100}, wantTick: 100, wantTarget: 99},
+		{name: "half rtt in flight each way", ticks: []int64{100}, rtt: 400 * time.Millisecond, wantTick: 102, wantTarget: 103},
+		{name: "older snapshot ignored", ticks: []int64{100, 90}, wantTick: 100, wantTarget: 99},
+		{name: "target never below 1", ticks: []int64{1}, wantTick: 1, wantTarget: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClock(10)
+			if tt.rtt > 0 {
+				c.ObserveRTT(tt.rtt)
+			}
+			receivedAt := time.Now().Add(-50 * time.Millisecond)
+			for _, tick := range tt.ticks {
+				c.ObserveSnapshot(tick, 0, receivedAt)
+			}
+			if got := c.Tick(); got != tt.wantTick {
+				t.Fatalf("Tick = %d, want %d", got, tt.wantTick)
+			}
+			if got := c.TargetTick(); got != tt.wantTarget {
+				t.Fatalf("TargetTick = %d, want %d", got, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestClockSmoothing(t *testing.T) {
+	c := NewClock(0)
+	if c.TickDuration() != time.Second/DefaultTickRate {
+		t.Fatalf("tick duration = %v", c.TickDuration())
+	}
+
+	// 首个样本直接采用，之后每次向新样本移动 1/8
+	c.ObserveRTT(80 * time.Millisecond)
+	c.ObserveRTT(160 * time.Millisecond)
+	if rtt := c.RTT(); rtt != 90*time.Millisecond {
+		t.Fatalf("RTT = %v, want 90ms", rtt)
+	}
+
+	ahead := time.Now().Add(time.Hour)
+	c.ObserveSnapshot(1, ahead.UnixMilli(), time.Now())
+	if d := c.ServerTime().Sub(ahead); d < 0 || d > time.Second {
+		t.Fatalf("server time off by %v", d)
+	}
+}
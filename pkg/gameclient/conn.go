@@ -0,0 +1,389 @@
+package gameclient
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "mygame/proto"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// 瞄准方向，与 ChargeCmd.angle 一致
+const (
+	AngleRight int32 = 0 // +x
+	AngleUp    int32 = 1 // +y
+	AngleLeft  int32 = 2 // -x
+	AngleDown  int32 = 3 // -y
+)
+
+const (
+	writeTimeout        = 10 * time.Second
+	defaultPingInterval = time.Second
+)
+
+// ErrConnClosed 连接已关闭
+var ErrConnClosed = errors.New("gameclient: connection closed")
+
+// Handler 连接上的回调，均在读协程中调用，不能阻塞
+type Handler struct {
+	OnSnapshot func(snapshot *pb.S2CSnapshot)
+	OnEvent    func(event *pb.GameEvent)
+	OnClose    func(err error)
+}
+
+// ConnectOptions 连接参数，零值即可使用
+type ConnectOptions struct {
+	Handler
+	TickRate     int           // 服务端 tick 频率，默认 64
+	PingInterval time.Duration // 测量 RTT 的 ping 间隔，默认 1s，负数表示不测量
+	Dialer       *websocket.Dialer
+}
+
+// Stats 连接的累计流量，字节数为 WebSocket 消息体大小
+type Stats struct {
+	PacketsIn     int64
+	BytesIn       int64
+	Snapshots     int64
+	SnapshotBytes int64
+	PacketsOut    int64
+	BytesOut      int64
+}
+
+// Conn 经 Gateway 代理的游戏 WebSocket 连接
+type Conn struct {
+	uid     int64
+	ws      *websocket.Conn
+	clock   *Clock
+	handler Handler
+
+	wmu sync.Mutex
+
+	packetsIn, bytesIn       atomic.Int64
+	snapshots, snapshotBytes atomic.Int64
+	packetsOut, bytesOut     atomic.Int64
+
+	mu       sync.Mutex
+	snapshot *pb.S2CSnapshot
+	events   []*pb.GameEvent
+	changed  chan struct{} // 每收到一个包关闭并替换，用于唤醒等待者
+	err      error         // 读循环退出的原因
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Connect 使用入场 ticket 连接房间所在的 Game Server（经 Gateway /ws/game 代理）
+func (c *Client) Connect(ctx context.Context, room *Room, opts ConnectOptions) (*Conn, error) {
+	token := c.Token()
+	if token == "" {
+		return nil, ErrNotLoggedIn
+	}
+
+	// 1. ws(s)://gateway/ws/game?room_id=&token=<ticket>，用户 JWT 放在 Authorization 头
+	query := url.Values{}
+	query.Set("room_id", room.ID)
+	query.Set("token", room.Ticket)
+	if room.Observer {
+		query.Set("observer", "1")
+	}
+	target := c.BaseURL + "/ws/game?" + query.Encode()
+	switch {
+	case strings.HasPrefix(target, "https://"):
+		target = "wss://" + strings.TrimPrefix(target, "https://")
+	case strings.HasPrefix(target, "http://"):
+		target = "ws://" + strings.TrimPrefix(target, "http://")
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token)
+
+	dialer := opts.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	ws, resp, err := dialer.DialContext(ctx, target, header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("connect room %s: %v (status %d)", room.ID, err, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("connect room %s: %v", room.ID, err)
+	}
+
+	// 2. 启动读协程与 ping
+	conn := &Conn{
+		uid:     c.UID(),
+		ws:      ws,
+		clock:   NewClock(opts.TickRate),
+		handler: opts.Handler,
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	ws.SetPongHandler(conn.handlePong)
+	go conn.readLoop()
+
+	interval := opts.PingInterval
+	if interval == 0 {
+		interval = defaultPingInterval
+	}
+	if interval > 0 {
+		go conn.pingLoop(interval)
+	}
+	return conn, nil
+}
+
+func (c *Conn) readLoop() {
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			c.shutdown(err)
+			return
+		}
+		c.packetsIn.Add(1)
+		c.bytesIn.Add(int64(len(data)))
+
+		var packet pb.GamePacket
+		if err := proto.Unmarshal(data, &packet); err != nil {
+			continue
+		}
+		switch p := packet.Payload.(type) {
+		case *pb.GamePacket_Snapshot:
+			c.snapshots.Add(1)
+			c.snapshotBytes.Add(int64(len(data)))
+			c.clock.ObserveSnapshot(p.Snapshot.Tick, p.Snapshot.ServerTime, time.Now())
+			c.update(func() { c.snapshot = p.Snapshot })
+			if c.handler.OnSnapshot != nil {
+				c.handler.OnSnapshot(p.Snapshot)
+			}
+		case *pb.GamePacket_Event:
+			c.update(func() { c.events = append(c.events, p.Event) })
+			if c.handler.OnEvent != nil {
+				c.handler.OnEvent(p.Event)
+			}
+		}
+	}
+}
+
+// update 修改状态并唤醒等待者
+func (c *Conn) update(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fn()
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// pingLoop ping 载荷为发送时的纳秒时间戳，由 Gateway 回 pong
+func (c *Conn) pingLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		payload := make([]byte, 8)
+		binary.BigEndian.PutUint64(payload, uint64(time.Now().UnixNano()))
+		if err := c.ws.WriteControl(websocket.PingMessage, payload, time.Now().Add(writeTimeout)); err != nil {
+			return
+		}
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Conn) handlePong(data string) error {
+	if len(data) == 8 {
+		sent := time.Unix(0, int64(binary.BigEndian.Uint64([]byte(data))))
+		c.clock.ObserveRTT(time.Since(sent))
+	}
+	return nil
+}
+
+func (c *Conn) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		close(c.changed)
+		c.mu.Unlock()
+		close(c.done)
+		if c.handler.OnClose != nil {
+			c.handler.OnClose(err)
+		}
+	})
+}
+
+// Close 主动断开连接
+func (c *Conn) Close() error {
+	c.wmu.Lock()
+	c.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	c.wmu.Unlock()
+	// 先记录关闭原因，避免读协程以 use of closed network connection 抢先关闭
+	c.shutdown(ErrConnClosed)
+	return c.ws.Close()
+}
+
+// Done 连接关闭后关闭
+func (c *Conn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err 连接关闭的原因，连接仍可用时为 nil
+func (c *Conn) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// UID 该连接对应的玩家
+func (c *Conn) UID() int64 {
+	return c.uid
+}
+
+// Clock 该连接的时钟估算
+func (c *Conn) Clock() *Clock {
+	return c.clock
+}
+
+// Stats 连接建立以来的流量统计
+func (c *Conn) Stats() Stats {
+	return Stats{
+		PacketsIn:     c.packetsIn.Load(),
+		BytesIn:       c.bytesIn.Load(),
+		Snapshots:     c.snapshots.Load(),
+		SnapshotBytes: c.snapshotBytes.Load(),
+		PacketsOut:    c.packetsOut.Load(),
+		BytesOut:      c.bytesOut.Load(),
+	}
+}
+
+// Snapshot 最近一次收到的快照，尚未收到时为 nil
+func (c *Conn) Snapshot() *pb.S2CSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.snapshot
+}
+
+// Events 已收到的全部事件
+func (c *Conn) Events() []*pb.GameEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*pb.GameEvent(nil), c.events...)
+}
+
+// Player 最近快照中指定玩家的状态
+func (c *Conn) Player(uid int64) *pb.PlayerState {
+	snapshot := c.Snapshot()
+	if snapshot == nil {
+		return nil
+	}
+	for _, p := range snapshot.Players {
+		if p.Uid == uid {
+			return p
+		}
+	}
+	return nil
+}
+
+// Self 最近快照中自己的状态
+func (c *Conn) Self() *pb.PlayerState {
+	return c.Player(c.uid)
+}
+
+// WaitFor 等待直到 cond 对最近的快照与事件成立；cond 在持锁时调用，不能调用 Conn 的其他方法
+func (c *Conn) WaitFor(ctx context.Context, cond func(snapshot *pb.S2CSnapshot, events []*pb.GameEvent) bool) error {
+	for {
+		c.mu.Lock()
+		ok := cond(c.snapshot, c.events)
+		changed, err := c.changed, c.err
+		c.mu.Unlock()
+		if ok {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("connection closed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// WaitEvent 等待指定类型的事件（包括已经收到的），返回第一个匹配的事件
+func (c *Conn) WaitEvent(ctx context.Context, typ pb.GameEvent_EventType) (*pb.GameEvent, error) {
+	var found *pb.GameEvent
+	err := c.WaitFor(ctx, func(_ *pb.S2CSnapshot, events []*pb.GameEvent) bool {
+		for _, e := range events {
+			if e.Type == typ {
+				found = e
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wait for %s: %v", typ, err)
+	}
+	return found, nil
+}
+
+// NextSnapshot 等待 tick 大于 after 的快照
+func (c *Conn) NextSnapshot(ctx context.Context, after int64) (*pb.S2CSnapshot, error) {
+	var snapshot *pb.S2CSnapshot
+	err := c.WaitFor(ctx, func(s *pb.S2CSnapshot, _ []*pb.GameEvent) bool {
+		snapshot = s
+		return s != nil && s.Tick > after
+	})
+	return snapshot, err
+}
+
+// Send 发送一次操作；未设置 Timestamp / TargetTick 时按时钟估算填充
+func (c *Conn) Send(input *pb.C2SInput) error {
+	if input.Timestamp == 0 {
+		input.Timestamp = time.Now().UnixMilli()
+	}
+	if input.TargetTick == 0 {
+		input.TargetTick = c.clock.TargetTick()
+	}
+	data, err := proto.Marshal(&pb.GamePacket{
+		Payload: &pb.GamePacket_Input{Input: input},
+	})
+	if err != nil {
+		return err
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := c.ws.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return err
+	}
+	c.packetsOut.Add(1)
+	c.bytesOut.Add(int64(len(data)))
+	return nil
+}
+
+// SendMove 移动一个 tick，dx / dy 取值 -1 到 1
+func (c *Conn) SendMove(dx, dy float32) error {
+	return c.Send(&pb.C2SInput{Move: &pb.MoveCmd{Dx: dx, Dy: dy}})
+}
+
+// StartCharge 朝 angle 方向开始蓄力，蓄力越久伤害越高
+func (c *Conn) StartCharge(angle int32) error {
+	return c.Send(&pb.C2SInput{Charge: &pb.ChargeCmd{IsCharging: true, Angle: angle}})
+}
+
+// ReleaseCharge 松开蓄力，发射光柱
+func (c *Conn) ReleaseCharge() error {
+	return c.Send(&pb.C2SInput{Charge: &pb.ChargeCmd{IsCharging: false}})
+}
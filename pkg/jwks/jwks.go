@@ -0,0 +1,202 @@
+// Package jwks 各服务共用的 JWKS 编解码与公钥缓存。
+// User Service 用私钥签发 JWT（header 带 kid），Gateway / Game Service 只持有公钥验签。
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWK RFC 7517 公钥，只支持 RSA (RS256) 与 Ed25519 (EdDSA)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n,omitempty"`   // RSA modulus
+	E   string `json:"e,omitempty"`   // RSA exponent
+	Crv string `json:"crv,omitempty"` // OKP curve
+	X   string `json:"x,omitempty"`   // OKP public key
+}
+
+// Document /.well-known/jwks.json 的内容
+type Document struct {
+	Keys []JWK `json:"keys"`
+}
+
+// FromPublicKey 公钥 -> JWK
+func FromPublicKey(kid string, key interface{}) (JWK, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Kid: kid,
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k),
+		}, nil
+	default:
+		return JWK{}, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// PublicKey JWK -> 公钥
+func (k JWK) PublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %v", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %v", err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
+
+// FetchFunc 拉取最新的 JWKS（Gateway 走 gRPC，Game Service 走 HTTP）
+type FetchFunc func(ctx context.Context) ([]JWK, error)
+
+// 遇到未知 kid 时最多这么频繁地重新拉取，防止伪造 kid 打爆 User Service
+const minRefetchInterval = 10 * time.Second
+
+// Store 缓存公钥，定期刷新；新 key 上线后第一次遇到其 kid 会触发一次拉取
+type Store struct {
+	fetch FetchFunc
+
+	mu        sync.RWMutex
+	keys      map[string]interface{}
+	doc       []JWK
+	lastFetch time.Time
+}
+
+func NewStore(fetch FetchFunc) *Store {
+	return &Store{fetch: fetch, keys: make(map[string]interface{})}
+}
+
+// Refresh 拉取并替换全部公钥
+func (s *Store) Refresh(ctx context.Context) error {
+	s.mu.Lock()
+	s.lastFetch = time.Now()
+	s.mu.Unlock()
+
+	doc, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]interface{}, len(doc))
+	for _, k := range doc {
+		pub, err := k.PublicKey()
+		if err != nil {
+			log.Printf("Skip JWK %s: %v", k.Kid, err)
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.doc = doc
+	s.mu.Unlock()
+	return nil
+}
+
+// StartRefresh 后台定期刷新
+func (s *Store) StartRefresh(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("Refresh JWKS failed: %v", err)
+			}
+			cancel()
+		}
+	}()
+}
+
+// Keys 当前缓存的 JWKS
+func (s *Store) Keys() []JWK {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.doc
+}
+
+// Keyfunc 供 jwt.Parse 使用：按 header 中的 kid 取公钥，并校验算法与 key 类型一致
+func (s *Store) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("missing kid")
+	}
+
+	key, ok := s.lookup(kid)
+	if !ok {
+		// 可能是刚轮换上线的新 key
+		s.mu.RLock()
+		stale := time.Since(s.lastFetch) > minRefetchInterval
+		s.mu.RUnlock()
+		if stale {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.Refresh(ctx); err != nil {
+				return nil, err
+			}
+			key, ok = s.lookup(kid)
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %s", kid)
+		}
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	case ed25519.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	}
+	return key, nil
+}
+
+func (s *Store) lookup(kid string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[kid]
+	return key, ok
+}
@@ -0,0 +1,228 @@
+// Package bustest messaging.Bus 的公共行为测试，各后端以自己的实现运行同一套用例
+package bustest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"mygame/pkg/messaging"
+)
+
+// waitTimeout 等待消息送达的上限，redisstream 每轮最多阻塞 1s 读取
+const waitTimeout = 10 * time.Second
+
+// Run 对 open 返回的总线运行全部用例，每个用例使用一个新的空总线
+func Run(t *testing.T, open func(t *testing.T) messaging.Bus) {
+	t.Run("Deliver", func(t *testing.T) { testDeliver(t, open(t)) })
+	t.Run("WorkQueue", func(t *testing.T) { testWorkQueue(t, open(t)) })
+	t.Run("Retry", func(t *testing.T) { testRetry(t, open(t)) })
+	t.Run("DeadLetter", func(t *testing.T) { testDeadLetter(t, open(t)) })
+	t.Run("Replay", func(t *testing.T) { testReplay(t, open(t)) })
+}
+
+// subscribe 在后台消费 topic，用例结束时停止
+func subscribe(t *testing.T, bus messaging.Bus, topic string, h messaging.Handler, opts ...messaging.SubscribeOption) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bus.Subscribe(ctx, topic, h, opts...)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func publish(t *testing.T, bus messaging.Bus, topic string, ids ...string) {
+	t.Helper()
+	for _, id := range ids {
+		msg := &messaging.Message{ID: id, Body: []byte(fmt.Sprintf(`{"id":%q}`, id)), Headers: map[string]string{"k": "v"}}
+		if err := bus.Publish(context.Background(), topic, msg); err != nil {
+			t.Fatalf("publish %s: %v", id, err)
+		}
+	}
+}
+
+// collect 收集处理成功的消息
+type collect struct {
+	mu   sync.Mutex
+	msgs []*messaging.Message
+	got  chan struct{}
+}
+
+func newCollect() *collect {
+	return &collect{got: make(chan struct{}, 100)}
+}
+
+func (c *collect) handle(ctx context.Context, msg *messaging.Message) error {
+	c.mu.Lock()
+	c.msgs = append(c.msgs, msg)
+	c.mu.Unlock()
+	c.got <- struct{}{}
+	return nil
+}
+
+// wait 等待 n 条消息，返回已收到的全部消息
+func (c *collect) wait(t *testing.T, n int) []*messaging.Message {
+	t.Helper()
+	timeout := time.After(waitTimeout)
+	for i := 0; i < n; i++ {
+		select {
+		case <-c.got:
+		case <-timeout:
+			t.Fatalf("received %d messages, want %d", i, n)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*messaging.Message(nil), c.msgs...)
+}
+
+// waitPeek 等待 topic 中恰好有 n 条消息
+func waitPeek(t *testing.T, bus messaging.Bus, topic string, n int) []*messaging.Message {
+	t.Helper()
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		msgs, total, err := bus.Peek(context.Background(), topic, 10)
+		if err != nil {
+			t.Fatalf("peek %s: %v", topic, err)
+		}
+		if total == n {
+			return msgs
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s has %d messages, want %d", topic, total, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func testDeliver(t *testing.T, bus messaging.Bus) {
+	// 订阅前发布的消息也不会丢失
+	publish(t, bus, "results", "m1")
+	c := newCollect()
+	subscribe(t, bus, "results", c.handle)
+	publish(t, bus, "results", "m2")
+
+	msgs := c.wait(t, 2)
+	for i, want := range []string{"m1", "m2"} {
+		m := msgs[i]
+		if m.ID != want || string(m.Body) != fmt.Sprintf(`{"id":%q}`, want) || m.Headers["k"] != "v" {
+			t.Fatalf("message %d = %+v, want %s", i, m, want)
+		}
+		if m.Timestamp.IsZero() || m.Attempts != 0 {
+			t.Fatalf("message %s timestamp %v, attempts %d", m.ID, m.Timestamp, m.Attempts)
+		}
+	}
+}
+
+func testWorkQueue(t *testing.T, bus messaging.Bus) {
+	c := newCollect()
+	subscribe(t, bus, "results", c.handle)
+	subscribe(t, bus, "results", c.handle)
+
+	ids := []string{"m1", "m2", "m3", "m4", "m5", "m6"}
+	publish(t, bus, "results", ids...)
+	c.wait(t, len(ids))
+
+	// 每条消息只被一个订阅者处理
+	time.Sleep(100 * time.Millisecond)
+	seen := make(map[string]int)
+	for _, m := range c.wait(t, 0) {
+		seen[m.ID]++
+	}
+	for _, id := range ids {
+		if seen[id] != 1 {
+			t.Fatalf("message %s handled %d times", id, seen[id])
+		}
+	}
+}
+
+func testRetry(t *testing.T, bus messaging.Bus) {
+	var mu sync.Mutex
+	var attempts []int
+	c := newCollect()
+	// 前两次失败，第三次成功
+	subscribe(t, bus, "results", func(ctx context.Context, msg *messaging.Message) error {
+		mu.Lock()
+		attempts = append(attempts, msg.Attempts)
+		mu.Unlock()
+		if msg.Attempts < 2 {
+			return errors.New("database unavailable")
+		}
+		return c.handle(ctx, msg)
+	}, messaging.WithRetry(10*time.Millisecond, 10*time.Millisecond, 10*time.Millisecond), messaging.WithDeadLetter("results.dlq"))
+	publish(t, bus, "results", "m1")
+
+	m := c.wait(t, 1)[0]
+	if m.Attempts != 2 || m.Error != "database unavailable" {
+		t.Fatalf("delivered attempts %d, error %q", m.Attempts, m.Error)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(attempts) != "[0 1 2]" {
+		t.Fatalf("attempts seen = %v, want [0 1 2]", attempts)
+	}
+	if _, total, _ := bus.Peek(context.Background(), "results.dlq", 10); total != 0 {
+		t.Fatalf("dead letters = %d, want 0", total)
+	}
+}
+
+func testDeadLetter(t *testing.T, bus messaging.Bus) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "retries exhausted", err: errors.New("database unavailable"), wantAttempts: 3},
+		{name: "permanent error skips retries", err: messaging.Permanent(errors.New("bad body")), wantAttempts: 1},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic := fmt.Sprintf("results%d", i)
+			subscribe(t, bus, topic, func(ctx context.Context, msg *messaging.Message) error {
+				return tt.err
+			}, messaging.WithRetry(10*time.Millisecond, 10*time.Millisecond), messaging.WithDeadLetter(topic+".dlq"))
+			publish(t, bus, topic, "m1")
+
+			m := waitPeek(t, bus, topic+".dlq", 1)[0]
+			if m.ID != "m1" || m.Attempts != tt.wantAttempts || m.Error != tt.err.Error() {
+				t.Fatalf("dead letter = %s, attempts %d, error %q", m.ID, m.Attempts, m.Error)
+			}
+			if m.Headers[messaging.HeaderDeadAt] == "" || m.Headers["k"] != "v" {
+				t.Fatalf("dead letter headers = %v", m.Headers)
+			}
+		})
+	}
+}
+
+func testReplay(t *testing.T, bus messaging.Bus) {
+	ctx := context.Background()
+	for _, id := range []string{"m1", "m2", "m3"} {
+		dead := messaging.Dead(messaging.Failed(&messaging.Message{ID: id, Body: []byte("{}")}, errors.New("boom")))
+		if err := bus.Publish(ctx, "results.dlq", dead); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := bus.Replay(ctx, "results.dlq", "results", func(m *messaging.Message) bool { return m.ID != "m2" })
+	if err != nil || n != 2 {
+		t.Fatalf("replay = %d, %v, want 2", n, err)
+	}
+	if left := waitPeek(t, bus, "results.dlq", 1); left[0].ID != "m2" {
+		t.Fatalf("left in dead letters = %s, want m2", left[0].ID)
+	}
+
+	// 重放的消息失败记录清零
+	for _, m := range waitPeek(t, bus, "results", 2) {
+		if m.Attempts != 0 || m.Error != "" || m.Headers[messaging.HeaderDeadAt] != "" {
+			t.Fatalf("replayed %s attempts %d, error %q, headers %v", m.ID, m.Attempts, m.Error, m.Headers)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package memory 进程内消息总线，用于单进程运行全部服务或测试，进程退出后消息丢失。
+package memory
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"mygame/pkg/messaging"
+)
+
+// Bus 每个 topic 一个 FIFO 队列，订阅者竞争消费
+type Bus struct {
+	mu     sync.Mutex
+	topics map[string]*queue
+	closed bool
+}
+
+type queue struct {
+	msgs []*messaging.Message
+	wake chan struct{} // 有新消息时关闭并替换，唤醒所有等待的订阅者
+}
+
+var (
+	sharedOnce sync.Once
+	shared     *Bus
+)
+
+// New 创建独立的总线
+func New() *Bus {
+	return &Bus{topics: make(map[string]*queue)}
+}
+
+// Shared 进程内共享的总线，同一进程中的多个服务通过它互通
+func Shared() *Bus {
+	sharedOnce.Do(func() {
+		shared = New()
+	})
+	return shared
+}
+
+// topic 取得队列，调用方需持有 mu
+func (b *Bus) topic(name string) *queue {
+	q, ok := b.topics[name]
+	if !ok {
+		q = &queue{wake: make(chan struct{})}
+		b.topics[name] = q
+	}
+	return q
+}
+
+func (b *Bus) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return messaging.ErrClosed
+	}
+	c := msg.Clone()
+	if c.Timestamp.IsZero() {
+		c.Timestamp = time.Now()
+	}
+	q := b.topic(topic)
+	q.msgs = append(q.msgs, c)
+	close(q.wake)
+	q.wake = make(chan struct{})
+	return nil
+}
+
+func (b *Bus) Subscribe(ctx context.Context, topic string, h messaging.Handler, opts ...messaging.SubscribeOption) error {
+	o := messaging.NewSubscribeOptions(opts...)
+	for {
+		// 1. 取队首消息，没有则等待
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return messaging.ErrClosed
+		}
+		q := b.topic(topic)
+		if len(q.msgs) == 0 {
+			wake := q.wake
+			b.mu.Unlock()
+			select {
+			case <-wake:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		msg := q.msgs[0]
+		q.msgs = q.msgs[1:]
+		b.mu.Unlock()
+
+		// 2. 处理失败时延迟重新入队或进入死信
+		err := h(ctx, msg.Clone())
+		if err == nil {
+			continue
+		}
+		failed := messaging.Failed(msg, err)
+		if delay, ok := o.NextRetry(msg, err); ok {
+			time.AfterFunc(delay, func() {
+				b.Publish(context.Background(), topic, failed)
+			})
+			continue
+		}
+		if o.DeadLetterTopic == "" {
+			log.Printf("messaging: drop message %s of %s after %d attempts: %v", msg.ID, topic, failed.Attempts, err)
+			continue
+		}
+		b.Publish(ctx, o.DeadLetterTopic, messaging.Dead(failed))
+	}
+}
+
+func (b *Bus) Peek(ctx context.Context, topic string, limit int) ([]*messaging.Message, int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q := b.topic(topic)
+	n := limit
+	if n > len(q.msgs) {
+		n = len(q.msgs)
+	}
+	msgs := make([]*messaging.Message, 0, n)
+	for _, m := range q.msgs[:n] {
+		msgs = append(msgs, m.Clone())
+	}
+	return msgs, len(q.msgs), nil
+}
+
+func (b *Bus) Replay(ctx context.Context, from, to string, match func(*messaging.Message) bool) (int, error) {
+	b.mu.Lock()
+	q := b.topic(from)
+	var moved []*messaging.Message
+	kept := q.msgs[:0]
+	for _, m := range q.msgs {
+		if match(m) {
+			moved = append(moved, messaging.Revived(m))
+		} else {
+			kept = append(kept, m)
+		}
+	}
+	q.msgs = kept
+	b.mu.Unlock()
+
+	for i, m := range moved {
+		if err := b.Publish(ctx, to, m); err != nil {
+			return i, err
+		}
+	}
+	return len(moved), nil
+}
+
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		for _, q := range b.topics {
+			close(q.wake)
+		}
+	}
+	return nil
+}
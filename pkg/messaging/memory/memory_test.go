@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	"mygame/pkg/messaging"
+	"mygame/pkg/messaging/bustest"
+)
+
+func TestBus(t *testing.T) {
+	bustest.Run(t, func(t *testing.T) messaging.Bus {
+		b := New()
+		t.Cleanup(func() { b.Close() })
+		return b
+	})
+}
@@ -0,0 +1,154 @@
+// Package messaging 服务间消息总线的统一接口。
+// topic 采用工作队列语义：同一 topic 的每条消息只会被一个订阅者处理。
+// 具体实现见 rabbitmq / redisstream / memory 子包，由各服务按配置选择。
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// HeaderDeadAt 消息进入死信 topic 的时间 (unix 秒)
+const HeaderDeadAt = "x-dead-at"
+
+// ErrClosed 总线已关闭
+var ErrClosed = errors.New("messaging: bus closed")
+
+// Message 总线上传递的消息
+type Message struct {
+	ID        string            // 业务 ID（如 match_id），消费端据此去重
+	Body      []byte            // 消息体，通常为 JSON
+	Headers   map[string]string // 自定义消息头
+	Timestamp time.Time         // 首次发布时间
+	Attempts  int               // 已失败的处理次数，首次投递为 0
+	Error     string            // 最后一次处理失败的原因
+}
+
+// Clone 深拷贝，各实现在投递前复制，避免订阅者之间共享可变状态
+func (m *Message) Clone() *Message {
+	c := *m
+	c.Body = append([]byte(nil), m.Body...)
+	if m.Headers != nil {
+		c.Headers = make(map[string]string, len(m.Headers))
+		for k, v := range m.Headers {
+			c.Headers[k] = v
+		}
+	}
+	return &c
+}
+
+// Handler 处理一条消息；返回 nil 表示处理成功，消息被确认
+type Handler func(ctx context.Context, msg *Message) error
+
+// Publisher 发布消息
+type Publisher interface {
+	// Publish 发送到 topic，返回 nil 表示消息已被后端持久化确认
+	Publish(ctx context.Context, topic string, msg *Message) error
+}
+
+// Subscriber 订阅消息
+type Subscriber interface {
+	// Subscribe 阻塞消费 topic 直到 ctx 取消，连接断开时自动重连
+	// Handler 返回错误时按 WithRetry 延迟重试，重试耗尽或 Permanent 错误进入 WithDeadLetter 指定的 topic
+	Subscribe(ctx context.Context, topic string, h Handler, opts ...SubscribeOption) error
+}
+
+// Inspector 运维接口，用于查看与重放死信
+type Inspector interface {
+	// Peek 查看 topic 中前 limit 条消息，不会移除消息；同时返回消息总数
+	Peek(ctx context.Context, topic string, limit int) ([]*Message, int, error)
+	// Replay 将 from 中满足 match 的消息移动到 to，失败次数与原因清零
+	Replay(ctx context.Context, from, to string, match func(*Message) bool) (int, error)
+}
+
+// Bus 完整的消息总线
+type Bus interface {
+	Publisher
+	Subscriber
+	Inspector
+	Close() error
+}
+
+// SubscribeOptions 订阅选项
+type SubscribeOptions struct {
+	RetryDelays     []time.Duration // 第 i 次失败后延迟 RetryDelays[i] 重试
+	DeadLetterTopic string          // 为空时重试耗尽的消息被丢弃
+}
+
+type SubscribeOption func(*SubscribeOptions)
+
+// WithRetry 设置每次重试前的延迟，次数即 len(delays)
+func WithRetry(delays ...time.Duration) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.RetryDelays = delays
+	}
+}
+
+// WithDeadLetter 设置死信 topic
+func WithDeadLetter(topic string) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.DeadLetterTopic = topic
+	}
+}
+
+// NewSubscribeOptions 合并订阅选项
+func NewSubscribeOptions(opts ...SubscribeOption) SubscribeOptions {
+	var o SubscribeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NextRetry 处理失败后是否重试：返回 (延迟, true) 表示重试，否则进入死信
+func (o SubscribeOptions) NextRetry(msg *Message, err error) (time.Duration, bool) {
+	if IsPermanent(err) || msg.Attempts >= len(o.RetryDelays) {
+		return 0, false
+	}
+	return o.RetryDelays[msg.Attempts], true
+}
+
+// Failed 返回记录了本次失败的消息副本
+func Failed(msg *Message, err error) *Message {
+	c := msg.Clone()
+	c.Attempts++
+	c.Error = err.Error()
+	return c
+}
+
+// Dead 返回标记了死信时间的消息副本
+func Dead(msg *Message) *Message {
+	c := msg.Clone()
+	if c.Headers == nil {
+		c.Headers = make(map[string]string, 1)
+	}
+	c.Headers[HeaderDeadAt] = strconv.FormatInt(time.Now().Unix(), 10)
+	return c
+}
+
+// Revived 返回重放用的消息副本，清除失败记录
+func Revived(msg *Message) *Message {
+	c := msg.Clone()
+	c.Attempts = 0
+	c.Error = ""
+	delete(c.Headers, HeaderDeadAt)
+	return c
+}
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent 标记不可重试的错误（如消息格式错误），消息直接进入死信
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// IsPermanent 是否为 Permanent 标记的错误
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
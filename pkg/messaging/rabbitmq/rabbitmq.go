@@ -0,0 +1,410 @@
+// Package rabbitmq 基于 RabbitMQ 的消息总线：topic 对应同名持久化队列，发布开启 publisher confirms。
+// 重试通过带 TTL 的延迟队列实现（<topic>.retry.<delay>，过期后回到原队列）。
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"mygame/pkg/messaging"
+
+	"github.com/streadway/amqp"
+)
+
+// 消息头中保存失败记录的字段
+const (
+	headerAttempts  = "x-retry-count"
+	headerLastError = "x-last-error"
+)
+
+const (
+	confirmTimeout = 5 * time.Second
+	maxBackoff     = 30 * time.Second
+)
+
+// Bus 连接断开后，Publish 在下次调用时重连，Subscribe 指数退避重连
+type Bus struct {
+	url string
+
+	mu       sync.Mutex
+	conn     *amqp.Connection
+	declared map[string]bool // 已声明的队列
+	closed   bool
+
+	pubMu    sync.Mutex
+	pub      *amqp.Channel // confirm 模式的发布 channel
+	confirms chan amqp.Confirmation
+}
+
+// New 创建总线，首次使用时才建立连接
+func New(url string) *Bus {
+	return &Bus{url: url, declared: make(map[string]bool)}
+}
+
+// connection 返回可用的连接，断开时重新拨号
+func (b *Bus) connection() (*amqp.Connection, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, messaging.ErrClosed
+	}
+	if b.conn != nil && !b.conn.IsClosed() {
+		return b.conn, nil
+	}
+	conn, err := amqp.Dial(b.url)
+	if err != nil {
+		return nil, fmt.Errorf("connect rabbitmq failed: %v", err)
+	}
+	b.conn = conn
+	return conn, nil
+}
+
+// declare 声明普通持久化队列；延迟队列由 Subscribe 带参数声明后标记
+func (b *Bus) declare(ch *amqp.Channel, name string, args amqp.Table) error {
+	b.mu.Lock()
+	done := b.declared[name]
+	b.mu.Unlock()
+	if done {
+		return nil
+	}
+	if _, err := ch.QueueDeclare(name, true, false, false, false, args); err != nil {
+		return fmt.Errorf("declare queue %s failed: %v", name, err)
+	}
+	b.mu.Lock()
+	b.declared[name] = true
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *Bus) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	b.pubMu.Lock()
+	defer b.pubMu.Unlock()
+
+	if err := b.publish(ctx, topic, msg); err != nil {
+		// 出错后丢弃 channel，避免迟到的确认与下一条消息错位
+		if b.pub != nil {
+			b.pub.Close()
+			b.pub = nil
+		}
+		return err
+	}
+	return nil
+}
+
+// publish 调用方需持有 pubMu
+func (b *Bus) publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	// 1. 打开 confirm 模式的 channel
+	if b.pub == nil {
+		conn, err := b.connection()
+		if err != nil {
+			return err
+		}
+		ch, err := conn.Channel()
+		if err != nil {
+			return fmt.Errorf("open channel failed: %v", err)
+		}
+		if err := ch.Confirm(false); err != nil {
+			ch.Close()
+			return fmt.Errorf("enable confirm failed: %v", err)
+		}
+		b.pub = ch
+		b.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+	}
+	if err := b.declare(b.pub, topic, nil); err != nil {
+		return err
+	}
+
+	// 2. 发送并等待 Broker 确认
+	if err := b.pub.Publish("", topic, false, false, toPublishing(msg)); err != nil {
+		return fmt.Errorf("publish failed: %v", err)
+	}
+
+	timer := time.NewTimer(confirmTimeout)
+	defer timer.Stop()
+	select {
+	case c, ok := <-b.confirms:
+		if !ok {
+			return fmt.Errorf("publish channel closed")
+		}
+		if !c.Ack {
+			return fmt.Errorf("broker nacked message %s", msg.ID)
+		}
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("confirm of message %s timed out", msg.ID)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryQueue 延迟队列名包含延迟时长，修改配置不会与已存在队列的参数冲突
+func retryQueue(topic string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", topic, delay)
+}
+
+func (b *Bus) Subscribe(ctx context.Context, topic string, h messaging.Handler, opts ...messaging.SubscribeOption) error {
+	o := messaging.NewSubscribeOptions(opts...)
+	backoff := time.Second
+	for {
+		started := time.Now()
+		err := b.consume(ctx, topic, h, o)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err == messaging.ErrClosed {
+			return err
+		}
+
+		// 稳定运行过一段时间后重置退避
+		if time.Since(started) > maxBackoff {
+			backoff = time.Second
+		}
+		log.Printf("rabbitmq: consumer of %s stopped, retry in %v: %v", topic, backoff, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// consume 在一条连接上消费，直到连接断开或 ctx 取消
+func (b *Bus) consume(ctx context.Context, topic string, h messaging.Handler, o messaging.SubscribeOptions) error {
+	conn, err := b.connection()
+	if err != nil {
+		return err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel failed: %v", err)
+	}
+	defer ch.Close()
+
+	// 1. 声明主队列、延迟队列与死信队列
+	if err := b.declare(ch, topic, nil); err != nil {
+		return err
+	}
+	for _, delay := range o.RetryDelays {
+		err := b.declare(ch, retryQueue(topic, delay), amqp.Table{
+			"x-message-ttl":             int32(delay / time.Millisecond),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": topic,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if o.DeadLetterTopic != "" {
+		if err := b.declare(ch, o.DeadLetterTopic, nil); err != nil {
+			return err
+		}
+	}
+
+	deliveries, err := ch.Consume(topic, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("consume %s failed: %v", topic, err)
+	}
+
+	// 2. 逐条处理
+	for {
+		select {
+		case d, ok := <-deliveries:
+			if !ok {
+				return fmt.Errorf("delivery channel closed")
+			}
+			b.handle(ctx, topic, d, h, o)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handle 处理成功则确认；失败时转投延迟队列或死信队列，转投成功后才确认原消息
+func (b *Bus) handle(ctx context.Context, topic string, d amqp.Delivery, h messaging.Handler, o messaging.SubscribeOptions) {
+	msg := fromDelivery(d)
+	err := h(ctx, msg)
+	if err == nil {
+		d.Ack(false)
+		return
+	}
+
+	failed := messaging.Failed(msg, err)
+	target := o.DeadLetterTopic
+	if delay, ok := o.NextRetry(msg, err); ok {
+		target = retryQueue(topic, delay)
+	} else if target == "" {
+		log.Printf("rabbitmq: drop message %s of %s after %d attempts: %v", msg.ID, topic, failed.Attempts, err)
+		d.Ack(false)
+		return
+	} else {
+		failed = messaging.Dead(failed)
+	}
+
+	if err := b.Publish(ctx, target, failed); err != nil {
+		// 转投失败时放回原队列，稍等避免空转
+		log.Printf("rabbitmq: forward message %s to %s failed: %v", msg.ID, target, err)
+		time.Sleep(time.Second)
+		d.Nack(false, true)
+		return
+	}
+	d.Ack(false)
+}
+
+func (b *Bus) Peek(ctx context.Context, topic string, limit int) ([]*messaging.Message, int, error) {
+	// 独立 channel 取出的消息不确认，关闭 channel 后自动放回队列
+	conn, err := b.connection()
+	if err != nil {
+		return nil, 0, err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer ch.Close()
+
+	q, err := ch.QueueInspect(topic)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	msgs := make([]*messaging.Message, 0, limit)
+	for len(msgs) < limit {
+		d, ok, err := ch.Get(topic, false)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !ok {
+			break
+		}
+		msgs = append(msgs, fromDelivery(d))
+	}
+	return msgs, q.Messages, nil
+}
+
+func (b *Bus) Replay(ctx context.Context, from, to string, match func(*messaging.Message) bool) (int, error) {
+	conn, err := b.connection()
+	if err != nil {
+		return 0, err
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	q, err := ch.QueueInspect(from)
+	if err != nil {
+		return 0, err
+	}
+
+	// 1. 最多遍历一遍当前队列，未选中的消息保持未确认，关闭 channel 后放回
+	replayed := 0
+	for i := 0; i < q.Messages; i++ {
+		d, ok, err := ch.Get(from, false)
+		if err != nil {
+			return replayed, err
+		}
+		if !ok {
+			break
+		}
+		msg := fromDelivery(d)
+		if !match(msg) {
+			continue
+		}
+
+		// 2. 投递到目标队列成功后再从原队列移除
+		if err := b.Publish(ctx, to, messaging.Revived(msg)); err != nil {
+			return replayed, err
+		}
+		if err := d.Ack(false); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	if b.conn != nil {
+		return b.conn.Close()
+	}
+	return nil
+}
+
+func toPublishing(msg *messaging.Message) amqp.Publishing {
+	headers := make(amqp.Table, len(msg.Headers)+2)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	if msg.Attempts > 0 {
+		headers[headerAttempts] = int32(msg.Attempts)
+		headers[headerLastError] = msg.Error
+	}
+	ts := msg.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return amqp.Publishing{
+		Headers:      headers,
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.ID,
+		Timestamp:    ts,
+		Body:         msg.Body,
+	}
+}
+
+func fromDelivery(d amqp.Delivery) *messaging.Message {
+	msg := &messaging.Message{
+		ID:        d.MessageId,
+		Body:      d.Body,
+		Timestamp: d.Timestamp,
+		Headers:   make(map[string]string, len(d.Headers)),
+	}
+	for k, v := range d.Headers {
+		switch k {
+		case headerAttempts:
+			msg.Attempts = toInt(v)
+		case headerLastError:
+			msg.Error, _ = v.(string)
+		default:
+			// RabbitMQ 自身添加的 x-death 等字段不是字符串，忽略
+			if s, ok := v.(string); ok {
+				msg.Headers[k] = s
+			}
+		}
+	}
+	return msg
+}
+
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int8:
+		return int(n)
+	case int16:
+		return int(n)
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	case string:
+		i, _ := strconv.Atoi(n)
+		return i
+	}
+	return 0
+}
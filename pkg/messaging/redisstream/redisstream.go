@@ -0,0 +1,276 @@
+// Package redisstream 基于 Redis Streams 的消息总线，复用各服务已有的 Redis。
+// topic 对应 stream mq:<topic>，所有订阅者属于同一消费组，处理完成的消息确认后删除。
+// 延迟重试的消息暂存在有序集合 mq:<topic>:delayed 中，到期后由订阅者移回 stream。
+package redisstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"mygame/pkg/messaging"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	group     = "workers"
+	fieldMsg  = "msg"
+	batchSize = 16
+	// 其他消费者领取后超过该时间未确认（进程崩溃），由存活的消费者接管
+	claimIdle     = time.Minute
+	claimInterval = 30 * time.Second
+)
+
+// promoteScript 原子地把到期的延迟消息移回 stream
+var promoteScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, item in ipairs(items) do
+	redis.call('ZREM', KEYS[1], item)
+	redis.call('XADD', KEYS[2], '*', 'msg', item)
+end
+return #items
+`)
+
+// Bus Redis Streams 总线
+type Bus struct {
+	rdb      *redis.Client
+	consumer string
+}
+
+// envelope stream 中保存的消息，Nonce 保证相同内容的延迟消息在有序集合中不会合并
+type envelope struct {
+	Msg   *messaging.Message `json:"msg"`
+	Nonce int64              `json:"nonce,omitempty"`
+}
+
+// New 使用已有的 Redis 连接创建总线
+func New(rdb *redis.Client) *Bus {
+	host, _ := os.Hostname()
+	return &Bus{
+		rdb:      rdb,
+		consumer: fmt.Sprintf("%s-%d", host, os.Getpid()),
+	}
+}
+
+func streamKey(topic string) string  { return "mq:" + topic }
+func delayedKey(topic string) string { return "mq:" + topic + ":delayed" }
+
+func encode(msg *messaging.Message) (string, error) {
+	data, err := json.Marshal(envelope{Msg: msg, Nonce: time.Now().UnixNano()})
+	if err != nil {
+		return "", fmt.Errorf("marshal message failed: %v", err)
+	}
+	return string(data), nil
+}
+
+func decode(x redis.XMessage) (*messaging.Message, error) {
+	raw, _ := x.Values[fieldMsg].(string)
+	var env envelope
+	if err := json.Unmarshal([]byte(raw), &env); err != nil || env.Msg == nil {
+		return nil, fmt.Errorf("invalid stream entry %s", x.ID)
+	}
+	return env.Msg, nil
+}
+
+func (b *Bus) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
+	c := msg.Clone()
+	if c.Timestamp.IsZero() {
+		c.Timestamp = time.Now()
+	}
+	data, err := encode(c)
+	if err != nil {
+		return err
+	}
+	return b.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey(topic),
+		Values: map[string]interface{}{fieldMsg: data},
+	}).Err()
+}
+
+func (b *Bus) Subscribe(ctx context.Context, topic string, h messaging.Handler, opts ...messaging.SubscribeOption) error {
+	o := messaging.NewSubscribeOptions(opts...)
+	stream := streamKey(topic)
+
+	// 1. 创建消费组，从头消费，订阅前已发布的消息不会丢失
+	err := b.rdb.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("create consumer group failed: %v", err)
+	}
+
+	// 2. 先处理本消费者上次未确认的消息，再读取新消息
+	start := "0"
+	lastClaim := time.Time{}
+	for ctx.Err() == nil {
+		if err := b.promote(ctx, topic); err != nil {
+			log.Printf("redisstream: promote delayed messages of %s failed: %v", topic, err)
+		}
+		if time.Since(lastClaim) > claimInterval {
+			b.claim(ctx, topic, h, o)
+			lastClaim = time.Now()
+		}
+
+		res, err := b.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.consumer,
+			Streams:  []string{stream, start},
+			Count:    batchSize,
+			Block:    time.Second,
+		}).Result()
+		if err == redis.Nil {
+			start = ">"
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			log.Printf("redisstream: read %s failed: %v", topic, err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		count := 0
+		for _, s := range res {
+			for _, x := range s.Messages {
+				b.handle(ctx, topic, x, h, o)
+				count++
+			}
+		}
+		// 历史未确认消息处理完后切换到新消息
+		if start == "0" && count < batchSize {
+			start = ">"
+		}
+	}
+	return ctx.Err()
+}
+
+// promote 把到期的延迟重试消息移回 stream
+func (b *Bus) promote(ctx context.Context, topic string) error {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	return promoteScript.Run(ctx, b.rdb, []string{delayedKey(topic), streamKey(topic)}, now).Err()
+}
+
+// claim 接管其他消费者长时间未确认的消息
+func (b *Bus) claim(ctx context.Context, topic string, h messaging.Handler, o messaging.SubscribeOptions) {
+	msgs, _, err := b.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   streamKey(topic),
+		Group:    group,
+		MinIdle:  claimIdle,
+		Start:    "0-0",
+		Count:    batchSize,
+		Consumer: b.consumer,
+	}).Result()
+	if err != nil {
+		log.Printf("redisstream: claim %s failed: %v", topic, err)
+		return
+	}
+	for _, x := range msgs {
+		b.handle(ctx, topic, x, h, o)
+	}
+}
+
+// handle 处理成功则确认并删除；失败时先写入延迟集合或死信 stream，再确认原消息
+func (b *Bus) handle(ctx context.Context, topic string, x redis.XMessage, h messaging.Handler, o messaging.SubscribeOptions) {
+	msg, err := decode(x)
+	if err != nil {
+		// 无法解析的条目原样进入死信，避免反复读取
+		msg = &messaging.Message{ID: x.ID, Body: []byte(fmt.Sprint(x.Values[fieldMsg]))}
+		err = messaging.Permanent(err)
+	} else {
+		err = h(ctx, msg.Clone())
+	}
+
+	if err != nil {
+		if ferr := b.fail(ctx, topic, msg, err, o); ferr != nil {
+			// 保持未确认，稍后由 claim 重新处理
+			log.Printf("redisstream: forward message %s of %s failed: %v", msg.ID, topic, ferr)
+			return
+		}
+	}
+
+	stream := streamKey(topic)
+	pipe := b.rdb.TxPipeline()
+	pipe.XAck(ctx, stream, group, x.ID)
+	pipe.XDel(ctx, stream, x.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("redisstream: ack %s of %s failed: %v", x.ID, topic, err)
+	}
+}
+
+func (b *Bus) fail(ctx context.Context, topic string, msg *messaging.Message, err error, o messaging.SubscribeOptions) error {
+	failed := messaging.Failed(msg, err)
+	if delay, ok := o.NextRetry(msg, err); ok {
+		data, err := encode(failed)
+		if err != nil {
+			return err
+		}
+		due := float64(time.Now().Add(delay).UnixMilli())
+		return b.rdb.ZAdd(ctx, delayedKey(topic), redis.Z{Score: due, Member: data}).Err()
+	}
+	if o.DeadLetterTopic == "" {
+		log.Printf("redisstream: drop message %s of %s after %d attempts: %v", msg.ID, topic, failed.Attempts, err)
+		return nil
+	}
+	return b.Publish(ctx, o.DeadLetterTopic, messaging.Dead(failed))
+}
+
+func (b *Bus) Peek(ctx context.Context, topic string, limit int) ([]*messaging.Message, int, error) {
+	stream := streamKey(topic)
+	total, err := b.rdb.XLen(ctx, stream).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, err := b.rdb.XRangeN(ctx, stream, "-", "+", int64(limit)).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	msgs := make([]*messaging.Message, 0, len(entries))
+	for _, x := range entries {
+		if msg, err := decode(x); err == nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, int(total), nil
+}
+
+func (b *Bus) Replay(ctx context.Context, from, to string, match func(*messaging.Message) bool) (int, error) {
+	stream := streamKey(from)
+	replayed := 0
+	start := "-"
+	for {
+		entries, err := b.rdb.XRangeN(ctx, stream, start, "+", 100).Result()
+		if err != nil {
+			return replayed, err
+		}
+		for _, x := range entries {
+			msg, err := decode(x)
+			if err != nil || !match(msg) {
+				continue
+			}
+			// 写入目标 topic 成功后再从原 stream 删除
+			if err := b.Publish(ctx, to, messaging.Revived(msg)); err != nil {
+				return replayed, err
+			}
+			if err := b.rdb.XDel(ctx, stream, x.ID).Err(); err != nil {
+				return replayed, err
+			}
+			replayed++
+		}
+		if len(entries) < 100 {
+			return replayed, nil
+		}
+		start = "(" + entries[len(entries)-1].ID
+	}
+}
+
+// Close Redis 连接由调用方管理，这里不关闭
+func (b *Bus) Close() error {
+	return nil
+}
@@ -0,0 +1,20 @@
+package redisstream
+
+import (
+	"testing"
+
+	"mygame/pkg/messaging"
+	"mygame/pkg/messaging/bustest"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestBus(t *testing.T) {
+	bustest.Run(t, func(t *testing.T) messaging.Bus {
+		mr := miniredis.RunT(t)
+		rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		t.Cleanup(func() { rdb.Close() })
+		return New(rdb)
+	})
+}
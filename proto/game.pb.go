@@ -24,9 +24,12 @@ const (
 type GameEvent_EventType int32
 
 const (
-	GameEvent_GAME_START   GameEvent_EventType = 0
-	GameEvent_PLAYER_DEATH GameEvent_EventType = 1
-	GameEvent_GAME_OVER    GameEvent_EventType = 2
+	GameEvent_GAME_START    GameEvent_EventType = 0
+	GameEvent_PLAYER_DEATH  GameEvent_EventType = 1
+	GameEvent_GAME_OVER     GameEvent_EventType = 2
+	GameEvent_PLAYER_KICKED GameEvent_EventType = 3 // 被移出房间，message 为原因
+	GameEvent_HOST_CHANGED  GameEvent_EventType = 4 // target_uid 为新房主
+	GameEvent_COUNTDOWN     GameEvent_EventType = 5 // 开局倒计时，message 为剩余秒数
 )
 
 // Enum value maps for GameEvent_EventType.
@@ -35,11 +38,17 @@ var (
 		0: "GAME_START",
 		1: "PLAYER_DEATH",
 		2: "GAME_OVER",
+		3: "PLAYER_KICKED",
+		4: "HOST_CHANGED",
+		5: "COUNTDOWN",
 	}
 	GameEvent_EventType_value = map[string]int32{
-		"GAME_START":   0,
-		"PLAYER_DEATH": 1,
-		"GAME_OVER":    2,
+		"GAME_START":    0,
+		"PLAYER_DEATH":  1,
+		"GAME_OVER":     2,
+		"PLAYER_KICKED": 3,
+		"HOST_CHANGED":  4,
+		"COUNTDOWN":     5,
 	}
 )
 
@@ -660,6 +669,7 @@ type PlayerState struct {
 	IsCharging           bool                   `protobuf:"varint,7,opt,name=is_charging,json=isCharging,proto3" json:"is_charging,omitempty"`
 	ChargeStartTimeDelta int32                  `protobuf:"varint,8,opt,name=charge_start_time_delta,json=chargeStartTimeDelta,proto3" json:"charge_start_time_delta,omitempty"` // 相对当前时间的差值，用于前端平滑动画
 	Username             string                 `protobuf:"bytes,9,opt,name=username,proto3" json:"username,omitempty"`
+	Team                 int32                  `protobuf:"varint,10,opt,name=team,proto3" json:"team,omitempty"` // 团队模式下的队伍编号，个人混战为 0
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -757,6 +767,13 @@ func (x *PlayerState) GetUsername() string {
 	return ""
 }
 
+func (x *PlayerState) GetTeam() int32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
 type BeamState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -962,7 +979,7 @@ const file_game_proto_rawDesc = "" +
 	"serverTime\x12\x12\n" +
 	"\x04tick\x18\x02 \x01(\x03R\x04tick\x12)\n" +
 	"\aplayers\x18\x03 \x03(\v2\x0f.pb.PlayerStateR\aplayers\x12#\n" +
-	"\x05beams\x18\x04 \x03(\v2\r.pb.BeamStateR\x05beams\"\xef\x01\n" +
+	"\x05beams\x18\x04 \x03(\v2\r.pb.BeamStateR\x05beams\"\x83\x02\n" +
 	"\vPlayerState\x12\x10\n" +
 	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\f\n" +
 	"\x01x\x18\x02 \x01(\x02R\x01x\x12\f\n" +
@@ -973,7 +990,9 @@ const file_game_proto_rawDesc = "" +
 	"\vis_charging\x18\a \x01(\bR\n" +
 	"isCharging\x125\n" +
 	"\x17charge_start_time_delta\x18\b \x01(\x05R\x14chargeStartTimeDelta\x12\x1a\n" +
-	"\busername\x18\t \x01(\tR\busername\"\xb0\x01\n" +
+	"\busername\x18\t \x01(\tR\busername\x12\x12\n" +
+	"\x04team\x18\n" +
+	" \x01(\x05R\x04team\"\xb0\x01\n" +
 	"\tBeamState\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\astart_x\x18\x02 \x01(\x02R\x06startX\x12\x17\n" +
@@ -981,19 +1000,22 @@ const file_game_proto_rawDesc = "" +
 	"\x05end_x\x18\x04 \x01(\x02R\x04endX\x12\x13\n" +
 	"\x05end_y\x18\x05 \x01(\x02R\x04endY\x12\x14\n" +
 	"\x05width\x18\x06 \x01(\x02R\x05width\x12!\n" +
-	"\fremaining_ms\x18\a \x01(\x05R\vremainingMs\"\xce\x01\n" +
+	"\fremaining_ms\x18\a \x01(\x05R\vremainingMs\"\x82\x02\n" +
 	"\tGameEvent\x12+\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x17.pb.GameEvent.EventTypeR\x04type\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
 	"target_uid\x18\x03 \x01(\x03R\ttargetUid\x12\x1d\n" +
 	"\n" +
-	"extra_data\x18\x04 \x01(\tR\textraData\"<\n" +
+	"extra_data\x18\x04 \x01(\tR\textraData\"p\n" +
 	"\tEventType\x12\x0e\n" +
 	"\n" +
 	"GAME_START\x10\x00\x12\x10\n" +
 	"\fPLAYER_DEATH\x10\x01\x12\r\n" +
-	"\tGAME_OVER\x10\x02B\x06Z\x04./pbb\x06proto3"
+	"\tGAME_OVER\x10\x02\x12\x11\n" +
+	"\rPLAYER_KICKED\x10\x03\x12\x10\n" +
+	"\fHOST_CHANGED\x10\x04\x12\r\n" +
+	"\tCOUNTDOWN\x10\x05B\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_game_proto_rawDescOnce sync.Once
@@ -21,6 +21,211 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Presence_Status int32
+
+const (
+	Presence_OFFLINE  Presence_Status = 0
+	Presence_LOBBY    Presence_Status = 1 // 在大厅
+	Presence_IN_QUEUE Presence_Status = 2 // 在房间中等待开局
+	Presence_IN_MATCH Presence_Status = 3 // 对局中
+)
+
+// Enum value maps for Presence_Status.
+var (
+	Presence_Status_name = map[int32]string{
+		0: "OFFLINE",
+		1: "LOBBY",
+		2: "IN_QUEUE",
+		3: "IN_MATCH",
+	}
+	Presence_Status_value = map[string]int32{
+		"OFFLINE":  0,
+		"LOBBY":    1,
+		"IN_QUEUE": 2,
+		"IN_MATCH": 3,
+	}
+)
+
+func (x Presence_Status) Enum() *Presence_Status {
+	p := new(Presence_Status)
+	*p = x
+	return p
+}
+
+func (x Presence_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Presence_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Presence_Status) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[0]
+}
+
+func (x Presence_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Presence_Status.Descriptor instead.
+func (Presence_Status) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{33, 0}
+}
+
+type RoomConfig_Visibility int32
+
+const (
+	RoomConfig_UNSPECIFIED RoomConfig_Visibility = 0 // 创建时视为 PUBLIC，更新时表示不修改
+	RoomConfig_PUBLIC      RoomConfig_Visibility = 1
+	RoomConfig_PRIVATE     RoomConfig_Visibility = 2 // 不出现在房间列表中，只能通过邀请码加入
+)
+
+// Enum value maps for RoomConfig_Visibility.
+var (
+	RoomConfig_Visibility_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "PUBLIC",
+		2: "PRIVATE",
+	}
+	RoomConfig_Visibility_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"PUBLIC":      1,
+		"PRIVATE":     2,
+	}
+)
+
+func (x RoomConfig_Visibility) Enum() *RoomConfig_Visibility {
+	p := new(RoomConfig_Visibility)
+	*p = x
+	return p
+}
+
+func (x RoomConfig_Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomConfig_Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[1].Descriptor()
+}
+
+func (RoomConfig_Visibility) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[1]
+}
+
+func (x RoomConfig_Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomConfig_Visibility.Descriptor instead.
+func (RoomConfig_Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{55, 0}
+}
+
+type ListRoomsReq_SortBy int32
+
+const (
+	ListRoomsReq_NEWEST         ListRoomsReq_SortBy = 0
+	ListRoomsReq_MOST_PLAYERS   ListRoomsReq_SortBy = 1
+	ListRoomsReq_FEWEST_PLAYERS ListRoomsReq_SortBy = 2
+)
+
+// Enum value maps for ListRoomsReq_SortBy.
+var (
+	ListRoomsReq_SortBy_name = map[int32]string{
+		0: "NEWEST",
+		1: "MOST_PLAYERS",
+		2: "FEWEST_PLAYERS",
+	}
+	ListRoomsReq_SortBy_value = map[string]int32{
+		"NEWEST":         0,
+		"MOST_PLAYERS":   1,
+		"FEWEST_PLAYERS": 2,
+	}
+)
+
+func (x ListRoomsReq_SortBy) Enum() *ListRoomsReq_SortBy {
+	p := new(ListRoomsReq_SortBy)
+	*p = x
+	return p
+}
+
+func (x ListRoomsReq_SortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListRoomsReq_SortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ListRoomsReq_SortBy) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[2]
+}
+
+func (x ListRoomsReq_SortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListRoomsReq_SortBy.Descriptor instead.
+func (ListRoomsReq_SortBy) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{58, 0}
+}
+
+type RoomEvent_Type int32
+
+const (
+	RoomEvent_SNAPSHOT     RoomEvent_Type = 0 // 订阅时的全量数据，每个房间一条
+	RoomEvent_SNAPSHOT_END RoomEvent_Type = 1 // 快照结束，之后均为增量
+	RoomEvent_ADDED        RoomEvent_Type = 2
+	RoomEvent_UPDATED      RoomEvent_Type = 3 // 客户端按 upsert 处理
+	RoomEvent_REMOVED      RoomEvent_Type = 4 // 房间销毁或不再公开
+)
+
+// Enum value maps for RoomEvent_Type.
+var (
+	RoomEvent_Type_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "SNAPSHOT_END",
+		2: "ADDED",
+		3: "UPDATED",
+		4: "REMOVED",
+	}
+	RoomEvent_Type_value = map[string]int32{
+		"SNAPSHOT":     0,
+		"SNAPSHOT_END": 1,
+		"ADDED":        2,
+		"UPDATED":      3,
+		"REMOVED":      4,
+	}
+)
+
+func (x RoomEvent_Type) Enum() *RoomEvent_Type {
+	p := new(RoomEvent_Type)
+	*p = x
+	return p
+}
+
+func (x RoomEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_service_proto_enumTypes[3].Descriptor()
+}
+
+func (RoomEvent_Type) Type() protoreflect.EnumType {
+	return &file_service_proto_enumTypes[3]
+}
+
+func (x RoomEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomEvent_Type.Descriptor instead.
+func (RoomEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{79, 0}
+}
+
 type RegisterReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -171,9 +376,11 @@ func (x *LoginReq) GetPassword() string {
 
 type LoginResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // 短期 access token (JWT)
 	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
 	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // 长期 refresh token，每次刷新后轮换
+	ExpiresIn     int64                  `protobuf:"varint,5,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`         // access token 有效秒数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,6 +436,20 @@ func (x *LoginResp) GetUsername() string {
 	return ""
 }
 
+func (x *LoginResp) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginResp) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
 type ValidateTokenReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
@@ -277,6 +498,7 @@ type ValidateTokenResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
 	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -325,29 +547,34 @@ func (x *ValidateTokenResp) GetUid() int64 {
 	return 0
 }
 
-type GetHistoryReq struct {
+func (x *ValidateTokenResp) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type RefreshTokenReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoryReq) Reset() {
-	*x = GetHistoryReq{}
+func (x *RefreshTokenReq) Reset() {
+	*x = RefreshTokenReq{}
 	mi := &file_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoryReq) String() string {
+func (x *RefreshTokenReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoryReq) ProtoMessage() {}
+func (*RefreshTokenReq) ProtoMessage() {}
 
-func (x *GetHistoryReq) ProtoReflect() protoreflect.Message {
+func (x *RefreshTokenReq) ProtoReflect() protoreflect.Message {
 	mi := &file_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -359,53 +586,41 @@ func (x *GetHistoryReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoryReq.ProtoReflect.Descriptor instead.
-func (*GetHistoryReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use RefreshTokenReq.ProtoReflect.Descriptor instead.
+func (*RefreshTokenReq) Descriptor() ([]byte, []int) {
 	return file_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetHistoryReq) GetUid() int64 {
-	if x != nil {
-		return x.Uid
-	}
-	return 0
-}
-
-func (x *GetHistoryReq) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *GetHistoryReq) GetLimit() int32 {
+func (x *RefreshTokenReq) GetRefreshToken() string {
 	if x != nil {
-		return x.Limit
+		return x.RefreshToken
 	}
-	return 0
+	return ""
 }
 
-type GetHistoryResp struct {
+type LogoutReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	History       []*MatchRecord         `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // 当前 access token，立即加入吊销名单
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // 当前 refresh token
+	All           bool                   `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`                                      // 登出所有设备
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetHistoryResp) Reset() {
-	*x = GetHistoryResp{}
+func (x *LogoutReq) Reset() {
+	*x = LogoutReq{}
 	mi := &file_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetHistoryResp) String() string {
+func (x *LogoutReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetHistoryResp) ProtoMessage() {}
+func (*LogoutReq) ProtoMessage() {}
 
-func (x *GetHistoryResp) ProtoReflect() protoreflect.Message {
+func (x *LogoutReq) ProtoReflect() protoreflect.Message {
 	mi := &file_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -417,42 +632,53 @@ func (x *GetHistoryResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetHistoryResp.ProtoReflect.Descriptor instead.
-func (*GetHistoryResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use LogoutReq.ProtoReflect.Descriptor instead.
+func (*LogoutReq) Descriptor() ([]byte, []int) {
 	return file_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetHistoryResp) GetHistory() []*MatchRecord {
+func (x *LogoutReq) GetToken() string {
 	if x != nil {
-		return x.History
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-type MatchRecord struct {
+func (x *LogoutReq) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LogoutReq) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+type LogoutResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
-	IsWinner      bool                   `protobuf:"varint,2,opt,name=is_winner,json=isWinner,proto3" json:"is_winner,omitempty"`
-	Kills         int32                  `protobuf:"varint,3,opt,name=kills,proto3" json:"kills,omitempty"`
-	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MatchRecord) Reset() {
-	*x = MatchRecord{}
+func (x *LogoutResp) Reset() {
+	*x = LogoutResp{}
 	mi := &file_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MatchRecord) String() string {
+func (x *LogoutResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MatchRecord) ProtoMessage() {}
+func (*LogoutResp) ProtoMessage() {}
 
-func (x *MatchRecord) ProtoReflect() protoreflect.Message {
+func (x *LogoutResp) ProtoReflect() protoreflect.Message {
 	mi := &file_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -464,61 +690,47 @@ func (x *MatchRecord) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MatchRecord.ProtoReflect.Descriptor instead.
-func (*MatchRecord) Descriptor() ([]byte, []int) {
+// Deprecated: Use LogoutResp.ProtoReflect.Descriptor instead.
+func (*LogoutResp) Descriptor() ([]byte, []int) {
 	return file_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *MatchRecord) GetMatchId() string {
-	if x != nil {
-		return x.MatchId
-	}
-	return ""
-}
-
-func (x *MatchRecord) GetIsWinner() bool {
+func (x *LogoutResp) GetSuccess() bool {
 	if x != nil {
-		return x.IsWinner
+		return x.Success
 	}
 	return false
 }
 
-func (x *MatchRecord) GetKills() int32 {
-	if x != nil {
-		return x.Kills
-	}
-	return 0
-}
-
-func (x *MatchRecord) GetTimestamp() int64 {
-	if x != nil {
-		return x.Timestamp
-	}
-	return 0
-}
-
-type CreateRoomReq struct {
+// JWK (RFC 7517)，RSA 填 n/e，Ed25519 填 crv/x
+type JWK struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Config        *RoomConfig            `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Kty           string                 `protobuf:"bytes,1,opt,name=kty,proto3" json:"kty,omitempty"`
+	Kid           string                 `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
+	Alg           string                 `protobuf:"bytes,3,opt,name=alg,proto3" json:"alg,omitempty"`
+	Use           string                 `protobuf:"bytes,4,opt,name=use,proto3" json:"use,omitempty"`
+	N             string                 `protobuf:"bytes,5,opt,name=n,proto3" json:"n,omitempty"`
+	E             string                 `protobuf:"bytes,6,opt,name=e,proto3" json:"e,omitempty"`
+	Crv           string                 `protobuf:"bytes,7,opt,name=crv,proto3" json:"crv,omitempty"`
+	X             string                 `protobuf:"bytes,8,opt,name=x,proto3" json:"x,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomReq) Reset() {
-	*x = CreateRoomReq{}
+func (x *JWK) Reset() {
+	*x = JWK{}
 	mi := &file_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomReq) String() string {
+func (x *JWK) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomReq) ProtoMessage() {}
+func (*JWK) ProtoMessage() {}
 
-func (x *CreateRoomReq) ProtoReflect() protoreflect.Message {
+func (x *JWK) ProtoReflect() protoreflect.Message {
 	mi := &file_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -530,48 +742,87 @@ func (x *CreateRoomReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomReq.ProtoReflect.Descriptor instead.
-func (*CreateRoomReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use JWK.ProtoReflect.Descriptor instead.
+func (*JWK) Descriptor() ([]byte, []int) {
 	return file_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateRoomReq) GetUid() int64 {
+func (x *JWK) GetKty() string {
 	if x != nil {
-		return x.Uid
+		return x.Kty
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateRoomReq) GetConfig() *RoomConfig {
+func (x *JWK) GetKid() string {
 	if x != nil {
-		return x.Config
+		return x.Kid
 	}
-	return nil
+	return ""
 }
 
-type RoomConfig struct {
+func (x *JWK) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *JWK) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *JWK) GetN() string {
+	if x != nil {
+		return x.N
+	}
+	return ""
+}
+
+func (x *JWK) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
+}
+
+func (x *JWK) GetCrv() string {
+	if x != nil {
+		return x.Crv
+	}
+	return ""
+}
+
+func (x *JWK) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+type GetJWKSReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomName      string                 `protobuf:"bytes,1,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
-	MapId         int32                  `protobuf:"varint,2,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
-	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomConfig) Reset() {
-	*x = RoomConfig{}
+func (x *GetJWKSReq) Reset() {
+	*x = GetJWKSReq{}
 	mi := &file_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomConfig) String() string {
+func (x *GetJWKSReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomConfig) ProtoMessage() {}
+func (*GetJWKSReq) ProtoMessage() {}
 
-func (x *RoomConfig) ProtoReflect() protoreflect.Message {
+func (x *GetJWKSReq) ProtoReflect() protoreflect.Message {
 	mi := &file_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -583,58 +834,82 @@ func (x *RoomConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomConfig.ProtoReflect.Descriptor instead.
-func (*RoomConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetJWKSReq.ProtoReflect.Descriptor instead.
+func (*GetJWKSReq) Descriptor() ([]byte, []int) {
 	return file_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RoomConfig) GetRoomName() string {
-	if x != nil {
-		return x.RoomName
-	}
-	return ""
+type GetJWKSResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*JWK                 `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomConfig) GetMapId() int32 {
+func (x *GetJWKSResp) Reset() {
+	*x = GetJWKSResp{}
+	mi := &file_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJWKSResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJWKSResp) ProtoMessage() {}
+
+func (x *GetJWKSResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[11]
 	if x != nil {
-		return x.MapId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *RoomConfig) GetMaxPlayers() int32 {
+// Deprecated: Use GetJWKSResp.ProtoReflect.Descriptor instead.
+func (*GetJWKSResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetJWKSResp) GetKeys() []*JWK {
 	if x != nil {
-		return x.MaxPlayers
+		return x.Keys
 	}
-	return 0
+	return nil
 }
 
-type CreateRoomResp struct {
+// DeadLetter 重试耗尽后进入死信队列的战绩消息
+type DeadLetter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	RoomName      string                 `protobuf:"bytes,2,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
-	ServerIp      string                 `protobuf:"bytes,3,opt,name=server_ip,json=serverIp,proto3" json:"server_ip,omitempty"`
-	ServerPort    int32                  `protobuf:"varint,4,opt,name=server_port,json=serverPort,proto3" json:"server_port,omitempty"`
-	RoomToken     string                 `protobuf:"bytes,5,opt,name=room_token,json=roomToken,proto3" json:"room_token,omitempty"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`                  // 最后一次处理失败的原因
+	Attempts      int32                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`           // 已处理次数
+	DeadAt        int64                  `protobuf:"varint,4,opt,name=dead_at,json=deadAt,proto3" json:"dead_at,omitempty"` // 进入死信队列的时间 (unix 秒)
+	Body          string                 `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`                    // 原始消息 JSON
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomResp) Reset() {
-	*x = CreateRoomResp{}
-	mi := &file_service_proto_msgTypes[11]
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomResp) String() string {
+func (x *DeadLetter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomResp) ProtoMessage() {}
+func (*DeadLetter) ProtoMessage() {}
 
-func (x *CreateRoomResp) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[11]
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,67 +920,68 @@ func (x *CreateRoomResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomResp.ProtoReflect.Descriptor instead.
-func (*CreateRoomResp) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CreateRoomResp) GetRoomId() string {
+func (x *DeadLetter) GetMatchId() string {
 	if x != nil {
-		return x.RoomId
+		return x.MatchId
 	}
 	return ""
 }
 
-func (x *CreateRoomResp) GetRoomName() string {
+func (x *DeadLetter) GetError() string {
 	if x != nil {
-		return x.RoomName
+		return x.Error
 	}
 	return ""
 }
 
-func (x *CreateRoomResp) GetServerIp() string {
+func (x *DeadLetter) GetAttempts() int32 {
 	if x != nil {
-		return x.ServerIp
+		return x.Attempts
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateRoomResp) GetServerPort() int32 {
+func (x *DeadLetter) GetDeadAt() int64 {
 	if x != nil {
-		return x.ServerPort
+		return x.DeadAt
 	}
 	return 0
 }
 
-func (x *CreateRoomResp) GetRoomToken() string {
+func (x *DeadLetter) GetBody() string {
 	if x != nil {
-		return x.RoomToken
+		return x.Body
 	}
 	return ""
 }
 
-type ListRoomsReq struct {
+type ListDeadLettersReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // 最多返回条数，默认 20
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRoomsReq) Reset() {
-	*x = ListRoomsReq{}
-	mi := &file_service_proto_msgTypes[12]
+func (x *ListDeadLettersReq) Reset() {
+	*x = ListDeadLettersReq{}
+	mi := &file_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRoomsReq) String() string {
+func (x *ListDeadLettersReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRoomsReq) ProtoMessage() {}
+func (*ListDeadLettersReq) ProtoMessage() {}
 
-func (x *ListRoomsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[12]
+func (x *ListDeadLettersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,33 +992,41 @@ func (x *ListRoomsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRoomsReq.ProtoReflect.Descriptor instead.
-func (*ListRoomsReq) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListDeadLettersReq.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{13}
 }
 
-type ListRoomsResp struct {
+func (x *ListDeadLettersReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeadLettersResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rooms         []*RoomInfo            `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	Letters       []*DeadLetter          `protobuf:"bytes,1,rep,name=letters,proto3" json:"letters,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // 死信队列中的消息总数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRoomsResp) Reset() {
-	*x = ListRoomsResp{}
-	mi := &file_service_proto_msgTypes[13]
+func (x *ListDeadLettersResp) Reset() {
+	*x = ListDeadLettersResp{}
+	mi := &file_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRoomsResp) String() string {
+func (x *ListDeadLettersResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRoomsResp) ProtoMessage() {}
+func (*ListDeadLettersResp) ProtoMessage() {}
 
-func (x *ListRoomsResp) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[13]
+func (x *ListDeadLettersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -753,44 +1037,48 @@ func (x *ListRoomsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRoomsResp.ProtoReflect.Descriptor instead.
-func (*ListRoomsResp) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListDeadLettersResp.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListRoomsResp) GetRooms() []*RoomInfo {
+func (x *ListDeadLettersResp) GetLetters() []*DeadLetter {
 	if x != nil {
-		return x.Rooms
+		return x.Letters
 	}
 	return nil
 }
 
-type RoomInfo struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	RoomId         string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	RoomName       string                 `protobuf:"bytes,2,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
-	CurrentPlayers int32                  `protobuf:"varint,3,opt,name=current_players,json=currentPlayers,proto3" json:"current_players,omitempty"`
-	MaxPlayers     int32                  `protobuf:"varint,4,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
-	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ListDeadLettersResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
 }
 
-func (x *RoomInfo) Reset() {
-	*x = RoomInfo{}
-	mi := &file_service_proto_msgTypes[14]
+type ReplayDeadLettersReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchIds      []string               `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"` // 指定重放的比赛
+	All           bool                   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`                          // 重放全部死信，忽略 match_ids
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLettersReq) Reset() {
+	*x = ReplayDeadLettersReq{}
+	mi := &file_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomInfo) String() string {
+func (x *ReplayDeadLettersReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomInfo) ProtoMessage() {}
+func (*ReplayDeadLettersReq) ProtoMessage() {}
 
-func (x *RoomInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[14]
+func (x *ReplayDeadLettersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,69 +1089,93 @@ func (x *RoomInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
-func (*RoomInfo) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ReplayDeadLettersReq.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *RoomInfo) GetRoomId() string {
+func (x *ReplayDeadLettersReq) GetMatchIds() []string {
 	if x != nil {
-		return x.RoomId
+		return x.MatchIds
 	}
-	return ""
+	return nil
 }
 
-func (x *RoomInfo) GetRoomName() string {
+func (x *ReplayDeadLettersReq) GetAll() bool {
 	if x != nil {
-		return x.RoomName
+		return x.All
 	}
-	return ""
+	return false
 }
 
-func (x *RoomInfo) GetCurrentPlayers() int32 {
-	if x != nil {
-		return x.CurrentPlayers
-	}
-	return 0
+type ReplayDeadLettersResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replayed      int32                  `protobuf:"varint,1,opt,name=replayed,proto3" json:"replayed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomInfo) GetMaxPlayers() int32 {
+func (x *ReplayDeadLettersResp) Reset() {
+	*x = ReplayDeadLettersResp{}
+	mi := &file_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLettersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLettersResp) ProtoMessage() {}
+
+func (x *ReplayDeadLettersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[16]
 	if x != nil {
-		return x.MaxPlayers
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *RoomInfo) GetStatus() string {
+// Deprecated: Use ReplayDeadLettersResp.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLettersResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReplayDeadLettersResp) GetReplayed() int32 {
 	if x != nil {
-		return x.Status
+		return x.Replayed
 	}
-	return ""
+	return 0
 }
 
-type JoinRoomReq struct {
+type GetHistoryReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinRoomReq) Reset() {
-	*x = JoinRoomReq{}
-	mi := &file_service_proto_msgTypes[15]
+func (x *GetHistoryReq) Reset() {
+	*x = GetHistoryReq{}
+	mi := &file_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRoomReq) String() string {
+func (x *GetHistoryReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRoomReq) ProtoMessage() {}
+func (*GetHistoryReq) ProtoMessage() {}
 
-func (x *JoinRoomReq) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[15]
+func (x *GetHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -874,50 +1186,54 @@ func (x *JoinRoomReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRoomReq.ProtoReflect.Descriptor instead.
-func (*JoinRoomReq) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use GetHistoryReq.ProtoReflect.Descriptor instead.
+func (*GetHistoryReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *JoinRoomReq) GetRoomId() string {
+func (x *GetHistoryReq) GetUid() int64 {
 	if x != nil {
-		return x.RoomId
+		return x.Uid
 	}
-	return ""
+	return 0
 }
 
-func (x *JoinRoomReq) GetUid() int64 {
+func (x *GetHistoryReq) GetPage() int32 {
 	if x != nil {
-		return x.Uid
+		return x.Page
 	}
 	return 0
 }
 
-type JoinRoomResp struct {
+func (x *GetHistoryReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetHistoryResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	ServerIp      string                 `protobuf:"bytes,2,opt,name=server_ip,json=serverIp,proto3" json:"server_ip,omitempty"`
-	ServerPort    int32                  `protobuf:"varint,3,opt,name=server_port,json=serverPort,proto3" json:"server_port,omitempty"`
-	RoomToken     string                 `protobuf:"bytes,4,opt,name=room_token,json=roomToken,proto3" json:"room_token,omitempty"`
+	History       []*MatchRecord         `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *JoinRoomResp) Reset() {
-	*x = JoinRoomResp{}
-	mi := &file_service_proto_msgTypes[16]
+func (x *GetHistoryResp) Reset() {
+	*x = GetHistoryResp{}
+	mi := &file_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRoomResp) String() string {
+func (x *GetHistoryResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRoomResp) ProtoMessage() {}
+func (*GetHistoryResp) ProtoMessage() {}
 
-func (x *JoinRoomResp) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[16]
+func (x *GetHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,63 +1244,4900 @@ func (x *JoinRoomResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRoomResp.ProtoReflect.Descriptor instead.
-func (*JoinRoomResp) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *JoinRoomResp) GetRoomId() string {
-	if x != nil {
-		return x.RoomId
-	}
-	return ""
+// Deprecated: Use GetHistoryResp.ProtoReflect.Descriptor instead.
+func (*GetHistoryResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *JoinRoomResp) GetServerIp() string {
+func (x *GetHistoryResp) GetHistory() []*MatchRecord {
 	if x != nil {
-		return x.ServerIp
+		return x.History
 	}
-	return ""
+	return nil
 }
 
-func (x *JoinRoomResp) GetServerPort() int32 {
+type MatchRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	IsWinner      bool                   `protobuf:"varint,2,opt,name=is_winner,json=isWinner,proto3" json:"is_winner,omitempty"`
+	Kills         int32                  `protobuf:"varint,3,opt,name=kills,proto3" json:"kills,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Placement     int32                  `protobuf:"varint,5,opt,name=placement,proto3" json:"placement,omitempty"`
+	Deaths        int32                  `protobuf:"varint,6,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	MapId         int32                  `protobuf:"varint,7,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchRecord) Reset() {
+	*x = MatchRecord{}
+	mi := &file_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRecord) ProtoMessage() {}
+
+func (x *MatchRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRecord.ProtoReflect.Descriptor instead.
+func (*MatchRecord) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MatchRecord) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetIsWinner() bool {
+	if x != nil {
+		return x.IsWinner
+	}
+	return false
+}
+
+func (x *MatchRecord) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetPlacement() int32 {
+	if x != nil {
+		return x.Placement
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetMapId() int32 {
+	if x != nil {
+		return x.MapId
+	}
+	return 0
+}
+
+// --- 段位分 (Glicko-2) ---
+type RatingInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Season        string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"`
+	Rating        float64                `protobuf:"fixed64,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Deviation     float64                `protobuf:"fixed64,5,opt,name=deviation,proto3" json:"deviation,omitempty"` // 评分偏差 RD，越小越可信
+	Volatility    float64                `protobuf:"fixed64,6,opt,name=volatility,proto3" json:"volatility,omitempty"`
+	Matches       int32                  `protobuf:"varint,7,opt,name=matches,proto3" json:"matches,omitempty"`
+	Wins          int32                  `protobuf:"varint,8,opt,name=wins,proto3" json:"wins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingInfo) Reset() {
+	*x = RatingInfo{}
+	mi := &file_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingInfo) ProtoMessage() {}
+
+func (x *RatingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingInfo.ProtoReflect.Descriptor instead.
+func (*RatingInfo) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RatingInfo) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RatingInfo) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *RatingInfo) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *RatingInfo) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *RatingInfo) GetDeviation() float64 {
+	if x != nil {
+		return x.Deviation
+	}
+	return 0
+}
+
+func (x *RatingInfo) GetVolatility() float64 {
+	if x != nil {
+		return x.Volatility
+	}
+	return 0
+}
+
+func (x *RatingInfo) GetMatches() int32 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *RatingInfo) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+type GetRatingReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`     // 为空时使用默认模式
+	Season        string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"` // 为空时使用当前赛季
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingReq) Reset() {
+	*x = GetRatingReq{}
+	mi := &file_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingReq) ProtoMessage() {}
+
+func (x *GetRatingReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingReq.ProtoReflect.Descriptor instead.
+func (*GetRatingReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetRatingReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetRatingReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetRatingReq) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+type GetRatingResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        *RatingInfo            `protobuf:"bytes,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingResp) Reset() {
+	*x = GetRatingResp{}
+	mi := &file_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingResp) ProtoMessage() {}
+
+func (x *GetRatingResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingResp.ProtoReflect.Descriptor instead.
+func (*GetRatingResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetRatingResp) GetRating() *RatingInfo {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+type BatchGetRatingsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uids          []int64                `protobuf:"varint,1,rep,packed,name=uids,proto3" json:"uids,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Season        string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetRatingsReq) Reset() {
+	*x = BatchGetRatingsReq{}
+	mi := &file_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRatingsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRatingsReq) ProtoMessage() {}
+
+func (x *BatchGetRatingsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRatingsReq.ProtoReflect.Descriptor instead.
+func (*BatchGetRatingsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BatchGetRatingsReq) GetUids() []int64 {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
+}
+
+func (x *BatchGetRatingsReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *BatchGetRatingsReq) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+type BatchGetRatingsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ratings       []*RatingInfo          `protobuf:"bytes,1,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetRatingsResp) Reset() {
+	*x = BatchGetRatingsResp{}
+	mi := &file_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRatingsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRatingsResp) ProtoMessage() {}
+
+func (x *BatchGetRatingsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRatingsResp.ProtoReflect.Descriptor instead.
+func (*BatchGetRatingsResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BatchGetRatingsResp) GetRatings() []*RatingInfo {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+type GetRatingHistoryReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Season        string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingHistoryReq) Reset() {
+	*x = GetRatingHistoryReq{}
+	mi := &file_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingHistoryReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingHistoryReq) ProtoMessage() {}
+
+func (x *GetRatingHistoryReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingHistoryReq.ProtoReflect.Descriptor instead.
+func (*GetRatingHistoryReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetRatingHistoryReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetRatingHistoryReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetRatingHistoryReq) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *GetRatingHistoryReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetRatingHistoryReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RatingChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	RatingBefore  float64                `protobuf:"fixed64,2,opt,name=rating_before,json=ratingBefore,proto3" json:"rating_before,omitempty"`
+	RatingAfter   float64                `protobuf:"fixed64,3,opt,name=rating_after,json=ratingAfter,proto3" json:"rating_after,omitempty"`
+	Delta         float64                `protobuf:"fixed64,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	Placement     int32                  `protobuf:"varint,5,opt,name=placement,proto3" json:"placement,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingChange) Reset() {
+	*x = RatingChange{}
+	mi := &file_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingChange) ProtoMessage() {}
+
+func (x *RatingChange) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingChange.ProtoReflect.Descriptor instead.
+func (*RatingChange) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RatingChange) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RatingChange) GetRatingBefore() float64 {
+	if x != nil {
+		return x.RatingBefore
+	}
+	return 0
+}
+
+func (x *RatingChange) GetRatingAfter() float64 {
+	if x != nil {
+		return x.RatingAfter
+	}
+	return 0
+}
+
+func (x *RatingChange) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *RatingChange) GetPlacement() int32 {
+	if x != nil {
+		return x.Placement
+	}
+	return 0
+}
+
+func (x *RatingChange) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type GetRatingHistoryResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	History       []*RatingChange        `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingHistoryResp) Reset() {
+	*x = GetRatingHistoryResp{}
+	mi := &file_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingHistoryResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingHistoryResp) ProtoMessage() {}
+
+func (x *GetRatingHistoryResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingHistoryResp.ProtoReflect.Descriptor instead.
+func (*GetRatingHistoryResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetRatingHistoryResp) GetHistory() []*RatingChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+// --- 排行榜 ---
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int64                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"` // 从 1 开始
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *LeaderboardEntry) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetLeaderboardReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Board         string                 `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`   // wins / kills / kd / rating
+	Season        string                 `protobuf:"bytes,2,opt,name=season,proto3" json:"season,omitempty"` // 为空表示全服总榜（rating 榜为当前赛季）
+	Mode          string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`     // 仅 rating 榜使用
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardReq) Reset() {
+	*x = GetLeaderboardReq{}
+	mi := &file_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardReq) ProtoMessage() {}
+
+func (x *GetLeaderboardReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardReq.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetLeaderboardReq) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *GetLeaderboardReq) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *GetLeaderboardReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetLeaderboardReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetLeaderboardReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetLeaderboardResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderboardResp) Reset() {
+	*x = GetLeaderboardResp{}
+	mi := &file_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderboardResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderboardResp) ProtoMessage() {}
+
+func (x *GetLeaderboardResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderboardResp.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetLeaderboardResp) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetLeaderboardResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetRankReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Board         string                 `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Season        string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"`
+	Mode          string                 `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	Neighbours    int32                  `protobuf:"varint,5,opt,name=neighbours,proto3" json:"neighbours,omitempty"` // 返回前后各多少名
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankReq) Reset() {
+	*x = GetRankReq{}
+	mi := &file_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankReq) ProtoMessage() {}
+
+func (x *GetRankReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankReq.ProtoReflect.Descriptor instead.
+func (*GetRankReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetRankReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetRankReq) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *GetRankReq) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+func (x *GetRankReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetRankReq) GetNeighbours() int32 {
+	if x != nil {
+		return x.Neighbours
+	}
+	return 0
+}
+
+type GetRankResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *LeaderboardEntry      `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"` // 未上榜时 rank 为 0
+	Neighbours    []*LeaderboardEntry    `protobuf:"bytes,2,rep,name=neighbours,proto3" json:"neighbours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRankResp) Reset() {
+	*x = GetRankResp{}
+	mi := &file_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRankResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRankResp) ProtoMessage() {}
+
+func (x *GetRankResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRankResp.ProtoReflect.Descriptor instead.
+func (*GetRankResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetRankResp) GetEntry() *LeaderboardEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *GetRankResp) GetNeighbours() []*LeaderboardEntry {
+	if x != nil {
+		return x.Neighbours
+	}
+	return nil
+}
+
+type Presence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        Presence_Status        `protobuf:"varint,1,opt,name=status,proto3,enum=pb.Presence_Status" json:"status,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // IN_QUEUE / IN_MATCH 时所在房间
+	UpdatedAt     int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Presence) Reset() {
+	*x = Presence{}
+	mi := &file_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Presence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Presence) ProtoMessage() {}
+
+func (x *Presence) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Presence.ProtoReflect.Descriptor instead.
+func (*Presence) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Presence) GetStatus() Presence_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Presence_OFFLINE
+}
+
+func (x *Presence) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *Presence) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type FriendInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Presence      *Presence              `protobuf:"bytes,3,opt,name=presence,proto3" json:"presence,omitempty"`
+	Since         int64                  `protobuf:"varint,4,opt,name=since,proto3" json:"since,omitempty"` // 成为好友的时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendInfo) Reset() {
+	*x = FriendInfo{}
+	mi := &file_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendInfo) ProtoMessage() {}
+
+func (x *FriendInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendInfo.ProtoReflect.Descriptor instead.
+func (*FriendInfo) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FriendInfo) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *FriendInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *FriendInfo) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+func (x *FriendInfo) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type FriendRequestInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     int64                  `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	FromUid       int64                  `protobuf:"varint,2,opt,name=from_uid,json=fromUid,proto3" json:"from_uid,omitempty"`
+	FromUsername  string                 `protobuf:"bytes,3,opt,name=from_username,json=fromUsername,proto3" json:"from_username,omitempty"`
+	ToUid         int64                  `protobuf:"varint,4,opt,name=to_uid,json=toUid,proto3" json:"to_uid,omitempty"`
+	ToUsername    string                 `protobuf:"bytes,5,opt,name=to_username,json=toUsername,proto3" json:"to_username,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequestInfo) Reset() {
+	*x = FriendRequestInfo{}
+	mi := &file_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequestInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequestInfo) ProtoMessage() {}
+
+func (x *FriendRequestInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequestInfo.ProtoReflect.Descriptor instead.
+func (*FriendRequestInfo) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FriendRequestInfo) GetRequestId() int64 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *FriendRequestInfo) GetFromUid() int64 {
+	if x != nil {
+		return x.FromUid
+	}
+	return 0
+}
+
+func (x *FriendRequestInfo) GetFromUsername() string {
+	if x != nil {
+		return x.FromUsername
+	}
+	return ""
+}
+
+func (x *FriendRequestInfo) GetToUid() int64 {
+	if x != nil {
+		return x.ToUid
+	}
+	return 0
+}
+
+func (x *FriendRequestInfo) GetToUsername() string {
+	if x != nil {
+		return x.ToUsername
+	}
+	return ""
+}
+
+func (x *FriendRequestInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type FriendActionResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendActionResp) Reset() {
+	*x = FriendActionResp{}
+	mi := &file_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendActionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendActionResp) ProtoMessage() {}
+
+func (x *FriendActionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendActionResp.ProtoReflect.Descriptor instead.
+func (*FriendActionResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *FriendActionResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FriendActionResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SendFriendRequestReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	TargetUid     int64                  `protobuf:"varint,2,opt,name=target_uid,json=targetUid,proto3" json:"target_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendFriendRequestReq) Reset() {
+	*x = SendFriendRequestReq{}
+	mi := &file_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendFriendRequestReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendFriendRequestReq) ProtoMessage() {}
+
+func (x *SendFriendRequestReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendFriendRequestReq.ProtoReflect.Descriptor instead.
+func (*SendFriendRequestReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SendFriendRequestReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *SendFriendRequestReq) GetTargetUid() int64 {
+	if x != nil {
+		return x.TargetUid
+	}
+	return 0
+}
+
+type RespondFriendRequestReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	RequestId     int64                  `protobuf:"varint,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondFriendRequestReq) Reset() {
+	*x = RespondFriendRequestReq{}
+	mi := &file_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondFriendRequestReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondFriendRequestReq) ProtoMessage() {}
+
+func (x *RespondFriendRequestReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondFriendRequestReq.ProtoReflect.Descriptor instead.
+func (*RespondFriendRequestReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RespondFriendRequestReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RespondFriendRequestReq) GetRequestId() int64 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+func (x *RespondFriendRequestReq) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type RemoveFriendReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	FriendUid     int64                  `protobuf:"varint,2,opt,name=friend_uid,json=friendUid,proto3" json:"friend_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFriendReq) Reset() {
+	*x = RemoveFriendReq{}
+	mi := &file_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFriendReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFriendReq) ProtoMessage() {}
+
+func (x *RemoveFriendReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFriendReq.ProtoReflect.Descriptor instead.
+func (*RemoveFriendReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemoveFriendReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RemoveFriendReq) GetFriendUid() int64 {
+	if x != nil {
+		return x.FriendUid
+	}
+	return 0
+}
+
+type BlockUserReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	TargetUid     int64                  `protobuf:"varint,2,opt,name=target_uid,json=targetUid,proto3" json:"target_uid,omitempty"`
+	Unblock       bool                   `protobuf:"varint,3,opt,name=unblock,proto3" json:"unblock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserReq) Reset() {
+	*x = BlockUserReq{}
+	mi := &file_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserReq) ProtoMessage() {}
+
+func (x *BlockUserReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserReq.ProtoReflect.Descriptor instead.
+func (*BlockUserReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BlockUserReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *BlockUserReq) GetTargetUid() int64 {
+	if x != nil {
+		return x.TargetUid
+	}
+	return 0
+}
+
+func (x *BlockUserReq) GetUnblock() bool {
+	if x != nil {
+		return x.Unblock
+	}
+	return false
+}
+
+type GetFriendsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendsReq) Reset() {
+	*x = GetFriendsReq{}
+	mi := &file_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendsReq) ProtoMessage() {}
+
+func (x *GetFriendsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendsReq.ProtoReflect.Descriptor instead.
+func (*GetFriendsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetFriendsReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type GetFriendsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Friends       []*FriendInfo          `protobuf:"bytes,1,rep,name=friends,proto3" json:"friends,omitempty"`
+	BlockedUids   []int64                `protobuf:"varint,2,rep,packed,name=blocked_uids,json=blockedUids,proto3" json:"blocked_uids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendsResp) Reset() {
+	*x = GetFriendsResp{}
+	mi := &file_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendsResp) ProtoMessage() {}
+
+func (x *GetFriendsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendsResp.ProtoReflect.Descriptor instead.
+func (*GetFriendsResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetFriendsResp) GetFriends() []*FriendInfo {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+func (x *GetFriendsResp) GetBlockedUids() []int64 {
+	if x != nil {
+		return x.BlockedUids
+	}
+	return nil
+}
+
+type GetFriendRequestsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendRequestsReq) Reset() {
+	*x = GetFriendRequestsReq{}
+	mi := &file_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendRequestsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendRequestsReq) ProtoMessage() {}
+
+func (x *GetFriendRequestsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendRequestsReq.ProtoReflect.Descriptor instead.
+func (*GetFriendRequestsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetFriendRequestsReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type GetFriendRequestsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incoming      []*FriendRequestInfo   `protobuf:"bytes,1,rep,name=incoming,proto3" json:"incoming,omitempty"`
+	Outgoing      []*FriendRequestInfo   `protobuf:"bytes,2,rep,name=outgoing,proto3" json:"outgoing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendRequestsResp) Reset() {
+	*x = GetFriendRequestsResp{}
+	mi := &file_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendRequestsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendRequestsResp) ProtoMessage() {}
+
+func (x *GetFriendRequestsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendRequestsResp.ProtoReflect.Descriptor instead.
+func (*GetFriendRequestsResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetFriendRequestsResp) GetIncoming() []*FriendRequestInfo {
+	if x != nil {
+		return x.Incoming
+	}
+	return nil
+}
+
+func (x *GetFriendRequestsResp) GetOutgoing() []*FriendRequestInfo {
+	if x != nil {
+		return x.Outgoing
+	}
+	return nil
+}
+
+type UpdatePresenceReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Status        Presence_Status        `protobuf:"varint,2,opt,name=status,proto3,enum=pb.Presence_Status" json:"status,omitempty"`
+	RoomId        string                 `protobuf:"bytes,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Touch         bool                   `protobuf:"varint,4,opt,name=touch,proto3" json:"touch,omitempty"` // 只续期当前状态，没有状态时视为 LOBBY
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePresenceReq) Reset() {
+	*x = UpdatePresenceReq{}
+	mi := &file_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePresenceReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePresenceReq) ProtoMessage() {}
+
+func (x *UpdatePresenceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePresenceReq.ProtoReflect.Descriptor instead.
+func (*UpdatePresenceReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UpdatePresenceReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *UpdatePresenceReq) GetStatus() Presence_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Presence_OFFLINE
+}
+
+func (x *UpdatePresenceReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *UpdatePresenceReq) GetTouch() bool {
+	if x != nil {
+		return x.Touch
+	}
+	return false
+}
+
+type UpdatePresenceResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePresenceResp) Reset() {
+	*x = UpdatePresenceResp{}
+	mi := &file_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePresenceResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePresenceResp) ProtoMessage() {}
+
+func (x *UpdatePresenceResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePresenceResp.ProtoReflect.Descriptor instead.
+func (*UpdatePresenceResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{46}
+}
+
+type GetFriendRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	FriendUid     int64                  `protobuf:"varint,2,opt,name=friend_uid,json=friendUid,proto3" json:"friend_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendRoomReq) Reset() {
+	*x = GetFriendRoomReq{}
+	mi := &file_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendRoomReq) ProtoMessage() {}
+
+func (x *GetFriendRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendRoomReq.ProtoReflect.Descriptor instead.
+func (*GetFriendRoomReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetFriendRoomReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetFriendRoomReq) GetFriendUid() int64 {
+	if x != nil {
+		return x.FriendUid
+	}
+	return 0
+}
+
+type GetFriendRoomResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 好友不在房间中时为空
+	Presence      *Presence              `protobuf:"bytes,2,opt,name=presence,proto3" json:"presence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFriendRoomResp) Reset() {
+	*x = GetFriendRoomResp{}
+	mi := &file_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFriendRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFriendRoomResp) ProtoMessage() {}
+
+func (x *GetFriendRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFriendRoomResp.ProtoReflect.Descriptor instead.
+func (*GetFriendRoomResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetFriendRoomResp) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *GetFriendRoomResp) GetPresence() *Presence {
+	if x != nil {
+		return x.Presence
+	}
+	return nil
+}
+
+type AreFriendsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	FriendUid     int64                  `protobuf:"varint,2,opt,name=friend_uid,json=friendUid,proto3" json:"friend_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreFriendsReq) Reset() {
+	*x = AreFriendsReq{}
+	mi := &file_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreFriendsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreFriendsReq) ProtoMessage() {}
+
+func (x *AreFriendsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreFriendsReq.ProtoReflect.Descriptor instead.
+func (*AreFriendsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *AreFriendsReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AreFriendsReq) GetFriendUid() int64 {
+	if x != nil {
+		return x.FriendUid
+	}
+	return 0
+}
+
+type AreFriendsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Friends       bool                   `protobuf:"varint,1,opt,name=friends,proto3" json:"friends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreFriendsResp) Reset() {
+	*x = AreFriendsResp{}
+	mi := &file_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreFriendsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreFriendsResp) ProtoMessage() {}
+
+func (x *AreFriendsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreFriendsResp.ProtoReflect.Descriptor instead.
+func (*AreFriendsResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AreFriendsResp) GetFriends() bool {
+	if x != nil {
+		return x.Friends
+	}
+	return false
+}
+
+// --- 个人资料 ---
+type GetProfileReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"`     // 段位分所属模式，为空时使用默认模式
+	Season        string                 `protobuf:"bytes,3,opt,name=season,proto3" json:"season,omitempty"` // 为空时使用当前赛季
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileReq) Reset() {
+	*x = GetProfileReq{}
+	mi := &file_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileReq) ProtoMessage() {}
+
+func (x *GetProfileReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileReq.ProtoReflect.Descriptor instead.
+func (*GetProfileReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetProfileReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetProfileReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetProfileReq) GetSeason() string {
+	if x != nil {
+		return x.Season
+	}
+	return ""
+}
+
+type PlayerProfile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uid            int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	JoinedAt       int64                  `protobuf:"varint,3,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	TotalMatches   int32                  `protobuf:"varint,4,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	Wins           int32                  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	WinRate        float64                `protobuf:"fixed64,6,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`
+	Kills          int32                  `protobuf:"varint,7,opt,name=kills,proto3" json:"kills,omitempty"`
+	Deaths         int32                  `protobuf:"varint,8,opt,name=deaths,proto3" json:"deaths,omitempty"`
+	Kd             float64                `protobuf:"fixed64,9,opt,name=kd,proto3" json:"kd,omitempty"`
+	FavouriteMapId int32                  `protobuf:"varint,10,opt,name=favourite_map_id,json=favouriteMapId,proto3" json:"favourite_map_id,omitempty"` // 没有对局记录时为 0
+	Rating         *RatingInfo            `protobuf:"bytes,11,opt,name=rating,proto3" json:"rating,omitempty"`
+	RecentForm     string                 `protobuf:"bytes,12,opt,name=recent_form,json=recentForm,proto3" json:"recent_form,omitempty"` // 最近对局胜负，新的在前，如 "WWLWL"
+	LastMatchAt    int64                  `protobuf:"varint,13,opt,name=last_match_at,json=lastMatchAt,proto3" json:"last_match_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PlayerProfile) Reset() {
+	*x = PlayerProfile{}
+	mi := &file_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerProfile) ProtoMessage() {}
+
+func (x *PlayerProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerProfile.ProtoReflect.Descriptor instead.
+func (*PlayerProfile) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *PlayerProfile) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PlayerProfile) GetJoinedAt() int64 {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetWinRate() float64 {
+	if x != nil {
+		return x.WinRate
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetKills() int32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetDeaths() int32 {
+	if x != nil {
+		return x.Deaths
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetKd() float64 {
+	if x != nil {
+		return x.Kd
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetFavouriteMapId() int32 {
+	if x != nil {
+		return x.FavouriteMapId
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetRating() *RatingInfo {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+func (x *PlayerProfile) GetRecentForm() string {
+	if x != nil {
+		return x.RecentForm
+	}
+	return ""
+}
+
+func (x *PlayerProfile) GetLastMatchAt() int64 {
+	if x != nil {
+		return x.LastMatchAt
+	}
+	return 0
+}
+
+type GetProfileResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profile       *PlayerProfile         `protobuf:"bytes,1,opt,name=profile,proto3" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileResp) Reset() {
+	*x = GetProfileResp{}
+	mi := &file_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileResp) ProtoMessage() {}
+
+func (x *GetProfileResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileResp.ProtoReflect.Descriptor instead.
+func (*GetProfileResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetProfileResp) GetProfile() *PlayerProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type CreateRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Config        *RoomConfig            `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomReq) Reset() {
+	*x = CreateRoomReq{}
+	mi := &file_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomReq) ProtoMessage() {}
+
+func (x *CreateRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomReq.ProtoReflect.Descriptor instead.
+func (*CreateRoomReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CreateRoomReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CreateRoomReq) GetConfig() *RoomConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type RoomConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomName      string                 `protobuf:"bytes,1,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
+	MapId         int32                  `protobuf:"varint,2,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	Visibility    RoomConfig_Visibility  `protobuf:"varint,4,opt,name=visibility,proto3,enum=pb.RoomConfig_Visibility" json:"visibility,omitempty"`
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"` // 明文，服务端只保存哈希
+	Mode          string                 `protobuf:"bytes,6,opt,name=mode,proto3" json:"mode,omitempty"`         // 游戏模式：ffa 个人混战 / tdm 团队死斗，为空时为 ffa
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomConfig) Reset() {
+	*x = RoomConfig{}
+	mi := &file_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomConfig) ProtoMessage() {}
+
+func (x *RoomConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomConfig.ProtoReflect.Descriptor instead.
+func (*RoomConfig) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RoomConfig) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *RoomConfig) GetMapId() int32 {
+	if x != nil {
+		return x.MapId
+	}
+	return 0
+}
+
+func (x *RoomConfig) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *RoomConfig) GetVisibility() RoomConfig_Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return RoomConfig_UNSPECIFIED
+}
+
+func (x *RoomConfig) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RoomConfig) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+type CreateRoomResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	RoomName      string                 `protobuf:"bytes,2,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
+	ServerIp      string                 `protobuf:"bytes,3,opt,name=server_ip,json=serverIp,proto3" json:"server_ip,omitempty"`
+	ServerPort    int32                  `protobuf:"varint,4,opt,name=server_port,json=serverPort,proto3" json:"server_port,omitempty"`
+	RoomToken     string                 `protobuf:"bytes,5,opt,name=room_token,json=roomToken,proto3" json:"room_token,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,6,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	Tickets       []*RoomTicket          `protobuf:"bytes,7,rep,name=tickets,proto3" json:"tickets,omitempty"` // 队伍一起创建时，每名队员各自的 ticket
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomResp) Reset() {
+	*x = CreateRoomResp{}
+	mi := &file_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomResp) ProtoMessage() {}
+
+func (x *CreateRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomResp.ProtoReflect.Descriptor instead.
+func (*CreateRoomResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CreateRoomResp) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *CreateRoomResp) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *CreateRoomResp) GetServerIp() string {
+	if x != nil {
+		return x.ServerIp
+	}
+	return ""
+}
+
+func (x *CreateRoomResp) GetServerPort() int32 {
+	if x != nil {
+		return x.ServerPort
+	}
+	return 0
+}
+
+func (x *CreateRoomResp) GetRoomToken() string {
+	if x != nil {
+		return x.RoomToken
+	}
+	return ""
+}
+
+func (x *CreateRoomResp) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *CreateRoomResp) GetTickets() []*RoomTicket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+type RoomTicket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Ticket        string                 `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Team          int32                  `protobuf:"varint,3,opt,name=team,proto3" json:"team,omitempty"` // 团队模式下的队伍编号，从 1 开始
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomTicket) Reset() {
+	*x = RoomTicket{}
+	mi := &file_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomTicket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomTicket) ProtoMessage() {}
+
+func (x *RoomTicket) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomTicket.ProtoReflect.Descriptor instead.
+func (*RoomTicket) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RoomTicket) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RoomTicket) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *RoomTicket) GetTeam() int32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+type ListRoomsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`             // 为空不过滤
+	MapId         int32                  `protobuf:"varint,2,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"` // 0 不过滤
+	NotFull       bool                   `protobuf:"varint,3,opt,name=not_full,json=notFull,proto3" json:"not_full,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"` // 房间名模糊搜索
+	SortBy        ListRoomsReq_SortBy    `protobuf:"varint,5,opt,name=sort_by,json=sortBy,proto3,enum=pb.ListRoomsReq_SortBy" json:"sort_by,omitempty"`
+	Cursor        string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"` // 上一页返回的 next_cursor，首页为空
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsReq) Reset() {
+	*x = ListRoomsReq{}
+	mi := &file_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsReq) ProtoMessage() {}
+
+func (x *ListRoomsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsReq.ProtoReflect.Descriptor instead.
+func (*ListRoomsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListRoomsReq) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListRoomsReq) GetMapId() int32 {
+	if x != nil {
+		return x.MapId
+	}
+	return 0
+}
+
+func (x *ListRoomsReq) GetNotFull() bool {
+	if x != nil {
+		return x.NotFull
+	}
+	return false
+}
+
+func (x *ListRoomsReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListRoomsReq) GetSortBy() ListRoomsReq_SortBy {
+	if x != nil {
+		return x.SortBy
+	}
+	return ListRoomsReq_NEWEST
+}
+
+func (x *ListRoomsReq) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRoomsReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRoomsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rooms         []*RoomInfo            `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 为空表示没有下一页
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsResp) Reset() {
+	*x = ListRoomsResp{}
+	mi := &file_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsResp) ProtoMessage() {}
+
+func (x *ListRoomsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsResp.ProtoReflect.Descriptor instead.
+func (*ListRoomsResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListRoomsResp) GetRooms() []*RoomInfo {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *ListRoomsResp) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListRoomsResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type RoomInfo struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RoomId         string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	RoomName       string                 `protobuf:"bytes,2,opt,name=room_name,json=roomName,proto3" json:"room_name,omitempty"`
+	CurrentPlayers int32                  `protobuf:"varint,3,opt,name=current_players,json=currentPlayers,proto3" json:"current_players,omitempty"`
+	MaxPlayers     int32                  `protobuf:"varint,4,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	HasPassword    bool                   `protobuf:"varint,6,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	HostUid        int64                  `protobuf:"varint,7,opt,name=host_uid,json=hostUid,proto3" json:"host_uid,omitempty"`
+	MapId          int32                  `protobuf:"varint,8,opt,name=map_id,json=mapId,proto3" json:"map_id,omitempty"`
+	CreatedAt      int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ServerRegion   string                 `protobuf:"bytes,10,opt,name=server_region,json=serverRegion,proto3" json:"server_region,omitempty"`
+	ServerAddr     string                 `protobuf:"bytes,11,opt,name=server_addr,json=serverAddr,proto3" json:"server_addr,omitempty"` // 供客户端测速 (ip:port)
+	Mode           string                 `protobuf:"bytes,12,opt,name=mode,proto3" json:"mode,omitempty"`
+	Observers      int32                  `protobuf:"varint,13,opt,name=observers,proto3" json:"observers,omitempty"` // 当前观战人数
+	MaxObservers   int32                  `protobuf:"varint,14,opt,name=max_observers,json=maxObservers,proto3" json:"max_observers,omitempty"`
+	Bots           int32                  `protobuf:"varint,15,opt,name=bots,proto3" json:"bots,omitempty"` // 机器人占用的座位，不计入 current_players
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RoomInfo) Reset() {
+	*x = RoomInfo{}
+	mi := &file_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInfo) ProtoMessage() {}
+
+func (x *RoomInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInfo.ProtoReflect.Descriptor instead.
+func (*RoomInfo) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RoomInfo) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetRoomName() string {
+	if x != nil {
+		return x.RoomName
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetCurrentPlayers() int32 {
+	if x != nil {
+		return x.CurrentPlayers
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *RoomInfo) GetHostUid() int64 {
+	if x != nil {
+		return x.HostUid
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetMapId() int32 {
+	if x != nil {
+		return x.MapId
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetServerRegion() string {
+	if x != nil {
+		return x.ServerRegion
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetServerAddr() string {
+	if x != nil {
+		return x.ServerAddr
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *RoomInfo) GetObservers() int32 {
+	if x != nil {
+		return x.Observers
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetMaxObservers() int32 {
+	if x != nil {
+		return x.MaxObservers
+	}
+	return 0
+}
+
+func (x *RoomInfo) GetBots() int32 {
+	if x != nil {
+		return x.Bots
+	}
+	return 0
+}
+
+type JoinRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 与 invite_code 二选一
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,4,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	FriendUid     int64                  `protobuf:"varint,5,opt,name=friend_uid,json=friendUid,proto3" json:"friend_uid,omitempty"` // 通过好友加入：好友在房间内时无需邀请码
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomReq) Reset() {
+	*x = JoinRoomReq{}
+	mi := &file_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomReq) ProtoMessage() {}
+
+func (x *JoinRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomReq.ProtoReflect.Descriptor instead.
+func (*JoinRoomReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *JoinRoomReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRoomReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *JoinRoomReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *JoinRoomReq) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *JoinRoomReq) GetFriendUid() int64 {
+	if x != nil {
+		return x.FriendUid
+	}
+	return 0
+}
+
+type JoinRoomResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	ServerIp      string                 `protobuf:"bytes,2,opt,name=server_ip,json=serverIp,proto3" json:"server_ip,omitempty"`
+	ServerPort    int32                  `protobuf:"varint,3,opt,name=server_port,json=serverPort,proto3" json:"server_port,omitempty"`
+	RoomToken     string                 `protobuf:"bytes,4,opt,name=room_token,json=roomToken,proto3" json:"room_token,omitempty"`
+	Tickets       []*RoomTicket          `protobuf:"bytes,5,rep,name=tickets,proto3" json:"tickets,omitempty"` // 队伍一起加入时，每名队员各自的 ticket
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomResp) Reset() {
+	*x = JoinRoomResp{}
+	mi := &file_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomResp) ProtoMessage() {}
+
+func (x *JoinRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomResp.ProtoReflect.Descriptor instead.
+func (*JoinRoomResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *JoinRoomResp) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRoomResp) GetServerIp() string {
+	if x != nil {
+		return x.ServerIp
+	}
+	return ""
+}
+
+func (x *JoinRoomResp) GetServerPort() int32 {
+	if x != nil {
+		return x.ServerPort
+	}
+	return 0
+}
+
+func (x *JoinRoomResp) GetRoomToken() string {
+	if x != nil {
+		return x.RoomToken
+	}
+	return ""
+}
+
+func (x *JoinRoomResp) GetTickets() []*RoomTicket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+type UpdateRoomReq struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	RoomId               string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid                  int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"` // 主持人UID
+	Config               *RoomConfig            `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	ClearPassword        bool                   `protobuf:"varint,4,opt,name=clear_password,json=clearPassword,proto3" json:"clear_password,omitempty"`
+	RegenerateInviteCode bool                   `protobuf:"varint,5,opt,name=regenerate_invite_code,json=regenerateInviteCode,proto3" json:"regenerate_invite_code,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateRoomReq) Reset() {
+	*x = UpdateRoomReq{}
+	mi := &file_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomReq) ProtoMessage() {}
+
+func (x *UpdateRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomReq.ProtoReflect.Descriptor instead.
+func (*UpdateRoomReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *UpdateRoomReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *UpdateRoomReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *UpdateRoomReq) GetConfig() *RoomConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *UpdateRoomReq) GetClearPassword() bool {
+	if x != nil {
+		return x.ClearPassword
+	}
+	return false
+}
+
+func (x *UpdateRoomReq) GetRegenerateInviteCode() bool {
+	if x != nil {
+		return x.RegenerateInviteCode
+	}
+	return false
+}
+
+type UpdateRoomResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,3,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoomResp) Reset() {
+	*x = UpdateRoomResp{}
+	mi := &file_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomResp) ProtoMessage() {}
+
+func (x *UpdateRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomResp.ProtoReflect.Descriptor instead.
+func (*UpdateRoomResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *UpdateRoomResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateRoomResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateRoomResp) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+type LeaveRoomReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRoomReq) Reset() {
+	*x = LeaveRoomReq{}
+	mi := &file_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRoomReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRoomReq) ProtoMessage() {}
+
+func (x *LeaveRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRoomReq.ProtoReflect.Descriptor instead.
+func (*LeaveRoomReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *LeaveRoomReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *LeaveRoomReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type LeaveRoomResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	HostUid       int64                  `protobuf:"varint,3,opt,name=host_uid,json=hostUid,proto3" json:"host_uid,omitempty"` // 离开后的房主，房间解散时为 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRoomResp) Reset() {
+	*x = LeaveRoomResp{}
+	mi := &file_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRoomResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRoomResp) ProtoMessage() {}
+
+func (x *LeaveRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRoomResp.ProtoReflect.Descriptor instead.
+func (*LeaveRoomResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *LeaveRoomResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *LeaveRoomResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LeaveRoomResp) GetHostUid() int64 {
+	if x != nil {
+		return x.HostUid
+	}
+	return 0
+}
+
+type KickPlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"` // 房主UID
+	TargetUid     int64                  `protobuf:"varint,3,opt,name=target_uid,json=targetUid,proto3" json:"target_uid,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Ban           bool                   `protobuf:"varint,5,opt,name=ban,proto3" json:"ban,omitempty"` // 同时加入封禁名单，禁止再次加入
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KickPlayerReq) Reset() {
+	*x = KickPlayerReq{}
+	mi := &file_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickPlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickPlayerReq) ProtoMessage() {}
+
+func (x *KickPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickPlayerReq.ProtoReflect.Descriptor instead.
+func (*KickPlayerReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *KickPlayerReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *KickPlayerReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *KickPlayerReq) GetTargetUid() int64 {
+	if x != nil {
+		return x.TargetUid
+	}
+	return 0
+}
+
+func (x *KickPlayerReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *KickPlayerReq) GetBan() bool {
+	if x != nil {
+		return x.Ban
+	}
+	return false
+}
+
+type KickPlayerResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KickPlayerResp) Reset() {
+	*x = KickPlayerResp{}
+	mi := &file_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickPlayerResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickPlayerResp) ProtoMessage() {}
+
+func (x *KickPlayerResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickPlayerResp.ProtoReflect.Descriptor instead.
+func (*KickPlayerResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *KickPlayerResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *KickPlayerResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type TransferHostReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"` // 当前房主UID
+	NewHostUid    int64                  `protobuf:"varint,3,opt,name=new_host_uid,json=newHostUid,proto3" json:"new_host_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferHostReq) Reset() {
+	*x = TransferHostReq{}
+	mi := &file_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferHostReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferHostReq) ProtoMessage() {}
+
+func (x *TransferHostReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferHostReq.ProtoReflect.Descriptor instead.
+func (*TransferHostReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *TransferHostReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *TransferHostReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *TransferHostReq) GetNewHostUid() int64 {
+	if x != nil {
+		return x.NewHostUid
+	}
+	return 0
+}
+
+type TransferHostResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferHostResp) Reset() {
+	*x = TransferHostResp{}
+	mi := &file_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferHostResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferHostResp) ProtoMessage() {}
+
+func (x *TransferHostResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferHostResp.ProtoReflect.Descriptor instead.
+func (*TransferHostResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *TransferHostResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TransferHostResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StartMatchReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartMatchReq) Reset() {
+	*x = StartMatchReq{}
+	mi := &file_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartMatchReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartMatchReq) ProtoMessage() {}
+
+func (x *StartMatchReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartMatchReq.ProtoReflect.Descriptor instead.
+func (*StartMatchReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *StartMatchReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *StartMatchReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type JoinAsObserverReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 与 invite_code 二选一
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,4,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
+	FriendUid     int64                  `protobuf:"varint,5,opt,name=friend_uid,json=friendUid,proto3" json:"friend_uid,omitempty"` // 观看好友的对局
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinAsObserverReq) Reset() {
+	*x = JoinAsObserverReq{}
+	mi := &file_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinAsObserverReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinAsObserverReq) ProtoMessage() {}
+
+func (x *JoinAsObserverReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinAsObserverReq.ProtoReflect.Descriptor instead.
+func (*JoinAsObserverReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *JoinAsObserverReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinAsObserverReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *JoinAsObserverReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *JoinAsObserverReq) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *JoinAsObserverReq) GetFriendUid() int64 {
+	if x != nil {
+		return x.FriendUid
+	}
+	return 0
+}
+
+type JoinAsObserverResp struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RoomId         string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	ServerIp       string                 `protobuf:"bytes,2,opt,name=server_ip,json=serverIp,proto3" json:"server_ip,omitempty"`
+	ServerPort     int32                  `protobuf:"varint,3,opt,name=server_port,json=serverPort,proto3" json:"server_port,omitempty"`
+	ObserverTicket string                 `protobuf:"bytes,4,opt,name=observer_ticket,json=observerTicket,proto3" json:"observer_ticket,omitempty"` // 连接 WS 时以 observer=1 携带
+	DelayMs        int32                  `protobuf:"varint,5,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`                     // 观战画面相对实际对局的延迟
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *JoinAsObserverResp) Reset() {
+	*x = JoinAsObserverResp{}
+	mi := &file_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinAsObserverResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinAsObserverResp) ProtoMessage() {}
+
+func (x *JoinAsObserverResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinAsObserverResp.ProtoReflect.Descriptor instead.
+func (*JoinAsObserverResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *JoinAsObserverResp) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinAsObserverResp) GetServerIp() string {
+	if x != nil {
+		return x.ServerIp
+	}
+	return ""
+}
+
+func (x *JoinAsObserverResp) GetServerPort() int32 {
+	if x != nil {
+		return x.ServerPort
+	}
+	return 0
+}
+
+func (x *JoinAsObserverResp) GetObserverTicket() string {
+	if x != nil {
+		return x.ObserverTicket
+	}
+	return ""
+}
+
+func (x *JoinAsObserverResp) GetDelayMs() int32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+// --- 机器人 ---
+type AddBotsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`              // 房主UID
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`          // 为 0 时加入 1 个
+	Difficulty    string                 `protobuf:"bytes,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"` // easy / normal / hard，为空时使用 Match 配置的默认难度
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBotsReq) Reset() {
+	*x = AddBotsReq{}
+	mi := &file_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBotsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBotsReq) ProtoMessage() {}
+
+func (x *AddBotsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBotsReq.ProtoReflect.Descriptor instead.
+func (*AddBotsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *AddBotsReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *AddBotsReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AddBotsReq) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AddBotsReq) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+type AddBotsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BotUids       []int64                `protobuf:"varint,3,rep,packed,name=bot_uids,json=botUids,proto3" json:"bot_uids,omitempty"` // 机器人 uid 为负数，房间内唯一
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddBotsResp) Reset() {
+	*x = AddBotsResp{}
+	mi := &file_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddBotsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBotsResp) ProtoMessage() {}
+
+func (x *AddBotsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBotsResp.ProtoReflect.Descriptor instead.
+func (*AddBotsResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *AddBotsResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddBotsResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddBotsResp) GetBotUids() []int64 {
+	if x != nil {
+		return x.BotUids
+	}
+	return nil
+}
+
+type RemoveBotReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`                     // 房主UID
+	BotUid        int64                  `protobuf:"varint,3,opt,name=bot_uid,json=botUid,proto3" json:"bot_uid,omitempty"` // 为 0 时移除最后加入的机器人
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBotReq) Reset() {
+	*x = RemoveBotReq{}
+	mi := &file_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBotReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBotReq) ProtoMessage() {}
+
+func (x *RemoveBotReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBotReq.ProtoReflect.Descriptor instead.
+func (*RemoveBotReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RemoveBotReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RemoveBotReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RemoveBotReq) GetBotUid() int64 {
+	if x != nil {
+		return x.BotUid
+	}
+	return 0
+}
+
+type RemoveBotResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveBotResp) Reset() {
+	*x = RemoveBotResp{}
+	mi := &file_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveBotResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveBotResp) ProtoMessage() {}
+
+func (x *RemoveBotResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveBotResp.ProtoReflect.Descriptor instead.
+func (*RemoveBotResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RemoveBotResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveBotResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// --- 大厅实时推送 ---
+type WatchRoomsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 为空时订阅公开房间列表；指定时订阅单个房间（含成员变化）
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRoomsReq) Reset() {
+	*x = WatchRoomsReq{}
+	mi := &file_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRoomsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRoomsReq) ProtoMessage() {}
+
+func (x *WatchRoomsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRoomsReq.ProtoReflect.Descriptor instead.
+func (*WatchRoomsReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *WatchRoomsReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *WatchRoomsReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type RoomEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          RoomEvent_Type         `protobuf:"varint,1,opt,name=type,proto3,enum=pb.RoomEvent_Type" json:"type,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Room          *RoomInfo              `protobuf:"bytes,3,opt,name=room,proto3" json:"room,omitempty"`               // REMOVED / SNAPSHOT_END 时为空
+	Members       []int64                `protobuf:"varint,4,rep,packed,name=members,proto3" json:"members,omitempty"` // 仅单房间订阅时填充
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomEvent) Reset() {
+	*x = RoomEvent{}
+	mi := &file_service_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEvent) ProtoMessage() {}
+
+func (x *RoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RoomEvent) GetType() RoomEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return RoomEvent_SNAPSHOT
+}
+
+func (x *RoomEvent) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomEvent) GetRoom() *RoomInfo {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetMembers() []int64 {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type PartyInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PartyId       string                 `protobuf:"bytes,1,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	LeaderUid     int64                  `protobuf:"varint,2,opt,name=leader_uid,json=leaderUid,proto3" json:"leader_uid,omitempty"`
+	Members       []int64                `protobuf:"varint,3,rep,packed,name=members,proto3" json:"members,omitempty"`
+	Invites       []int64                `protobuf:"varint,4,rep,packed,name=invites,proto3" json:"invites,omitempty"` // 已邀请、尚未加入
+	MaxSize       int32                  `protobuf:"varint,5,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	RoomId        string                 `protobuf:"bytes,6,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"` // 队伍当前所在房间，队员据此调用 JoinRoom 获取自己的 ticket
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartyInfo) Reset() {
+	*x = PartyInfo{}
+	mi := &file_service_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartyInfo) ProtoMessage() {}
+
+func (x *PartyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartyInfo.ProtoReflect.Descriptor instead.
+func (*PartyInfo) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *PartyInfo) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+func (x *PartyInfo) GetLeaderUid() int64 {
+	if x != nil {
+		return x.LeaderUid
+	}
+	return 0
+}
+
+func (x *PartyInfo) GetMembers() []int64 {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *PartyInfo) GetInvites() []int64 {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+func (x *PartyInfo) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *PartyInfo) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type PartyResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Party         *PartyInfo             `protobuf:"bytes,3,opt,name=party,proto3" json:"party,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartyResp) Reset() {
+	*x = PartyResp{}
+	mi := &file_service_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartyResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartyResp) ProtoMessage() {}
+
+func (x *PartyResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartyResp.ProtoReflect.Descriptor instead.
+func (*PartyResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *PartyResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PartyResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PartyResp) GetParty() *PartyInfo {
+	if x != nil {
+		return x.Party
+	}
+	return nil
+}
+
+type CreatePartyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePartyReq) Reset() {
+	*x = CreatePartyReq{}
+	mi := &file_service_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePartyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePartyReq) ProtoMessage() {}
+
+func (x *CreatePartyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePartyReq.ProtoReflect.Descriptor instead.
+func (*CreatePartyReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *CreatePartyReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type InviteToPartyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	TargetUid     int64                  `protobuf:"varint,2,opt,name=target_uid,json=targetUid,proto3" json:"target_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToPartyReq) Reset() {
+	*x = InviteToPartyReq{}
+	mi := &file_service_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToPartyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToPartyReq) ProtoMessage() {}
+
+func (x *InviteToPartyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToPartyReq.ProtoReflect.Descriptor instead.
+func (*InviteToPartyReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *InviteToPartyReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *InviteToPartyReq) GetTargetUid() int64 {
+	if x != nil {
+		return x.TargetUid
+	}
+	return 0
+}
+
+type JoinPartyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	PartyId       string                 `protobuf:"bytes,2,opt,name=party_id,json=partyId,proto3" json:"party_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinPartyReq) Reset() {
+	*x = JoinPartyReq{}
+	mi := &file_service_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinPartyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinPartyReq) ProtoMessage() {}
+
+func (x *JoinPartyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinPartyReq.ProtoReflect.Descriptor instead.
+func (*JoinPartyReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *JoinPartyReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *JoinPartyReq) GetPartyId() string {
+	if x != nil {
+		return x.PartyId
+	}
+	return ""
+}
+
+type LeavePartyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeavePartyReq) Reset() {
+	*x = LeavePartyReq{}
+	mi := &file_service_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeavePartyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeavePartyReq) ProtoMessage() {}
+
+func (x *LeavePartyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeavePartyReq.ProtoReflect.Descriptor instead.
+func (*LeavePartyReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *LeavePartyReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type KickFromPartyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	TargetUid     int64                  `protobuf:"varint,2,opt,name=target_uid,json=targetUid,proto3" json:"target_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KickFromPartyReq) Reset() {
+	*x = KickFromPartyReq{}
+	mi := &file_service_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickFromPartyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickFromPartyReq) ProtoMessage() {}
+
+func (x *KickFromPartyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickFromPartyReq.ProtoReflect.Descriptor instead.
+func (*KickFromPartyReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *KickFromPartyReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *KickFromPartyReq) GetTargetUid() int64 {
+	if x != nil {
+		return x.TargetUid
+	}
+	return 0
+}
+
+type GetPartyReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPartyReq) Reset() {
+	*x = GetPartyReq{}
+	mi := &file_service_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPartyReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPartyReq) ProtoMessage() {}
+
+func (x *GetPartyReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPartyReq.ProtoReflect.Descriptor instead.
+func (*GetPartyReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetPartyReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type StartMatchResp struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CountdownSeconds int32                  `protobuf:"varint,3,opt,name=countdown_seconds,json=countdownSeconds,proto3" json:"countdown_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StartMatchResp) Reset() {
+	*x = StartMatchResp{}
+	mi := &file_service_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartMatchResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartMatchResp) ProtoMessage() {}
+
+func (x *StartMatchResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartMatchResp.ProtoReflect.Descriptor instead.
+func (*StartMatchResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *StartMatchResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *StartMatchResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StartMatchResp) GetCountdownSeconds() int32 {
+	if x != nil {
+		return x.CountdownSeconds
+	}
+	return 0
+}
+
+type GameValidateTokenReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameValidateTokenReq) Reset() {
+	*x = GameValidateTokenReq{}
+	mi := &file_service_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameValidateTokenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameValidateTokenReq) ProtoMessage() {}
+
+func (x *GameValidateTokenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameValidateTokenReq.ProtoReflect.Descriptor instead.
+func (*GameValidateTokenReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GameValidateTokenReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GameValidateTokenReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type GameValidateTokenResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameValidateTokenResp) Reset() {
+	*x = GameValidateTokenResp{}
+	mi := &file_service_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameValidateTokenResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameValidateTokenResp) ProtoMessage() {}
+
+func (x *GameValidateTokenResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[90]
 	if x != nil {
-		return x.ServerPort
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameValidateTokenResp.ProtoReflect.Descriptor instead.
+func (*GameValidateTokenResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *GameValidateTokenResp) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+type NotifyGameStartReq struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	RoomId           string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	CountdownSeconds int32                  `protobuf:"varint,2,opt,name=countdown_seconds,json=countdownSeconds,proto3" json:"countdown_seconds,omitempty"` // 倒计时秒数，0 表示立即开始
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NotifyGameStartReq) Reset() {
+	*x = NotifyGameStartReq{}
+	mi := &file_service_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyGameStartReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyGameStartReq) ProtoMessage() {}
+
+func (x *NotifyGameStartReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyGameStartReq.ProtoReflect.Descriptor instead.
+func (*NotifyGameStartReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *NotifyGameStartReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *NotifyGameStartReq) GetCountdownSeconds() int32 {
+	if x != nil {
+		return x.CountdownSeconds
+	}
+	return 0
+}
+
+type NotifyGameStartResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyGameStartResp) Reset() {
+	*x = NotifyGameStartResp{}
+	mi := &file_service_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyGameStartResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyGameStartResp) ProtoMessage() {}
+
+func (x *NotifyGameStartResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyGameStartResp.ProtoReflect.Descriptor instead.
+func (*NotifyGameStartResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *NotifyGameStartResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RemovePlayerReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // 随 PLAYER_KICKED 事件下发给被移除的玩家
+	Ban           bool                   `protobuf:"varint,4,opt,name=ban,proto3" json:"ban,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlayerReq) Reset() {
+	*x = RemovePlayerReq{}
+	mi := &file_service_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlayerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlayerReq) ProtoMessage() {}
+
+func (x *RemovePlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlayerReq.ProtoReflect.Descriptor instead.
+func (*RemovePlayerReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *RemovePlayerReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RemovePlayerReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RemovePlayerReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RemovePlayerReq) GetBan() bool {
+	if x != nil {
+		return x.Ban
+	}
+	return false
+}
+
+type RemovePlayerResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovePlayerResp) Reset() {
+	*x = RemovePlayerResp{}
+	mi := &file_service_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovePlayerResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePlayerResp) ProtoMessage() {}
+
+func (x *RemovePlayerResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePlayerResp.ProtoReflect.Descriptor instead.
+func (*RemovePlayerResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *RemovePlayerResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GameTransferHostReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	NewHostUid    int64                  `protobuf:"varint,2,opt,name=new_host_uid,json=newHostUid,proto3" json:"new_host_uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameTransferHostReq) Reset() {
+	*x = GameTransferHostReq{}
+	mi := &file_service_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameTransferHostReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameTransferHostReq) ProtoMessage() {}
+
+func (x *GameTransferHostReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameTransferHostReq.ProtoReflect.Descriptor instead.
+func (*GameTransferHostReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GameTransferHostReq) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *GameTransferHostReq) GetNewHostUid() int64 {
+	if x != nil {
+		return x.NewHostUid
 	}
 	return 0
 }
 
-func (x *JoinRoomResp) GetRoomToken() string {
+type GameTransferHostResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameTransferHostResp) Reset() {
+	*x = GameTransferHostResp{}
+	mi := &file_service_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameTransferHostResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameTransferHostResp) ProtoMessage() {}
+
+func (x *GameTransferHostResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameTransferHostResp.ProtoReflect.Descriptor instead.
+func (*GameTransferHostResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *GameTransferHostResp) GetSuccess() bool {
 	if x != nil {
-		return x.RoomToken
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-type UpdateRoomReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"` // 主持人UID
-	Config        *RoomConfig            `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type AllocateRoomReq struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RoomId          string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Config          *RoomConfig            `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	MaxPlayers      int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"`           // 房间容量
+	AllowedUids     []int64                `protobuf:"varint,4,rep,packed,name=allowed_uids,json=allowedUids,proto3" json:"allowed_uids,omitempty"` // 允许连接的玩家
+	HostUid         int64                  `protobuf:"varint,5,opt,name=host_uid,json=hostUid,proto3" json:"host_uid,omitempty"`
+	Mode            string                 `protobuf:"bytes,6,opt,name=mode,proto3" json:"mode,omitempty"`
+	AllowedTeams    []int32                `protobuf:"varint,7,rep,packed,name=allowed_teams,json=allowedTeams,proto3" json:"allowed_teams,omitempty"`     // 与 allowed_uids 一一对应，非团队模式为 0
+	ObserverDelayMs int32                  `protobuf:"varint,8,opt,name=observer_delay_ms,json=observerDelayMs,proto3" json:"observer_delay_ms,omitempty"` // 观战者收到快照与事件的延迟，防止透视，0 表示不延迟
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *UpdateRoomReq) Reset() {
-	*x = UpdateRoomReq{}
-	mi := &file_service_proto_msgTypes[17]
+func (x *AllocateRoomReq) Reset() {
+	*x = AllocateRoomReq{}
+	mi := &file_service_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRoomReq) String() string {
+func (x *AllocateRoomReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRoomReq) ProtoMessage() {}
+func (*AllocateRoomReq) ProtoMessage() {}
 
-func (x *UpdateRoomReq) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[17]
+func (x *AllocateRoomReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -995,55 +6148,90 @@ func (x *UpdateRoomReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRoomReq.ProtoReflect.Descriptor instead.
-func (*UpdateRoomReq) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use AllocateRoomReq.ProtoReflect.Descriptor instead.
+func (*AllocateRoomReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *UpdateRoomReq) GetRoomId() string {
+func (x *AllocateRoomReq) GetRoomId() string {
 	if x != nil {
 		return x.RoomId
 	}
 	return ""
 }
 
-func (x *UpdateRoomReq) GetUid() int64 {
+func (x *AllocateRoomReq) GetConfig() *RoomConfig {
 	if x != nil {
-		return x.Uid
+		return x.Config
+	}
+	return nil
+}
+
+func (x *AllocateRoomReq) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
 	}
 	return 0
 }
 
-func (x *UpdateRoomReq) GetConfig() *RoomConfig {
+func (x *AllocateRoomReq) GetAllowedUids() []int64 {
 	if x != nil {
-		return x.Config
+		return x.AllowedUids
 	}
 	return nil
 }
 
-type UpdateRoomResp struct {
+func (x *AllocateRoomReq) GetHostUid() int64 {
+	if x != nil {
+		return x.HostUid
+	}
+	return 0
+}
+
+func (x *AllocateRoomReq) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *AllocateRoomReq) GetAllowedTeams() []int32 {
+	if x != nil {
+		return x.AllowedTeams
+	}
+	return nil
+}
+
+func (x *AllocateRoomReq) GetObserverDelayMs() int32 {
+	if x != nil {
+		return x.ObserverDelayMs
+	}
+	return 0
+}
+
+type AllocateRoomResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"` // 失败原因，Match 据此换一台服务器重试
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRoomResp) Reset() {
-	*x = UpdateRoomResp{}
-	mi := &file_service_proto_msgTypes[18]
+func (x *AllocateRoomResp) Reset() {
+	*x = AllocateRoomResp{}
+	mi := &file_service_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRoomResp) String() string {
+func (x *AllocateRoomResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRoomResp) ProtoMessage() {}
+func (*AllocateRoomResp) ProtoMessage() {}
 
-func (x *UpdateRoomResp) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[18]
+func (x *AllocateRoomResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,48 +6242,50 @@ func (x *UpdateRoomResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRoomResp.ProtoReflect.Descriptor instead.
-func (*UpdateRoomResp) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use AllocateRoomResp.ProtoReflect.Descriptor instead.
+func (*AllocateRoomResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *UpdateRoomResp) GetSuccess() bool {
+func (x *AllocateRoomResp) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *UpdateRoomResp) GetMessage() string {
+func (x *AllocateRoomResp) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-type GameValidateTokenReq struct {
+type AdmitPlayerReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	MaxPlayers    int32                  `protobuf:"varint,3,opt,name=max_players,json=maxPlayers,proto3" json:"max_players,omitempty"` // 当前房间容量，房主可能在等待中修改过
+	Team          int32                  `protobuf:"varint,4,opt,name=team,proto3" json:"team,omitempty"`                               // 团队模式下的队伍编号
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GameValidateTokenReq) Reset() {
-	*x = GameValidateTokenReq{}
-	mi := &file_service_proto_msgTypes[19]
+func (x *AdmitPlayerReq) Reset() {
+	*x = AdmitPlayerReq{}
+	mi := &file_service_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameValidateTokenReq) String() string {
+func (x *AdmitPlayerReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameValidateTokenReq) ProtoMessage() {}
+func (*AdmitPlayerReq) ProtoMessage() {}
 
-func (x *GameValidateTokenReq) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[19]
+func (x *AdmitPlayerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1106,47 +6296,62 @@ func (x *GameValidateTokenReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameValidateTokenReq.ProtoReflect.Descriptor instead.
-func (*GameValidateTokenReq) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use AdmitPlayerReq.ProtoReflect.Descriptor instead.
+func (*AdmitPlayerReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *GameValidateTokenReq) GetToken() string {
+func (x *AdmitPlayerReq) GetRoomId() string {
 	if x != nil {
-		return x.Token
+		return x.RoomId
 	}
 	return ""
 }
 
-func (x *GameValidateTokenReq) GetRoomId() string {
+func (x *AdmitPlayerReq) GetUid() int64 {
 	if x != nil {
-		return x.RoomId
+		return x.Uid
 	}
-	return ""
+	return 0
 }
 
-type GameValidateTokenResp struct {
+func (x *AdmitPlayerReq) GetMaxPlayers() int32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *AdmitPlayerReq) GetTeam() int32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+type AdmitPlayerResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GameValidateTokenResp) Reset() {
-	*x = GameValidateTokenResp{}
-	mi := &file_service_proto_msgTypes[20]
+func (x *AdmitPlayerResp) Reset() {
+	*x = AdmitPlayerResp{}
+	mi := &file_service_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameValidateTokenResp) String() string {
+func (x *AdmitPlayerResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameValidateTokenResp) ProtoMessage() {}
+func (*AdmitPlayerResp) ProtoMessage() {}
 
-func (x *GameValidateTokenResp) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[20]
+func (x *AdmitPlayerResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1157,40 +6362,51 @@ func (x *GameValidateTokenResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameValidateTokenResp.ProtoReflect.Descriptor instead.
-func (*GameValidateTokenResp) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use AdmitPlayerResp.ProtoReflect.Descriptor instead.
+func (*AdmitPlayerResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *GameValidateTokenResp) GetValid() bool {
+func (x *AdmitPlayerResp) GetSuccess() bool {
 	if x != nil {
-		return x.Valid
+		return x.Success
 	}
 	return false
 }
 
-type NotifyGameStartReq struct {
+func (x *AdmitPlayerResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AddBotReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`   // 负数，由 Match 分配
+	Team          int32                  `protobuf:"varint,3,opt,name=team,proto3" json:"team,omitempty"` // 团队模式下的队伍编号
+	Difficulty    string                 `protobuf:"bytes,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Name          string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotifyGameStartReq) Reset() {
-	*x = NotifyGameStartReq{}
-	mi := &file_service_proto_msgTypes[21]
+func (x *AddBotReq) Reset() {
+	*x = AddBotReq{}
+	mi := &file_service_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotifyGameStartReq) String() string {
+func (x *AddBotReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotifyGameStartReq) ProtoMessage() {}
+func (*AddBotReq) ProtoMessage() {}
 
-func (x *NotifyGameStartReq) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[21]
+func (x *AddBotReq) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1201,40 +6417,69 @@ func (x *NotifyGameStartReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotifyGameStartReq.ProtoReflect.Descriptor instead.
-func (*NotifyGameStartReq) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use AddBotReq.ProtoReflect.Descriptor instead.
+func (*AddBotReq) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *NotifyGameStartReq) GetRoomId() string {
+func (x *AddBotReq) GetRoomId() string {
 	if x != nil {
 		return x.RoomId
 	}
 	return ""
 }
 
-type NotifyGameStartResp struct {
+func (x *AddBotReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *AddBotReq) GetTeam() int32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+func (x *AddBotReq) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *AddBotReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type AddBotResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotifyGameStartResp) Reset() {
-	*x = NotifyGameStartResp{}
-	mi := &file_service_proto_msgTypes[22]
+func (x *AddBotResp) Reset() {
+	*x = AddBotResp{}
+	mi := &file_service_proto_msgTypes[102]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotifyGameStartResp) String() string {
+func (x *AddBotResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotifyGameStartResp) ProtoMessage() {}
+func (*AddBotResp) ProtoMessage() {}
 
-func (x *NotifyGameStartResp) ProtoReflect() protoreflect.Message {
-	mi := &file_service_proto_msgTypes[22]
+func (x *AddBotResp) ProtoReflect() protoreflect.Message {
+	mi := &file_service_proto_msgTypes[102]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1245,18 +6490,25 @@ func (x *NotifyGameStartResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotifyGameStartResp.ProtoReflect.Descriptor instead.
-func (*NotifyGameStartResp) Descriptor() ([]byte, []int) {
-	return file_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use AddBotResp.ProtoReflect.Descriptor instead.
+func (*AddBotResp) Descriptor() ([]byte, []int) {
+	return file_service_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *NotifyGameStartResp) GetSuccess() bool {
+func (x *AddBotResp) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
+func (x *AddBotResp) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_service_proto protoreflect.FileDescriptor
 
 const file_service_proto_rawDesc = "" +
@@ -1269,36 +6521,258 @@ const file_service_proto_rawDesc = "" +
 	"\x03uid\x18\x01 \x01(\x03R\x03uid\"B\n" +
 	"\bLoginReq\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"O\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x93\x01\n" +
 	"\tLoginResp\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x10\n" +
 	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1a\n" +
-	"\busername\x18\x03 \x01(\tR\busername\"(\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12#\n" +
+	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x05 \x01(\x03R\texpiresIn\"(\n" +
 	"\x10ValidateTokenReq\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\";\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"W\n" +
 	"\x11ValidateTokenResp\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x10\n" +
-	"\x03uid\x18\x02 \x01(\x03R\x03uid\"K\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\"6\n" +
+	"\x0fRefreshTokenReq\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"X\n" +
+	"\tLogoutReq\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x10\n" +
+	"\x03all\x18\x03 \x01(\bR\x03all\"&\n" +
+	"\n" +
+	"LogoutResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x89\x01\n" +
+	"\x03JWK\x12\x10\n" +
+	"\x03kty\x18\x01 \x01(\tR\x03kty\x12\x10\n" +
+	"\x03kid\x18\x02 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03alg\x18\x03 \x01(\tR\x03alg\x12\x10\n" +
+	"\x03use\x18\x04 \x01(\tR\x03use\x12\f\n" +
+	"\x01n\x18\x05 \x01(\tR\x01n\x12\f\n" +
+	"\x01e\x18\x06 \x01(\tR\x01e\x12\x10\n" +
+	"\x03crv\x18\a \x01(\tR\x03crv\x12\f\n" +
+	"\x01x\x18\b \x01(\tR\x01x\"\f\n" +
+	"\n" +
+	"GetJWKSReq\"*\n" +
+	"\vGetJWKSResp\x12\x1b\n" +
+	"\x04keys\x18\x01 \x03(\v2\a.pb.JWKR\x04keys\"\x86\x01\n" +
+	"\n" +
+	"DeadLetter\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\x05R\battempts\x12\x17\n" +
+	"\adead_at\x18\x04 \x01(\x03R\x06deadAt\x12\x12\n" +
+	"\x04body\x18\x05 \x01(\tR\x04body\"*\n" +
+	"\x12ListDeadLettersReq\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"U\n" +
+	"\x13ListDeadLettersResp\x12(\n" +
+	"\aletters\x18\x01 \x03(\v2\x0e.pb.DeadLetterR\aletters\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"E\n" +
+	"\x14ReplayDeadLettersReq\x12\x1b\n" +
+	"\tmatch_ids\x18\x01 \x03(\tR\bmatchIds\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\"3\n" +
+	"\x15ReplayDeadLettersResp\x12\x1a\n" +
+	"\breplayed\x18\x01 \x01(\x05R\breplayed\"K\n" +
 	"\rGetHistoryReq\x12\x10\n" +
 	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\";\n" +
 	"\x0eGetHistoryResp\x12)\n" +
-	"\ahistory\x18\x01 \x03(\v2\x0f.pb.MatchRecordR\ahistory\"y\n" +
+	"\ahistory\x18\x01 \x03(\v2\x0f.pb.MatchRecordR\ahistory\"\xc6\x01\n" +
 	"\vMatchRecord\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
 	"\tis_winner\x18\x02 \x01(\bR\bisWinner\x12\x14\n" +
 	"\x05kills\x18\x03 \x01(\x05R\x05kills\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"I\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1c\n" +
+	"\tplacement\x18\x05 \x01(\x05R\tplacement\x12\x16\n" +
+	"\x06deaths\x18\x06 \x01(\x05R\x06deaths\x12\x15\n" +
+	"\x06map_id\x18\a \x01(\x05R\x05mapId\"\xce\x01\n" +
+	"\n" +
+	"RatingInfo\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06season\x18\x03 \x01(\tR\x06season\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x01R\x06rating\x12\x1c\n" +
+	"\tdeviation\x18\x05 \x01(\x01R\tdeviation\x12\x1e\n" +
+	"\n" +
+	"volatility\x18\x06 \x01(\x01R\n" +
+	"volatility\x12\x18\n" +
+	"\amatches\x18\a \x01(\x05R\amatches\x12\x12\n" +
+	"\x04wins\x18\b \x01(\x05R\x04wins\"L\n" +
+	"\fGetRatingReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06season\x18\x03 \x01(\tR\x06season\"7\n" +
+	"\rGetRatingResp\x12&\n" +
+	"\x06rating\x18\x01 \x01(\v2\x0e.pb.RatingInfoR\x06rating\"T\n" +
+	"\x12BatchGetRatingsReq\x12\x12\n" +
+	"\x04uids\x18\x01 \x03(\x03R\x04uids\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06season\x18\x03 \x01(\tR\x06season\"?\n" +
+	"\x13BatchGetRatingsResp\x12(\n" +
+	"\aratings\x18\x01 \x03(\v2\x0e.pb.RatingInfoR\aratings\"}\n" +
+	"\x13GetRatingHistoryReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06season\x18\x03 \x01(\tR\x06season\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"\xc3\x01\n" +
+	"\fRatingChange\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12#\n" +
+	"\rrating_before\x18\x02 \x01(\x01R\fratingBefore\x12!\n" +
+	"\frating_after\x18\x03 \x01(\x01R\vratingAfter\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\x01R\x05delta\x12\x1c\n" +
+	"\tplacement\x18\x05 \x01(\x05R\tplacement\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\"B\n" +
+	"\x14GetRatingHistoryResp\x12*\n" +
+	"\ahistory\x18\x01 \x03(\v2\x10.pb.RatingChangeR\ahistory\"j\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x03R\x04rank\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\"\x7f\n" +
+	"\x11GetLeaderboardReq\x12\x14\n" +
+	"\x05board\x18\x01 \x01(\tR\x05board\x12\x16\n" +
+	"\x06season\x18\x02 \x01(\tR\x06season\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"Z\n" +
+	"\x12GetLeaderboardResp\x12.\n" +
+	"\aentries\x18\x01 \x03(\v2\x14.pb.LeaderboardEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\x80\x01\n" +
+	"\n" +
+	"GetRankReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x14\n" +
+	"\x05board\x18\x02 \x01(\tR\x05board\x12\x16\n" +
+	"\x06season\x18\x03 \x01(\tR\x06season\x12\x12\n" +
+	"\x04mode\x18\x04 \x01(\tR\x04mode\x12\x1e\n" +
+	"\n" +
+	"neighbours\x18\x05 \x01(\x05R\n" +
+	"neighbours\"o\n" +
+	"\vGetRankResp\x12*\n" +
+	"\x05entry\x18\x01 \x01(\v2\x14.pb.LeaderboardEntryR\x05entry\x124\n" +
+	"\n" +
+	"neighbours\x18\x02 \x03(\v2\x14.pb.LeaderboardEntryR\n" +
+	"neighbours\"\xad\x01\n" +
+	"\bPresence\x12+\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x13.pb.Presence.StatusR\x06status\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\"<\n" +
+	"\x06Status\x12\v\n" +
+	"\aOFFLINE\x10\x00\x12\t\n" +
+	"\x05LOBBY\x10\x01\x12\f\n" +
+	"\bIN_QUEUE\x10\x02\x12\f\n" +
+	"\bIN_MATCH\x10\x03\"z\n" +
+	"\n" +
+	"FriendInfo\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12(\n" +
+	"\bpresence\x18\x03 \x01(\v2\f.pb.PresenceR\bpresence\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\x03R\x05since\"\xc9\x01\n" +
+	"\x11FriendRequestInfo\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\x03R\trequestId\x12\x19\n" +
+	"\bfrom_uid\x18\x02 \x01(\x03R\afromUid\x12#\n" +
+	"\rfrom_username\x18\x03 \x01(\tR\ffromUsername\x12\x15\n" +
+	"\x06to_uid\x18\x04 \x01(\x03R\x05toUid\x12\x1f\n" +
+	"\vto_username\x18\x05 \x01(\tR\n" +
+	"toUsername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\"F\n" +
+	"\x10FriendActionResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"G\n" +
+	"\x14SendFriendRequestReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"target_uid\x18\x02 \x01(\x03R\ttargetUid\"b\n" +
+	"\x17RespondFriendRequestReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\x03R\trequestId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\"B\n" +
+	"\x0fRemoveFriendReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"friend_uid\x18\x02 \x01(\x03R\tfriendUid\"Y\n" +
+	"\fBlockUserReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"target_uid\x18\x02 \x01(\x03R\ttargetUid\x12\x18\n" +
+	"\aunblock\x18\x03 \x01(\bR\aunblock\"!\n" +
+	"\rGetFriendsReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\"]\n" +
+	"\x0eGetFriendsResp\x12(\n" +
+	"\afriends\x18\x01 \x03(\v2\x0e.pb.FriendInfoR\afriends\x12!\n" +
+	"\fblocked_uids\x18\x02 \x03(\x03R\vblockedUids\"(\n" +
+	"\x14GetFriendRequestsReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\"}\n" +
+	"\x15GetFriendRequestsResp\x121\n" +
+	"\bincoming\x18\x01 \x03(\v2\x15.pb.FriendRequestInfoR\bincoming\x121\n" +
+	"\boutgoing\x18\x02 \x03(\v2\x15.pb.FriendRequestInfoR\boutgoing\"\x81\x01\n" +
+	"\x11UpdatePresenceReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12+\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.pb.Presence.StatusR\x06status\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\tR\x06roomId\x12\x14\n" +
+	"\x05touch\x18\x04 \x01(\bR\x05touch\"\x14\n" +
+	"\x12UpdatePresenceResp\"C\n" +
+	"\x10GetFriendRoomReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"friend_uid\x18\x02 \x01(\x03R\tfriendUid\"V\n" +
+	"\x11GetFriendRoomResp\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12(\n" +
+	"\bpresence\x18\x02 \x01(\v2\f.pb.PresenceR\bpresence\"@\n" +
+	"\rAreFriendsReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"friend_uid\x18\x02 \x01(\x03R\tfriendUid\"*\n" +
+	"\x0eAreFriendsResp\x12\x18\n" +
+	"\afriends\x18\x01 \x01(\bR\afriends\"M\n" +
+	"\rGetProfileReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06season\x18\x03 \x01(\tR\x06season\"\x83\x03\n" +
+	"\rPlayerProfile\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tjoined_at\x18\x03 \x01(\x03R\bjoinedAt\x12#\n" +
+	"\rtotal_matches\x18\x04 \x01(\x05R\ftotalMatches\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x05R\x04wins\x12\x19\n" +
+	"\bwin_rate\x18\x06 \x01(\x01R\awinRate\x12\x14\n" +
+	"\x05kills\x18\a \x01(\x05R\x05kills\x12\x16\n" +
+	"\x06deaths\x18\b \x01(\x05R\x06deaths\x12\x0e\n" +
+	"\x02kd\x18\t \x01(\x01R\x02kd\x12(\n" +
+	"\x10favourite_map_id\x18\n" +
+	" \x01(\x05R\x0efavouriteMapId\x12&\n" +
+	"\x06rating\x18\v \x01(\v2\x0e.pb.RatingInfoR\x06rating\x12\x1f\n" +
+	"\vrecent_form\x18\f \x01(\tR\n" +
+	"recentForm\x12\"\n" +
+	"\rlast_match_at\x18\r \x01(\x03R\vlastMatchAt\"=\n" +
+	"\x0eGetProfileResp\x12+\n" +
+	"\aprofile\x18\x01 \x01(\v2\x11.pb.PlayerProfileR\aprofile\"I\n" +
 	"\rCreateRoomReq\x12\x10\n" +
 	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12&\n" +
-	"\x06config\x18\x02 \x01(\v2\x0e.pb.RoomConfigR\x06config\"a\n" +
+	"\x06config\x18\x02 \x01(\v2\x0e.pb.RoomConfigR\x06config\"\x84\x02\n" +
 	"\n" +
 	"RoomConfig\x12\x1b\n" +
 	"\troom_name\x18\x01 \x01(\tR\broomName\x12\x15\n" +
 	"\x06map_id\x18\x02 \x01(\x05R\x05mapId\x12\x1f\n" +
 	"\vmax_players\x18\x03 \x01(\x05R\n" +
-	"maxPlayers\"\xa3\x01\n" +
+	"maxPlayers\x129\n" +
+	"\n" +
+	"visibility\x18\x04 \x01(\x0e2\x19.pb.RoomConfig.VisibilityR\n" +
+	"visibility\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04mode\x18\x06 \x01(\tR\x04mode\"6\n" +
+	"\n" +
+	"Visibility\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06PUBLIC\x10\x01\x12\v\n" +
+	"\aPRIVATE\x10\x02\"\xee\x01\n" +
 	"\x0eCreateRoomResp\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
 	"\troom_name\x18\x02 \x01(\tR\broomName\x12\x1b\n" +
@@ -1306,59 +6780,309 @@ const file_service_proto_rawDesc = "" +
 	"\vserver_port\x18\x04 \x01(\x05R\n" +
 	"serverPort\x12\x1d\n" +
 	"\n" +
-	"room_token\x18\x05 \x01(\tR\troomToken\"\x0e\n" +
-	"\fListRoomsReq\"3\n" +
+	"room_token\x18\x05 \x01(\tR\troomToken\x12\x1f\n" +
+	"\vinvite_code\x18\x06 \x01(\tR\n" +
+	"inviteCode\x12(\n" +
+	"\atickets\x18\a \x03(\v2\x0e.pb.RoomTicketR\atickets\"J\n" +
+	"\n" +
+	"RoomTicket\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x16\n" +
+	"\x06ticket\x18\x02 \x01(\tR\x06ticket\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\x05R\x04team\"\x88\x02\n" +
+	"\fListRoomsReq\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x15\n" +
+	"\x06map_id\x18\x02 \x01(\x05R\x05mapId\x12\x19\n" +
+	"\bnot_full\x18\x03 \x01(\bR\anotFull\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x120\n" +
+	"\asort_by\x18\x05 \x01(\x0e2\x17.pb.ListRoomsReq.SortByR\x06sortBy\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\":\n" +
+	"\x06SortBy\x12\n" +
+	"\n" +
+	"\x06NEWEST\x10\x00\x12\x10\n" +
+	"\fMOST_PLAYERS\x10\x01\x12\x12\n" +
+	"\x0eFEWEST_PLAYERS\x10\x02\"j\n" +
 	"\rListRoomsResp\x12\"\n" +
-	"\x05rooms\x18\x01 \x03(\v2\f.pb.RoomInfoR\x05rooms\"\xa2\x01\n" +
+	"\x05rooms\x18\x01 \x03(\v2\f.pb.RoomInfoR\x05rooms\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\"\xc7\x03\n" +
 	"\bRoomInfo\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
 	"\troom_name\x18\x02 \x01(\tR\broomName\x12'\n" +
 	"\x0fcurrent_players\x18\x03 \x01(\x05R\x0ecurrentPlayers\x12\x1f\n" +
 	"\vmax_players\x18\x04 \x01(\x05R\n" +
 	"maxPlayers\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\"8\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\fhas_password\x18\x06 \x01(\bR\vhasPassword\x12\x19\n" +
+	"\bhost_uid\x18\a \x01(\x03R\ahostUid\x12\x15\n" +
+	"\x06map_id\x18\b \x01(\x05R\x05mapId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12#\n" +
+	"\rserver_region\x18\n" +
+	" \x01(\tR\fserverRegion\x12\x1f\n" +
+	"\vserver_addr\x18\v \x01(\tR\n" +
+	"serverAddr\x12\x12\n" +
+	"\x04mode\x18\f \x01(\tR\x04mode\x12\x1c\n" +
+	"\tobservers\x18\r \x01(\x05R\tobservers\x12#\n" +
+	"\rmax_observers\x18\x0e \x01(\x05R\fmaxObservers\x12\x12\n" +
+	"\x04bots\x18\x0f \x01(\x05R\x04bots\"\x94\x01\n" +
 	"\vJoinRoomReq\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
-	"\x03uid\x18\x02 \x01(\x03R\x03uid\"\x84\x01\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vinvite_code\x18\x04 \x01(\tR\n" +
+	"inviteCode\x12\x1d\n" +
+	"\n" +
+	"friend_uid\x18\x05 \x01(\x03R\tfriendUid\"\xae\x01\n" +
 	"\fJoinRoomResp\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
 	"\tserver_ip\x18\x02 \x01(\tR\bserverIp\x12\x1f\n" +
 	"\vserver_port\x18\x03 \x01(\x05R\n" +
 	"serverPort\x12\x1d\n" +
 	"\n" +
-	"room_token\x18\x04 \x01(\tR\troomToken\"b\n" +
+	"room_token\x18\x04 \x01(\tR\troomToken\x12(\n" +
+	"\atickets\x18\x05 \x03(\v2\x0e.pb.RoomTicketR\atickets\"\xbf\x01\n" +
 	"\rUpdateRoomReq\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
 	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12&\n" +
-	"\x06config\x18\x03 \x01(\v2\x0e.pb.RoomConfigR\x06config\"D\n" +
+	"\x06config\x18\x03 \x01(\v2\x0e.pb.RoomConfigR\x06config\x12%\n" +
+	"\x0eclear_password\x18\x04 \x01(\bR\rclearPassword\x124\n" +
+	"\x16regenerate_invite_code\x18\x05 \x01(\bR\x14regenerateInviteCode\"e\n" +
 	"\x0eUpdateRoomResp\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"E\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1f\n" +
+	"\vinvite_code\x18\x03 \x01(\tR\n" +
+	"inviteCode\"9\n" +
+	"\fLeaveRoomReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\"^\n" +
+	"\rLeaveRoomResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bhost_uid\x18\x03 \x01(\x03R\ahostUid\"\x83\x01\n" +
+	"\rKickPlayerReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"target_uid\x18\x03 \x01(\x03R\ttargetUid\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x10\n" +
+	"\x03ban\x18\x05 \x01(\bR\x03ban\"D\n" +
+	"\x0eKickPlayerResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"^\n" +
+	"\x0fTransferHostReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12 \n" +
+	"\fnew_host_uid\x18\x03 \x01(\x03R\n" +
+	"newHostUid\"F\n" +
+	"\x10TransferHostResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\":\n" +
+	"\rStartMatchReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\"\x9a\x01\n" +
+	"\x11JoinAsObserverReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vinvite_code\x18\x04 \x01(\tR\n" +
+	"inviteCode\x12\x1d\n" +
+	"\n" +
+	"friend_uid\x18\x05 \x01(\x03R\tfriendUid\"\xaf\x01\n" +
+	"\x12JoinAsObserverResp\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tserver_ip\x18\x02 \x01(\tR\bserverIp\x12\x1f\n" +
+	"\vserver_port\x18\x03 \x01(\x05R\n" +
+	"serverPort\x12'\n" +
+	"\x0fobserver_ticket\x18\x04 \x01(\tR\x0eobserverTicket\x12\x19\n" +
+	"\bdelay_ms\x18\x05 \x01(\x05R\adelayMs\"m\n" +
+	"\n" +
+	"AddBotsReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\tR\n" +
+	"difficulty\"\\\n" +
+	"\vAddBotsResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bbot_uids\x18\x03 \x03(\x03R\abotUids\"R\n" +
+	"\fRemoveBotReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x17\n" +
+	"\abot_uid\x18\x03 \x01(\x03R\x06botUid\"C\n" +
+	"\rRemoveBotResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\":\n" +
+	"\rWatchRoomsReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\"\xd5\x01\n" +
+	"\tRoomEvent\x12&\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x12.pb.RoomEvent.TypeR\x04type\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12 \n" +
+	"\x04room\x18\x03 \x01(\v2\f.pb.RoomInfoR\x04room\x12\x18\n" +
+	"\amembers\x18\x04 \x03(\x03R\amembers\"K\n" +
+	"\x04Type\x12\f\n" +
+	"\bSNAPSHOT\x10\x00\x12\x10\n" +
+	"\fSNAPSHOT_END\x10\x01\x12\t\n" +
+	"\x05ADDED\x10\x02\x12\v\n" +
+	"\aUPDATED\x10\x03\x12\v\n" +
+	"\aREMOVED\x10\x04\"\xad\x01\n" +
+	"\tPartyInfo\x12\x19\n" +
+	"\bparty_id\x18\x01 \x01(\tR\apartyId\x12\x1d\n" +
+	"\n" +
+	"leader_uid\x18\x02 \x01(\x03R\tleaderUid\x12\x18\n" +
+	"\amembers\x18\x03 \x03(\x03R\amembers\x12\x18\n" +
+	"\ainvites\x18\x04 \x03(\x03R\ainvites\x12\x19\n" +
+	"\bmax_size\x18\x05 \x01(\x05R\amaxSize\x12\x17\n" +
+	"\aroom_id\x18\x06 \x01(\tR\x06roomId\"d\n" +
+	"\tPartyResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\x05party\x18\x03 \x01(\v2\r.pb.PartyInfoR\x05party\"\"\n" +
+	"\x0eCreatePartyReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\"C\n" +
+	"\x10InviteToPartyReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"target_uid\x18\x02 \x01(\x03R\ttargetUid\";\n" +
+	"\fJoinPartyReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x19\n" +
+	"\bparty_id\x18\x02 \x01(\tR\apartyId\"!\n" +
+	"\rLeavePartyReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\"C\n" +
+	"\x10KickFromPartyReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"target_uid\x18\x02 \x01(\x03R\ttargetUid\"\x1f\n" +
+	"\vGetPartyReq\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\"q\n" +
+	"\x0eStartMatchResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x11countdown_seconds\x18\x03 \x01(\x05R\x10countdownSeconds\"E\n" +
 	"\x14GameValidateTokenReq\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
 	"\aroom_id\x18\x02 \x01(\tR\x06roomId\"-\n" +
 	"\x15GameValidateTokenResp\x12\x14\n" +
-	"\x05valid\x18\x01 \x01(\bR\x05valid\"-\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\"Z\n" +
 	"\x12NotifyGameStartReq\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"/\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12+\n" +
+	"\x11countdown_seconds\x18\x02 \x01(\x05R\x10countdownSeconds\"/\n" +
 	"\x13NotifyGameStartResp\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xd5\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"f\n" +
+	"\x0fRemovePlayerReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x10\n" +
+	"\x03ban\x18\x04 \x01(\bR\x03ban\",\n" +
+	"\x10RemovePlayerResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"P\n" +
+	"\x13GameTransferHostReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12 \n" +
+	"\fnew_host_uid\x18\x02 \x01(\x03R\n" +
+	"newHostUid\"0\n" +
+	"\x14GameTransferHostResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x96\x02\n" +
+	"\x0fAllocateRoomReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12&\n" +
+	"\x06config\x18\x02 \x01(\v2\x0e.pb.RoomConfigR\x06config\x12\x1f\n" +
+	"\vmax_players\x18\x03 \x01(\x05R\n" +
+	"maxPlayers\x12!\n" +
+	"\fallowed_uids\x18\x04 \x03(\x03R\vallowedUids\x12\x19\n" +
+	"\bhost_uid\x18\x05 \x01(\x03R\ahostUid\x12\x12\n" +
+	"\x04mode\x18\x06 \x01(\tR\x04mode\x12#\n" +
+	"\rallowed_teams\x18\a \x03(\x05R\fallowedTeams\x12*\n" +
+	"\x11observer_delay_ms\x18\b \x01(\x05R\x0fobserverDelayMs\"F\n" +
+	"\x10AllocateRoomResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"p\n" +
+	"\x0eAdmitPlayerReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x1f\n" +
+	"\vmax_players\x18\x03 \x01(\x05R\n" +
+	"maxPlayers\x12\x12\n" +
+	"\x04team\x18\x04 \x01(\x05R\x04team\"E\n" +
+	"\x0fAdmitPlayerResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"~\n" +
+	"\tAddBotReq\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\x05R\x04team\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"@\n" +
+	"\n" +
+	"AddBotResp\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage2\xfe\n" +
+	"\n" +
 	"\vUserService\x12-\n" +
 	"\bRegister\x12\x0f.pb.RegisterReq\x1a\x10.pb.RegisterResp\x12$\n" +
 	"\x05Login\x12\f.pb.LoginReq\x1a\r.pb.LoginResp\x123\n" +
 	"\n" +
 	"GetHistory\x12\x11.pb.GetHistoryReq\x1a\x12.pb.GetHistoryResp\x12<\n" +
-	"\rValidateToken\x12\x14.pb.ValidateTokenReq\x1a\x15.pb.ValidateTokenResp2\xd9\x01\n" +
+	"\rValidateToken\x12\x14.pb.ValidateTokenReq\x1a\x15.pb.ValidateTokenResp\x120\n" +
+	"\tGetRating\x12\x10.pb.GetRatingReq\x1a\x11.pb.GetRatingResp\x12B\n" +
+	"\x0fBatchGetRatings\x12\x16.pb.BatchGetRatingsReq\x1a\x17.pb.BatchGetRatingsResp\x12E\n" +
+	"\x10GetRatingHistory\x12\x17.pb.GetRatingHistoryReq\x1a\x18.pb.GetRatingHistoryResp\x12?\n" +
+	"\x0eGetLeaderboard\x12\x15.pb.GetLeaderboardReq\x1a\x16.pb.GetLeaderboardResp\x12*\n" +
+	"\aGetRank\x12\x0e.pb.GetRankReq\x1a\x0f.pb.GetRankResp\x12C\n" +
+	"\x11SendFriendRequest\x12\x18.pb.SendFriendRequestReq\x1a\x14.pb.FriendActionResp\x12I\n" +
+	"\x14RespondFriendRequest\x12\x1b.pb.RespondFriendRequestReq\x1a\x14.pb.FriendActionResp\x129\n" +
+	"\fRemoveFriend\x12\x13.pb.RemoveFriendReq\x1a\x14.pb.FriendActionResp\x123\n" +
+	"\tBlockUser\x12\x10.pb.BlockUserReq\x1a\x14.pb.FriendActionResp\x123\n" +
+	"\n" +
+	"GetFriends\x12\x11.pb.GetFriendsReq\x1a\x12.pb.GetFriendsResp\x12H\n" +
+	"\x11GetFriendRequests\x12\x18.pb.GetFriendRequestsReq\x1a\x19.pb.GetFriendRequestsResp\x12?\n" +
+	"\x0eUpdatePresence\x12\x15.pb.UpdatePresenceReq\x1a\x16.pb.UpdatePresenceResp\x12<\n" +
+	"\rGetFriendRoom\x12\x14.pb.GetFriendRoomReq\x1a\x15.pb.GetFriendRoomResp\x123\n" +
+	"\n" +
+	"AreFriends\x12\x11.pb.AreFriendsReq\x1a\x12.pb.AreFriendsResp\x123\n" +
+	"\n" +
+	"GetProfile\x12\x11.pb.GetProfileReq\x1a\x12.pb.GetProfileResp\x122\n" +
+	"\fRefreshToken\x12\x13.pb.RefreshTokenReq\x1a\r.pb.LoginResp\x12'\n" +
+	"\x06Logout\x12\r.pb.LogoutReq\x1a\x0e.pb.LogoutResp\x12*\n" +
+	"\aGetJWKS\x12\x0e.pb.GetJWKSReq\x1a\x0f.pb.GetJWKSResp\x12B\n" +
+	"\x0fListDeadLetters\x12\x16.pb.ListDeadLettersReq\x1a\x17.pb.ListDeadLettersResp\x12H\n" +
+	"\x11ReplayDeadLetters\x12\x18.pb.ReplayDeadLettersReq\x1a\x19.pb.ReplayDeadLettersResp2\xa9\a\n" +
 	"\fMatchService\x123\n" +
 	"\n" +
 	"CreateRoom\x12\x11.pb.CreateRoomReq\x1a\x12.pb.CreateRoomResp\x120\n" +
 	"\tListRooms\x12\x10.pb.ListRoomsReq\x1a\x11.pb.ListRoomsResp\x12-\n" +
 	"\bJoinRoom\x12\x0f.pb.JoinRoomReq\x1a\x10.pb.JoinRoomResp\x123\n" +
 	"\n" +
-	"UpdateRoom\x12\x11.pb.UpdateRoomReq\x1a\x12.pb.UpdateRoomResp2\x97\x01\n" +
+	"UpdateRoom\x12\x11.pb.UpdateRoomReq\x1a\x12.pb.UpdateRoomResp\x120\n" +
+	"\tLeaveRoom\x12\x10.pb.LeaveRoomReq\x1a\x11.pb.LeaveRoomResp\x123\n" +
+	"\n" +
+	"KickPlayer\x12\x11.pb.KickPlayerReq\x1a\x12.pb.KickPlayerResp\x129\n" +
+	"\fTransferHost\x12\x13.pb.TransferHostReq\x1a\x14.pb.TransferHostResp\x123\n" +
+	"\n" +
+	"StartMatch\x12\x11.pb.StartMatchReq\x1a\x12.pb.StartMatchResp\x120\n" +
+	"\vCreateParty\x12\x12.pb.CreatePartyReq\x1a\r.pb.PartyResp\x124\n" +
+	"\rInviteToParty\x12\x14.pb.InviteToPartyReq\x1a\r.pb.PartyResp\x12,\n" +
+	"\tJoinParty\x12\x10.pb.JoinPartyReq\x1a\r.pb.PartyResp\x12.\n" +
+	"\n" +
+	"LeaveParty\x12\x11.pb.LeavePartyReq\x1a\r.pb.PartyResp\x124\n" +
+	"\rKickFromParty\x12\x14.pb.KickFromPartyReq\x1a\r.pb.PartyResp\x12*\n" +
+	"\bGetParty\x12\x0f.pb.GetPartyReq\x1a\r.pb.PartyResp\x12?\n" +
+	"\x0eJoinAsObserver\x12\x15.pb.JoinAsObserverReq\x1a\x16.pb.JoinAsObserverResp\x120\n" +
+	"\n" +
+	"WatchRooms\x12\x11.pb.WatchRoomsReq\x1a\r.pb.RoomEvent0\x01\x12*\n" +
+	"\aAddBots\x12\x0e.pb.AddBotsReq\x1a\x0f.pb.AddBotsResp\x120\n" +
+	"\tRemoveBot\x12\x10.pb.RemoveBotReq\x1a\x11.pb.RemoveBotResp2\xb1\x03\n" +
 	"\vGameService\x12D\n" +
 	"\rValidateToken\x12\x18.pb.GameValidateTokenReq\x1a\x19.pb.GameValidateTokenResp\x12B\n" +
-	"\x0fNotifyGameStart\x12\x16.pb.NotifyGameStartReq\x1a\x17.pb.NotifyGameStartRespB\x06Z\x04./pbb\x06proto3"
+	"\x0fNotifyGameStart\x12\x16.pb.NotifyGameStartReq\x1a\x17.pb.NotifyGameStartResp\x129\n" +
+	"\fRemovePlayer\x12\x13.pb.RemovePlayerReq\x1a\x14.pb.RemovePlayerResp\x12A\n" +
+	"\fTransferHost\x12\x17.pb.GameTransferHostReq\x1a\x18.pb.GameTransferHostResp\x129\n" +
+	"\fAllocateRoom\x12\x13.pb.AllocateRoomReq\x1a\x14.pb.AllocateRoomResp\x126\n" +
+	"\vAdmitPlayer\x12\x12.pb.AdmitPlayerReq\x1a\x13.pb.AdmitPlayerResp\x12'\n" +
+	"\x06AddBot\x12\r.pb.AddBotReq\x1a\x0e.pb.AddBotRespB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_service_proto_rawDescOnce sync.Once
@@ -1372,62 +7096,250 @@ func file_service_proto_rawDescGZIP() []byte {
 	return file_service_proto_rawDescData
 }
 
-var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_service_proto_msgTypes = make([]protoimpl.MessageInfo, 103)
 var file_service_proto_goTypes = []any{
-	(*RegisterReq)(nil),           // 0: pb.RegisterReq
-	(*RegisterResp)(nil),          // 1: pb.RegisterResp
-	(*LoginReq)(nil),              // 2: pb.LoginReq
-	(*LoginResp)(nil),             // 3: pb.LoginResp
-	(*ValidateTokenReq)(nil),      // 4: pb.ValidateTokenReq
-	(*ValidateTokenResp)(nil),     // 5: pb.ValidateTokenResp
-	(*GetHistoryReq)(nil),         // 6: pb.GetHistoryReq
-	(*GetHistoryResp)(nil),        // 7: pb.GetHistoryResp
-	(*MatchRecord)(nil),           // 8: pb.MatchRecord
-	(*CreateRoomReq)(nil),         // 9: pb.CreateRoomReq
-	(*RoomConfig)(nil),            // 10: pb.RoomConfig
-	(*CreateRoomResp)(nil),        // 11: pb.CreateRoomResp
-	(*ListRoomsReq)(nil),          // 12: pb.ListRoomsReq
-	(*ListRoomsResp)(nil),         // 13: pb.ListRoomsResp
-	(*RoomInfo)(nil),              // 14: pb.RoomInfo
-	(*JoinRoomReq)(nil),           // 15: pb.JoinRoomReq
-	(*JoinRoomResp)(nil),          // 16: pb.JoinRoomResp
-	(*UpdateRoomReq)(nil),         // 17: pb.UpdateRoomReq
-	(*UpdateRoomResp)(nil),        // 18: pb.UpdateRoomResp
-	(*GameValidateTokenReq)(nil),  // 19: pb.GameValidateTokenReq
-	(*GameValidateTokenResp)(nil), // 20: pb.GameValidateTokenResp
-	(*NotifyGameStartReq)(nil),    // 21: pb.NotifyGameStartReq
-	(*NotifyGameStartResp)(nil),   // 22: pb.NotifyGameStartResp
+	(Presence_Status)(0),            // 0: pb.Presence.Status
+	(RoomConfig_Visibility)(0),      // 1: pb.RoomConfig.Visibility
+	(ListRoomsReq_SortBy)(0),        // 2: pb.ListRoomsReq.SortBy
+	(RoomEvent_Type)(0),             // 3: pb.RoomEvent.Type
+	(*RegisterReq)(nil),             // 4: pb.RegisterReq
+	(*RegisterResp)(nil),            // 5: pb.RegisterResp
+	(*LoginReq)(nil),                // 6: pb.LoginReq
+	(*LoginResp)(nil),               // 7: pb.LoginResp
+	(*ValidateTokenReq)(nil),        // 8: pb.ValidateTokenReq
+	(*ValidateTokenResp)(nil),       // 9: pb.ValidateTokenResp
+	(*RefreshTokenReq)(nil),         // 10: pb.RefreshTokenReq
+	(*LogoutReq)(nil),               // 11: pb.LogoutReq
+	(*LogoutResp)(nil),              // 12: pb.LogoutResp
+	(*JWK)(nil),                     // 13: pb.JWK
+	(*GetJWKSReq)(nil),              // 14: pb.GetJWKSReq
+	(*GetJWKSResp)(nil),             // 15: pb.GetJWKSResp
+	(*DeadLetter)(nil),              // 16: pb.DeadLetter
+	(*ListDeadLettersReq)(nil),      // 17: pb.ListDeadLettersReq
+	(*ListDeadLettersResp)(nil),     // 18: pb.ListDeadLettersResp
+	(*ReplayDeadLettersReq)(nil),    // 19: pb.ReplayDeadLettersReq
+	(*ReplayDeadLettersResp)(nil),   // 20: pb.ReplayDeadLettersResp
+	(*GetHistoryReq)(nil),           // 21: pb.GetHistoryReq
+	(*GetHistoryResp)(nil),          // 22: pb.GetHistoryResp
+	(*MatchRecord)(nil),             // 23: pb.MatchRecord
+	(*RatingInfo)(nil),              // 24: pb.RatingInfo
+	(*GetRatingReq)(nil),            // 25: pb.GetRatingReq
+	(*GetRatingResp)(nil),           // 26: pb.GetRatingResp
+	(*BatchGetRatingsReq)(nil),      // 27: pb.BatchGetRatingsReq
+	(*BatchGetRatingsResp)(nil),     // 28: pb.BatchGetRatingsResp
+	(*GetRatingHistoryReq)(nil),     // 29: pb.GetRatingHistoryReq
+	(*RatingChange)(nil),            // 30: pb.RatingChange
+	(*GetRatingHistoryResp)(nil),    // 31: pb.GetRatingHistoryResp
+	(*LeaderboardEntry)(nil),        // 32: pb.LeaderboardEntry
+	(*GetLeaderboardReq)(nil),       // 33: pb.GetLeaderboardReq
+	(*GetLeaderboardResp)(nil),      // 34: pb.GetLeaderboardResp
+	(*GetRankReq)(nil),              // 35: pb.GetRankReq
+	(*GetRankResp)(nil),             // 36: pb.GetRankResp
+	(*Presence)(nil),                // 37: pb.Presence
+	(*FriendInfo)(nil),              // 38: pb.FriendInfo
+	(*FriendRequestInfo)(nil),       // 39: pb.FriendRequestInfo
+	(*FriendActionResp)(nil),        // 40: pb.FriendActionResp
+	(*SendFriendRequestReq)(nil),    // 41: pb.SendFriendRequestReq
+	(*RespondFriendRequestReq)(nil), // 42: pb.RespondFriendRequestReq
+	(*RemoveFriendReq)(nil),         // 43: pb.RemoveFriendReq
+	(*BlockUserReq)(nil),            // 44: pb.BlockUserReq
+	(*GetFriendsReq)(nil),           // 45: pb.GetFriendsReq
+	(*GetFriendsResp)(nil),          // 46: pb.GetFriendsResp
+	(*GetFriendRequestsReq)(nil),    // 47: pb.GetFriendRequestsReq
+	(*GetFriendRequestsResp)(nil),   // 48: pb.GetFriendRequestsResp
+	(*UpdatePresenceReq)(nil),       // 49: pb.UpdatePresenceReq
+	(*UpdatePresenceResp)(nil),      // 50: pb.UpdatePresenceResp
+	(*GetFriendRoomReq)(nil),        // 51: pb.GetFriendRoomReq
+	(*GetFriendRoomResp)(nil),       // 52: pb.GetFriendRoomResp
+	(*AreFriendsReq)(nil),           // 53: pb.AreFriendsReq
+	(*AreFriendsResp)(nil),          // 54: pb.AreFriendsResp
+	(*GetProfileReq)(nil),           // 55: pb.GetProfileReq
+	(*PlayerProfile)(nil),           // 56: pb.PlayerProfile
+	(*GetProfileResp)(nil),          // 57: pb.GetProfileResp
+	(*CreateRoomReq)(nil),           // 58: pb.CreateRoomReq
+	(*RoomConfig)(nil),              // 59: pb.RoomConfig
+	(*CreateRoomResp)(nil),          // 60: pb.CreateRoomResp
+	(*RoomTicket)(nil),              // 61: pb.RoomTicket
+	(*ListRoomsReq)(nil),            // 62: pb.ListRoomsReq
+	(*ListRoomsResp)(nil),           // 63: pb.ListRoomsResp
+	(*RoomInfo)(nil),                // 64: pb.RoomInfo
+	(*JoinRoomReq)(nil),             // 65: pb.JoinRoomReq
+	(*JoinRoomResp)(nil),            // 66: pb.JoinRoomResp
+	(*UpdateRoomReq)(nil),           // 67: pb.UpdateRoomReq
+	(*UpdateRoomResp)(nil),          // 68: pb.UpdateRoomResp
+	(*LeaveRoomReq)(nil),            // 69: pb.LeaveRoomReq
+	(*LeaveRoomResp)(nil),           // 70: pb.LeaveRoomResp
+	(*KickPlayerReq)(nil),           // 71: pb.KickPlayerReq
+	(*KickPlayerResp)(nil),          // 72: pb.KickPlayerResp
+	(*TransferHostReq)(nil),         // 73: pb.TransferHostReq
+	(*TransferHostResp)(nil),        // 74: pb.TransferHostResp
+	(*StartMatchReq)(nil),           // 75: pb.StartMatchReq
+	(*JoinAsObserverReq)(nil),       // 76: pb.JoinAsObserverReq
+	(*JoinAsObserverResp)(nil),      // 77: pb.JoinAsObserverResp
+	(*AddBotsReq)(nil),              // 78: pb.AddBotsReq
+	(*AddBotsResp)(nil),             // 79: pb.AddBotsResp
+	(*RemoveBotReq)(nil),            // 80: pb.RemoveBotReq
+	(*RemoveBotResp)(nil),           // 81: pb.RemoveBotResp
+	(*WatchRoomsReq)(nil),           // 82: pb.WatchRoomsReq
+	(*RoomEvent)(nil),               // 83: pb.RoomEvent
+	(*PartyInfo)(nil),               // 84: pb.PartyInfo
+	(*PartyResp)(nil),               // 85: pb.PartyResp
+	(*CreatePartyReq)(nil),          // 86: pb.CreatePartyReq
+	(*InviteToPartyReq)(nil),        // 87: pb.InviteToPartyReq
+	(*JoinPartyReq)(nil),            // 88: pb.JoinPartyReq
+	(*LeavePartyReq)(nil),           // 89: pb.LeavePartyReq
+	(*KickFromPartyReq)(nil),        // 90: pb.KickFromPartyReq
+	(*GetPartyReq)(nil),             // 91: pb.GetPartyReq
+	(*StartMatchResp)(nil),          // 92: pb.StartMatchResp
+	(*GameValidateTokenReq)(nil),    // 93: pb.GameValidateTokenReq
+	(*GameValidateTokenResp)(nil),   // 94: pb.GameValidateTokenResp
+	(*NotifyGameStartReq)(nil),      // 95: pb.NotifyGameStartReq
+	(*NotifyGameStartResp)(nil),     // 96: pb.NotifyGameStartResp
+	(*RemovePlayerReq)(nil),         // 97: pb.RemovePlayerReq
+	(*RemovePlayerResp)(nil),        // 98: pb.RemovePlayerResp
+	(*GameTransferHostReq)(nil),     // 99: pb.GameTransferHostReq
+	(*GameTransferHostResp)(nil),    // 100: pb.GameTransferHostResp
+	(*AllocateRoomReq)(nil),         // 101: pb.AllocateRoomReq
+	(*AllocateRoomResp)(nil),        // 102: pb.AllocateRoomResp
+	(*AdmitPlayerReq)(nil),          // 103: pb.AdmitPlayerReq
+	(*AdmitPlayerResp)(nil),         // 104: pb.AdmitPlayerResp
+	(*AddBotReq)(nil),               // 105: pb.AddBotReq
+	(*AddBotResp)(nil),              // 106: pb.AddBotResp
 }
 var file_service_proto_depIdxs = []int32{
-	8,  // 0: pb.GetHistoryResp.history:type_name -> pb.MatchRecord
-	10, // 1: pb.CreateRoomReq.config:type_name -> pb.RoomConfig
-	14, // 2: pb.ListRoomsResp.rooms:type_name -> pb.RoomInfo
-	10, // 3: pb.UpdateRoomReq.config:type_name -> pb.RoomConfig
-	0,  // 4: pb.UserService.Register:input_type -> pb.RegisterReq
-	2,  // 5: pb.UserService.Login:input_type -> pb.LoginReq
-	6,  // 6: pb.UserService.GetHistory:input_type -> pb.GetHistoryReq
-	4,  // 7: pb.UserService.ValidateToken:input_type -> pb.ValidateTokenReq
-	9,  // 8: pb.MatchService.CreateRoom:input_type -> pb.CreateRoomReq
-	12, // 9: pb.MatchService.ListRooms:input_type -> pb.ListRoomsReq
-	15, // 10: pb.MatchService.JoinRoom:input_type -> pb.JoinRoomReq
-	17, // 11: pb.MatchService.UpdateRoom:input_type -> pb.UpdateRoomReq
-	19, // 12: pb.GameService.ValidateToken:input_type -> pb.GameValidateTokenReq
-	21, // 13: pb.GameService.NotifyGameStart:input_type -> pb.NotifyGameStartReq
-	1,  // 14: pb.UserService.Register:output_type -> pb.RegisterResp
-	3,  // 15: pb.UserService.Login:output_type -> pb.LoginResp
-	7,  // 16: pb.UserService.GetHistory:output_type -> pb.GetHistoryResp
-	5,  // 17: pb.UserService.ValidateToken:output_type -> pb.ValidateTokenResp
-	11, // 18: pb.MatchService.CreateRoom:output_type -> pb.CreateRoomResp
-	13, // 19: pb.MatchService.ListRooms:output_type -> pb.ListRoomsResp
-	16, // 20: pb.MatchService.JoinRoom:output_type -> pb.JoinRoomResp
-	18, // 21: pb.MatchService.UpdateRoom:output_type -> pb.UpdateRoomResp
-	20, // 22: pb.GameService.ValidateToken:output_type -> pb.GameValidateTokenResp
-	22, // 23: pb.GameService.NotifyGameStart:output_type -> pb.NotifyGameStartResp
-	14, // [14:24] is the sub-list for method output_type
-	4,  // [4:14] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13,  // 0: pb.GetJWKSResp.keys:type_name -> pb.JWK
+	16,  // 1: pb.ListDeadLettersResp.letters:type_name -> pb.DeadLetter
+	23,  // 2: pb.GetHistoryResp.history:type_name -> pb.MatchRecord
+	24,  // 3: pb.GetRatingResp.rating:type_name -> pb.RatingInfo
+	24,  // 4: pb.BatchGetRatingsResp.ratings:type_name -> pb.RatingInfo
+	30,  // 5: pb.GetRatingHistoryResp.history:type_name -> pb.RatingChange
+	32,  // 6: pb.GetLeaderboardResp.entries:type_name -> pb.LeaderboardEntry
+	32,  // 7: pb.GetRankResp.entry:type_name -> pb.LeaderboardEntry
+	32,  // 8: pb.GetRankResp.neighbours:type_name -> pb.LeaderboardEntry
+	0,   // 9: pb.Presence.status:type_name -> pb.Presence.Status
+	37,  // 10: pb.FriendInfo.presence:type_name -> pb.Presence
+	38,  // 11: pb.GetFriendsResp.friends:type_name -> pb.FriendInfo
+	39,  // 12: pb.GetFriendRequestsResp.incoming:type_name -> pb.FriendRequestInfo
+	39,  // 13: pb.GetFriendRequestsResp.outgoing:type_name -> pb.FriendRequestInfo
+	0,   // 14: pb.UpdatePresenceReq.status:type_name -> pb.Presence.Status
+	37,  // 15: pb.GetFriendRoomResp.presence:type_name -> pb.Presence
+	24,  // 16: pb.PlayerProfile.rating:type_name -> pb.RatingInfo
+	56,  // 17: pb.GetProfileResp.profile:type_name -> pb.PlayerProfile
+	59,  // 18: pb.CreateRoomReq.config:type_name -> pb.RoomConfig
+	1,   // 19: pb.RoomConfig.visibility:type_name -> pb.RoomConfig.Visibility
+	61,  // 20: pb.CreateRoomResp.tickets:type_name -> pb.RoomTicket
+	2,   // 21: pb.ListRoomsReq.sort_by:type_name -> pb.ListRoomsReq.SortBy
+	64,  // 22: pb.ListRoomsResp.rooms:type_name -> pb.RoomInfo
+	61,  // 23: pb.JoinRoomResp.tickets:type_name -> pb.RoomTicket
+	59,  // 24: pb.UpdateRoomReq.config:type_name -> pb.RoomConfig
+	3,   // 25: pb.RoomEvent.type:type_name -> pb.RoomEvent.Type
+	64,  // 26: pb.RoomEvent.room:type_name -> pb.RoomInfo
+	84,  // 27: pb.PartyResp.party:type_name -> pb.PartyInfo
+	59,  // 28: pb.AllocateRoomReq.config:type_name -> pb.RoomConfig
+	4,   // 29: pb.UserService.Register:input_type -> pb.RegisterReq
+	6,   // 30: pb.UserService.Login:input_type -> pb.LoginReq
+	21,  // 31: pb.UserService.GetHistory:input_type -> pb.GetHistoryReq
+	8,   // 32: pb.UserService.ValidateToken:input_type -> pb.ValidateTokenReq
+	25,  // 33: pb.UserService.GetRating:input_type -> pb.GetRatingReq
+	27,  // 34: pb.UserService.BatchGetRatings:input_type -> pb.BatchGetRatingsReq
+	29,  // 35: pb.UserService.GetRatingHistory:input_type -> pb.GetRatingHistoryReq
+	33,  // 36: pb.UserService.GetLeaderboard:input_type -> pb.GetLeaderboardReq
+	35,  // 37: pb.UserService.GetRank:input_type -> pb.GetRankReq
+	41,  // 38: pb.UserService.SendFriendRequest:input_type -> pb.SendFriendRequestReq
+	42,  // 39: pb.UserService.RespondFriendRequest:input_type -> pb.RespondFriendRequestReq
+	43,  // 40: pb.UserService.RemoveFriend:input_type -> pb.RemoveFriendReq
+	44,  // 41: pb.UserService.BlockUser:input_type -> pb.BlockUserReq
+	45,  // 42: pb.UserService.GetFriends:input_type -> pb.GetFriendsReq
+	47,  // 43: pb.UserService.GetFriendRequests:input_type -> pb.GetFriendRequestsReq
+	49,  // 44: pb.UserService.UpdatePresence:input_type -> pb.UpdatePresenceReq
+	51,  // 45: pb.UserService.GetFriendRoom:input_type -> pb.GetFriendRoomReq
+	53,  // 46: pb.UserService.AreFriends:input_type -> pb.AreFriendsReq
+	55,  // 47: pb.UserService.GetProfile:input_type -> pb.GetProfileReq
+	10,  // 48: pb.UserService.RefreshToken:input_type -> pb.RefreshTokenReq
+	11,  // 49: pb.UserService.Logout:input_type -> pb.LogoutReq
+	14,  // 50: pb.UserService.GetJWKS:input_type -> pb.GetJWKSReq
+	17,  // 51: pb.UserService.ListDeadLetters:input_type -> pb.ListDeadLettersReq
+	19,  // 52: pb.UserService.ReplayDeadLetters:input_type -> pb.ReplayDeadLettersReq
+	58,  // 53: pb.MatchService.CreateRoom:input_type -> pb.CreateRoomReq
+	62,  // 54: pb.MatchService.ListRooms:input_type -> pb.ListRoomsReq
+	65,  // 55: pb.MatchService.JoinRoom:input_type -> pb.JoinRoomReq
+	67,  // 56: pb.MatchService.UpdateRoom:input_type -> pb.UpdateRoomReq
+	69,  // 57: pb.MatchService.LeaveRoom:input_type -> pb.LeaveRoomReq
+	71,  // 58: pb.MatchService.KickPlayer:input_type -> pb.KickPlayerReq
+	73,  // 59: pb.MatchService.TransferHost:input_type -> pb.TransferHostReq
+	75,  // 60: pb.MatchService.StartMatch:input_type -> pb.StartMatchReq
+	86,  // 61: pb.MatchService.CreateParty:input_type -> pb.CreatePartyReq
+	87,  // 62: pb.MatchService.InviteToParty:input_type -> pb.InviteToPartyReq
+	88,  // 63: pb.MatchService.JoinParty:input_type -> pb.JoinPartyReq
+	89,  // 64: pb.MatchService.LeaveParty:input_type -> pb.LeavePartyReq
+	90,  // 65: pb.MatchService.KickFromParty:input_type -> pb.KickFromPartyReq
+	91,  // 66: pb.MatchService.GetParty:input_type -> pb.GetPartyReq
+	76,  // 67: pb.MatchService.JoinAsObserver:input_type -> pb.JoinAsObserverReq
+	82,  // 68: pb.MatchService.WatchRooms:input_type -> pb.WatchRoomsReq
+	78,  // 69: pb.MatchService.AddBots:input_type -> pb.AddBotsReq
+	80,  // 70: pb.MatchService.RemoveBot:input_type -> pb.RemoveBotReq
+	93,  // 71: pb.GameService.ValidateToken:input_type -> pb.GameValidateTokenReq
+	95,  // 72: pb.GameService.NotifyGameStart:input_type -> pb.NotifyGameStartReq
+	97,  // 73: pb.GameService.RemovePlayer:input_type -> pb.RemovePlayerReq
+	99,  // 74: pb.GameService.TransferHost:input_type -> pb.GameTransferHostReq
+	101, // 75: pb.GameService.AllocateRoom:input_type -> pb.AllocateRoomReq
+	103, // 76: pb.GameService.AdmitPlayer:input_type -> pb.AdmitPlayerReq
+	105, // 77: pb.GameService.AddBot:input_type -> pb.AddBotReq
+	5,   // 78: pb.UserService.Register:output_type -> pb.RegisterResp
+	7,   // 79: pb.UserService.Login:output_type -> pb.LoginResp
+	22,  // 80: pb.UserService.GetHistory:output_type -> pb.GetHistoryResp
+	9,   // 81: pb.UserService.ValidateToken:output_type -> pb.ValidateTokenResp
+	26,  // 82: pb.UserService.GetRating:output_type -> pb.GetRatingResp
+	28,  // 83: pb.UserService.BatchGetRatings:output_type -> pb.BatchGetRatingsResp
+	31,  // 84: pb.UserService.GetRatingHistory:output_type -> pb.GetRatingHistoryResp
+	34,  // 85: pb.UserService.GetLeaderboard:output_type -> pb.GetLeaderboardResp
+	36,  // 86: pb.UserService.GetRank:output_type -> pb.GetRankResp
+	40,  // 87: pb.UserService.SendFriendRequest:output_type -> pb.FriendActionResp
+	40,  // 88: pb.UserService.RespondFriendRequest:output_type -> pb.FriendActionResp
+	40,  // 89: pb.UserService.RemoveFriend:output_type -> pb.FriendActionResp
+	40,  // 90: pb.UserService.BlockUser:output_type -> pb.FriendActionResp
+	46,  // 91: pb.UserService.GetFriends:output_type -> pb.GetFriendsResp
+	48,  // 92: pb.UserService.GetFriendRequests:output_type -> pb.GetFriendRequestsResp
+	50,  // 93: pb.UserService.UpdatePresence:output_type -> pb.UpdatePresenceResp
+	52,  // 94: pb.UserService.GetFriendRoom:output_type -> pb.GetFriendRoomResp
+	54,  // 95: pb.UserService.AreFriends:output_type -> pb.AreFriendsResp
+	57,  // 96: pb.UserService.GetProfile:output_type -> pb.GetProfileResp
+	7,   // 97: pb.UserService.RefreshToken:output_type -> pb.LoginResp
+	12,  // 98: pb.UserService.Logout:output_type -> pb.LogoutResp
+	15,  // 99: pb.UserService.GetJWKS:output_type -> pb.GetJWKSResp
+	18,  // 100: pb.UserService.ListDeadLetters:output_type -> pb.ListDeadLettersResp
+	20,  // 101: pb.UserService.ReplayDeadLetters:output_type -> pb.ReplayDeadLettersResp
+	60,  // 102: pb.MatchService.CreateRoom:output_type -> pb.CreateRoomResp
+	63,  // 103: pb.MatchService.ListRooms:output_type -> pb.ListRoomsResp
+	66,  // 104: pb.MatchService.JoinRoom:output_type -> pb.JoinRoomResp
+	68,  // 105: pb.MatchService.UpdateRoom:output_type -> pb.UpdateRoomResp
+	70,  // 106: pb.MatchService.LeaveRoom:output_type -> pb.LeaveRoomResp
+	72,  // 107: pb.MatchService.KickPlayer:output_type -> pb.KickPlayerResp
+	74,  // 108: pb.MatchService.TransferHost:output_type -> pb.TransferHostResp
+	92,  // 109: pb.MatchService.StartMatch:output_type -> pb.StartMatchResp
+	85,  // 110: pb.MatchService.CreateParty:output_type -> pb.PartyResp
+	85,  // 111: pb.MatchService.InviteToParty:output_type -> pb.PartyResp
+	85,  // 112: pb.MatchService.JoinParty:output_type -> pb.PartyResp
+	85,  // 113: pb.MatchService.LeaveParty:output_type -> pb.PartyResp
+	85,  // 114: pb.MatchService.KickFromParty:output_type -> pb.PartyResp
+	85,  // 115: pb.MatchService.GetParty:output_type -> pb.PartyResp
+	77,  // 116: pb.MatchService.JoinAsObserver:output_type -> pb.JoinAsObserverResp
+	83,  // 117: pb.MatchService.WatchRooms:output_type -> pb.RoomEvent
+	79,  // 118: pb.MatchService.AddBots:output_type -> pb.AddBotsResp
+	81,  // 119: pb.MatchService.RemoveBot:output_type -> pb.RemoveBotResp
+	94,  // 120: pb.GameService.ValidateToken:output_type -> pb.GameValidateTokenResp
+	96,  // 121: pb.GameService.NotifyGameStart:output_type -> pb.NotifyGameStartResp
+	98,  // 122: pb.GameService.RemovePlayer:output_type -> pb.RemovePlayerResp
+	100, // 123: pb.GameService.TransferHost:output_type -> pb.GameTransferHostResp
+	102, // 124: pb.GameService.AllocateRoom:output_type -> pb.AllocateRoomResp
+	104, // 125: pb.GameService.AdmitPlayer:output_type -> pb.AdmitPlayerResp
+	106, // 126: pb.GameService.AddBot:output_type -> pb.AddBotResp
+	78,  // [78:127] is the sub-list for method output_type
+	29,  // [29:78] is the sub-list for method input_type
+	29,  // [29:29] is the sub-list for extension type_name
+	29,  // [29:29] is the sub-list for extension extendee
+	0,   // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_service_proto_init() }
@@ -1440,13 +7352,14 @@ func file_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_service_proto_rawDesc), len(file_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   23,
+			NumEnums:      4,
+			NumMessages:   103,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
 		GoTypes:           file_service_proto_goTypes,
 		DependencyIndexes: file_service_proto_depIdxs,
+		EnumInfos:         file_service_proto_enumTypes,
 		MessageInfos:      file_service_proto_msgTypes,
 	}.Build()
 	File_service_proto = out.File
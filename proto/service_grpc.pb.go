@@ -19,10 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_Register_FullMethodName      = "/pb.UserService/Register"
-	UserService_Login_FullMethodName         = "/pb.UserService/Login"
-	UserService_GetHistory_FullMethodName    = "/pb.UserService/GetHistory"
-	UserService_ValidateToken_FullMethodName = "/pb.UserService/ValidateToken"
+	UserService_Register_FullMethodName             = "/pb.UserService/Register"
+	UserService_Login_FullMethodName                = "/pb.UserService/Login"
+	UserService_GetHistory_FullMethodName           = "/pb.UserService/GetHistory"
+	UserService_ValidateToken_FullMethodName        = "/pb.UserService/ValidateToken"
+	UserService_GetRating_FullMethodName            = "/pb.UserService/GetRating"
+	UserService_BatchGetRatings_FullMethodName      = "/pb.UserService/BatchGetRatings"
+	UserService_GetRatingHistory_FullMethodName     = "/pb.UserService/GetRatingHistory"
+	UserService_GetLeaderboard_FullMethodName       = "/pb.UserService/GetLeaderboard"
+	UserService_GetRank_FullMethodName              = "/pb.UserService/GetRank"
+	UserService_SendFriendRequest_FullMethodName    = "/pb.UserService/SendFriendRequest"
+	UserService_RespondFriendRequest_FullMethodName = "/pb.UserService/RespondFriendRequest"
+	UserService_RemoveFriend_FullMethodName         = "/pb.UserService/RemoveFriend"
+	UserService_BlockUser_FullMethodName            = "/pb.UserService/BlockUser"
+	UserService_GetFriends_FullMethodName           = "/pb.UserService/GetFriends"
+	UserService_GetFriendRequests_FullMethodName    = "/pb.UserService/GetFriendRequests"
+	UserService_UpdatePresence_FullMethodName       = "/pb.UserService/UpdatePresence"
+	UserService_GetFriendRoom_FullMethodName        = "/pb.UserService/GetFriendRoom"
+	UserService_AreFriends_FullMethodName           = "/pb.UserService/AreFriends"
+	UserService_GetProfile_FullMethodName           = "/pb.UserService/GetProfile"
+	UserService_RefreshToken_FullMethodName         = "/pb.UserService/RefreshToken"
+	UserService_Logout_FullMethodName               = "/pb.UserService/Logout"
+	UserService_GetJWKS_FullMethodName              = "/pb.UserService/GetJWKS"
+	UserService_ListDeadLetters_FullMethodName      = "/pb.UserService/ListDeadLetters"
+	UserService_ReplayDeadLetters_FullMethodName    = "/pb.UserService/ReplayDeadLetters"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -35,6 +55,26 @@ type UserServiceClient interface {
 	Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error)
 	GetHistory(ctx context.Context, in *GetHistoryReq, opts ...grpc.CallOption) (*GetHistoryResp, error)
 	ValidateToken(ctx context.Context, in *ValidateTokenReq, opts ...grpc.CallOption) (*ValidateTokenResp, error)
+	GetRating(ctx context.Context, in *GetRatingReq, opts ...grpc.CallOption) (*GetRatingResp, error)
+	BatchGetRatings(ctx context.Context, in *BatchGetRatingsReq, opts ...grpc.CallOption) (*BatchGetRatingsResp, error)
+	GetRatingHistory(ctx context.Context, in *GetRatingHistoryReq, opts ...grpc.CallOption) (*GetRatingHistoryResp, error)
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardReq, opts ...grpc.CallOption) (*GetLeaderboardResp, error)
+	GetRank(ctx context.Context, in *GetRankReq, opts ...grpc.CallOption) (*GetRankResp, error)
+	SendFriendRequest(ctx context.Context, in *SendFriendRequestReq, opts ...grpc.CallOption) (*FriendActionResp, error)
+	RespondFriendRequest(ctx context.Context, in *RespondFriendRequestReq, opts ...grpc.CallOption) (*FriendActionResp, error)
+	RemoveFriend(ctx context.Context, in *RemoveFriendReq, opts ...grpc.CallOption) (*FriendActionResp, error)
+	BlockUser(ctx context.Context, in *BlockUserReq, opts ...grpc.CallOption) (*FriendActionResp, error)
+	GetFriends(ctx context.Context, in *GetFriendsReq, opts ...grpc.CallOption) (*GetFriendsResp, error)
+	GetFriendRequests(ctx context.Context, in *GetFriendRequestsReq, opts ...grpc.CallOption) (*GetFriendRequestsResp, error)
+	UpdatePresence(ctx context.Context, in *UpdatePresenceReq, opts ...grpc.CallOption) (*UpdatePresenceResp, error)
+	GetFriendRoom(ctx context.Context, in *GetFriendRoomReq, opts ...grpc.CallOption) (*GetFriendRoomResp, error)
+	AreFriends(ctx context.Context, in *AreFriendsReq, opts ...grpc.CallOption) (*AreFriendsResp, error)
+	GetProfile(ctx context.Context, in *GetProfileReq, opts ...grpc.CallOption) (*GetProfileResp, error)
+	RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*LoginResp, error)
+	Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutResp, error)
+	GetJWKS(ctx context.Context, in *GetJWKSReq, opts ...grpc.CallOption) (*GetJWKSResp, error)
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (*ListDeadLettersResp, error)
+	ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersReq, opts ...grpc.CallOption) (*ReplayDeadLettersResp, error)
 }
 
 type userServiceClient struct {
@@ -85,6 +125,206 @@ func (c *userServiceClient) ValidateToken(ctx context.Context, in *ValidateToken
 	return out, nil
 }
 
+func (c *userServiceClient) GetRating(ctx context.Context, in *GetRatingReq, opts ...grpc.CallOption) (*GetRatingResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatingResp)
+	err := c.cc.Invoke(ctx, UserService_GetRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BatchGetRatings(ctx context.Context, in *BatchGetRatingsReq, opts ...grpc.CallOption) (*BatchGetRatingsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetRatingsResp)
+	err := c.cc.Invoke(ctx, UserService_BatchGetRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetRatingHistory(ctx context.Context, in *GetRatingHistoryReq, opts ...grpc.CallOption) (*GetRatingHistoryResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatingHistoryResp)
+	err := c.cc.Invoke(ctx, UserService_GetRatingHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardReq, opts ...grpc.CallOption) (*GetLeaderboardResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardResp)
+	err := c.cc.Invoke(ctx, UserService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetRank(ctx context.Context, in *GetRankReq, opts ...grpc.CallOption) (*GetRankResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRankResp)
+	err := c.cc.Invoke(ctx, UserService_GetRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SendFriendRequest(ctx context.Context, in *SendFriendRequestReq, opts ...grpc.CallOption) (*FriendActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendActionResp)
+	err := c.cc.Invoke(ctx, UserService_SendFriendRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RespondFriendRequest(ctx context.Context, in *RespondFriendRequestReq, opts ...grpc.CallOption) (*FriendActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendActionResp)
+	err := c.cc.Invoke(ctx, UserService_RespondFriendRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RemoveFriend(ctx context.Context, in *RemoveFriendReq, opts ...grpc.CallOption) (*FriendActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendActionResp)
+	err := c.cc.Invoke(ctx, UserService_RemoveFriend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BlockUser(ctx context.Context, in *BlockUserReq, opts ...grpc.CallOption) (*FriendActionResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendActionResp)
+	err := c.cc.Invoke(ctx, UserService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetFriends(ctx context.Context, in *GetFriendsReq, opts ...grpc.CallOption) (*GetFriendsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFriendsResp)
+	err := c.cc.Invoke(ctx, UserService_GetFriends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetFriendRequests(ctx context.Context, in *GetFriendRequestsReq, opts ...grpc.CallOption) (*GetFriendRequestsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFriendRequestsResp)
+	err := c.cc.Invoke(ctx, UserService_GetFriendRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdatePresence(ctx context.Context, in *UpdatePresenceReq, opts ...grpc.CallOption) (*UpdatePresenceResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePresenceResp)
+	err := c.cc.Invoke(ctx, UserService_UpdatePresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetFriendRoom(ctx context.Context, in *GetFriendRoomReq, opts ...grpc.CallOption) (*GetFriendRoomResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFriendRoomResp)
+	err := c.cc.Invoke(ctx, UserService_GetFriendRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) AreFriends(ctx context.Context, in *AreFriendsReq, opts ...grpc.CallOption) (*AreFriendsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AreFriendsResp)
+	err := c.cc.Invoke(ctx, UserService_AreFriends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetProfile(ctx context.Context, in *GetProfileReq, opts ...grpc.CallOption) (*GetProfileResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProfileResp)
+	err := c.cc.Invoke(ctx, UserService_GetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResp)
+	err := c.cc.Invoke(ctx, UserService_RefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResp)
+	err := c.cc.Invoke(ctx, UserService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetJWKS(ctx context.Context, in *GetJWKSReq, opts ...grpc.CallOption) (*GetJWKSResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJWKSResp)
+	err := c.cc.Invoke(ctx, UserService_GetJWKS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersReq, opts ...grpc.CallOption) (*ListDeadLettersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResp)
+	err := c.cc.Invoke(ctx, UserService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReplayDeadLetters(ctx context.Context, in *ReplayDeadLettersReq, opts ...grpc.CallOption) (*ReplayDeadLettersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayDeadLettersResp)
+	err := c.cc.Invoke(ctx, UserService_ReplayDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -95,6 +335,26 @@ type UserServiceServer interface {
 	Login(context.Context, *LoginReq) (*LoginResp, error)
 	GetHistory(context.Context, *GetHistoryReq) (*GetHistoryResp, error)
 	ValidateToken(context.Context, *ValidateTokenReq) (*ValidateTokenResp, error)
+	GetRating(context.Context, *GetRatingReq) (*GetRatingResp, error)
+	BatchGetRatings(context.Context, *BatchGetRatingsReq) (*BatchGetRatingsResp, error)
+	GetRatingHistory(context.Context, *GetRatingHistoryReq) (*GetRatingHistoryResp, error)
+	GetLeaderboard(context.Context, *GetLeaderboardReq) (*GetLeaderboardResp, error)
+	GetRank(context.Context, *GetRankReq) (*GetRankResp, error)
+	SendFriendRequest(context.Context, *SendFriendRequestReq) (*FriendActionResp, error)
+	RespondFriendRequest(context.Context, *RespondFriendRequestReq) (*FriendActionResp, error)
+	RemoveFriend(context.Context, *RemoveFriendReq) (*FriendActionResp, error)
+	BlockUser(context.Context, *BlockUserReq) (*FriendActionResp, error)
+	GetFriends(context.Context, *GetFriendsReq) (*GetFriendsResp, error)
+	GetFriendRequests(context.Context, *GetFriendRequestsReq) (*GetFriendRequestsResp, error)
+	UpdatePresence(context.Context, *UpdatePresenceReq) (*UpdatePresenceResp, error)
+	GetFriendRoom(context.Context, *GetFriendRoomReq) (*GetFriendRoomResp, error)
+	AreFriends(context.Context, *AreFriendsReq) (*AreFriendsResp, error)
+	GetProfile(context.Context, *GetProfileReq) (*GetProfileResp, error)
+	RefreshToken(context.Context, *RefreshTokenReq) (*LoginResp, error)
+	Logout(context.Context, *LogoutReq) (*LogoutResp, error)
+	GetJWKS(context.Context, *GetJWKSReq) (*GetJWKSResp, error)
+	ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error)
+	ReplayDeadLetters(context.Context, *ReplayDeadLettersReq) (*ReplayDeadLettersResp, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -117,6 +377,66 @@ func (UnimplementedUserServiceServer) GetHistory(context.Context, *GetHistoryReq
 func (UnimplementedUserServiceServer) ValidateToken(context.Context, *ValidateTokenReq) (*ValidateTokenResp, error) {
 	return nil, status.Error(codes.Unimplemented, "method ValidateToken not implemented")
 }
+func (UnimplementedUserServiceServer) GetRating(context.Context, *GetRatingReq) (*GetRatingResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRating not implemented")
+}
+func (UnimplementedUserServiceServer) BatchGetRatings(context.Context, *BatchGetRatingsReq) (*BatchGetRatingsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchGetRatings not implemented")
+}
+func (UnimplementedUserServiceServer) GetRatingHistory(context.Context, *GetRatingHistoryReq) (*GetRatingHistoryResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRatingHistory not implemented")
+}
+func (UnimplementedUserServiceServer) GetLeaderboard(context.Context, *GetLeaderboardReq) (*GetLeaderboardResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedUserServiceServer) GetRank(context.Context, *GetRankReq) (*GetRankResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRank not implemented")
+}
+func (UnimplementedUserServiceServer) SendFriendRequest(context.Context, *SendFriendRequestReq) (*FriendActionResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method SendFriendRequest not implemented")
+}
+func (UnimplementedUserServiceServer) RespondFriendRequest(context.Context, *RespondFriendRequestReq) (*FriendActionResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RespondFriendRequest not implemented")
+}
+func (UnimplementedUserServiceServer) RemoveFriend(context.Context, *RemoveFriendReq) (*FriendActionResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveFriend not implemented")
+}
+func (UnimplementedUserServiceServer) BlockUser(context.Context, *BlockUserReq) (*FriendActionResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetFriends(context.Context, *GetFriendsReq) (*GetFriendsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFriends not implemented")
+}
+func (UnimplementedUserServiceServer) GetFriendRequests(context.Context, *GetFriendRequestsReq) (*GetFriendRequestsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFriendRequests not implemented")
+}
+func (UnimplementedUserServiceServer) UpdatePresence(context.Context, *UpdatePresenceReq) (*UpdatePresenceResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePresence not implemented")
+}
+func (UnimplementedUserServiceServer) GetFriendRoom(context.Context, *GetFriendRoomReq) (*GetFriendRoomResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFriendRoom not implemented")
+}
+func (UnimplementedUserServiceServer) AreFriends(context.Context, *AreFriendsReq) (*AreFriendsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AreFriends not implemented")
+}
+func (UnimplementedUserServiceServer) GetProfile(context.Context, *GetProfileReq) (*GetProfileResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetProfile not implemented")
+}
+func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenReq) (*LoginResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedUserServiceServer) Logout(context.Context, *LogoutReq) (*LogoutResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedUserServiceServer) GetJWKS(context.Context, *GetJWKSReq) (*GetJWKSResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetJWKS not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeadLetters(context.Context, *ListDeadLettersReq) (*ListDeadLettersResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedUserServiceServer) ReplayDeadLetters(context.Context, *ReplayDeadLettersReq) (*ReplayDeadLettersResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplayDeadLetters not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -210,222 +530,1140 @@ func _UserService_ValidateToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var UserService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.UserService",
-	HandlerType: (*UserServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Register",
-			Handler:    _UserService_Register_Handler,
-		},
-		{
-			MethodName: "Login",
-			Handler:    _UserService_Login_Handler,
-		},
-		{
-			MethodName: "GetHistory",
-			Handler:    _UserService_GetHistory_Handler,
-		},
-		{
-			MethodName: "ValidateToken",
-			Handler:    _UserService_ValidateToken_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "service.proto",
-}
-
-const (
-	MatchService_CreateRoom_FullMethodName = "/pb.MatchService/CreateRoom"
-	MatchService_ListRooms_FullMethodName  = "/pb.MatchService/ListRooms"
-	MatchService_JoinRoom_FullMethodName   = "/pb.MatchService/JoinRoom"
-	MatchService_UpdateRoom_FullMethodName = "/pb.MatchService/UpdateRoom"
-)
-
-// MatchServiceClient is the client API for MatchService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-//
-// --- Match Service 定义 ---
-type MatchServiceClient interface {
-	CreateRoom(ctx context.Context, in *CreateRoomReq, opts ...grpc.CallOption) (*CreateRoomResp, error)
-	ListRooms(ctx context.Context, in *ListRoomsReq, opts ...grpc.CallOption) (*ListRoomsResp, error)
-	JoinRoom(ctx context.Context, in *JoinRoomReq, opts ...grpc.CallOption) (*JoinRoomResp, error)
-	UpdateRoom(ctx context.Context, in *UpdateRoomReq, opts ...grpc.CallOption) (*UpdateRoomResp, error)
-}
-
-type matchServiceClient struct {
-	cc grpc.ClientConnInterface
-}
-
-func NewMatchServiceClient(cc grpc.ClientConnInterface) MatchServiceClient {
-	return &matchServiceClient{cc}
+func _UserService_GetRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetRating(ctx, req.(*GetRatingReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchServiceClient) CreateRoom(ctx context.Context, in *CreateRoomReq, opts ...grpc.CallOption) (*CreateRoomResp, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(CreateRoomResp)
-	err := c.cc.Invoke(ctx, MatchService_CreateRoom_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _UserService_BatchGetRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRatingsReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(UserServiceServer).BatchGetRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BatchGetRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BatchGetRatings(ctx, req.(*BatchGetRatingsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchServiceClient) ListRooms(ctx context.Context, in *ListRoomsReq, opts ...grpc.CallOption) (*ListRoomsResp, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListRoomsResp)
-	err := c.cc.Invoke(ctx, MatchService_ListRooms_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _UserService_GetRatingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingHistoryReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetRatingHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetRatingHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetRatingHistory(ctx, req.(*GetRatingHistoryReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchServiceClient) JoinRoom(ctx context.Context, in *JoinRoomReq, opts ...grpc.CallOption) (*JoinRoomResp, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(JoinRoomResp)
-	err := c.cc.Invoke(ctx, MatchService_JoinRoom_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _UserService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRankReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetRank(ctx, req.(*GetRankReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SendFriendRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendFriendRequestReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SendFriendRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SendFriendRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SendFriendRequest(ctx, req.(*SendFriendRequestReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RespondFriendRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondFriendRequestReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RespondFriendRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RespondFriendRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RespondFriendRequest(ctx, req.(*RespondFriendRequestReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RemoveFriend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFriendReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveFriend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveFriend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveFriend(ctx, req.(*RemoveFriendReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BlockUser(ctx, req.(*BlockUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFriendsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFriends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFriends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFriends(ctx, req.(*GetFriendsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetFriendRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFriendRequestsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFriendRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFriendRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFriendRequests(ctx, req.(*GetFriendRequestsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdatePresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePresenceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdatePresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdatePresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdatePresence(ctx, req.(*UpdatePresenceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetFriendRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFriendRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetFriendRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetFriendRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetFriendRoom(ctx, req.(*GetFriendRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_AreFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreFriendsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).AreFriends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_AreFriends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).AreFriends(ctx, req.(*AreFriendsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfileReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetProfile(ctx, req.(*GetProfileReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RefreshToken(ctx, req.(*RefreshTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Logout(ctx, req.(*LogoutReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJWKSReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetJWKS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetJWKS(ctx, req.(*GetJWKSReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReplayDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLettersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReplayDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReplayDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReplayDeadLetters(ctx, req.(*ReplayDeadLettersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _UserService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _UserService_Login_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _UserService_GetHistory_Handler,
+		},
+		{
+			MethodName: "ValidateToken",
+			Handler:    _UserService_ValidateToken_Handler,
+		},
+		{
+			MethodName: "GetRating",
+			Handler:    _UserService_GetRating_Handler,
+		},
+		{
+			MethodName: "BatchGetRatings",
+			Handler:    _UserService_BatchGetRatings_Handler,
+		},
+		{
+			MethodName: "GetRatingHistory",
+			Handler:    _UserService_GetRatingHistory_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _UserService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetRank",
+			Handler:    _UserService_GetRank_Handler,
+		},
+		{
+			MethodName: "SendFriendRequest",
+			Handler:    _UserService_SendFriendRequest_Handler,
+		},
+		{
+			MethodName: "RespondFriendRequest",
+			Handler:    _UserService_RespondFriendRequest_Handler,
+		},
+		{
+			MethodName: "RemoveFriend",
+			Handler:    _UserService_RemoveFriend_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _UserService_BlockUser_Handler,
+		},
+		{
+			MethodName: "GetFriends",
+			Handler:    _UserService_GetFriends_Handler,
+		},
+		{
+			MethodName: "GetFriendRequests",
+			Handler:    _UserService_GetFriendRequests_Handler,
+		},
+		{
+			MethodName: "UpdatePresence",
+			Handler:    _UserService_UpdatePresence_Handler,
+		},
+		{
+			MethodName: "GetFriendRoom",
+			Handler:    _UserService_GetFriendRoom_Handler,
+		},
+		{
+			MethodName: "AreFriends",
+			Handler:    _UserService_AreFriends_Handler,
+		},
+		{
+			MethodName: "GetProfile",
+			Handler:    _UserService_GetProfile_Handler,
+		},
+		{
+			MethodName: "RefreshToken",
+			Handler:    _UserService_RefreshToken_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _UserService_Logout_Handler,
+		},
+		{
+			MethodName: "GetJWKS",
+			Handler:    _UserService_GetJWKS_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _UserService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetters",
+			Handler:    _UserService_ReplayDeadLetters_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service.proto",
+}
+
+const (
+	MatchService_CreateRoom_FullMethodName     = "/pb.MatchService/CreateRoom"
+	MatchService_ListRooms_FullMethodName      = "/pb.MatchService/ListRooms"
+	MatchService_JoinRoom_FullMethodName       = "/pb.MatchService/JoinRoom"
+	MatchService_UpdateRoom_FullMethodName     = "/pb.MatchService/UpdateRoom"
+	MatchService_LeaveRoom_FullMethodName      = "/pb.MatchService/LeaveRoom"
+	MatchService_KickPlayer_FullMethodName     = "/pb.MatchService/KickPlayer"
+	MatchService_TransferHost_FullMethodName   = "/pb.MatchService/TransferHost"
+	MatchService_StartMatch_FullMethodName     = "/pb.MatchService/StartMatch"
+	MatchService_CreateParty_FullMethodName    = "/pb.MatchService/CreateParty"
+	MatchService_InviteToParty_FullMethodName  = "/pb.MatchService/InviteToParty"
+	MatchService_JoinParty_FullMethodName      = "/pb.MatchService/JoinParty"
+	MatchService_LeaveParty_FullMethodName     = "/pb.MatchService/LeaveParty"
+	MatchService_KickFromParty_FullMethodName  = "/pb.MatchService/KickFromParty"
+	MatchService_GetParty_FullMethodName       = "/pb.MatchService/GetParty"
+	MatchService_JoinAsObserver_FullMethodName = "/pb.MatchService/JoinAsObserver"
+	MatchService_WatchRooms_FullMethodName     = "/pb.MatchService/WatchRooms"
+	MatchService_AddBots_FullMethodName        = "/pb.MatchService/AddBots"
+	MatchService_RemoveBot_FullMethodName      = "/pb.MatchService/RemoveBot"
+)
+
+// MatchServiceClient is the client API for MatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// --- Match Service 定义 ---
+type MatchServiceClient interface {
+	CreateRoom(ctx context.Context, in *CreateRoomReq, opts ...grpc.CallOption) (*CreateRoomResp, error)
+	ListRooms(ctx context.Context, in *ListRoomsReq, opts ...grpc.CallOption) (*ListRoomsResp, error)
+	JoinRoom(ctx context.Context, in *JoinRoomReq, opts ...grpc.CallOption) (*JoinRoomResp, error)
+	UpdateRoom(ctx context.Context, in *UpdateRoomReq, opts ...grpc.CallOption) (*UpdateRoomResp, error)
+	LeaveRoom(ctx context.Context, in *LeaveRoomReq, opts ...grpc.CallOption) (*LeaveRoomResp, error)
+	KickPlayer(ctx context.Context, in *KickPlayerReq, opts ...grpc.CallOption) (*KickPlayerResp, error)
+	TransferHost(ctx context.Context, in *TransferHostReq, opts ...grpc.CallOption) (*TransferHostResp, error)
+	StartMatch(ctx context.Context, in *StartMatchReq, opts ...grpc.CallOption) (*StartMatchResp, error)
+	CreateParty(ctx context.Context, in *CreatePartyReq, opts ...grpc.CallOption) (*PartyResp, error)
+	InviteToParty(ctx context.Context, in *InviteToPartyReq, opts ...grpc.CallOption) (*PartyResp, error)
+	JoinParty(ctx context.Context, in *JoinPartyReq, opts ...grpc.CallOption) (*PartyResp, error)
+	LeaveParty(ctx context.Context, in *LeavePartyReq, opts ...grpc.CallOption) (*PartyResp, error)
+	KickFromParty(ctx context.Context, in *KickFromPartyReq, opts ...grpc.CallOption) (*PartyResp, error)
+	GetParty(ctx context.Context, in *GetPartyReq, opts ...grpc.CallOption) (*PartyResp, error)
+	JoinAsObserver(ctx context.Context, in *JoinAsObserverReq, opts ...grpc.CallOption) (*JoinAsObserverResp, error)
+	WatchRooms(ctx context.Context, in *WatchRoomsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error)
+	AddBots(ctx context.Context, in *AddBotsReq, opts ...grpc.CallOption) (*AddBotsResp, error)
+	RemoveBot(ctx context.Context, in *RemoveBotReq, opts ...grpc.CallOption) (*RemoveBotResp, error)
+}
+
+type matchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchServiceClient(cc grpc.ClientConnInterface) MatchServiceClient {
+	return &matchServiceClient{cc}
+}
+
+func (c *matchServiceClient) CreateRoom(ctx context.Context, in *CreateRoomReq, opts ...grpc.CallOption) (*CreateRoomResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateRoomResp)
+	err := c.cc.Invoke(ctx, MatchService_CreateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) ListRooms(ctx context.Context, in *ListRoomsReq, opts ...grpc.CallOption) (*ListRoomsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoomsResp)
+	err := c.cc.Invoke(ctx, MatchService_ListRooms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) JoinRoom(ctx context.Context, in *JoinRoomReq, opts ...grpc.CallOption) (*JoinRoomResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinRoomResp)
+	err := c.cc.Invoke(ctx, MatchService_JoinRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) UpdateRoom(ctx context.Context, in *UpdateRoomReq, opts ...grpc.CallOption) (*UpdateRoomResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRoomResp)
+	err := c.cc.Invoke(ctx, MatchService_UpdateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) LeaveRoom(ctx context.Context, in *LeaveRoomReq, opts ...grpc.CallOption) (*LeaveRoomResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaveRoomResp)
+	err := c.cc.Invoke(ctx, MatchService_LeaveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) KickPlayer(ctx context.Context, in *KickPlayerReq, opts ...grpc.CallOption) (*KickPlayerResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KickPlayerResp)
+	err := c.cc.Invoke(ctx, MatchService_KickPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) TransferHost(ctx context.Context, in *TransferHostReq, opts ...grpc.CallOption) (*TransferHostResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferHostResp)
+	err := c.cc.Invoke(ctx, MatchService_TransferHost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) StartMatch(ctx context.Context, in *StartMatchReq, opts ...grpc.CallOption) (*StartMatchResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartMatchResp)
+	err := c.cc.Invoke(ctx, MatchService_StartMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) CreateParty(ctx context.Context, in *CreatePartyReq, opts ...grpc.CallOption) (*PartyResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResp)
+	err := c.cc.Invoke(ctx, MatchService_CreateParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) InviteToParty(ctx context.Context, in *InviteToPartyReq, opts ...grpc.CallOption) (*PartyResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResp)
+	err := c.cc.Invoke(ctx, MatchService_InviteToParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) JoinParty(ctx context.Context, in *JoinPartyReq, opts ...grpc.CallOption) (*PartyResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResp)
+	err := c.cc.Invoke(ctx, MatchService_JoinParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) LeaveParty(ctx context.Context, in *LeavePartyReq, opts ...grpc.CallOption) (*PartyResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResp)
+	err := c.cc.Invoke(ctx, MatchService_LeaveParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) KickFromParty(ctx context.Context, in *KickFromPartyReq, opts ...grpc.CallOption) (*PartyResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResp)
+	err := c.cc.Invoke(ctx, MatchService_KickFromParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) GetParty(ctx context.Context, in *GetPartyReq, opts ...grpc.CallOption) (*PartyResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PartyResp)
+	err := c.cc.Invoke(ctx, MatchService_GetParty_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) JoinAsObserver(ctx context.Context, in *JoinAsObserverReq, opts ...grpc.CallOption) (*JoinAsObserverResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinAsObserverResp)
+	err := c.cc.Invoke(ctx, MatchService_JoinAsObserver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) WatchRooms(ctx context.Context, in *WatchRoomsReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchService_ServiceDesc.Streams[0], MatchService_WatchRooms_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRoomsReq, RoomEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_WatchRoomsClient = grpc.ServerStreamingClient[RoomEvent]
+
+func (c *matchServiceClient) AddBots(ctx context.Context, in *AddBotsReq, opts ...grpc.CallOption) (*AddBotsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBotsResp)
+	err := c.cc.Invoke(ctx, MatchService_AddBots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchServiceClient) RemoveBot(ctx context.Context, in *RemoveBotReq, opts ...grpc.CallOption) (*RemoveBotResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveBotResp)
+	err := c.cc.Invoke(ctx, MatchService_RemoveBot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MatchServiceServer is the server API for MatchService service.
+// All implementations must embed UnimplementedMatchServiceServer
+// for forward compatibility.
+//
+// --- Match Service 定义 ---
+type MatchServiceServer interface {
+	CreateRoom(context.Context, *CreateRoomReq) (*CreateRoomResp, error)
+	ListRooms(context.Context, *ListRoomsReq) (*ListRoomsResp, error)
+	JoinRoom(context.Context, *JoinRoomReq) (*JoinRoomResp, error)
+	UpdateRoom(context.Context, *UpdateRoomReq) (*UpdateRoomResp, error)
+	LeaveRoom(context.Context, *LeaveRoomReq) (*LeaveRoomResp, error)
+	KickPlayer(context.Context, *KickPlayerReq) (*KickPlayerResp, error)
+	TransferHost(context.Context, *TransferHostReq) (*TransferHostResp, error)
+	StartMatch(context.Context, *StartMatchReq) (*StartMatchResp, error)
+	CreateParty(context.Context, *CreatePartyReq) (*PartyResp, error)
+	InviteToParty(context.Context, *InviteToPartyReq) (*PartyResp, error)
+	JoinParty(context.Context, *JoinPartyReq) (*PartyResp, error)
+	LeaveParty(context.Context, *LeavePartyReq) (*PartyResp, error)
+	KickFromParty(context.Context, *KickFromPartyReq) (*PartyResp, error)
+	GetParty(context.Context, *GetPartyReq) (*PartyResp, error)
+	JoinAsObserver(context.Context, *JoinAsObserverReq) (*JoinAsObserverResp, error)
+	WatchRooms(*WatchRoomsReq, grpc.ServerStreamingServer[RoomEvent]) error
+	AddBots(context.Context, *AddBotsReq) (*AddBotsResp, error)
+	RemoveBot(context.Context, *RemoveBotReq) (*RemoveBotResp, error)
+	mustEmbedUnimplementedMatchServiceServer()
+}
+
+// UnimplementedMatchServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMatchServiceServer struct{}
+
+func (UnimplementedMatchServiceServer) CreateRoom(context.Context, *CreateRoomReq) (*CreateRoomResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedMatchServiceServer) ListRooms(context.Context, *ListRoomsReq) (*ListRoomsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (UnimplementedMatchServiceServer) JoinRoom(context.Context, *JoinRoomReq) (*JoinRoomResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinRoom not implemented")
+}
+func (UnimplementedMatchServiceServer) UpdateRoom(context.Context, *UpdateRoomReq) (*UpdateRoomResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateRoom not implemented")
+}
+func (UnimplementedMatchServiceServer) LeaveRoom(context.Context, *LeaveRoomReq) (*LeaveRoomResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaveRoom not implemented")
+}
+func (UnimplementedMatchServiceServer) KickPlayer(context.Context, *KickPlayerReq) (*KickPlayerResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method KickPlayer not implemented")
+}
+func (UnimplementedMatchServiceServer) TransferHost(context.Context, *TransferHostReq) (*TransferHostResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferHost not implemented")
+}
+func (UnimplementedMatchServiceServer) StartMatch(context.Context, *StartMatchReq) (*StartMatchResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartMatch not implemented")
+}
+func (UnimplementedMatchServiceServer) CreateParty(context.Context, *CreatePartyReq) (*PartyResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateParty not implemented")
+}
+func (UnimplementedMatchServiceServer) InviteToParty(context.Context, *InviteToPartyReq) (*PartyResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method InviteToParty not implemented")
+}
+func (UnimplementedMatchServiceServer) JoinParty(context.Context, *JoinPartyReq) (*PartyResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinParty not implemented")
+}
+func (UnimplementedMatchServiceServer) LeaveParty(context.Context, *LeavePartyReq) (*PartyResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaveParty not implemented")
+}
+func (UnimplementedMatchServiceServer) KickFromParty(context.Context, *KickFromPartyReq) (*PartyResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method KickFromParty not implemented")
+}
+func (UnimplementedMatchServiceServer) GetParty(context.Context, *GetPartyReq) (*PartyResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetParty not implemented")
+}
+func (UnimplementedMatchServiceServer) JoinAsObserver(context.Context, *JoinAsObserverReq) (*JoinAsObserverResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method JoinAsObserver not implemented")
+}
+func (UnimplementedMatchServiceServer) WatchRooms(*WatchRoomsReq, grpc.ServerStreamingServer[RoomEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchRooms not implemented")
+}
+func (UnimplementedMatchServiceServer) AddBots(context.Context, *AddBotsReq) (*AddBotsResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddBots not implemented")
+}
+func (UnimplementedMatchServiceServer) RemoveBot(context.Context, *RemoveBotReq) (*RemoveBotResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveBot not implemented")
+}
+func (UnimplementedMatchServiceServer) mustEmbedUnimplementedMatchServiceServer() {}
+func (UnimplementedMatchServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeMatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchServiceServer will
+// result in compilation errors.
+type UnsafeMatchServiceServer interface {
+	mustEmbedUnimplementedMatchServiceServer()
+}
+
+func RegisterMatchServiceServer(s grpc.ServiceRegistrar, srv MatchServiceServer) {
+	// If the following call panics, it indicates UnimplementedMatchServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MatchService_ServiceDesc, srv)
+}
+
+func _MatchService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_CreateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).CreateRoom(ctx, req.(*CreateRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_ListRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).ListRooms(ctx, req.(*ListRoomsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).JoinRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_JoinRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).JoinRoom(ctx, req.(*JoinRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_UpdateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).UpdateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_UpdateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).UpdateRoom(ctx, req.(*UpdateRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).LeaveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_LeaveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).LeaveRoom(ctx, req.(*LeaveRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_KickPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickPlayerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).KickPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_KickPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).KickPlayer(ctx, req.(*KickPlayerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_TransferHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferHostReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).TransferHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_TransferHost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).TransferHost(ctx, req.(*TransferHostReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_StartMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartMatchReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(MatchServiceServer).StartMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_StartMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).StartMatch(ctx, req.(*StartMatchReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_CreateParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePartyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).CreateParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_CreateParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).CreateParty(ctx, req.(*CreatePartyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchService_InviteToParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteToPartyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).InviteToParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_InviteToParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).InviteToParty(ctx, req.(*InviteToPartyReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *matchServiceClient) UpdateRoom(ctx context.Context, in *UpdateRoomReq, opts ...grpc.CallOption) (*UpdateRoomResp, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateRoomResp)
-	err := c.cc.Invoke(ctx, MatchService_UpdateRoom_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _MatchService_JoinParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinPartyReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// MatchServiceServer is the server API for MatchService service.
-// All implementations must embed UnimplementedMatchServiceServer
-// for forward compatibility.
-//
-// --- Match Service 定义 ---
-type MatchServiceServer interface {
-	CreateRoom(context.Context, *CreateRoomReq) (*CreateRoomResp, error)
-	ListRooms(context.Context, *ListRoomsReq) (*ListRoomsResp, error)
-	JoinRoom(context.Context, *JoinRoomReq) (*JoinRoomResp, error)
-	UpdateRoom(context.Context, *UpdateRoomReq) (*UpdateRoomResp, error)
-	mustEmbedUnimplementedMatchServiceServer()
-}
-
-// UnimplementedMatchServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedMatchServiceServer struct{}
-
-func (UnimplementedMatchServiceServer) CreateRoom(context.Context, *CreateRoomReq) (*CreateRoomResp, error) {
-	return nil, status.Error(codes.Unimplemented, "method CreateRoom not implemented")
-}
-func (UnimplementedMatchServiceServer) ListRooms(context.Context, *ListRoomsReq) (*ListRoomsResp, error) {
-	return nil, status.Error(codes.Unimplemented, "method ListRooms not implemented")
-}
-func (UnimplementedMatchServiceServer) JoinRoom(context.Context, *JoinRoomReq) (*JoinRoomResp, error) {
-	return nil, status.Error(codes.Unimplemented, "method JoinRoom not implemented")
-}
-func (UnimplementedMatchServiceServer) UpdateRoom(context.Context, *UpdateRoomReq) (*UpdateRoomResp, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateRoom not implemented")
+	if interceptor == nil {
+		return srv.(MatchServiceServer).JoinParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_JoinParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).JoinParty(ctx, req.(*JoinPartyReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedMatchServiceServer) mustEmbedUnimplementedMatchServiceServer() {}
-func (UnimplementedMatchServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeMatchServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MatchServiceServer will
-// result in compilation errors.
-type UnsafeMatchServiceServer interface {
-	mustEmbedUnimplementedMatchServiceServer()
+func _MatchService_LeaveParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeavePartyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchServiceServer).LeaveParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_LeaveParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).LeaveParty(ctx, req.(*LeavePartyReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterMatchServiceServer(s grpc.ServiceRegistrar, srv MatchServiceServer) {
-	// If the following call panics, it indicates UnimplementedMatchServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _MatchService_KickFromParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickFromPartyReq)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&MatchService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(MatchServiceServer).KickFromParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchService_KickFromParty_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchServiceServer).KickFromParty(ctx, req.(*KickFromPartyReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRoomReq)
+func _MatchService_GetParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartyReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchServiceServer).CreateRoom(ctx, in)
+		return srv.(MatchServiceServer).GetParty(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchService_CreateRoom_FullMethodName,
+		FullMethod: MatchService_GetParty_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchServiceServer).CreateRoom(ctx, req.(*CreateRoomReq))
+		return srv.(MatchServiceServer).GetParty(ctx, req.(*GetPartyReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRoomsReq)
+func _MatchService_JoinAsObserver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinAsObserverReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchServiceServer).ListRooms(ctx, in)
+		return srv.(MatchServiceServer).JoinAsObserver(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchService_ListRooms_FullMethodName,
+		FullMethod: MatchService_JoinAsObserver_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchServiceServer).ListRooms(ctx, req.(*ListRoomsReq))
+		return srv.(MatchServiceServer).JoinAsObserver(ctx, req.(*JoinAsObserverReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchService_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRoomReq)
+func _MatchService_WatchRooms_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRoomsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchServiceServer).WatchRooms(m, &grpc.GenericServerStream[WatchRoomsReq, RoomEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchService_WatchRoomsServer = grpc.ServerStreamingServer[RoomEvent]
+
+func _MatchService_AddBots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBotsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchServiceServer).JoinRoom(ctx, in)
+		return srv.(MatchServiceServer).AddBots(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchService_JoinRoom_FullMethodName,
+		FullMethod: MatchService_AddBots_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchServiceServer).JoinRoom(ctx, req.(*JoinRoomReq))
+		return srv.(MatchServiceServer).AddBots(ctx, req.(*AddBotsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _MatchService_UpdateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRoomReq)
+func _MatchService_RemoveBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveBotReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MatchServiceServer).UpdateRoom(ctx, in)
+		return srv.(MatchServiceServer).RemoveBot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: MatchService_UpdateRoom_FullMethodName,
+		FullMethod: MatchService_RemoveBot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MatchServiceServer).UpdateRoom(ctx, req.(*UpdateRoomReq))
+		return srv.(MatchServiceServer).RemoveBot(ctx, req.(*RemoveBotReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -453,14 +1691,77 @@ var MatchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateRoom",
 			Handler:    _MatchService_UpdateRoom_Handler,
 		},
+		{
+			MethodName: "LeaveRoom",
+			Handler:    _MatchService_LeaveRoom_Handler,
+		},
+		{
+			MethodName: "KickPlayer",
+			Handler:    _MatchService_KickPlayer_Handler,
+		},
+		{
+			MethodName: "TransferHost",
+			Handler:    _MatchService_TransferHost_Handler,
+		},
+		{
+			MethodName: "StartMatch",
+			Handler:    _MatchService_StartMatch_Handler,
+		},
+		{
+			MethodName: "CreateParty",
+			Handler:    _MatchService_CreateParty_Handler,
+		},
+		{
+			MethodName: "InviteToParty",
+			Handler:    _MatchService_InviteToParty_Handler,
+		},
+		{
+			MethodName: "JoinParty",
+			Handler:    _MatchService_JoinParty_Handler,
+		},
+		{
+			MethodName: "LeaveParty",
+			Handler:    _MatchService_LeaveParty_Handler,
+		},
+		{
+			MethodName: "KickFromParty",
+			Handler:    _MatchService_KickFromParty_Handler,
+		},
+		{
+			MethodName: "GetParty",
+			Handler:    _MatchService_GetParty_Handler,
+		},
+		{
+			MethodName: "JoinAsObserver",
+			Handler:    _MatchService_JoinAsObserver_Handler,
+		},
+		{
+			MethodName: "AddBots",
+			Handler:    _MatchService_AddBots_Handler,
+		},
+		{
+			MethodName: "RemoveBot",
+			Handler:    _MatchService_RemoveBot_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRooms",
+			Handler:       _MatchService_WatchRooms_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
 }
 
 const (
 	GameService_ValidateToken_FullMethodName   = "/pb.GameService/ValidateToken"
 	GameService_NotifyGameStart_FullMethodName = "/pb.GameService/NotifyGameStart"
+	GameService_RemovePlayer_FullMethodName    = "/pb.GameService/RemovePlayer"
+	GameService_TransferHost_FullMethodName    = "/pb.GameService/TransferHost"
+	GameService_AllocateRoom_FullMethodName    = "/pb.GameService/AllocateRoom"
+	GameService_AdmitPlayer_FullMethodName     = "/pb.GameService/AdmitPlayer"
+	GameService_AddBot_FullMethodName          = "/pb.GameService/AddBot"
 )
 
 // GameServiceClient is the client API for GameService service.
@@ -471,6 +1772,11 @@ const (
 type GameServiceClient interface {
 	ValidateToken(ctx context.Context, in *GameValidateTokenReq, opts ...grpc.CallOption) (*GameValidateTokenResp, error)
 	NotifyGameStart(ctx context.Context, in *NotifyGameStartReq, opts ...grpc.CallOption) (*NotifyGameStartResp, error)
+	RemovePlayer(ctx context.Context, in *RemovePlayerReq, opts ...grpc.CallOption) (*RemovePlayerResp, error)
+	TransferHost(ctx context.Context, in *GameTransferHostReq, opts ...grpc.CallOption) (*GameTransferHostResp, error)
+	AllocateRoom(ctx context.Context, in *AllocateRoomReq, opts ...grpc.CallOption) (*AllocateRoomResp, error)
+	AdmitPlayer(ctx context.Context, in *AdmitPlayerReq, opts ...grpc.CallOption) (*AdmitPlayerResp, error)
+	AddBot(ctx context.Context, in *AddBotReq, opts ...grpc.CallOption) (*AddBotResp, error)
 }
 
 type gameServiceClient struct {
@@ -501,6 +1807,56 @@ func (c *gameServiceClient) NotifyGameStart(ctx context.Context, in *NotifyGameS
 	return out, nil
 }
 
+func (c *gameServiceClient) RemovePlayer(ctx context.Context, in *RemovePlayerReq, opts ...grpc.CallOption) (*RemovePlayerResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemovePlayerResp)
+	err := c.cc.Invoke(ctx, GameService_RemovePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) TransferHost(ctx context.Context, in *GameTransferHostReq, opts ...grpc.CallOption) (*GameTransferHostResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameTransferHostResp)
+	err := c.cc.Invoke(ctx, GameService_TransferHost_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) AllocateRoom(ctx context.Context, in *AllocateRoomReq, opts ...grpc.CallOption) (*AllocateRoomResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllocateRoomResp)
+	err := c.cc.Invoke(ctx, GameService_AllocateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) AdmitPlayer(ctx context.Context, in *AdmitPlayerReq, opts ...grpc.CallOption) (*AdmitPlayerResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdmitPlayerResp)
+	err := c.cc.Invoke(ctx, GameService_AdmitPlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) AddBot(ctx context.Context, in *AddBotReq, opts ...grpc.CallOption) (*AddBotResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBotResp)
+	err := c.cc.Invoke(ctx, GameService_AddBot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServiceServer is the server API for GameService service.
 // All implementations must embed UnimplementedGameServiceServer
 // for forward compatibility.
@@ -509,6 +1865,11 @@ func (c *gameServiceClient) NotifyGameStart(ctx context.Context, in *NotifyGameS
 type GameServiceServer interface {
 	ValidateToken(context.Context, *GameValidateTokenReq) (*GameValidateTokenResp, error)
 	NotifyGameStart(context.Context, *NotifyGameStartReq) (*NotifyGameStartResp, error)
+	RemovePlayer(context.Context, *RemovePlayerReq) (*RemovePlayerResp, error)
+	TransferHost(context.Context, *GameTransferHostReq) (*GameTransferHostResp, error)
+	AllocateRoom(context.Context, *AllocateRoomReq) (*AllocateRoomResp, error)
+	AdmitPlayer(context.Context, *AdmitPlayerReq) (*AdmitPlayerResp, error)
+	AddBot(context.Context, *AddBotReq) (*AddBotResp, error)
 	mustEmbedUnimplementedGameServiceServer()
 }
 
@@ -525,6 +1886,21 @@ func (UnimplementedGameServiceServer) ValidateToken(context.Context, *GameValida
 func (UnimplementedGameServiceServer) NotifyGameStart(context.Context, *NotifyGameStartReq) (*NotifyGameStartResp, error) {
 	return nil, status.Error(codes.Unimplemented, "method NotifyGameStart not implemented")
 }
+func (UnimplementedGameServiceServer) RemovePlayer(context.Context, *RemovePlayerReq) (*RemovePlayerResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemovePlayer not implemented")
+}
+func (UnimplementedGameServiceServer) TransferHost(context.Context, *GameTransferHostReq) (*GameTransferHostResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferHost not implemented")
+}
+func (UnimplementedGameServiceServer) AllocateRoom(context.Context, *AllocateRoomReq) (*AllocateRoomResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AllocateRoom not implemented")
+}
+func (UnimplementedGameServiceServer) AdmitPlayer(context.Context, *AdmitPlayerReq) (*AdmitPlayerResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdmitPlayer not implemented")
+}
+func (UnimplementedGameServiceServer) AddBot(context.Context, *AddBotReq) (*AddBotResp, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddBot not implemented")
+}
 func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
 func (UnimplementedGameServiceServer) testEmbeddedByValue()                     {}
 
@@ -582,6 +1958,96 @@ func _GameService_NotifyGameStart_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_RemovePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemovePlayerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).RemovePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_RemovePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).RemovePlayer(ctx, req.(*RemovePlayerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_TransferHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GameTransferHostReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).TransferHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_TransferHost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).TransferHost(ctx, req.(*GameTransferHostReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_AllocateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AllocateRoomReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).AllocateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_AllocateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).AllocateRoom(ctx, req.(*AllocateRoomReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_AdmitPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdmitPlayerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).AdmitPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_AdmitPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).AdmitPlayer(ctx, req.(*AdmitPlayerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_AddBot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBotReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).AddBot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_AddBot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).AddBot(ctx, req.(*AddBotReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -597,6 +2063,26 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NotifyGameStart",
 			Handler:    _GameService_NotifyGameStart_Handler,
 		},
+		{
+			MethodName: "RemovePlayer",
+			Handler:    _GameService_RemovePlayer_Handler,
+		},
+		{
+			MethodName: "TransferHost",
+			Handler:    _GameService_TransferHost_Handler,
+		},
+		{
+			MethodName: "AllocateRoom",
+			Handler:    _GameService_AllocateRoom_Handler,
+		},
+		{
+			MethodName: "AdmitPlayer",
+			Handler:    _GameService_AdmitPlayer_Handler,
+		},
+		{
+			MethodName: "AddBot",
+			Handler:    _GameService_AddBot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
@@ -0,0 +1,58 @@
+// Package app Game Service 的初始化与路由，供 main 与进程内集成测试共用
+package app
+
+import (
+	pb "mygame/proto"
+	"mygame/server/game-service/internal/auth"
+	"mygame/server/game-service/internal/core"
+	"mygame/server/game-service/internal/dao"
+	"mygame/server/game-service/internal/handler"
+	"mygame/server/game-service/internal/mq"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+// Init 按 config.AppConfig 初始化 Redis、MQ 与验签公钥
+func Init() {
+	// 初始化 Redis（用于房间 ticket 校验）
+	dao.InitRedis()
+
+	mq.InitMQ()
+	auth.InitJWKS()
+}
+
+// NewRouter 创建提供 WebSocket 的 Gin 引擎
+func NewRouter() *gin.Engine {
+	r := gin.Default()
+
+	r.Use(func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	})
+
+	r.GET("/ws", core.HandleWebSocket)
+	return r
+}
+
+// NewDebugRouter 调试接口，不挂在对外的 WebSocket 端口上，由 server.debug_addr 单独监听
+func NewDebugRouter() *gin.Engine {
+	r := gin.Default()
+	r.GET("/debug/ticks", core.HandleTickStats)
+	return r
+}
+
+// NewGRPCServer 创建注册了 GameService 的 gRPC 服务，供 Match 预分配房间、通知开局等
+func NewGRPCServer() *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterGameServiceServer(s, &handler.GameServiceServer{})
+	return s
+}
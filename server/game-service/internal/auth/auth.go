@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"mygame/pkg/jwks"
+	"mygame/server/game-service/pkg/config"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// keys 从 Gateway 的 /.well-known/jwks.json 拉取的验签公钥，未配置 jwks_url 时为 nil
+var keys *jwks.Store
+
+// InitJWKS 配置了 auth.jwks_url 时启用 WebSocket 连接的用户 JWT 校验
+func InitJWKS() {
+	url := config.AppConfig.Auth.JWKSURL
+	if url == "" {
+		return
+	}
+
+	keys = jwks.NewStore(func(ctx context.Context) ([]jwks.JWK, error) {
+		return fetch(ctx, url)
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Gateway 可能晚于本服启动，拉取失败时等首次校验或定时刷新再拉取
+	if err := keys.Refresh(ctx); err != nil {
+		log.Printf("Fetch JWKS from %s failed: %v", url, err)
+	}
+
+	interval, err := time.ParseDuration(config.AppConfig.Auth.RefreshInterval)
+	if err != nil || interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	keys.StartRefresh(interval)
+}
+
+// Enabled 是否要求连接携带用户 JWT
+func Enabled() bool {
+	return keys != nil
+}
+
+// VerifyToken 用公钥校验 access token，返回其中的 uid
+func VerifyToken(tokenString string) (int64, error) {
+	token, err := jwt.Parse(tokenString, keys.Keyfunc)
+	if err != nil || !token.Valid {
+		return 0, fmt.Errorf("invalid token")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, fmt.Errorf("invalid claims")
+	}
+	// JSON 解析出来的数字是 float64
+	uid, _ := claims["uid"].(float64)
+	if uid == 0 {
+		return 0, fmt.Errorf("invalid claims")
+	}
+	return int64(uid), nil
+}
+
+func fetch(ctx context.Context, url string) ([]jwks.JWK, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var doc jwks.Document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc.Keys, nil
+}
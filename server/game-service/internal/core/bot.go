@@ -0,0 +1,448 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/game-service/pkg/config"
+)
+
+// 服务端机器人：作为 Player 加入房间，通过进程内连接接收与真人相同的快照和事件，
+// 按难度参数生成 C2SInput 写入输入队列，和真人的操作一样经 ProcessInputs 处理
+
+// DefaultBotDifficulty 未指定难度时使用
+const DefaultBotDifficulty = "normal"
+
+// 内置难度，game.bots 中的同名配置按字段覆盖
+var botDifficulties = map[string]config.BotConfig{
+	"easy":   {ReactionMs: 450, AimError: 40, MinChargeMs: 50, MaxChargeMs: 250, DodgeChance: 0.1},
+	"normal": {ReactionMs: 250, AimError: 15, MinChargeMs: 200, MaxChargeMs: 450, DodgeChance: 0.4},
+	"hard":   {ReactionMs: 120, AimError: 5, MinChargeMs: 400, MaxChargeMs: 550, DodgeChance: 0.8},
+}
+
+const (
+	beamRange       = 800.0 // 与 FireBeam 的射程一致
+	botAimTolerance = 25.0  // 目标偏离光柱中线小于该值时开火
+	botThreatWidth  = 40.0  // 敌人与自己偏离小于该值时视为被瞄准
+	botDodgeTime    = 300 * time.Millisecond
+)
+
+// 瞄准方向，与 direction 的下标一致
+const (
+	AngleRight int32 = 0
+	AngleUp    int32 = 1
+	AngleLeft  int32 = 2
+	AngleDown  int32 = 3
+)
+
+// botDifficulty 查找难度参数
+func botDifficulty(name string) (config.BotConfig, error) {
+	if name == "" {
+		name = DefaultBotDifficulty
+	}
+	cfg, builtin := botDifficulties[name]
+	custom, ok := config.AppConfig.Game.Bots[name]
+	if !builtin && !ok {
+		return config.BotConfig{}, fmt.Errorf("unknown bot difficulty: %s", name)
+	}
+	if ok {
+		if custom.ReactionMs > 0 {
+			cfg.ReactionMs = custom.ReactionMs
+		}
+		if custom.AimError > 0 {
+			cfg.AimError = custom.AimError
+		}
+		if custom.MinChargeMs > 0 {
+			cfg.MinChargeMs = custom.MinChargeMs
+		}
+		if custom.MaxChargeMs > 0 {
+			cfg.MaxChargeMs = custom.MaxChargeMs
+		}
+		if custom.DodgeChance > 0 {
+			cfg.DodgeChance = custom.DodgeChance
+		}
+	}
+	if cfg.MaxChargeMs < cfg.MinChargeMs {
+		cfg.MaxChargeMs = cfg.MinChargeMs
+	}
+	return cfg, nil
+}
+
+type timedSnapshot struct {
+	at       time.Time
+	snapshot *pb.S2CSnapshot
+}
+
+// botConn 机器人的进程内连接：Send 在房间锁内调用，只记录快照与事件，不做序列化
+type botConn struct {
+	reaction time.Duration
+
+	mu        sync.Mutex
+	snapshots []timedSnapshot // 按接收先后排列，只保留反应时间窗口内会用到的
+	started   bool
+	over      bool
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *botConn) Send(pkt *pb.GamePacket) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	switch p := pkt.Payload.(type) {
+	case *pb.GamePacket_Snapshot:
+		c.snapshots = append(c.snapshots, timedSnapshot{at: now, snapshot: p.Snapshot})
+		// 保留最后一个已超过反应时间的快照，更早的不会再被看到
+		i := 0
+		for i+1 < len(c.snapshots) && now.Sub(c.snapshots[i+1].at) >= c.reaction {
+			i++
+		}
+		c.snapshots = c.snapshots[i:]
+	case *pb.GamePacket_Event:
+		switch p.Event.Type {
+		case pb.GameEvent_GAME_START:
+			c.started = true
+		case pb.GameEvent_GAME_OVER:
+			c.over = true
+		}
+	}
+}
+
+// Close 被移出房间或房间关闭时调用，机器人随之退出
+func (c *botConn) Close(reason string) {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+// view 反应时间之前收到的最新快照，机器人只能据此决策
+func (c *botConn) view(now time.Time) *pb.S2CSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var view *pb.S2CSnapshot
+	for _, s := range c.snapshots {
+		if now.Sub(s.at) < c.reaction {
+			break
+		}
+		view = s.snapshot
+	}
+	return view
+}
+
+// playing 已开局且尚未结束
+func (c *botConn) playing() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.started && !c.over
+}
+
+// bot 一名机器人的决策状态，只在自己的协程中访问
+type bot struct {
+	room *Room
+	uid  int64
+	team int32
+	cfg  config.BotConfig
+	conn *botConn
+	rnd  *rand.Rand
+
+	// 瞄准误差：每次开火后重新采样
+	aimX, aimY float64
+
+	// 蓄力计划
+	charging  bool
+	releaseAt time.Time
+	nextFire  time.Time
+
+	// 躲避
+	threats          map[int64]bool // 已对其蓄力做过躲避判定的敌人
+	dodgeUntil       time.Time
+	dodgeDx, dodgeDy float32
+}
+
+func newBot(room *Room, uid int64, team int32, cfg config.BotConfig) *bot {
+	b := &bot{
+		room: room,
+		uid:  uid,
+		team: team,
+		cfg:  cfg,
+		conn: &botConn{
+			reaction: time.Duration(cfg.ReactionMs) * time.Millisecond,
+			done:     make(chan struct{}),
+		},
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano() + uid)),
+		threats: make(map[int64]bool),
+	}
+	b.resampleAim()
+	return b
+}
+
+// run 每个 tick 决策一次，直到被移出房间或房间关闭
+func (b *bot) run() {
+	ticker := time.NewTicker(TickDuration)
+	defer ticker.Stop()
+	defer func() {
+		select {
+		case b.room.Unregister <- b.uid:
+		case <-b.room.StopChan:
+		}
+	}()
+
+	for {
+		select {
+		case <-b.conn.done:
+			return
+		case <-b.room.StopChan:
+			return
+		case now := <-ticker.C:
+			if input := b.think(now); input != nil {
+				b.room.pushInput(b.uid, input)
+			}
+		}
+	}
+}
+
+// think 根据反应时间之前的快照决定本 tick 的操作：躲避 > 对准目标 > 蓄力/松开
+func (b *bot) think(now time.Time) *pb.C2SInput {
+	if !b.conn.playing() {
+		return nil
+	}
+	view := b.conn.view(now)
+	if view == nil {
+		return nil
+	}
+
+	var self *pb.PlayerState
+	enemies := make([]*pb.PlayerState, 0, len(view.Players))
+	for _, p := range view.Players {
+		if p.Uid == b.uid {
+			self = p
+			continue
+		}
+		if p.IsDead || (b.team != 0 && p.Team == b.team) {
+			continue
+		}
+		enemies = append(enemies, p)
+	}
+	if self == nil || self.IsDead {
+		return nil
+	}
+
+	input := &pb.C2SInput{}
+
+	// 1. 正在蓄力时按计划松开，蓄力时长体现难度
+	if b.charging && !now.Before(b.releaseAt) {
+		input.Charge = &pb.ChargeCmd{IsCharging: false}
+		b.charging = false
+		b.nextFire = now.Add(b.reaction())
+		b.resampleAim()
+	}
+
+	// 2. 发现敌人蓄力且自己在其射线上时，按概率横向躲开
+	b.checkThreats(now, self, enemies)
+	if now.Before(b.dodgeUntil) {
+		input.Move = &pb.MoveCmd{Dx: b.dodgeDx, Dy: b.dodgeDy}
+		return input
+	}
+
+	target := nearest(self, enemies)
+	if target == nil {
+		if input.Charge == nil {
+			return nil
+		}
+		return input
+	}
+
+	// 3. 沿偏差较小的轴对准目标，另一轴保持在射程内
+	dx := float64(target.X) + b.aimX - float64(self.X)
+	dy := float64(target.Y) + b.aimY - float64(self.Y)
+	var offset, along float64
+	var angle int32
+	if math.Abs(dx) <= math.Abs(dy) {
+		offset, along = dx, dy
+		input.Move = &pb.MoveCmd{Dx: b.steer(dx), Dy: b.approach(dy)}
+		angle = AngleUp
+		if dy < 0 {
+			angle = AngleDown
+		}
+	} else {
+		offset, along = dy, dx
+		input.Move = &pb.MoveCmd{Dx: b.approach(dx), Dy: b.steer(dy)}
+		angle = AngleRight
+		if dx < 0 {
+			angle = AngleLeft
+		}
+	}
+
+	// 4. 对准且在射程内时开始蓄力
+	if !b.charging && input.Charge == nil && !now.Before(b.nextFire) &&
+		math.Abs(offset) < botAimTolerance && math.Abs(along) <= beamRange {
+		hold := b.cfg.MinChargeMs
+		if b.cfg.MaxChargeMs > hold {
+			hold += b.rnd.Intn(b.cfg.MaxChargeMs - hold + 1)
+		}
+		input.Charge = &pb.ChargeCmd{IsCharging: true, Angle: angle}
+		b.charging = true
+		b.releaseAt = now.Add(time.Duration(hold) * time.Millisecond)
+	}
+	return input
+}
+
+// checkThreats 对每次新出现的敌人蓄力判定一次是否躲避
+func (b *bot) checkThreats(now time.Time, self *pb.PlayerState, enemies []*pb.PlayerState) {
+	charging := make(map[int64]bool, len(enemies))
+	for _, e := range enemies {
+		if !e.IsCharging {
+			continue
+		}
+		charging[e.Uid] = true
+		if b.threats[e.Uid] {
+			continue
+		}
+		b.threats[e.Uid] = true
+
+		dx := float64(self.X - e.X)
+		dy := float64(self.Y - e.Y)
+		var moveX bool
+		switch {
+		case math.Abs(dx) < botThreatWidth && math.Abs(dy) <= beamRange:
+			moveX = true // 敌人纵向开火，横向躲
+		case math.Abs(dy) < botThreatWidth && math.Abs(dx) <= beamRange:
+			moveX = false
+		default:
+			continue
+		}
+		if b.rnd.Float64() >= b.cfg.DodgeChance {
+			continue
+		}
+
+		// 朝远离射线中线的一侧躲，正好在中线上时随机
+		d := dy
+		if moveX {
+			d = dx
+		}
+		side := float32(1)
+		if d < 0 || (d == 0 && b.rnd.Intn(2) == 0) {
+			side = -1
+		}
+		b.dodgeDx, b.dodgeDy = 0, 0
+		if moveX {
+			b.dodgeDx = side
+		} else {
+			b.dodgeDy = side
+		}
+		b.dodgeUntil = now.Add(botDodgeTime)
+	}
+	// 敌人松开后允许对其下一次蓄力重新判定
+	for uid := range b.threats {
+		if !charging[uid] {
+			delete(b.threats, uid)
+		}
+	}
+}
+
+// steer 把偏差收敛到 0：按反应时间缩小步长，避免因看到的是旧位置而来回越过
+func (b *bot) steer(offset float64) float32 {
+	speed := playerSpeed()
+	lag := math.Max(b.reaction().Seconds(), 0.05)
+	return clampUnit(offset / (2 * speed * lag))
+}
+
+// approach 距离超出射程时靠近目标
+func (b *bot) approach(along float64) float32 {
+	if math.Abs(along) <= beamRange*0.8 {
+		return 0
+	}
+	if along > 0 {
+		return 1
+	}
+	return -1
+}
+
+func (b *bot) reaction() time.Duration {
+	return time.Duration(b.cfg.ReactionMs) * time.Millisecond
+}
+
+func (b *bot) resampleAim() {
+	b.aimX = b.rnd.NormFloat64() * b.cfg.AimError
+	b.aimY = b.rnd.NormFloat64() * b.cfg.AimError
+}
+
+// nearest 距离最近的敌人
+func nearest(self *pb.PlayerState, enemies []*pb.PlayerState) *pb.PlayerState {
+	var best *pb.PlayerState
+	bestDist := math.MaxFloat64
+	for _, e := range enemies {
+		dist := math.Hypot(float64(e.X-self.X), float64(e.Y-self.Y))
+		if dist < bestDist {
+			best, bestDist = e, dist
+		}
+	}
+	return best
+}
+
+func clampUnit(v float64) float32 {
+	return float32(math.Max(-1, math.Min(1, v)))
+}
+
+// AddBot 在等待室加入一名机器人，与真人一样占用座位；uid 为负数，由 Match 分配
+func (r *Room) AddBot(uid int64, name string, team int32, difficulty string) error {
+	if uid >= 0 {
+		return fmt.Errorf("bot uid must be negative")
+	}
+	if difficulty == "" {
+		difficulty = DefaultBotDifficulty
+	}
+	cfg, err := botDifficulty(difficulty)
+	if err != nil {
+		return err
+	}
+
+	r.Mutex.Lock()
+	if !r.IsInWaitingMode || r.IsCountingDown {
+		r.Mutex.Unlock()
+		return fmt.Errorf("game already started")
+	}
+	if r.Allowed[uid] {
+		r.Mutex.Unlock()
+		return fmt.Errorf("bot %d already in room", uid)
+	}
+	if len(r.Allowed) >= r.MaxPlayers {
+		r.Mutex.Unlock()
+		return fmt.Errorf("room is full")
+	}
+	r.Allowed[uid] = true
+	r.Teams[uid] = team
+	r.Mutex.Unlock()
+
+	if name == "" {
+		name = fmt.Sprintf("Bot %d", -uid)
+	}
+	b := newBot(r, uid, team, cfg)
+	p := NewPlayer(uid, name, b.conn)
+	p.IsBot = true
+	select {
+	case r.Register <- p:
+	case <-r.StopChan:
+		return fmt.Errorf("room closed")
+	}
+	go b.run()
+	log.Printf("Bot %d (%s) added to room %s", uid, difficulty, r.ID)
+	return nil
+}
+
+// pushInput 把机器人的操作写入输入队列，target_tick 为下一个 tick 的执行帧
+func (r *Room) pushInput(uid int64, input *pb.C2SInput) {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	p, ok := r.Players[uid]
+	if !ok {
+		return
+	}
+	input.Timestamp = time.Now().UnixMilli()
+	input.TargetTick = r.CurrentTick + 1 - DelayCompensation
+	p.InputQueue = append(p.InputQueue, input)
+}
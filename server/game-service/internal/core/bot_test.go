@@ -0,0 +1,138 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/game-service/pkg/config"
+)
+
+func TestBotDifficulty(t *testing.T) {
+	defer func(bots map[string]config.BotConfig) { config.AppConfig.Game.Bots = bots }(config.AppConfig.Game.Bots)
+	config.AppConfig.Game.Bots = map[string]config.BotConfig{
+		"hard":    {ReactionMs: 80},
+		"sniper":  {ReactionMs: 300, MinChargeMs: 500, MaxChargeMs: 100},
+		"invalid": {},
+	}
+
+	tests := []struct {
+		name    string
+		want    config.BotConfig
+		wantErr bool
+	}{
+		{name: "", want: botDifficulties["normal"]},
+		{name: "easy", want: botDifficulties["easy"]},
+		{name: "hard", want: config.BotConfig{ReactionMs: 80, AimError: 5, MinChargeMs: 400, MaxChargeMs: 550, DodgeChance: 0.8}},
+		{name: "sniper", want: config.BotConfig{ReactionMs: 300, MinChargeMs: 500, MaxChargeMs: 500}},
+		{name: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := botDifficulty(tt.name)
+			if (err != nil) != tt.wantErr || cfg != tt.want {
+				t.Fatalf("botDifficulty = %+v, %v, want %+v", cfg, err, tt.want)
+			}
+		})
+	}
+}
+
+// testBot 反应时间 100ms、无瞄准误差的机器人，开局后已看到 players 所在的快照
+func testBot(team int32, dodge float64, players ...*pb.PlayerState) (*bot, time.Time) {
+	b := newBot(nil, -1, team, config.BotConfig{ReactionMs: 100, MinChargeMs: 200, MaxChargeMs: 200, DodgeChance: dodge})
+	b.conn.Send(&pb.GamePacket{Payload: &pb.GamePacket_Event{Event: &pb.GameEvent{Type: pb.GameEvent_GAME_START}}})
+	b.conn.Send(&pb.GamePacket{Payload: &pb.GamePacket_Snapshot{Snapshot: &pb.S2CSnapshot{Players: players}}})
+	return b, time.Now().Add(150 * time.Millisecond)
+}
+
+func TestBotThink(t *testing.T) {
+	self := &pb.PlayerState{Uid: -1}
+	tests := []struct {
+		name    string
+		team    int32
+		dodge   float64
+		players []*pb.PlayerState
+		want    *pb.C2SInput
+	}{
+		{
+			name:    "aim and charge at target in range",
+			players: []*pb.PlayerState{self, {Uid: 2, Y: 300}},
+			want:    &pb.C2SInput{Move: &pb.MoveCmd{}, Charge: &pb.ChargeCmd{IsCharging: true, Angle: AngleUp}},
+		},
+		{
+			name:    "approach target out of range",
+			players: []*pb.PlayerState{self, {Uid: 2, X: -900}},
+			want:    &pb.C2SInput{Move: &pb.MoveCmd{Dx: -1}},
+		},
+		{
+			name:    "line up on the smaller axis",
+			players: []*pb.PlayerState{self, {Uid: 2, X: 300, Y: -100}},
+			want:    &pb.C2SInput{Move: &pb.MoveCmd{Dy: -1}},
+		},
+		{
+			name:    "dodge charging enemy",
+			dodge:   1,
+			players: []*pb.PlayerState{self, {Uid: 2, X: 10, Y: 300, IsCharging: true}},
+			want:    &pb.C2SInput{Move: &pb.MoveCmd{Dx: -1}},
+		},
+		{
+			name:    "failed dodge roll keeps aiming",
+			players: []*pb.PlayerState{self, {Uid: 2, X: 10, Y: 300, IsCharging: true}},
+			want:    &pb.C2SInput{Move: &pb.MoveCmd{Dx: 1}, Charge: &pb.ChargeCmd{IsCharging: true, Angle: AngleUp}},
+		},
+		{
+			name:    "teammates and dead players ignored",
+			team:    1,
+			players: []*pb.PlayerState{self, {Uid: 2, Y: 300, Team: 1}, {Uid: 3, Y: 300, Team: 2, IsDead: true}},
+		},
+		{
+			name:    "dead bot idles",
+			players: []*pb.PlayerState{{Uid: -1, IsDead: true}, {Uid: 2, Y: 300}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, now := testBot(tt.team, tt.dodge, tt.players...)
+			got := b.think(now)
+			if got.String() != tt.want.String() {
+				t.Fatalf("think = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBotReactionAndRelease(t *testing.T) {
+	b, now := testBot(0, 0, &pb.PlayerState{Uid: -1}, &pb.PlayerState{Uid: 2, Y: 300})
+
+	// 1. 反应时间内看不到刚收到的快照
+	if input := b.think(time.Now()); input != nil {
+		t.Fatalf("think before reaction time = %v", input)
+	}
+
+	// 2. 蓄力到计划时长后松开，之后间隔一个反应时间才再次蓄力
+	if input := b.think(now); !input.GetCharge().GetIsCharging() {
+		t.Fatalf("first think = %v, want charge", input)
+	}
+	steps := []struct {
+		after    time.Duration
+		charge   bool
+		charging bool
+	}{
+		{after: 100 * time.Millisecond},
+		{after: 200 * time.Millisecond, charge: true},
+		{after: 250 * time.Millisecond},
+		{after: 300 * time.Millisecond, charge: true, charging: true},
+	}
+	for _, s := range steps {
+		input := b.think(now.Add(s.after))
+		if (input.GetCharge() != nil) != s.charge || input.GetCharge().GetIsCharging() != s.charging {
+			t.Fatalf("think after %v = %v, want charge %v charging %v", s.after, input, s.charge, s.charging)
+		}
+	}
+
+	// 3. 对局结束后不再操作
+	b.conn.Send(&pb.GamePacket{Payload: &pb.GamePacket_Event{Event: &pb.GameEvent{Type: pb.GameEvent_GAME_OVER}}})
+	if input := b.think(now.Add(time.Second)); input != nil {
+		t.Fatalf("think after game over = %v", input)
+	}
+}
@@ -3,11 +3,18 @@ package core
 import (
 	pb "mygame/proto"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"google.golang.org/protobuf/proto"
 )
 
+// Connection 玩家的下行连接：真人为 WebSocket，机器人为进程内的 botConn
+type Connection interface {
+	Send(pkt *pb.GamePacket)
+	Close(reason string)
+}
+
 type WebSocketConn struct {
 	Conn *websocket.Conn
 	mu   sync.Mutex
@@ -23,3 +30,12 @@ func (c *WebSocketConn) Send(pkt *pb.GamePacket) {
 	// WriteMessage 是线程不安全的，所以需要加锁
 	c.Conn.WriteMessage(websocket.BinaryMessage, data)
 }
+
+// Close 发送关闭帧后断开连接，读循环随之退出并触发 Unregister
+func (c *WebSocketConn) Close(reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	c.Conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second))
+	c.Conn.Close()
+}
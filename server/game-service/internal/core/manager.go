@@ -1,8 +1,13 @@
 package core
 
 import (
+	"context"
+	"fmt"
+	"log"
 	"sync"
 	"time"
+
+	"mygame/server/game-service/internal/dao"
 )
 
 var (
@@ -28,15 +33,21 @@ func CleanupEmptyRooms() {
 	defer mu.Unlock()
 
 	now := time.Now().Unix()
-	for id, room := range Rooms {
+	for _, room := range Rooms {
+		// 只剩机器人的房间同样视为空房间
 		room.Mutex.RLock()
-		playerCount := len(room.Players)
+		playerCount := 0
+		for _, p := range room.Players {
+			if !p.IsBot {
+				playerCount++
+			}
+		}
 		lastActive := room.LastActiveTime
 		room.Mutex.RUnlock()
 
+		// 主循环退出时由 releaseRoom 从 Rooms 中移除
 		if playerCount == 0 && (now-lastActive) > 60 {
-			room.StopChan <- true
-			delete(Rooms, id)
+			room.Stop()
 		}
 	}
 }
@@ -47,16 +58,48 @@ func GetRoom(roomID string) *Room {
 	return Rooms[roomID]
 }
 
-func CreateRoom(roomID string) *Room {
+// RoomSettings Match 预分配房间时下发的配置
+type RoomSettings struct {
+	Name        string
+	MapID       int32
+	MaxPlayers  int
+	Mode        string
+	HostUID     int64
+	AllowedUIDs []int64
+	Teams       map[int64]int32 // 团队模式下的队伍分配
+
+	ObserverDelay time.Duration // 观战延迟
+}
+
+// AllocateRoom 按 Match 下发的配置创建房间，只有这样创建的房间才接受 WebSocket 连接
+// maxRooms 为本服可承载的房间数，0 表示不限制
+func AllocateRoom(roomID string, settings RoomSettings, maxRooms int) error {
 	mu.Lock()
 	defer mu.Unlock()
 	if _, ok := Rooms[roomID]; ok {
-		return Rooms[roomID]
+		return fmt.Errorf("room %s already allocated", roomID)
 	}
+	if maxRooms > 0 && len(Rooms) >= maxRooms {
+		return fmt.Errorf("server at capacity (%d rooms)", maxRooms)
+	}
+
 	room := NewRoom(roomID)
+	room.Name = settings.Name
+	room.MapID = settings.MapID
+	room.MaxPlayers = settings.MaxPlayers
+	if settings.Mode != "" {
+		room.Mode = settings.Mode
+	}
+	room.HostUID = settings.HostUID
+	room.ObserverDelay = settings.ObserverDelay
+	for _, uid := range settings.AllowedUIDs {
+		room.Allowed[uid] = true
+		room.Teams[uid] = settings.Teams[uid]
+	}
+
 	Rooms[roomID] = room
 	go room.Run()
-	return room
+	return nil
 }
 
 func RegisterPlayerToken(token string, uid int64) {
@@ -65,16 +108,16 @@ func RegisterPlayerToken(token string, uid int64) {
 	//PlayerTokens[uid] = token
 }
 
-func StartRoom(roomID string) {
+// StartRoom 开始倒计时并开局，countdown 为倒计时秒数
+func StartRoom(roomID string, countdown int) bool {
 	mu.RLock()
 	room := Rooms[roomID]
 	mu.RUnlock()
 
-	if room != nil {
-		room.Mutex.Lock()
-		room.IsRunning = true
-		room.Mutex.Unlock()
+	if room == nil {
+		return false
 	}
+	return room.StartCountdown(countdown)
 }
 
 func RemoveRoom(roomID string) {
@@ -82,3 +125,23 @@ func RemoveRoom(roomID string) {
 	defer mu.Unlock()
 	delete(Rooms, roomID)
 }
+
+// releaseRoom 房间停止后释放本服的名额，并通知 Match 销毁房间
+// 未经 AllocateRoom 创建的房间不在 Rooms 中，直接忽略
+func releaseRoom(r *Room) {
+	mu.Lock()
+	managed := Rooms[r.ID] == r
+	if managed {
+		delete(Rooms, r.ID)
+	}
+	mu.Unlock()
+	if !managed {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dao.CloseRoom(ctx, r.ID); err != nil {
+		log.Printf("Failed to close room %s: %v", r.ID, err)
+	}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"mygame/server/game-service/internal/dao"
+)
+
+// handleObserver 观战连接：只接收快照与事件，客户端发来的任何消息都被丢弃
+func handleObserver(c *gin.Context, roomID string, uid int64, token string) {
+	if ok, err := dao.ValidateObserverTicket(context.Background(), roomID, uid, token); err != nil {
+		log.Println("redis error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	} else if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "invalid observer ticket"})
+		return
+	}
+
+	room := GetRoom(roomID)
+	if room == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not allocated on this server"})
+		return
+	}
+	if room.IsBanned(uid) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "banned from room"})
+		return
+	}
+
+	// 确认 Match 预留的观战名额，超时未连接的预留已被回收
+	if ok, err := dao.ConfirmObserver(context.Background(), roomID, uid); err != nil {
+		log.Println("redis error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	} else if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "observer reservation expired"})
+		return
+	}
+	releaseSlot := func() {
+		if err := dao.RemoveObserver(context.Background(), roomID, uid); err != nil {
+			log.Printf("Failed to release observer slot of %d: %v", uid, err)
+		}
+	}
+
+	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Println("Upgrade failed:", err)
+		releaseSlot()
+		return
+	}
+	defer ws.Close()
+
+	conn := &WebSocketConn{Conn: ws}
+	room.AddObserver(uid, conn)
+	defer func() {
+		// 已被同一 uid 的新连接替换时，名额归新连接所有
+		if room.RemoveObserver(uid, conn) {
+			releaseSlot()
+		}
+	}()
+
+	ws.SetReadDeadline(time.Now().Add(wsReadDeadline))
+	ws.SetPongHandler(func(string) error {
+		ws.SetReadDeadline(time.Now().Add(wsReadDeadline))
+		return nil
+	})
+
+	pingTicker := time.NewTicker(wsPingPeriod)
+	defer pingTicker.Stop()
+
+	doneChan := make(chan bool)
+	go func() {
+		defer close(doneChan)
+		for {
+			// 观战者的输入一律忽略，只用读循环感知断开
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+			ws.SetReadDeadline(time.Now().Add(wsReadDeadline))
+		}
+	}()
+
+	for {
+		select {
+		case <-pingTicker.C:
+			conn.mu.Lock()
+			ws.SetWriteDeadline(time.Now().Add(wsWriteDeadline))
+			err := ws.WriteMessage(websocket.PingMessage, nil)
+			conn.mu.Unlock()
+			if err != nil {
+				return
+			}
+		case <-doneChan:
+			return
+		}
+	}
+}
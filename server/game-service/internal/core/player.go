@@ -2,12 +2,14 @@ package core
 
 import (
 	pb "mygame/proto"
+	"mygame/server/game-service/pkg/config"
 )
 
 type Player struct {
 	UID      int64
 	Username string
-	Conn     *WebSocketConn // 网络连接封装
+	Conn     Connection // 网络连接封装
+	IsBot    bool       // 服务端机器人，不参与结算与在线状态
 
 	// 物理属性
 	X, Y   float64
@@ -24,6 +26,13 @@ type Player struct {
 	// 等待室状态
 	IsReady bool
 
+	// 团队模式下的队伍编号，个人混战为 0
+	Team int32
+
+	// 战绩统计
+	Kills  int32
+	Deaths int32
+
 	// 输入缓冲区 (Sub-tick)
 	InputQueue []*pb.C2SInput
 
@@ -32,14 +41,22 @@ type Player struct {
 	LastProcessedTick int64 // 已处理的最后tick
 }
 
-func NewPlayer(uid int64, name string, conn *WebSocketConn) *Player {
+func NewPlayer(uid int64, name string, conn Connection) *Player {
 	return &Player{
 		UID:        uid,
 		Username:   name,
 		Conn:       conn,
 		HP:         100,
 		MaxHP:      100,
-		Speed:      10.0, // 配置读取
+		Speed:      playerSpeed(),
 		InputQueue: make([]*pb.C2SInput, 0),
 	}
 }
+
+// playerSpeed 移动速度 (game.player_speed)，未配置时为 10
+func playerSpeed() float64 {
+	if speed := config.AppConfig.Game.PlayerSpeed; speed > 0 {
+		return speed
+	}
+	return 10.0
+}
@@ -1,14 +1,19 @@
 package core
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"math"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	pb "mygame/proto"
+	"mygame/server/game-service/internal/dao"
 	"mygame/server/game-service/internal/mq"
+	"mygame/server/game-service/pkg/config"
 )
 
 // Tick system constants
@@ -20,6 +25,9 @@ const (
 	AcceptableLagTicks = 2                       // 可接受的最大延迟tick数
 )
 
+// DefaultMode 默认游戏模式（个人混战）
+const DefaultMode = "ffa"
+
 type Room struct {
 	ID         string
 	Players    map[int64]*Player
@@ -32,20 +40,51 @@ type Room struct {
 	Mutex           sync.RWMutex
 	Ticker          *time.Ticker
 	StopChan        chan bool
+	stopOnce        sync.Once
 	IsRunning       bool
 	IsInWaitingMode bool // true = 等待中, false = 游戏中
+	IsCountingDown  bool // 已收到开局通知，倒计时中
 	MapSize         float64
+	Mode            string // 游戏模式，用于分模式计算段位
 
 	// Tick系统
 	CurrentTick int64
 
+	// 房间配置，由 Match 预分配时下发
+	Name       string
+	MapID      int32
+	MaxPlayers int
+	Allowed    map[int64]bool  // 允许连接的玩家
+	Teams      map[int64]int32 // 团队模式下玩家所属队伍，由 Match 分配
+
+	// 观战者：只接收快照与事件，ObserverDelay 大于 0 时延迟下发
+	Observers     map[int64]*WebSocketConn
+	ObserverDelay time.Duration
+	observerQueue []delayedPacket
+
 	// 房主信息
 	HostUID int64 // 房主UID
 
+	// 被房主封禁的玩家，禁止再次连接
+	Banned map[int64]bool
+
+	// 死亡顺序（按时间先后记录 UID），用于结算名次
+	DeathOrder []int64
+
+	// 开局时的参赛名单：中途断线的玩家已从 Players 移除，仍按名单结算
+	Roster map[int64]*Player
+	// 对局中未死亡就断线的玩家（按断线先后），按弃权排在最后
+	Forfeits []int64
+
 	LastActiveTime int64
 	CreatedAt      int64
 }
 
+type delayedPacket struct {
+	sendAt time.Time
+	packet *pb.GamePacket
+}
+
 type Beam struct {
 	ID             string
 	OwnerID        int64
@@ -58,76 +97,258 @@ type Beam struct {
 func NewRoom(id string) *Room {
 	now := time.Now().Unix()
 	return &Room{
-		ID:             id,
-		Players:        make(map[int64]*Player),
-		Broadcast:      make(chan *pb.GamePacket),
-		Register:       make(chan *Player),
-		Unregister:     make(chan int64),
-		StopChan:       make(chan bool),
-		MapSize:        2000.0,
-		LastActiveTime: now,
-		CreatedAt:      now,
+		ID:              id,
+		Players:         make(map[int64]*Player),
+		Banned:          make(map[int64]bool),
+		Allowed:         make(map[int64]bool),
+		Teams:           make(map[int64]int32),
+		Observers:       make(map[int64]*WebSocketConn),
+		Broadcast:       make(chan *pb.GamePacket),
+		Register:        make(chan *Player),
+		Unregister:      make(chan int64),
+		StopChan:        make(chan bool),
+		MapSize:         mapSize(),
+		Mode:            DefaultMode,
+		IsInWaitingMode: true,
+		LastActiveTime:  now,
+		CreatedAt:       now,
+	}
+}
+
+// mapSize 地图边长 (game.map_size)，未配置时为 2000
+func mapSize() float64 {
+	if size := config.AppConfig.Game.MapSize; size > 0 {
+		return size
 	}
+	return 2000.0
 }
 
 func (r *Room) Run() {
-	r.IsRunning = true
 	r.CurrentTick = 1
 	r.Ticker = time.NewTicker(TickDuration)
 	defer r.Ticker.Stop()
+	var lastTick time.Time
 
 	for {
 		select {
 		case <-r.StopChan:
+			releaseRoom(r)
 			return
 
 		case p := <-r.Register:
 			r.Mutex.Lock()
 			r.Players[p.UID] = p
+			p.Team = r.Teams[p.UID]
 			p.X = 100 + float64(time.Now().UnixNano()%1000)
 			p.Y = 100 + float64(time.Now().UnixNano()%1000)
-			// 第一个加入的玩家设为房主
-			if r.HostUID == 0 {
+			// 没有从 Match 同步到房主时，第一个加入的真人玩家设为房主
+			if r.HostUID == 0 && !p.IsBot {
 				r.HostUID = p.UID
+				go r.syncHost(p.UID)
 			}
 			r.LastActiveTime = time.Now().Unix()
 			r.Mutex.Unlock()
-			fmt.Printf("Player %d joined room %s\n", p.UID, r.ID)
+			log.Printf("Player %d joined room %s", p.UID, r.ID)
 
 		case uid := <-r.Unregister:
 			r.Mutex.Lock()
+			if p, ok := r.Players[uid]; ok && r.IsRunning && !p.IsDead {
+				r.Forfeits = append(r.Forfeits, uid)
+			}
 			delete(r.Players, uid)
 			r.LastActiveTime = time.Now().Unix()
 
-			// 如果房主离开，转移房主给另一个玩家
+			// 如果房主离开，转移房主给另一个真人玩家，并同步到 Redis
 			if uid == r.HostUID && len(r.Players) > 0 {
-				for newHostUID := range r.Players {
+				for newHostUID, p := range r.Players {
+					if p.IsBot {
+						continue
+					}
 					r.HostUID = newHostUID
-					fmt.Printf("Host transferred from %d to %d in room %s\n", uid, newHostUID, r.ID)
+					log.Printf("Host transferred from %d to %d in room %s", uid, newHostUID, r.ID)
+					r.BroadcastEvent(pb.GameEvent_HOST_CHANGED, newHostUID, "host left")
+					go r.syncHost(newHostUID)
 					break
 				}
 			}
 
-			if len(r.Players) == 0 {
-				r.IsRunning = false
-				r.HostUID = 0
-				r.Mutex.Unlock()
-				r.StopChan <- true
-				go RemoveRoom(r.ID)
-			} else {
-				r.Mutex.Unlock()
-			}
+			// 房间空了不立即销毁，玩家可以重连，由清理任务回收长时间无人的房间
+			r.Mutex.Unlock()
 
 		case <-r.Ticker.C:
+			start := time.Now()
 			r.GameLoop()
+			var interval time.Duration
+			if !lastTick.IsZero() {
+				interval = start.Sub(lastTick)
+			}
+			lastTick = start
+			tickStats.record(time.Since(start), interval)
+		}
+	}
+}
+
+// Stop 停止房间主循环，可重复调用
+func (r *Room) Stop() {
+	r.stopOnce.Do(func() { close(r.StopChan) })
+}
+
+// Admit 允许新成员连接，容量已满、被封禁或已开局时拒绝
+// maxPlayers 大于 0 时同步房主修改后的容量，team 为 Match 分配的队伍
+func (r *Room) Admit(uid int64, maxPlayers int, team int32) error {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	if maxPlayers > 0 {
+		r.MaxPlayers = maxPlayers
+	}
+	if r.Banned[uid] {
+		return fmt.Errorf("player is banned")
+	}
+	if r.Allowed[uid] {
+		r.Teams[uid] = team
+		return nil
+	}
+	if !r.IsInWaitingMode || r.IsCountingDown {
+		return fmt.Errorf("game already started")
+	}
+	if len(r.Allowed) >= r.MaxPlayers {
+		return fmt.Errorf("room is full")
+	}
+	r.Allowed[uid] = true
+	r.Teams[uid] = team
+	return nil
+}
+
+// IsAllowed 玩家是否被允许连接本房间
+func (r *Room) IsAllowed(uid int64) bool {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+	return r.Allowed[uid] && !r.Banned[uid]
+}
+
+// TransferHost 把房主转移给房间内的另一名玩家
+func (r *Room) TransferHost(uid int64) bool {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	if _, ok := r.Players[uid]; !ok {
+		return false
+	}
+	r.HostUID = uid
+	r.BroadcastEvent(pb.GameEvent_HOST_CHANGED, uid, "host transferred")
+	return true
+}
+
+// RemovePlayer 把玩家移出房间：下发原因后断开连接，ban 为 true 时加入封禁名单
+func (r *Room) RemovePlayer(uid int64, reason string, ban bool) bool {
+	r.Mutex.Lock()
+	if ban {
+		r.Banned[uid] = true
+	}
+	allowed := r.Allowed[uid]
+	delete(r.Allowed, uid)
+	p := r.Players[uid]
+	r.Mutex.Unlock()
+
+	if p == nil {
+		return ban || allowed
+	}
+	p.Conn.Send(&pb.GamePacket{
+		Payload: &pb.GamePacket_Event{
+			Event: &pb.GameEvent{
+				Type:      pb.GameEvent_PLAYER_KICKED,
+				TargetUid: uid,
+				Message:   reason,
+			},
+		},
+	})
+	p.Conn.Close(reason)
+	return true
+}
+
+func (r *Room) IsBanned(uid int64) bool {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+	return r.Banned[uid]
+}
+
+func (r *Room) syncHost(uid int64) {
+	if err := dao.SetRoomHost(context.Background(), r.ID, uid); err != nil {
+		log.Printf("Failed to sync host of room %s: %v", r.ID, err)
+	}
+}
+
+// StartCountdown 收到 Match 的开局通知后开始倒计时，结束时正式开局
+// 房间已开局或倒计时中时返回 false
+func (r *Room) StartCountdown(seconds int) bool {
+	r.Mutex.Lock()
+	if !r.IsInWaitingMode || r.IsCountingDown {
+		r.Mutex.Unlock()
+		return false
+	}
+	r.IsCountingDown = true
+	r.Mutex.Unlock()
+
+	go func() {
+		for i := seconds; i > 0; i-- {
+			r.Mutex.Lock()
+			r.BroadcastEvent(pb.GameEvent_COUNTDOWN, 0, strconv.Itoa(i))
+			r.Mutex.Unlock()
+			time.Sleep(time.Second)
+		}
+		r.begin()
+	}()
+	return true
+}
+
+// begin 结束等待：重置等待室中产生的状态并广播开局
+func (r *Room) begin() {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+
+	for _, p := range r.Players {
+		p.HP = p.MaxHP
+		p.IsDead = false
+		p.IsCharging = false
+		p.Kills = 0
+		p.Deaths = 0
+	}
+	r.Beams = nil
+	r.DeathOrder = nil
+	r.Forfeits = nil
+	r.Roster = make(map[int64]*Player, len(r.Players))
+	for uid, p := range r.Players {
+		r.Roster[uid] = p
+	}
+	r.IsCountingDown = false
+	r.IsInWaitingMode = false
+	r.IsRunning = true
+	r.BroadcastEvent(pb.GameEvent_GAME_START, 0, "Game Start")
+	for uid, p := range r.Players {
+		if !p.IsBot {
+			go setPresence(uid, dao.PresenceInMatch, r.ID)
 		}
 	}
+	log.Printf("Room %s started with %d players", r.ID, len(r.Players))
+}
+
+// HasStarted 是否已开局（含倒计时），开局后不再接受新玩家
+func (r *Room) HasStarted() bool {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+	return !r.IsInWaitingMode || r.IsCountingDown
+}
+
+// HasPlayer 玩家是否在房间中
+func (r *Room) HasPlayer(uid int64) bool {
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+	_, ok := r.Players[uid]
+	return ok
 }
 
 // --- 核心 Tick 逻辑 ---
 func (r *Room) GameLoop() {
 	r.Mutex.Lock()
-	defer r.Mutex.Unlock()
 
 	r.CurrentTick++
 
@@ -145,10 +366,21 @@ func (r *Room) GameLoop() {
 	r.Beams = activeBeams
 
 	// 3. 检查胜利条件
-	r.CheckWinCondition()
+	result := r.CheckWinCondition()
 
 	// 4. 发送快照 (Snapshot)
 	r.BroadcastSnapshot()
+
+	// 5. 下发到期的观战数据
+	r.flushObservers()
+	r.Mutex.Unlock()
+
+	// 6. 战绩写入 outbox 需要落盘，放在锁外，避免磁盘延迟卡住 tick 与玩家进出
+	if result != nil {
+		if err := mq.PublishGameResult(result); err != nil {
+			log.Printf("Failed to publish result of room %s: %v", r.ID, err)
+		}
+	}
 }
 
 func (r *Room) ProcessInputs() {
@@ -275,11 +507,18 @@ func (r *Room) FireBeam(owner *Player, duration int64) {
 		if target.UID == owner.UID || target.IsDead {
 			continue
 		}
+		// 团队模式不会误伤队友
+		if owner.Team != 0 && target.Team == owner.Team {
+			continue
+		}
 		if IsHit(owner.X, owner.Y, endX, endY, width, target.X, target.Y, 20) {
 			target.HP -= damage
 			if target.HP <= 0 {
 				target.HP = 0
 				target.IsDead = true
+				target.Deaths++
+				owner.Kills++
+				r.DeathOrder = append(r.DeathOrder, target.UID)
 				r.BroadcastEvent(pb.GameEvent_PLAYER_DEATH, target.UID, "wasted")
 			}
 		}
@@ -324,19 +563,29 @@ func IsHit(x1, y1, x2, y2, w, px, py, pr float64) bool {
 	return true
 }
 
-func (r *Room) CheckWinCondition() {
-	aliveCount := 0
+// CheckWinCondition 判断对局是否结束，结束时返回需要发送的战绩（调用方需持有锁，在锁外发送）
+func (r *Room) CheckWinCondition() *mq.GameResult {
+	// 个人混战每名玩家是一方，团队模式每支队伍是一方
+	sides := make(map[int64]bool)
+	aliveSides := make(map[int64]bool)
 	var lastSurvivor *Player
 
 	for _, p := range r.Players {
+		side := p.UID
+		if p.Team != 0 {
+			side = -int64(p.Team)
+		}
+		sides[side] = true
 		if !p.IsDead {
-			aliveCount++
-			lastSurvivor = p
+			aliveSides[side] = true
+			if lastSurvivor == nil || p.UID < lastSurvivor.UID {
+				lastSurvivor = p
+			}
 		}
 	}
 
-	// 至少要有2个人开始游戏才算，否则单人测试不结束
-	if len(r.Players) > 1 && aliveCount <= 1 && r.IsRunning {
+	// 至少要有2方开始游戏才算，否则单人测试不结束
+	if len(sides) > 1 && len(aliveSides) <= 1 && r.IsRunning {
 		winnerID := int64(-1)
 		if lastSurvivor != nil {
 			winnerID = lastSurvivor.UID
@@ -346,19 +595,109 @@ func (r *Room) CheckWinCondition() {
 		r.BroadcastEvent(pb.GameEvent_GAME_OVER, winnerID, "Game Over")
 		r.IsRunning = false
 
-		// 发送战绩到 MQ
-		go mq.PublishGameResult(map[string]interface{}{
-			"match_id":  r.ID,
-			"winner":    winnerID,
-			"timestamp": time.Now().Unix(),
-		})
-
 		// 5秒后关闭房间
 		go func() {
 			time.Sleep(5 * time.Second)
-			r.StopChan <- true
+			r.Stop()
 		}()
+
+		// 战绩由 GameLoop 在锁外发送到 MQ（先写入本地 outbox，异步确认投递），只剩机器人时不结算
+		if result := r.BuildGameResult(winnerID); len(result.Players) > 0 {
+			return result
+		}
+	}
+	return nil
+}
+
+// BuildGameResult 根据存活情况与死亡顺序计算名次，组装结算数据
+// 存活者排在最前（胜者第一），其余按死亡先后倒序排列：越晚死亡名次越靠前
+// 按开局名单结算，中途断线的玩家视为弃权排在最后（越晚断线越靠前），不能借断线逃避掉分
+// 机器人参与排名但不写入结算，真人的名次与机器人一起计算
+// 团队模式下同队成员名次相同，队伍按其最好成员的排名先后排列，弃权者仍排在所有队伍之后
+func (r *Room) BuildGameResult(winnerID int64) *mq.GameResult {
+	roster := r.Roster
+	if roster == nil {
+		roster = r.Players
+	}
+	forfeited := make(map[int64]bool, len(r.Forfeits))
+	for _, uid := range r.Forfeits {
+		forfeited[uid] = true
+	}
+
+	ranked := make([]*Player, 0, len(roster))
+	if winner, ok := roster[winnerID]; ok && !forfeited[winnerID] {
+		ranked = append(ranked, winner)
+	}
+
+	alive := make([]*Player, 0)
+	for _, p := range roster {
+		if !p.IsDead && !forfeited[p.UID] && p.UID != winnerID {
+			alive = append(alive, p)
+		}
+	}
+	sort.Slice(alive, func(i, j int) bool { return alive[i].UID < alive[j].UID })
+	ranked = append(ranked, alive...)
+
+	for i := len(r.DeathOrder) - 1; i >= 0; i-- {
+		if p, ok := roster[r.DeathOrder[i]]; ok && p.IsDead {
+			ranked = append(ranked, p)
+		}
+	}
+
+	for i := len(r.Forfeits) - 1; i >= 0; i-- {
+		if p, ok := roster[r.Forfeits[i]]; ok {
+			ranked = append(ranked, p)
+		}
 	}
+
+	result := &mq.GameResult{
+		MatchID:   r.ID,
+		Mode:      r.Mode,
+		MapID:     r.MapID,
+		Winner:    winnerID,
+		Timestamp: time.Now().Unix(),
+		Players:   make([]mq.PlayerResult, 0, len(ranked)),
+	}
+	winnerTeam := int32(0)
+	if winner, ok := roster[winnerID]; ok {
+		winnerTeam = winner.Team
+	}
+	placements := rankPlacements(ranked, forfeited)
+	for i, p := range ranked {
+		if p.IsBot {
+			continue
+		}
+		result.Players = append(result.Players, mq.PlayerResult{
+			UID:       p.UID,
+			Username:  p.Username,
+			Placement: placements[i],
+			Kills:     p.Kills,
+			Deaths:    p.Deaths,
+			IsWinner:  !forfeited[p.UID] && (p.UID == winnerID || (winnerTeam != 0 && p.Team == winnerTeam)),
+		})
+	}
+	return result
+}
+
+// rankPlacements 把个人排名换算成名次：个人混战按排名顺序，
+// 团队模式同队成员共享队伍名次，弃权者依次排在所有队伍之后
+func rankPlacements(ranked []*Player, forfeited map[int64]bool) []int32 {
+	placements := make([]int32, len(ranked))
+	teamPlacement := make(map[int32]int32)
+	next := int32(1)
+	for i, p := range ranked {
+		if p.Team == 0 || forfeited[p.UID] {
+			placements[i] = next
+			next++
+			continue
+		}
+		if _, ok := teamPlacement[p.Team]; !ok {
+			teamPlacement[p.Team] = next
+			next++
+		}
+		placements[i] = teamPlacement[p.Team]
+	}
+	return placements
 }
 
 func (r *Room) BroadcastSnapshot() {
@@ -380,6 +719,7 @@ func (r *Room) BroadcastSnapshot() {
 			IsDead:     p.IsDead,
 			IsCharging: p.IsCharging,
 			Username:   p.Username,
+			Team:       p.Team,
 		})
 	}
 	// 序列化光柱
@@ -402,6 +742,7 @@ func (r *Room) BroadcastSnapshot() {
 	for _, p := range r.Players {
 		p.Conn.Send(packet)
 	}
+	r.sendToObservers(packet)
 }
 
 func (r *Room) BroadcastEvent(evtType pb.GameEvent_EventType, targetID int64, msg string) {
@@ -417,4 +758,61 @@ func (r *Room) BroadcastEvent(evtType pb.GameEvent_EventType, targetID int64, ms
 	for _, p := range r.Players {
 		p.Conn.Send(pkt)
 	}
+	r.sendToObservers(pkt)
+}
+
+// AddObserver 加入观战连接，同一 uid 重复连接时断开旧连接
+func (r *Room) AddObserver(uid int64, conn *WebSocketConn) {
+	r.Mutex.Lock()
+	old := r.Observers[uid]
+	r.Observers[uid] = conn
+	r.Mutex.Unlock()
+
+	if old != nil {
+		old.Close("observing from another connection")
+	}
+	log.Printf("Observer %d joined room %s", uid, r.ID)
+}
+
+// RemoveObserver 移除观战连接，只有 conn 仍是该 uid 的当前连接时才移除，返回是否移除
+func (r *Room) RemoveObserver(uid int64, conn *WebSocketConn) bool {
+	r.Mutex.Lock()
+	defer r.Mutex.Unlock()
+	if r.Observers[uid] != conn {
+		return false
+	}
+	delete(r.Observers, uid)
+	if len(r.Observers) == 0 {
+		r.observerQueue = nil
+	}
+	return true
+}
+
+// sendToObservers 把数据包发给观战者，有延迟时先进入队列（调用方需持有锁）
+func (r *Room) sendToObservers(packet *pb.GamePacket) {
+	if len(r.Observers) == 0 {
+		return
+	}
+	if r.ObserverDelay <= 0 {
+		for _, conn := range r.Observers {
+			conn.Send(packet)
+		}
+		return
+	}
+	r.observerQueue = append(r.observerQueue, delayedPacket{
+		sendAt: time.Now().Add(r.ObserverDelay),
+		packet: packet,
+	})
+}
+
+// flushObservers 下发已到延迟时间的数据包（调用方需持有锁）
+func (r *Room) flushObservers() {
+	now := time.Now()
+	i := 0
+	for ; i < len(r.observerQueue) && !r.observerQueue[i].sendAt.After(now); i++ {
+		for _, conn := range r.Observers {
+			conn.Send(r.observerQueue[i].packet)
+		}
+	}
+	r.observerQueue = r.observerQueue[i:]
 }
@@ -0,0 +1,190 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"mygame/server/game-service/pkg/config"
+)
+
+func init() {
+	config.AppConfig = &config.Config{}
+}
+
+// newTestRoom 开局后的房间：roster 为开局名单，players 为仍在线的玩家
+func newTestRoom(roster []*Player, online ...int64) *Room {
+	r := NewRoom("test")
+	r.IsInWaitingMode = false
+	r.IsRunning = true
+	r.HostUID = roster[0].UID
+	r.Roster = make(map[int64]*Player)
+	for _, p := range roster {
+		r.Roster[p.UID] = p
+	}
+	for _, uid := range online {
+		r.Players[uid] = r.Roster[uid]
+	}
+	return r
+}
+
+func testPlayer(uid int64, team int32, dead bool) *Player {
+	p := NewPlayer(uid, "", nil)
+	p.Team = team
+	p.IsDead = dead
+	return p
+}
+
+type placement struct {
+	placement int32
+	winner    bool
+}
+
+func checkResult(t *testing.T, r *Room, winnerID int64, want map[int64]placement) {
+	t.Helper()
+	result := r.BuildGameResult(winnerID)
+	if len(result.Players) != len(want) {
+		t.Fatalf("got %d players in result, want %d", len(result.Players), len(want))
+	}
+	for _, p := range result.Players {
+		w, ok := want[p.UID]
+		if !ok {
+			t.Fatalf("unexpected player %d in result", p.UID)
+		}
+		if p.Placement != w.placement || p.IsWinner != w.winner {
+			t.Errorf("player %d: placement %d winner %v, want %d %v", p.UID, p.Placement, p.IsWinner, w.placement, w.winner)
+		}
+	}
+}
+
+func TestBuildGameResultFFA(t *testing.T) {
+	tests := []struct {
+		name     string
+		roster   []*Player
+		online   []int64
+		deaths   []int64
+		forfeits []int64
+		winner   int64
+		want     map[int64]placement
+	}{
+		{
+			name:   "reverse death order",
+			roster: []*Player{testPlayer(1, 0, false), testPlayer(2, 0, true), testPlayer(3, 0, true)},
+			online: []int64{1, 2, 3},
+			deaths: []int64{3, 2},
+			winner: 1,
+			want:   map[int64]placement{1: {1, true}, 2: {2, false}, 3: {3, false}},
+		},
+		{
+			name:     "disconnected players forfeit to last place",
+			roster:   []*Player{testPlayer(1, 0, false), testPlayer(2, 0, true), testPlayer(3, 0, false), testPlayer(4, 0, false)},
+			online:   []int64{1, 2},
+			deaths:   []int64{2},
+			forfeits: []int64{4, 3},
+			winner:   1,
+			want:     map[int64]placement{1: {1, true}, 2: {2, false}, 3: {3, false}, 4: {4, false}},
+		},
+		{
+			name:     "bots keep their placement but are not reported",
+			roster:   []*Player{testPlayer(1, 0, true), func() *Player { p := testPlayer(-1, 0, false); p.IsBot = true; return p }(), testPlayer(2, 0, false)},
+			online:   []int64{1, -1},
+			deaths:   []int64{1},
+			forfeits: []int64{2},
+			winner:   -1,
+			want:     map[int64]placement{1: {2, false}, 2: {3, false}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRoom(tt.roster, tt.online...)
+			r.DeathOrder = tt.deaths
+			r.Forfeits = tt.forfeits
+			checkResult(t, r, tt.winner, tt.want)
+		})
+	}
+}
+
+func TestUnregisterDuringMatchForfeits(t *testing.T) {
+	r := newTestRoom([]*Player{testPlayer(1, 0, false), testPlayer(2, 0, false), testPlayer(3, 0, true)}, 1, 2, 3)
+	go r.Run()
+	defer r.Stop()
+
+	r.Unregister <- 2
+	r.Unregister <- 3
+	r.Register <- testPlayer(99, 0, false) // Run 处理完前两个请求后才会读取下一个
+
+	r.Mutex.RLock()
+	defer r.Mutex.RUnlock()
+	if len(r.Forfeits) != 1 || r.Forfeits[0] != 2 {
+		t.Fatalf("forfeits = %v, want [2]", r.Forfeits)
+	}
+}
+
+func TestBuildGameResultTeams(t *testing.T) {
+	// 队伍 1 获胜；队伍 2 全灭，3 号先死；队伍 3 的 6 号中途断线
+	r := newTestRoom([]*Player{
+		testPlayer(1, 1, false), testPlayer(2, 1, true),
+		testPlayer(3, 2, true), testPlayer(4, 2, true),
+		testPlayer(5, 3, true), testPlayer(6, 3, false),
+	}, 1, 2, 3, 4, 5)
+	r.DeathOrder = []int64{3, 5, 2, 4}
+	r.Forfeits = []int64{6}
+	checkResult(t, r, 1, map[int64]placement{
+		1: {1, true}, 2: {1, true},
+		3: {2, false}, 4: {2, false},
+		5: {3, false},
+		6: {4, false},
+	})
+}
+
+// wsPair 建立一条真实的 WebSocket 连接，返回服务端连接与客户端
+func wsPair(t *testing.T) (*WebSocketConn, *websocket.Conn) {
+	t.Helper()
+	accepted := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ws, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		accepted <- ws
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return &WebSocketConn{Conn: <-accepted}, client
+}
+
+func TestAddObserverReplacesConnection(t *testing.T) {
+	r := NewRoom("test")
+	first, firstClient := wsPair(t)
+	second, _ := wsPair(t)
+
+	r.AddObserver(7, first)
+	r.AddObserver(7, second)
+
+	// 1. 旧连接被断开
+	firstClient.SetReadDeadline(time.Now().Add(time.Second))
+	if _, _, err := firstClient.ReadMessage(); !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		t.Fatalf("old connection read = %v, want policy violation close", err)
+	}
+
+	// 2. 旧连接的清理不影响新连接
+	if r.RemoveObserver(7, first) {
+		t.Fatal("stale connection removed the current observer")
+	}
+	if r.Observers[7] != second {
+		t.Fatal("current observer connection lost")
+	}
+	if !r.RemoveObserver(7, second) || len(r.Observers) != 0 {
+		t.Fatalf("observers after removal = %v", r.Observers)
+	}
+}
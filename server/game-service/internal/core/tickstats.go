@@ -0,0 +1,148 @@
+package core
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tickSampleSize 保留最近多少个 tick 的样本（所有房间合计）
+const tickSampleSize = 1 << 16
+
+// tickStats 全部房间每个 tick 的耗时与间隔，用于压测时观察本进程能否维持 64Hz
+var tickStats = newTickRecorder(tickSampleSize)
+
+type tickRecorder struct {
+	mu        sync.Mutex
+	durations []time.Duration // 环形缓冲：GameLoop 执行耗时
+	intervals []time.Duration // 环形缓冲：与同一房间上一个 tick 的间隔
+	next      int
+	full      bool
+	total     int64 // 统计窗口内的 tick 总数
+	overruns  int64 // 耗时超过 TickDuration 的 tick 数
+	since     time.Time
+}
+
+func newTickRecorder(size int) *tickRecorder {
+	return &tickRecorder{
+		durations: make([]time.Duration, size),
+		intervals: make([]time.Duration, size),
+		since:     time.Now(),
+	}
+}
+
+func (t *tickRecorder) record(duration, interval time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.durations[t.next] = duration
+	t.intervals[t.next] = interval
+	t.next++
+	if t.next == len(t.durations) {
+		t.next = 0
+		t.full = true
+	}
+	t.total++
+	if duration > TickDuration {
+		t.overruns++
+	}
+}
+
+func (t *tickRecorder) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.next = 0
+	t.full = false
+	t.total = 0
+	t.overruns = 0
+	t.since = time.Now()
+}
+
+// TickPercentiles 毫秒
+type TickPercentiles struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// TickSummary /debug/ticks 的响应
+type TickSummary struct {
+	Rooms         int             `json:"rooms"`
+	Players       int             `json:"players"`
+	Ticks         int64           `json:"ticks"`
+	Overruns      int64           `json:"overruns"`
+	Samples       int             `json:"samples"`
+	WindowSeconds float64         `json:"window_seconds"`
+	DurationMs    TickPercentiles `json:"duration_ms"`
+	IntervalMs    TickPercentiles `json:"interval_ms"`
+}
+
+func (t *tickRecorder) summary() TickSummary {
+	t.mu.Lock()
+	n := t.next
+	if t.full {
+		n = len(t.durations)
+	}
+	durations := append([]time.Duration(nil), t.durations[:n]...)
+	intervals := make([]time.Duration, 0, n)
+	for _, d := range t.intervals[:n] {
+		if d > 0 {
+			intervals = append(intervals, d)
+		}
+	}
+	s := TickSummary{
+		Ticks:         t.total,
+		Overruns:      t.overruns,
+		Samples:       n,
+		WindowSeconds: time.Since(t.since).Seconds(),
+	}
+	t.mu.Unlock()
+
+	s.DurationMs = percentiles(durations)
+	s.IntervalMs = percentiles(intervals)
+	return s
+}
+
+func percentiles(samples []time.Duration) TickPercentiles {
+	if len(samples) == 0 {
+		return TickPercentiles{}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	at := func(q float64) float64 { return ms(samples[int(q*float64(len(samples)-1))]) }
+
+	var sum time.Duration
+	for _, d := range samples {
+		sum += d
+	}
+	return TickPercentiles{
+		Mean: ms(sum / time.Duration(len(samples))),
+		P50:  at(0.50),
+		P90:  at(0.90),
+		P99:  at(0.99),
+		Max:  ms(samples[len(samples)-1]),
+	}
+}
+
+// HandleTickStats 最近 tick 的耗时分布，?reset=1 在返回后清空统计窗口
+func HandleTickStats(c *gin.Context) {
+	s := tickStats.summary()
+
+	mu.RLock()
+	s.Rooms = len(Rooms)
+	for _, room := range Rooms {
+		room.Mutex.RLock()
+		s.Players += len(room.Players)
+		room.Mutex.RUnlock()
+	}
+	mu.RUnlock()
+
+	if c.Query("reset") == "1" {
+		tickStats.reset()
+	}
+	c.JSON(http.StatusOK, s)
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	pb "mygame/proto"
+	"mygame/server/game-service/internal/auth"
 	"mygame/server/game-service/internal/dao"
 )
 
@@ -36,8 +38,35 @@ func HandleWebSocket(c *gin.Context) {
 		return
 	}
 
-	// 在升级连接前通过 Redis 校验 room token 是否匹配该 room_id
-	if ok, err := dao.ValidateRoomToken(context.Background(), roomID, token); err != nil {
+	// 要求客户端提供真实的 uid（由登录/网关验证后得到）
+	uidStr := c.Query("uid")
+	if uidStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "uid required"})
+		return
+	}
+	uid, err := strconv.ParseInt(uidStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+		return
+	}
+
+	// 启用 JWKS 时校验用户 JWT，确保 uid 不是伪造的
+	if auth.Enabled() {
+		tokenUID, err := auth.VerifyToken(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+		if err != nil || tokenUID != uid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid access token"})
+			return
+		}
+	}
+
+	// 观战连接走单独的流程
+	if c.Query("observer") == "1" {
+		handleObserver(c, roomID, uid, token)
+		return
+	}
+
+	// 在升级连接前通过 Redis 校验该玩家的 ticket 是否匹配该 room_id
+	if ok, err := dao.ValidateRoomTicket(context.Background(), roomID, uid, token); err != nil {
 		log.Println("redis error:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
 		return
@@ -46,26 +75,42 @@ func HandleWebSocket(c *gin.Context) {
 		return
 	}
 
-	room := CreateRoom(roomID)
-
-	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		log.Println("Upgrade failed:", err)
+	// 被房主封禁的玩家不能再连接
+	if banned, err := dao.IsBanned(context.Background(), roomID, uid); err != nil {
+		log.Println("redis error:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	} else if banned {
+		c.JSON(http.StatusForbidden, gin.H{"error": "banned from room"})
 		return
 	}
-	defer ws.Close()
 
-	// 要求客户端提供真实的 uid（由登录/网关验证后得到）
-	uidStr := c.Query("uid")
-	if uidStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "uid required"})
+	// 只接受由 Match 预分配的房间
+	room := GetRoom(roomID)
+	if room == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not allocated on this server"})
 		return
 	}
-	uid, err := strconv.ParseInt(uidStr, 10, 64)
+	if room.IsBanned(uid) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "banned from room"})
+		return
+	}
+	if !room.IsAllowed(uid) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not a member of this room"})
+		return
+	}
+	// 开局后锁定房间，只允许已在房间内的玩家
+	if room.HasStarted() && !room.HasPlayer(uid) {
+		c.JSON(http.StatusConflict, gin.H{"error": "game already started"})
+		return
+	}
+
+	ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+		log.Println("Upgrade failed:", err)
 		return
 	}
+	defer ws.Close()
 
 	username := "Player"
 
@@ -75,7 +120,12 @@ func HandleWebSocket(c *gin.Context) {
 	playerConn := &WebSocketConn{Conn: ws}
 	player := NewPlayer(uid, username, playerConn)
 
-	room.Register <- player
+	// 房间已停止时主循环不再接收，直接断开
+	select {
+	case room.Register <- player:
+	case <-room.StopChan:
+		return
+	}
 
 	// 发送初始状态给客户端，确认连接已建立
 	initialPacket := &pb.GamePacket{
@@ -88,8 +138,13 @@ func HandleWebSocket(c *gin.Context) {
 		},
 	}
 	playerConn.Send(initialPacket)
+	updatePresence(room, uid)
 	defer func() {
-		room.Unregister <- uid
+		select {
+		case room.Unregister <- uid:
+		case <-room.StopChan:
+		}
+		go setPresence(uid, dao.PresenceLobby, "")
 	}()
 
 	ws.SetReadDeadline(time.Now().Add(wsReadDeadline))
@@ -119,6 +174,7 @@ func HandleWebSocket(c *gin.Context) {
 	for {
 		select {
 		case <-pingTicker.C:
+			updatePresence(room, uid)
 			ws.SetWriteDeadline(time.Now().Add(wsWriteDeadline))
 			if err := ws.WriteMessage(websocket.PingMessage, nil); err != nil {
 				log.Println("Ping error:", err)
@@ -148,3 +204,18 @@ func HandleWebSocket(c *gin.Context) {
 		}
 	}
 }
+
+// updatePresence 按房间是否开局上报玩家在线状态，随心跳续期
+func updatePresence(room *Room, uid int64) {
+	status := dao.PresenceInQueue
+	if room.HasStarted() {
+		status = dao.PresenceInMatch
+	}
+	go setPresence(uid, status, room.ID)
+}
+
+func setPresence(uid int64, status, roomID string) {
+	if err := dao.SetPresence(context.Background(), uid, status, roomID); err != nil {
+		log.Printf("Failed to update presence of %d: %v", uid, err)
+	}
+}
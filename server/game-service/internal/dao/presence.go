@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// 在线状态，与 User Service 共用 presence:{uid}，由 User Service 负责查询
+const (
+	keyPresencePrefix = "presence:"
+	presenceTTL       = 2 * time.Minute
+
+	PresenceLobby   = "lobby"
+	PresenceInQueue = "in_queue"
+	PresenceInMatch = "in_match"
+)
+
+// SetPresence 设置玩家在线状态并刷新 TTL
+func SetPresence(ctx context.Context, uid int64, status, roomID string) error {
+	key := keyPresencePrefix + strconv.FormatInt(uid, 10)
+	pipe := RDB.TxPipeline()
+	pipe.HSet(ctx, key, "status", status, "room_id", roomID, "updated_at", time.Now().Unix())
+	pipe.Expire(ctx, key, presenceTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -2,7 +2,9 @@ package dao
 
 import (
 	"context"
+	"encoding/json"
 	"log"
+	"strconv"
 	"time"
 
 	"mygame/server/game-service/pkg/config"
@@ -34,6 +36,18 @@ func GetRoom(ctx context.Context, roomID string) (map[string]string, error) {
 	return RDB.HGetAll(ctx, KeyRoomPrefix+roomID).Result()
 }
 
+// ValidateRoomTicket 校验玩家自己的 ticket（room:{id}:tickets），没有签发 ticket 的玩家不能连接
+func ValidateRoomTicket(ctx context.Context, roomID string, uid int64, token string) (bool, error) {
+	val, err := RDB.HGet(ctx, KeyRoomPrefix+roomID+":tickets", strconv.FormatInt(uid, 10)).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return val == token, nil
+}
+
 // ValidateRoomToken checks whether the given token matches the stored room token.
 func ValidateRoomToken(ctx context.Context, roomID, token string) (bool, error) {
 	val, err := RDB.HGet(ctx, KeyRoomPrefix+roomID, "token").Result()
@@ -45,3 +59,105 @@ func ValidateRoomToken(ctx context.Context, roomID, token string) (bool, error)
 	}
 	return val == token, nil
 }
+
+// hsetIfExistsScript 房间 Hash 仍存在时才写入字段，避免重新创建已被 Match 删除、没有 TTL 的房间
+// KEYS: 房间 Hash；ARGV: 字段, 值；返回 1 表示已写入，0 表示房间不存在
+var hsetIfExistsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+// updateRoomField 更新仍存在的房间字段，并通知 Match 房间信息已变化
+func updateRoomField(ctx context.Context, roomID, field string, value interface{}) error {
+	written, err := hsetIfExistsScript.Run(ctx, RDB, []string{KeyRoomPrefix + roomID}, field, value).Int64()
+	if err != nil || written == 0 {
+		return err
+	}
+	publishRoomUpdated(ctx, roomID)
+	return nil
+}
+
+// SetRoomHost 房主在 Game 内变化时同步到 Match 的房间数据
+func SetRoomHost(ctx context.Context, roomID string, uid int64) error {
+	return updateRoomField(ctx, roomID, "host_uid", uid)
+}
+
+// IsBanned 玩家是否在房间封禁名单中（由 Match 维护）
+func IsBanned(ctx context.Context, roomID string, uid int64) (bool, error) {
+	return RDB.SIsMember(ctx, KeyRoomPrefix+roomID+":bans", uid).Result()
+}
+
+// ValidateObserverTicket 校验观战 ticket（room:{id}:observer_tickets，由 Match 签发）
+func ValidateObserverTicket(ctx context.Context, roomID string, uid int64, token string) (bool, error) {
+	val, err := RDB.HGet(ctx, KeyRoomPrefix+roomID+":observer_tickets", strconv.FormatInt(uid, 10)).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return val == token, nil
+}
+
+// confirmObserverScript 把 Match 预留的观战名额转为已连接（score 置 0）
+// KEYS: 观战 ZSet（room:{id}:observer_slots）；ARGV: uid, 当前时间 ms
+// 返回 1 表示确认成功，0 表示没有预留或预留已过期
+var confirmObserverScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+  return 0
+end
+local deadline = tonumber(score)
+if deadline ~= 0 and deadline <= tonumber(ARGV[2]) then
+  return 0
+end
+redis.call('ZADD', KEYS[1], 0, ARGV[1])
+return 1
+`)
+
+// ConfirmObserver 观战连接建立时确认预留的名额，之后直到断开都不会被回收
+func ConfirmObserver(ctx context.Context, roomID string, uid int64) (bool, error) {
+	ok, err := confirmObserverScript.Run(ctx, RDB, []string{KeyRoomPrefix + roomID + ":observer_slots"}, uid, time.Now().UnixMilli()).Int64()
+	return ok == 1, err
+}
+
+// RemoveObserver 观战者断开后释放名额，并刷新房间的观战人数
+func RemoveObserver(ctx context.Context, roomID string, uid int64) error {
+	key := KeyRoomPrefix + roomID
+	member := strconv.FormatInt(uid, 10)
+
+	pipe := RDB.TxPipeline()
+	pipe.ZRem(ctx, key+":observer_slots", member)
+	pipe.HDel(ctx, key+":observer_tickets", member)
+	countCmd := pipe.ZCard(ctx, key+":observer_slots")
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	// 房间已被删除时不再写回
+	return updateRoomField(ctx, roomID, "observers", countCmd.Val())
+}
+
+// KeyClosedRooms 已在 Game 内停止的房间队列，由 Match 取出后销毁房间数据
+//
+//	rooms:closed  List: room_id
+const KeyClosedRooms = "rooms:closed"
+
+// CloseRoom 房间在本服停止后交给 Match 销毁（房间数据与列表索引都由 Match 维护）
+func CloseRoom(ctx context.Context, roomID string) error {
+	return RDB.LPush(ctx, KeyClosedRooms, roomID).Err()
+}
+
+// KeyRoomEvents 房间变化广播频道（由 Match 订阅后推送给大厅）
+const KeyRoomEvents = "rooms:events"
+
+// publishRoomUpdated 通知 Match 房间信息已变化，失败只记录日志
+func publishRoomUpdated(ctx context.Context, roomID string) {
+	payload, _ := json.Marshal(map[string]string{"type": "updated", "room_id": roomID})
+	if err := RDB.Publish(ctx, KeyRoomEvents, payload).Err(); err != nil {
+		log.Printf("Publish room event for %s failed: %v", roomID, err)
+	}
+}
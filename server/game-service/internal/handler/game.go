@@ -2,15 +2,13 @@ package handler
 
 import (
 	"context"
-	"fmt"
 	"log"
-	"net"
+	"time"
 
 	pb "mygame/proto"
 	"mygame/server/game-service/internal/core"
 	"mygame/server/game-service/internal/dao"
-
-	"google.golang.org/grpc"
+	"mygame/server/game-service/pkg/config"
 )
 
 type GameServiceServer struct {
@@ -44,24 +42,88 @@ func (s *GameServiceServer) NotifyGameStart(ctx context.Context, req *pb.NotifyG
 
 	log.Printf("Notifying game start for room %s", roomID)
 
-	room := core.GetRoom(roomID)
+	return &pb.NotifyGameStartResp{Success: core.StartRoom(roomID, int(req.CountdownSeconds))}, nil
+}
+
+func (s *GameServiceServer) RemovePlayer(ctx context.Context, req *pb.RemovePlayerReq) (*pb.RemovePlayerResp, error) {
+	log.Printf("Removing player %d from room %s (ban=%v): %s", req.Uid, req.RoomId, req.Ban, req.Reason)
+
+	room := core.GetRoom(req.RoomId)
 	if room == nil {
-		return &pb.NotifyGameStartResp{Success: false}, nil
+		// 房间尚未在本服运行，封禁名单由 Match 写入 Redis，连接时校验
+		return &pb.RemovePlayerResp{Success: false}, nil
 	}
 
-	core.StartRoom(roomID)
-	return &pb.NotifyGameStartResp{Success: true}, nil
+	return &pb.RemovePlayerResp{Success: room.RemovePlayer(req.Uid, req.Reason, req.Ban)}, nil
 }
 
-func StartGRPC(port int) error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
-	if err != nil {
-		return err
+func (s *GameServiceServer) TransferHost(ctx context.Context, req *pb.GameTransferHostReq) (*pb.GameTransferHostResp, error) {
+	log.Printf("Transferring host of room %s to %d", req.RoomId, req.NewHostUid)
+
+	room := core.GetRoom(req.RoomId)
+	if room == nil {
+		return &pb.GameTransferHostResp{Success: false}, nil
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterGameServiceServer(s, &GameServiceServer{})
+	return &pb.GameTransferHostResp{Success: room.TransferHost(req.NewHostUid)}, nil
+}
+
+func (s *GameServiceServer) AllocateRoom(ctx context.Context, req *pb.AllocateRoomReq) (*pb.AllocateRoomResp, error) {
+	log.Printf("Allocating room %s for host %d", req.RoomId, req.HostUid)
 
-	log.Printf("Game Service gRPC listening on :%d", port)
-	return s.Serve(lis)
+	settings := core.RoomSettings{
+		MaxPlayers:  int(req.MaxPlayers),
+		Mode:        req.Mode,
+		HostUID:     req.HostUid,
+		AllowedUIDs: req.AllowedUids,
+		Teams:       make(map[int64]int32),
+
+		ObserverDelay: time.Duration(req.ObserverDelayMs) * time.Millisecond,
+	}
+	for i, uid := range req.AllowedUids {
+		if i < len(req.AllowedTeams) {
+			settings.Teams[uid] = req.AllowedTeams[i]
+		}
+	}
+	if req.Config != nil {
+		settings.Name = req.Config.RoomName
+		settings.MapID = req.Config.MapId
+		if settings.MaxPlayers == 0 {
+			settings.MaxPlayers = int(req.Config.MaxPlayers)
+		}
+	}
+	if settings.MaxPlayers <= 0 {
+		return &pb.AllocateRoomResp{Success: false, Message: "invalid room capacity"}, nil
+	}
+
+	if err := core.AllocateRoom(req.RoomId, settings, config.AppConfig.Server.MaxRooms); err != nil {
+		return &pb.AllocateRoomResp{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.AllocateRoomResp{Success: true}, nil
+}
+
+func (s *GameServiceServer) AdmitPlayer(ctx context.Context, req *pb.AdmitPlayerReq) (*pb.AdmitPlayerResp, error) {
+	log.Printf("Admitting player %d to room %s", req.Uid, req.RoomId)
+
+	room := core.GetRoom(req.RoomId)
+	if room == nil {
+		return &pb.AdmitPlayerResp{Success: false, Message: "room not allocated"}, nil
+	}
+	if err := room.Admit(req.Uid, int(req.MaxPlayers), req.Team); err != nil {
+		return &pb.AdmitPlayerResp{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.AdmitPlayerResp{Success: true}, nil
+}
+
+func (s *GameServiceServer) AddBot(ctx context.Context, req *pb.AddBotReq) (*pb.AddBotResp, error) {
+	log.Printf("Adding bot %d (%s) to room %s", req.Uid, req.Difficulty, req.RoomId)
+
+	room := core.GetRoom(req.RoomId)
+	if room == nil {
+		return &pb.AddBotResp{Success: false, Message: "room not allocated"}, nil
+	}
+	if err := room.AddBot(req.Uid, req.Name, req.Team, req.Difficulty); err != nil {
+		return &pb.AddBotResp{Success: false, Message: err.Error()}, nil
+	}
+	return &pb.AddBotResp{Success: true}, nil
 }
@@ -0,0 +1,145 @@
+package mq
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// outboxRecord 本地 outbox 文件中的一行
+// op=pub 记录待投递的消息，op=ack 表示对应消息已被 Broker 确认
+type outboxRecord struct {
+	Op        string          `json:"op"`
+	ID        string          `json:"id"`
+	MessageID string          `json:"message_id,omitempty"`
+	Body      json.RawMessage `json:"body,omitempty"`
+}
+
+// outboxEntry 尚未被确认的消息
+type outboxEntry struct {
+	ID        string
+	MessageID string
+	Body      []byte
+}
+
+var outboxFile *os.File
+
+// openOutbox 读取 outbox 中未确认的消息，压缩文件后以追加模式打开
+func openOutbox(path string) ([]outboxEntry, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create outbox dir failed: %v", err)
+	}
+
+	// 1. 回放历史记录，pub 减去 ack 即为待投递消息
+	pending, err := loadOutbox(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 只保留未确认的消息重写文件，避免无限增长
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("create outbox failed: %v", err)
+	}
+	for _, e := range pending {
+		if err := writeRecord(f, outboxRecord{Op: "pub", ID: e.ID, MessageID: e.MessageID, Body: e.Body}); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("sync outbox failed: %v", err)
+	}
+	f.Close()
+	if err := os.Rename(tmp, path); err != nil {
+		return nil, fmt.Errorf("replace outbox failed: %v", err)
+	}
+
+	// 3. 之后所有写入都追加到文件末尾
+	outboxFile, err = os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open outbox failed: %v", err)
+	}
+	return pending, nil
+}
+
+func loadOutbox(path string) ([]outboxEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open outbox failed: %v", err)
+	}
+	defer f.Close()
+
+	var order []string
+	entries := make(map[string]outboxEntry)
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var rec outboxRecord
+		// 进程崩溃可能留下写了一半的最后一行，跳过即可
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		switch rec.Op {
+		case "pub":
+			if _, ok := entries[rec.ID]; !ok {
+				order = append(order, rec.ID)
+			}
+			entries[rec.ID] = outboxEntry{ID: rec.ID, MessageID: rec.MessageID, Body: rec.Body}
+		case "ack":
+			delete(entries, rec.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read outbox failed: %v", err)
+	}
+
+	pending := make([]outboxEntry, 0, len(entries))
+	for _, id := range order {
+		if e, ok := entries[id]; ok {
+			pending = append(pending, e)
+			delete(entries, id)
+		}
+	}
+	return pending, nil
+}
+
+// appendOutbox 追加一条记录并落盘，调用方需持有 mu
+func appendOutbox(rec outboxRecord) error {
+	if outboxFile == nil {
+		return fmt.Errorf("outbox not opened")
+	}
+	if err := writeRecord(outboxFile, rec); err != nil {
+		return err
+	}
+	if err := outboxFile.Sync(); err != nil {
+		return fmt.Errorf("sync outbox failed: %v", err)
+	}
+	return nil
+}
+
+// truncateOutbox 所有消息都已确认时清空文件，调用方需持有 mu
+func truncateOutbox() {
+	if outboxFile != nil {
+		outboxFile.Truncate(0)
+	}
+}
+
+func writeRecord(f *os.File, rec outboxRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("marshal outbox record failed: %v", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write outbox failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package mq
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// pendingIDs 取出未确认消息的 ID
+func pendingIDs(entries []outboxEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestOpenOutbox(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{name: "no file", want: []string{}},
+		{
+			name: "pub without ack stays pending",
+			lines: []string{
+				`{"op":"pub","id":"1","message_id":"m1","body":{"room_id":"a"}}`,
+				`{"op":"pub","id":"2","message_id":"m2","body":{"room_id":"b"}}`,
+			},
+			want: []string{"1", "2"},
+		},
+		{
+			name: "acked pub is dropped",
+			lines: []string{
+				`{"op":"pub","id":"1","message_id":"m1","body":{}}`,
+				`{"op":"pub","id":"2","message_id":"m2","body":{}}`,
+				`{"op":"ack","id":"1"}`,
+			},
+			want: []string{"2"},
+		},
+		{
+			name: "duplicate pub keeps first position",
+			lines: []string{
+				`{"op":"pub","id":"1","message_id":"m1","body":{}}`,
+				`{"op":"pub","id":"2","message_id":"m2","body":{}}`,
+				`{"op":"pub","id":"1","message_id":"m1","body":{}}`,
+			},
+			want: []string{"1", "2"},
+		},
+		{
+			name: "half written last line is skipped",
+			lines: []string{
+				`{"op":"pub","id":"1","message_id":"m1","body":{}}`,
+				`{"op":"ack","id":"1"}`,
+				`{"op":"pub","id":"2","mess`,
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "outbox", "results.log")
+			if tt.lines != nil {
+				os.MkdirAll(filepath.Dir(path), 0755)
+				if err := os.WriteFile(path, []byte(strings.Join(tt.lines, "\n")), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			pending, err := openOutbox(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer outboxFile.Close()
+			if got := pendingIDs(pending); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("pending = %v, want %v", got, tt.want)
+			}
+
+			// 重写后的文件只剩未确认的消息，再次回放结果不变
+			again, err := loadOutbox(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := pendingIDs(again); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("reloaded = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendOutbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.log")
+	if _, err := openOutbox(path); err != nil {
+		t.Fatal(err)
+	}
+	defer outboxFile.Close()
+
+	steps := []struct {
+		rec      outboxRecord
+		truncate bool
+		want     []string
+	}{
+		{rec: outboxRecord{Op: "pub", ID: "1", MessageID: "m1", Body: []byte(`{}`)}, want: []string{"1"}},
+		{rec: outboxRecord{Op: "pub", ID: "2", MessageID: "m2", Body: []byte(`{}`)}, want: []string{"1", "2"}},
+		{rec: outboxRecord{Op: "ack", ID: "1"}, want: []string{"2"}},
+		{rec: outboxRecord{Op: "ack", ID: "2"}, truncate: true, want: []string{}},
+	}
+	for _, s := range steps {
+		if err := appendOutbox(s.rec); err != nil {
+			t.Fatal(err)
+		}
+		if s.truncate {
+			truncateOutbox()
+		}
+		pending, err := loadOutbox(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := pendingIDs(pending); !reflect.DeepEqual(got, s.want) {
+			t.Fatalf("after %s %s: pending = %v, want %v", s.rec.Op, s.rec.ID, got, s.want)
+		}
+	}
+	if fi, _ := os.Stat(path); fi.Size() != 0 {
+		t.Fatalf("outbox size after all acks = %d, want 0", fi.Size())
+	}
+}
@@ -1,48 +1,180 @@
 package mq
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"mygame/pkg/messaging"
 	"mygame/server/game-service/pkg/config"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Bus 结算结果使用的消息总线，后端由 mq.backend 配置
+var Bus messaging.Bus
 
-	"github.com/streadway/amqp"
+var (
+	mu      sync.Mutex
+	pending []outboxEntry // 按提交顺序排列的未确认消息
+	wake    = make(chan struct{}, 1)
+	lastID  int64
 )
 
-var Channel *amqp.Channel
+// GameResult 一局比赛的结算结果，由 user-service 消费
+type GameResult struct {
+	MatchID   string         `json:"match_id"`
+	Mode      string         `json:"mode"`
+	MapID     int32          `json:"map_id"`
+	Winner    int64          `json:"winner"`
+	Timestamp int64          `json:"timestamp"`
+	Players   []PlayerResult `json:"players"`
+}
+
+// PlayerResult 单个玩家在本局中的表现
+type PlayerResult struct {
+	UID       int64  `json:"uid"`
+	Username  string `json:"username"`
+	Placement int32  `json:"placement"` // 名次，1 为第一名
+	Kills     int32  `json:"kills"`
+	Deaths    int32  `json:"deaths"`
+	IsWinner  bool   `json:"is_winner"`
+}
 
+// InitMQ 打开本地 outbox 与消息总线，在后台投递
+// 后端不可用时不会阻塞启动，消息先落盘，恢复后按顺序补发
 func InitMQ() {
-	conn, err := amqp.Dial(config.AppConfig.MQ.Url)
+	bus, err := openBus()
 	if err != nil {
-		log.Fatalf("MQ connect failed: %v", err)
+		log.Fatalf("MQ init failed: %v", err)
 	}
+	Bus = bus
 
-	Channel, err = conn.Channel()
+	path := config.AppConfig.MQ.OutboxPath
+	if path == "" {
+		path = "data/outbox.log"
+	}
+	entries, err := openOutbox(path)
 	if err != nil {
-		log.Fatalf("MQ channel failed: %v", err)
+		log.Fatalf("MQ outbox init failed: %v", err)
+	}
+	if len(entries) > 0 {
+		log.Printf("MQ outbox: replaying %d unconfirmed results", len(entries))
 	}
 
-	// 声明队列
-	_, err = Channel.QueueDeclare(
-		config.AppConfig.MQ.QueueName,
-		true, false, false, false, nil,
-	)
+	mu.Lock()
+	pending = entries
+	mu.Unlock()
+
+	go run()
+}
+
+// PublishGameResult 将结算结果写入 outbox 后异步投递
+// 返回 nil 表示结果已持久化，之后一定会被投递（至少一次）
+func PublishGameResult(result *GameResult) error {
+	body, err := json.Marshal(result)
 	if err != nil {
-		log.Fatalf("MQ queue declare failed: %v", err)
+		return fmt.Errorf("marshal game result failed: %v", err)
 	}
-}
 
-func PublishGameResult(result interface{}) {
-	body, _ := json.Marshal(result)
-	err := Channel.Publish(
-		"",
-		config.AppConfig.MQ.QueueName,
-		false, false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
-	)
+	mu.Lock()
+	entry := outboxEntry{ID: nextID(), MessageID: result.MatchID, Body: body}
+	err = appendOutbox(outboxRecord{Op: "pub", ID: entry.ID, MessageID: entry.MessageID, Body: body})
+	// 即使落盘失败也放入内存队列，尽量投递
+	pending = append(pending, entry)
+	mu.Unlock()
+
+	notify()
 	if err != nil {
-		log.Printf("Failed to publish result: %v", err)
+		return fmt.Errorf("persist game result %s failed: %v", result.MatchID, err)
+	}
+	return nil
+}
+
+// nextID 生成 outbox 内唯一的递增 ID，调用方需持有 mu
+func nextID() string {
+	id := time.Now().UnixNano()
+	if id <= lastID {
+		id = lastID + 1
+	}
+	lastID = id
+	return strconv.FormatInt(id, 10)
+}
+
+func notify() {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// run 按提交顺序投递 outbox 中的消息，失败时指数退避重试
+// Bus.Publish 返回 nil 表示消息已被后端确认，此后才从 outbox 中移除
+func run() {
+	maxBackoff := parseDuration(config.AppConfig.MQ.MaxBackoff, 30*time.Second)
+	timeout := parseDuration(config.AppConfig.MQ.ConfirmTimeout, 5*time.Second)
+	backoff := time.Second
+
+	for {
+		// 1. 取队首消息，没有则等待新消息
+		mu.Lock()
+		var entry *outboxEntry
+		if len(pending) > 0 {
+			e := pending[0]
+			entry = &e
+		}
+		mu.Unlock()
+
+		if entry == nil {
+			<-wake
+			continue
+		}
+
+		// 2. 发送并等待确认，失败后退避重试同一条消息
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := Bus.Publish(ctx, config.AppConfig.MQ.QueueName, &messaging.Message{
+			ID:   entry.MessageID,
+			Body: entry.Body,
+		})
+		cancel()
+		if err != nil {
+			log.Printf("MQ publish %s failed, retry in %v: %v", entry.MessageID, backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+
+		backoff = time.Second
+		ack(entry.ID)
+	}
+}
+
+// ack 将已确认的消息移出队列并记录到 outbox
+func ack(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(pending) > 0 && pending[0].ID == id {
+		pending = pending[1:]
+	}
+	if len(pending) == 0 {
+		truncateOutbox()
+		return
+	}
+	if err := appendOutbox(outboxRecord{Op: "ack", ID: id}); err != nil {
+		// 记录失败只会导致重启后重复投递，由消费端去重
+		log.Printf("MQ outbox ack %s failed: %v", id, err)
+	}
+}
+
+func parseDuration(s string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return def
 	}
+	return d
 }
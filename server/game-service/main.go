@@ -3,44 +3,38 @@ package main
 import (
 	"fmt"
 	"log"
-	"mygame/server/game-service/internal/core"
-	"mygame/server/game-service/internal/dao"
-	"mygame/server/game-service/internal/handler"
-	"mygame/server/game-service/internal/mq"
-	"mygame/server/game-service/pkg/config"
+	"net"
 
-	"github.com/gin-gonic/gin"
+	"mygame/server/game-service/app"
+	"mygame/server/game-service/pkg/config"
 )
 
 func main() {
 	config.InitConfig()
-	// 初始化 Redis（用于房间 ticket 校验）
-	dao.InitRedis()
-
-	mq.InitMQ()
+	app.Init()
 
 	go func() {
-		if err := handler.StartGRPC(config.AppConfig.Server.GrpcPort); err != nil {
+		port := config.AppConfig.Server.GrpcPort
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
 			log.Fatalf("gRPC server failed: %v", err)
 		}
-	}()
-
-	r := gin.Default()
-
-	r.Use(func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
+		log.Printf("Game Service gRPC listening on :%d", port)
+		if err := app.NewGRPCServer().Serve(lis); err != nil {
+			log.Fatalf("gRPC server failed: %v", err)
 		}
+	}()
 
-		c.Next()
-	})
+	if addr := config.AppConfig.Server.DebugAddr; addr != "" {
+		go func() {
+			log.Printf("Game Service debug listening on %s", addr)
+			if err := app.NewDebugRouter().Run(addr); err != nil {
+				log.Fatalf("debug server failed: %v", err)
+			}
+		}()
+	}
 
-	r.GET("/ws", core.HandleWebSocket)
+	r := app.NewRouter()
 
 	addr := fmt.Sprintf(":%d", config.AppConfig.Server.Port)
 	fmt.Printf("Game Service running on %s\n", addr)
@@ -11,17 +11,25 @@ type Config struct {
 	MQ     MQConfig     `mapstructure:"mq"`
 	Redis  RedisConfig  `mapstructure:"redis"`
 	Game   GameConfig   `mapstructure:"game"`
+	Auth   AuthConfig   `mapstructure:"auth"`
 }
 
 type ServerConfig struct {
 	Port     int `mapstructure:"port"`
 	GrpcPort int `mapstructure:"grpc_port"`
 	TickRate int `mapstructure:"tick_rate"`
+	MaxRooms int `mapstructure:"max_rooms"` // 本服最多承载的房间数，0 不限制
+
+	DebugAddr string `mapstructure:"debug_addr"` // 调试接口 (/debug/ticks) 的独立监听地址，为空时不开启
 }
 
 type MQConfig struct {
-	Url       string `mapstructure:"url"`
-	QueueName string `mapstructure:"queue_name"`
+	Backend        string `mapstructure:"backend"` // rabbitmq / redis / memory
+	Url            string `mapstructure:"url"`
+	QueueName      string `mapstructure:"queue_name"`
+	OutboxPath     string `mapstructure:"outbox_path"`     // 未确认结果的本地落盘文件
+	ConfirmTimeout string `mapstructure:"confirm_timeout"` // 等待 Broker 确认的超时
+	MaxBackoff     string `mapstructure:"max_backoff"`     // 重连退避上限
 }
 
 type RedisConfig struct {
@@ -34,6 +42,22 @@ type GameConfig struct {
 	MapSize     float64 `mapstructure:"map_size"`
 	PlayerSpeed float64 `mapstructure:"player_speed"`
 	ViewRadius  float64 `mapstructure:"view_radius"`
+
+	Bots map[string]BotConfig `mapstructure:"bots"` // 按难度覆盖机器人参数，key 为 easy / normal / hard
+}
+
+// BotConfig 机器人难度参数
+type BotConfig struct {
+	ReactionMs  int     `mapstructure:"reaction_ms"`   // 反应时间：只能看到这么久之前的快照
+	AimError    float64 `mapstructure:"aim_error"`     // 瞄准误差（像素，正态分布的标准差）
+	MinChargeMs int     `mapstructure:"min_charge_ms"` // 每次蓄力的时长范围，越接近满蓄力伤害越高
+	MaxChargeMs int     `mapstructure:"max_charge_ms"`
+	DodgeChance float64 `mapstructure:"dodge_chance"` // 发现自己被敌人瞄准时横向躲避的概率
+}
+
+type AuthConfig struct {
+	JWKSURL         string `mapstructure:"jwks_url"`         // 配置后 WebSocket 连接需在 Authorization 头携带用户 JWT
+	RefreshInterval string `mapstructure:"refresh_interval"` // 公钥刷新间隔
 }
 
 var AppConfig *Config
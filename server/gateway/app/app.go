@@ -0,0 +1,133 @@
+// Package app Gateway 的初始化与路由，供 main 与进程内集成测试共用
+package app
+
+import (
+	"log"
+
+	"mygame/server/gateway/dao"
+	handlers "mygame/server/gateway/handler"
+	"mygame/server/gateway/middleware"
+	"mygame/server/gateway/pkg/config"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Init 按 config.AppConfig 初始化 RPC 客户端与 Redis，需在 User Service 启动后调用（拉取 JWKS）
+func Init() {
+	rpc.InitClients()
+	rpc.InitJWKS()
+	dao.InitRedis()
+}
+
+// NewRouter 创建注册了全部路由的 Gin 引擎
+func NewRouter() *gin.Engine {
+	if config.AppConfig.Server.Mode == "release" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	r := gin.Default()
+	// 限流按 ClientIP 计数，只有配置的代理才能通过转发头指定客户端 IP
+	if err := r.SetTrustedProxies(config.AppConfig.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid server.trusted_proxies: %v", err)
+	}
+
+	// 1. 全局中间件
+	r.Use(middleware.Cors())
+
+	// 2. 路由注册
+	// 验签公钥，供 Game Service 等只持有公钥的服务使用
+	r.GET("/.well-known/jwks.json", handlers.HandleJWKS)
+
+	// 游戏 WebSocket 经 Gateway 代理到房间所在的 Game Server
+	r.GET("/ws/game", middleware.AuthMiddleware(), middleware.RateLimit("api"), handlers.HandleGameWebSocket)
+
+	api := r.Group("/api")
+	{
+		// 鉴权模块
+		auth := api.Group("/auth")
+		auth.Use(middleware.RateLimit("auth"))
+		{
+			auth.POST("/login", handlers.HandleLogin)
+			auth.POST("/register", handlers.HandleRegister)
+			auth.POST("/refresh", handlers.HandleRefreshToken)
+		}
+
+		// 登出 (需要登录)
+		logout := api.Group("/auth")
+		logout.Use(middleware.AuthMiddleware(), middleware.RateLimit("api"))
+		{
+			logout.POST("/logout", handlers.HandleLogout)
+			logout.POST("/logout-all", handlers.HandleLogoutAll)
+		}
+
+		// 用户模块 (需要登录)
+		user := api.Group("/user")
+		user.Use(middleware.AuthMiddleware(), middleware.RateLimit("api"), middleware.PresenceMiddleware())
+		{
+			user.GET("/history", handlers.HandleGetHistory)
+			user.GET("/profile/:uid", handlers.HandleGetProfile)
+		}
+
+		// 比赛模块 (需要登录)
+		match := api.Group("/match")
+		match.Use(middleware.AuthMiddleware(), middleware.RateLimit("api"), middleware.PresenceMiddleware())
+		{
+			match.POST("/create", middleware.RateLimit("match_create"), handlers.HandleCreateRoom)
+			match.GET("/rooms", handlers.HandleListRooms)
+			match.GET("/watch", handlers.HandleWatchRooms)
+			match.POST("/join", handlers.HandleJoinRoom)
+			match.POST("/update", handlers.HandleUpdateRoom)
+			match.POST("/leave", handlers.HandleLeaveRoom)
+			match.POST("/kick", handlers.HandleKickPlayer)
+			match.POST("/transfer", handlers.HandleTransferHost)
+			match.POST("/start", handlers.HandleStartMatch)
+			match.POST("/bots", handlers.HandleAddBots)
+			match.POST("/bots/remove", handlers.HandleRemoveBot)
+			match.POST("/observe", handlers.HandleObserveRoom)
+		}
+
+		// 组队模块 (需要登录)
+		party := api.Group("/party")
+		party.Use(middleware.AuthMiddleware(), middleware.RateLimit("api"), middleware.PresenceMiddleware())
+		{
+			party.POST("", handlers.HandleCreateParty)
+			party.GET("", handlers.HandleGetParty)
+			party.POST("/invite", handlers.HandleInviteToParty)
+			party.POST("/join", handlers.HandleJoinParty)
+			party.POST("/leave", handlers.HandleLeaveParty)
+			party.POST("/kick", handlers.HandleKickFromParty)
+		}
+
+		// 好友模块 (需要登录)
+		friends := api.Group("/friends")
+		friends.Use(middleware.AuthMiddleware(), middleware.RateLimit("api"), middleware.PresenceMiddleware())
+		{
+			friends.GET("", handlers.HandleGetFriends)
+			friends.GET("/requests", handlers.HandleGetFriendRequests)
+			friends.POST("/requests", handlers.HandleSendFriendRequest)
+			friends.POST("/requests/:id/accept", handlers.HandleRespondFriendRequest(true))
+			friends.POST("/requests/:id/decline", handlers.HandleRespondFriendRequest(false))
+			friends.DELETE("/:uid", handlers.HandleRemoveFriend)
+			friends.POST("/:uid/block", handlers.HandleBlockUser(false))
+			friends.DELETE("/:uid/block", handlers.HandleBlockUser(true))
+			friends.POST("/:uid/join", handlers.HandleJoinFriendRoom)
+		}
+
+		// 排行榜模块 (需要登录)
+		leaderboard := api.Group("/leaderboard")
+		leaderboard.Use(middleware.AuthMiddleware(), middleware.RateLimit("api"), middleware.PresenceMiddleware())
+		{
+			leaderboard.GET("/:board", handlers.HandleGetLeaderboard)
+			leaderboard.GET("/:board/rank", handlers.HandleGetRank)
+		}
+	}
+
+	return r
+}
+
+// NewDebugRouter 调试接口，不挂在对外端口上，由 server.debug_addr 单独监听
+func NewDebugRouter() *gin.Engine {
+	r := gin.Default()
+	r.GET("/debug/ws", handlers.HandleWSStats)
+	return r
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 限流与登录锁定，多个 Gateway 实例共享
+//
+//	ratelimit:{group}:{ip|uid}:{id}  Hash: 令牌桶 (tokens, ts)
+//	login:fails:{username}           String: 连续登录失败次数
+//	login:lock:{username}            String: 锁定标记，TTL 为剩余锁定时间
+const (
+	KeyRateLimitPrefix  = "ratelimit:"
+	KeyLoginFailsPrefix = "login:fails:"
+	KeyLoginLockPrefix  = "login:lock:"
+)
+
+// tokenBucket 原子地补充并扣减令牌，时间取 Redis 服务器时间，避免各实例时钟不一致
+// 返回 {是否放行, 需要等待的毫秒数}
+var tokenBucket = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait}
+`)
+
+// TakeToken 从令牌桶取一个令牌，被拒绝时返回需要等待的时间
+func TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, time.Duration, error) {
+	res, err := tokenBucket.Run(ctx, RDB, []string{KeyRateLimitPrefix + key}, rate, burst).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+func loginKey(username string) string {
+	return strings.ToLower(username)
+}
+
+// LoginLockedFor 账号剩余的锁定时间，未锁定返回 0
+func LoginLockedFor(ctx context.Context, username string) (time.Duration, error) {
+	ttl, err := RDB.PTTL(ctx, KeyLoginLockPrefix+loginKey(username)).Result()
+	if err != nil || ttl < 0 {
+		return 0, err
+	}
+	return ttl, nil
+}
+
+// RecordLoginFailure 记录一次登录失败；超过 freeAttempts 次后开始锁定，
+// 锁定时长从 base 起每多失败一次翻倍，最长 max。返回本次的锁定时长（未锁定为 0）
+func RecordLoginFailure(ctx context.Context, username string, freeAttempts int, window, base, max time.Duration) (time.Duration, error) {
+	key := loginKey(username)
+
+	pipe := RDB.TxPipeline()
+	countCmd := pipe.Incr(ctx, KeyLoginFailsPrefix+key)
+	pipe.Expire(ctx, KeyLoginFailsPrefix+key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	over := countCmd.Val() - int64(freeAttempts)
+	if over <= 0 {
+		return 0, nil
+	}
+	lock := base
+	for i := int64(1); i < over && lock < max; i++ {
+		lock *= 2
+	}
+	if lock > max {
+		lock = max
+	}
+	return lock, RDB.Set(ctx, KeyLoginLockPrefix+key, 1, lock).Err()
+}
+
+// ResetLoginFailures 登录成功后清空失败计数
+func ResetLoginFailures(ctx context.Context, username string) error {
+	key := loginKey(username)
+	return RDB.Del(ctx, KeyLoginFailsPrefix+key, KeyLoginLockPrefix+key).Err()
+}
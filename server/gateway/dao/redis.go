@@ -0,0 +1,29 @@
+package dao
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"mygame/server/gateway/pkg/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var RDB *redis.Client
+
+func InitRedis() {
+	cfg := config.AppConfig.Redis
+	RDB = redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := RDB.Ping(ctx).Result(); err != nil {
+		log.Fatalf("Redis connect failed: %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyRoomPrefix 房间信息由 Match Service 写入 (room:{id})，Gateway 只读取所在的 Game Server
+const KeyRoomPrefix = "room:"
+
+// GetRoomServer 房间所在 Game Server 的 WebSocket 地址 (ip:port)
+func GetRoomServer(ctx context.Context, roomID string) (string, error) {
+	vals, err := RDB.HMGet(ctx, KeyRoomPrefix+roomID, "server_ip", "server_port").Result()
+	if err != nil {
+		return "", err
+	}
+	ip, _ := vals[0].(string)
+	port, _ := vals[1].(string)
+	if ip == "" || port == "" {
+		return "", redis.Nil
+	}
+	return fmt.Sprintf("%s:%s", ip, port), nil
+}
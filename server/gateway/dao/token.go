@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 吊销名单由 User Service 写入，Gateway 只读
+//
+//	auth:revoked:{jti}            String: 被吊销的 access token
+//	auth:revoked_before_ms:{uid}  String: 该时间（unix 毫秒）及之前签发的 access token 全部失效
+const (
+	KeyRevokedTokenPrefix  = "auth:revoked:"
+	KeyRevokedBeforePrefix = "auth:revoked_before_ms:"
+)
+
+// IsTokenRevoked 检查 jti 吊销名单与用户级的统一失效时间，issuedAtMs 为签发时间（unix 毫秒）
+func IsTokenRevoked(ctx context.Context, jti string, uid int64, issuedAtMs int64) (bool, error) {
+	pipe := RDB.Pipeline()
+	existsCmd := pipe.Exists(ctx, KeyRevokedTokenPrefix+jti)
+	beforeCmd := pipe.Get(ctx, KeyRevokedBeforePrefix+strconv.FormatInt(uid, 10))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	if jti != "" && existsCmd.Val() > 0 {
+		return true, nil
+	}
+	if before, err := beforeCmd.Int64(); err == nil && issuedAtMs <= before {
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// List Friends (with presence)
+func HandleGetFriends(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.GetFriends(ctx, &pb.GetFriendsReq{Uid: uid.(int64)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fetch friends failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"friends": resp.Friends,
+		"blocked": resp.BlockedUids,
+	})
+}
+
+// List pending friend requests
+func HandleGetFriendRequests(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.GetFriendRequests(ctx, &pb.GetFriendRequestsReq{Uid: uid.(int64)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fetch friend requests failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"incoming": resp.Incoming,
+		"outgoing": resp.Outgoing,
+	})
+}
+
+// Send friend request
+func HandleSendFriendRequest(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		TargetUid int64 `json:"target_uid" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.SendFriendRequest(ctx, &pb.SendFriendRequestReq{
+		Uid:       uid.(int64),
+		TargetUid: req.TargetUid,
+	})
+	respondFriendAction(c, resp, err)
+}
+
+// Accept / Decline friend request
+func HandleRespondFriendRequest(accept bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid, _ := c.Get("uid")
+
+		requestID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request id"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := rpc.UserClient.RespondFriendRequest(ctx, &pb.RespondFriendRequestReq{
+			Uid:       uid.(int64),
+			RequestId: requestID,
+			Accept:    accept,
+		})
+		respondFriendAction(c, resp, err)
+	}
+}
+
+// Remove friend
+func HandleRemoveFriend(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	friendUid, err := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.RemoveFriend(ctx, &pb.RemoveFriendReq{
+		Uid:       uid.(int64),
+		FriendUid: friendUid,
+	})
+	respondFriendAction(c, resp, err)
+}
+
+// Block / Unblock user
+func HandleBlockUser(unblock bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		uid, _ := c.Get("uid")
+
+		targetUid, err := strconv.ParseInt(c.Param("uid"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := rpc.UserClient.BlockUser(ctx, &pb.BlockUserReq{
+			Uid:       uid.(int64),
+			TargetUid: targetUid,
+			Unblock:   unblock,
+		})
+		respondFriendAction(c, resp, err)
+	}
+}
+
+// Join the room a friend is currently in
+func HandleJoinFriendRoom(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	friendUid, err := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 1. 校验好友关系并查询好友所在房间
+	room, err := rpc.UserClient.GetFriendRoom(ctx, &pb.GetFriendRoomReq{
+		Uid:       uid.(int64),
+		FriendUid: friendUid,
+	})
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Join friend failed", "details": err.Error()})
+		return
+	}
+	if room.RoomId == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "friend is not in a room"})
+		return
+	}
+
+	// 2. 以好友身份加入，私有房间无需邀请码
+	resp, err := rpc.MatchClient.JoinRoom(ctx, &pb.JoinRoomReq{
+		RoomId:    room.RoomId,
+		Uid:       uid.(int64),
+		FriendUid: friendUid,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Join room failed", "details": err.Error()})
+		return
+	}
+	setPresence(uid.(int64), pb.Presence_IN_QUEUE, resp.RoomId)
+
+	c.JSON(http.StatusOK, withGameServer(gin.H{
+		"room_id": resp.RoomId,
+		"ticket":  resp.RoomToken,
+	}, resp.ServerIp, resp.ServerPort))
+}
+
+func respondFriendAction(c *gin.Context, resp *pb.FriendActionResp, err error) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Friend operation failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+}
+
+// setPresence 异步上报在线状态，失败只记录日志
+func setPresence(uid int64, status pb.Presence_Status, roomID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err := rpc.UserClient.UpdatePresence(ctx, &pb.UpdatePresenceReq{
+			Uid:    uid,
+			Status: status,
+			RoomId: roomID,
+		})
+		if err != nil {
+			log.Printf("Update presence of %d failed: %v", uid, err)
+		}
+	}()
+}
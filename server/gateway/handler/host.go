@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Leave Room
+func HandleLeaveRoom(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId string `json:"room_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.LeaveRoom(ctx, &pb.LeaveRoomReq{
+		RoomId: req.RoomId,
+		Uid:    uid.(int64),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Leave room failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+	setPresence(uid.(int64), pb.Presence_LOBBY, "")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  resp.Message,
+		"host_uid": resp.HostUid,
+	})
+}
+
+// Kick Player (host only)
+func HandleKickPlayer(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId    string `json:"room_id" binding:"required"`
+		TargetUid int64  `json:"target_uid" binding:"required"`
+		Reason    string `json:"reason"`
+		Ban       bool   `json:"ban"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.KickPlayer(ctx, &pb.KickPlayerReq{
+		RoomId:    req.RoomId,
+		Uid:       uid.(int64),
+		TargetUid: req.TargetUid,
+		Reason:    req.Reason,
+		Ban:       req.Ban,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Kick player failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusForbidden, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+}
+
+// Transfer Host (host only)
+func HandleTransferHost(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId     string `json:"room_id" binding:"required"`
+		NewHostUid int64  `json:"new_host_uid" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.TransferHost(ctx, &pb.TransferHostReq{
+		RoomId:     req.RoomId,
+		Uid:        uid.(int64),
+		NewHostUid: req.NewHostUid,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Transfer host failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusForbidden, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+}
+
+// Start Match (host only)
+func HandleStartMatch(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId string `json:"room_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.StartMatch(ctx, &pb.StartMatchReq{
+		RoomId: req.RoomId,
+		Uid:    uid.(int64),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Start match failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusConflict, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":           resp.Message,
+		"countdown_seconds": resp.CountdownSeconds,
+	})
+}
+
+// Add Bots (host only)
+func HandleAddBots(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId     string `json:"room_id" binding:"required"`
+		Count      int32  `json:"count"`
+		Difficulty string `json:"difficulty"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.AddBots(ctx, &pb.AddBotsReq{
+		RoomId:     req.RoomId,
+		Uid:        uid.(int64),
+		Count:      req.Count,
+		Difficulty: req.Difficulty,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Add bots failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusConflict, gin.H{"error": resp.Message, "bot_uids": resp.BotUids})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  resp.Message,
+		"bot_uids": resp.BotUids,
+	})
+}
+
+// Remove Bot (host only)
+func HandleRemoveBot(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId string `json:"room_id" binding:"required"`
+		BotUid int64  `json:"bot_uid"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.RemoveBot(ctx, &pb.RemoveBotReq{
+		RoomId: req.RoomId,
+		Uid:    uid.(int64),
+		BotUid: req.BotUid,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Remove bot failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusConflict, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": resp.Message})
+}
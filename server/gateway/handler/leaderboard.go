@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Get Leaderboard
+// GET /api/leaderboard/:board?season=S1&mode=ffa&page=1&limit=20
+func HandleGetLeaderboard(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.GetLeaderboard(ctx, &pb.GetLeaderboardReq{
+		Board:  c.Param("board"),
+		Season: c.Query("season"),
+		Mode:   c.Query("mode"),
+		Page:   int32(page),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fetch leaderboard failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": resp.Entries, "total": resp.Total})
+}
+
+// Get Rank
+// GET /api/leaderboard/:board/rank?uid=123&neighbours=5，uid 缺省时查询自己
+func HandleGetRank(c *gin.Context) {
+	uid, _ := c.Get("uid")
+	targetUid := uid.(int64)
+	if uidStr := c.Query("uid"); uidStr != "" {
+		parsed, err := strconv.ParseInt(uidStr, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+			return
+		}
+		targetUid = parsed
+	}
+	neighbours, _ := strconv.Atoi(c.DefaultQuery("neighbours", "5"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.GetRank(ctx, &pb.GetRankReq{
+		Uid:        targetUid,
+		Board:      c.Param("board"),
+		Season:     c.Query("season"),
+		Mode:       c.Query("mode"),
+		Neighbours: int32(neighbours),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fetch rank failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entry": resp.Entry, "neighbours": resp.Neighbours})
+}
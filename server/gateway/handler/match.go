@@ -3,9 +3,11 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	pb "mygame/proto"
+	"mygame/server/gateway/pkg/config"
 	"mygame/server/gateway/rpc"
 
 	"github.com/gin-gonic/gin"
@@ -16,8 +18,12 @@ func HandleCreateRoom(c *gin.Context) {
 	uid, _ := c.Get("uid")
 
 	var req struct {
-		MapId      int32 `json:"map_id"`
-		MaxPlayers int32 `json:"max_players"`
+		RoomName   string `json:"room_name"`
+		MapId      int32  `json:"map_id"`
+		MaxPlayers int32  `json:"max_players"`
+		Visibility string `json:"visibility"` // public / private
+		Password   string `json:"password"`
+		Mode       string `json:"mode"` // ffa / tdm
 	}
 	c.ShouldBindJSON(&req)
 
@@ -27,8 +33,12 @@ func HandleCreateRoom(c *gin.Context) {
 	resp, err := rpc.MatchClient.CreateRoom(ctx, &pb.CreateRoomReq{
 		Uid: uid.(int64),
 		Config: &pb.RoomConfig{
+			RoomName:   req.RoomName,
 			MapId:      req.MapId,
 			MaxPlayers: req.MaxPlayers,
+			Visibility: parseVisibility(req.Visibility),
+			Password:   req.Password,
+			Mode:       req.Mode,
 		},
 	})
 
@@ -37,26 +47,61 @@ func HandleCreateRoom(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	setPresence(uid.(int64), pb.Presence_IN_QUEUE, resp.RoomId)
+
+	c.JSON(http.StatusOK, withGameServer(gin.H{
 		"room_id":     resp.RoomId,
-		"server_ip":   resp.ServerIp,
-		"server_port": resp.ServerPort,
+		"room_name":   resp.RoomName,
 		"ticket":      resp.RoomToken,
-	})
+		"invite_code": resp.InviteCode,
+		"tickets":     resp.Tickets, // 整队创建时每名队员的 ticket
+	}, resp.ServerIp, resp.ServerPort))
 }
 
 // List Rooms
+// GET /api/match/rooms?status=WAITING&map_id=1&not_full=true&name=abc&sort=most_players&cursor=...&limit=20
 func HandleListRooms(c *gin.Context) {
+	mapID, _ := strconv.Atoi(c.Query("map_id"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	notFull, _ := strconv.ParseBool(c.DefaultQuery("not_full", "false"))
+
+	sortBy := pb.ListRoomsReq_NEWEST
+	switch c.Query("sort") {
+	case "most_players":
+		sortBy = pb.ListRoomsReq_MOST_PLAYERS
+	case "fewest_players":
+		sortBy = pb.ListRoomsReq_FEWEST_PLAYERS
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	resp, err := rpc.MatchClient.ListRooms(ctx, &pb.ListRoomsReq{})
+	resp, err := rpc.MatchClient.ListRooms(ctx, &pb.ListRoomsReq{
+		Status:  c.Query("status"),
+		MapId:   int32(mapID),
+		NotFull: notFull,
+		Name:    c.Query("name"),
+		SortBy:  sortBy,
+		Cursor:  c.Query("cursor"),
+		Limit:   int32(limit),
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "List rooms failed"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"rooms": resp.Rooms})
+	// 启用代理时不暴露 Game Server 地址
+	if config.AppConfig.GameProxy.HideServerAddr {
+		for _, room := range resp.Rooms {
+			room.ServerAddr = ""
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rooms":       resp.Rooms,
+		"next_cursor": resp.NextCursor,
+		"total":       resp.Total,
+	})
 }
 
 // Join Room
@@ -64,10 +109,12 @@ func HandleJoinRoom(c *gin.Context) {
 	uid, _ := c.Get("uid")
 
 	var req struct {
-		RoomId string `json:"room_id"`
+		RoomId     string `json:"room_id"`
+		InviteCode string `json:"invite_code"`
+		Password   string `json:"password"`
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "room_id is required"})
+	if err := c.ShouldBindJSON(&req); err != nil || (req.RoomId == "" && req.InviteCode == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room_id or invite_code is required"})
 		return
 	}
 
@@ -75,19 +122,79 @@ func HandleJoinRoom(c *gin.Context) {
 	defer cancel()
 
 	resp, err := rpc.MatchClient.JoinRoom(ctx, &pb.JoinRoomReq{
-		RoomId: req.RoomId,
-		Uid:    uid.(int64),
+		RoomId:     req.RoomId,
+		Uid:        uid.(int64),
+		Password:   req.Password,
+		InviteCode: req.InviteCode,
 	})
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Join room failed", "details": err.Error()})
 		return
 	}
+	setPresence(uid.(int64), pb.Presence_IN_QUEUE, resp.RoomId)
+
+	c.JSON(http.StatusOK, withGameServer(gin.H{
+		"room_id": resp.RoomId,
+		"ticket":  resp.RoomToken,
+		"tickets": resp.Tickets, // 整队加入时每名队员的 ticket
+	}, resp.ServerIp, resp.ServerPort))
+}
+
+// Update Room (仅房主)
+func HandleUpdateRoom(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId               string `json:"room_id" binding:"required"`
+		RoomName             string `json:"room_name"`
+		MaxPlayers           int32  `json:"max_players"`
+		Visibility           string `json:"visibility"`
+		Password             string `json:"password"`
+		ClearPassword        bool   `json:"clear_password"`
+		RegenerateInviteCode bool   `json:"regenerate_invite_code"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.UpdateRoom(ctx, &pb.UpdateRoomReq{
+		RoomId: req.RoomId,
+		Uid:    uid.(int64),
+		Config: &pb.RoomConfig{
+			RoomName:   req.RoomName,
+			MaxPlayers: req.MaxPlayers,
+			Visibility: parseVisibility(req.Visibility),
+			Password:   req.Password,
+		},
+		ClearPassword:        req.ClearPassword,
+		RegenerateInviteCode: req.RegenerateInviteCode,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Update room failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusForbidden, gin.H{"error": resp.Message})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"room_id":     resp.RoomId,
-		"server_ip":   resp.ServerIp,
-		"server_port": resp.ServerPort,
-		"ticket":      resp.RoomToken,
+		"message":     resp.Message,
+		"invite_code": resp.InviteCode,
 	})
 }
+
+func parseVisibility(v string) pb.RoomConfig_Visibility {
+	switch v {
+	case "public":
+		return pb.RoomConfig_PUBLIC
+	case "private":
+		return pb.RoomConfig_PRIVATE
+	}
+	return pb.RoomConfig_UNSPECIFIED
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+)
+
+// HandleObserveRoom 以观战者身份进入房间，连接 Game Server 时带上 observer=1 与观战 ticket
+func HandleObserveRoom(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		RoomId     string `json:"room_id"`
+		InviteCode string `json:"invite_code"`
+		Password   string `json:"password"`
+		FriendUid  int64  `json:"friend_uid"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.RoomId == "" && req.InviteCode == "" && req.FriendUid == 0) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room_id, invite_code or friend_uid is required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 观战好友时先校验好友关系并查询好友所在房间
+	if req.FriendUid != 0 {
+		room, err := rpc.UserClient.GetFriendRoom(ctx, &pb.GetFriendRoomReq{
+			Uid:       uid.(int64),
+			FriendUid: req.FriendUid,
+		})
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Observe friend failed", "details": err.Error()})
+			return
+		}
+		if room.RoomId == "" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "friend is not in a room"})
+			return
+		}
+		req.RoomId = room.RoomId
+	}
+
+	resp, err := rpc.MatchClient.JoinAsObserver(ctx, &pb.JoinAsObserverReq{
+		RoomId:     req.RoomId,
+		Uid:        uid.(int64),
+		Password:   req.Password,
+		InviteCode: req.InviteCode,
+		FriendUid:  req.FriendUid,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Observe room failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, withGameServer(gin.H{
+		"room_id":  resp.RoomId,
+		"ticket":   resp.ObserverTicket,
+		"delay_ms": resp.DelayMs,
+	}, resp.ServerIp, resp.ServerPort))
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Create Party
+func HandleCreateParty(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.CreateParty(ctx, &pb.CreatePartyReq{Uid: uid.(int64)})
+	respondParty(c, resp, err)
+}
+
+// Get current party
+func HandleGetParty(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.GetParty(ctx, &pb.GetPartyReq{Uid: uid.(int64)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fetch party failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": resp.Message})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"party": resp.Party})
+}
+
+// Invite to party (leader only)
+func HandleInviteToParty(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		TargetUid int64 `json:"target_uid" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.InviteToParty(ctx, &pb.InviteToPartyReq{
+		Uid:       uid.(int64),
+		TargetUid: req.TargetUid,
+	})
+	respondParty(c, resp, err)
+}
+
+// Join party (must be invited)
+func HandleJoinParty(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		PartyId string `json:"party_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.JoinParty(ctx, &pb.JoinPartyReq{
+		Uid:     uid.(int64),
+		PartyId: req.PartyId,
+	})
+	respondParty(c, resp, err)
+}
+
+// Leave party
+func HandleLeaveParty(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.LeaveParty(ctx, &pb.LeavePartyReq{Uid: uid.(int64)})
+	respondParty(c, resp, err)
+}
+
+// Kick from party (leader only)
+func HandleKickFromParty(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	var req struct {
+		TargetUid int64 `json:"target_uid" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.MatchClient.KickFromParty(ctx, &pb.KickFromPartyReq{
+		Uid:       uid.(int64),
+		TargetUid: req.TargetUid,
+	})
+	respondParty(c, resp, err)
+}
+
+func respondParty(c *gin.Context, resp *pb.PartyResp, err error) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Party operation failed", "details": err.Error()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": resp.Message,
+		"party":   resp.Party,
+	})
+}
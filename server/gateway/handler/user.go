@@ -2,13 +2,21 @@ package handlers
 
 import (
 	"context"
+	"log"
 	"net/http"
+	"strconv"
 	"time"
 
+	"mygame/pkg/jwks"
 	pb "mygame/proto"
+	"mygame/server/gateway/dao"
+	"mygame/server/gateway/middleware"
+	"mygame/server/gateway/pkg/config"
 	"mygame/server/gateway/rpc"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Login
@@ -22,25 +30,111 @@ func HandleLogin(c *gin.Context) {
 		return
 	}
 
-	// 调用 User Service
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// 1. 连续失败被锁定的账号直接拒绝，不再消耗 bcrypt 校验
+	if wait, err := dao.LoginLockedFor(ctx, req.Username); err != nil {
+		log.Printf("Login lockout check failed: %v", err)
+	} else if wait > 0 {
+		middleware.TooManyRequests(c, wait)
+		return
+	}
+
+	// 2. 调用 User Service
 	resp, err := rpc.UserClient.Login(ctx, &pb.LoginReq{
 		Username: req.Username,
 		Password: req.Password,
 	})
 
+	if status.Code(err) == codes.Unauthenticated {
+		// 3. 记录失败，超过次数后递进锁定
+		lockout := config.AppConfig.RateLimit.LoginLockout
+		lock, lerr := dao.RecordLoginFailure(ctx, req.Username, lockout.FreeAttempts,
+			time.Duration(lockout.WindowSeconds)*time.Second,
+			time.Duration(lockout.BaseSeconds)*time.Second,
+			time.Duration(lockout.MaxSeconds)*time.Second)
+		if lerr != nil {
+			log.Printf("Record login failure failed: %v", lerr)
+		}
+		if lock > 0 {
+			middleware.TooManyRequests(c, lock)
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid username or password"})
+		return
+	}
 	if err != nil {
-		// 实际项目中应解析 gRPC error code 返回更准确的 HTTP 状态码
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Login failed", "details": err.Error()})
 		return
 	}
+	dao.ResetLoginFailures(ctx, req.Username)
+
+	respondTokens(c, resp)
+}
+
+// Refresh Token：用 refresh token 换取新的令牌对，旧 refresh token 随即失效
+func HandleRefreshToken(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: req.RefreshToken})
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Refresh token failed", "details": err.Error()})
+		return
+	}
+
+	respondTokens(c, resp)
+}
+
+// Logout：吊销当前 access token 与所属的 refresh token
+func HandleLogout(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	c.ShouldBindJSON(&req)
+	logout(c, req.RefreshToken, false)
+}
+
+// Logout All：登出所有设备
+func HandleLogoutAll(c *gin.Context) {
+	logout(c, "", true)
+}
+
+func logout(c *gin.Context, refreshToken string, all bool) {
+	token, _ := c.Get("token")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := rpc.UserClient.Logout(ctx, &pb.LogoutReq{
+		Token:        token.(string),
+		RefreshToken: refreshToken,
+		All:          all,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed", "details": err.Error()})
+		return
+	}
 
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+func respondTokens(c *gin.Context, resp *pb.LoginResp) {
 	c.JSON(http.StatusOK, gin.H{
-		"token":    resp.Token,
-		"uid":      resp.Uid,
-		"username": resp.Username,
+		"token":         resp.Token,
+		"refresh_token": resp.RefreshToken,
+		"expires_in":    resp.ExpiresIn,
+		"uid":           resp.Uid,
+		"username":      resp.Username,
 	})
 }
 
@@ -91,3 +185,33 @@ func HandleGetHistory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"history": resp.History})
 }
+
+// HandleGetProfile 查看玩家资料与生涯统计
+func HandleGetProfile(c *gin.Context) {
+	uid, err := strconv.ParseInt(c.Param("uid"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid uid"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := rpc.UserClient.GetProfile(ctx, &pb.GetProfileReq{
+		Uid:    uid,
+		Mode:   c.Query("mode"),
+		Season: c.Query("season"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Fetch profile failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": resp.Profile})
+}
+
+// JWKS：对外发布验签公钥
+func HandleJWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, jwks.Document{Keys: rpc.JWKS.Keys()})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SSE 心跳间隔，防止中间代理因空闲断开连接
+const sseHeartbeat = 15 * time.Second
+
+// Watch Rooms (Server-Sent Events)
+// 不带 room_id 时订阅大厅公开房间列表；带 room_id 时订阅单个房间（含成员变化）。
+// 事件名为 snapshot / snapshot_end / added / updated / removed，data 为 RoomEvent JSON
+func HandleWatchRooms(c *gin.Context) {
+	uid, _ := c.Get("uid")
+
+	// 连接随客户端断开而取消
+	ctx := c.Request.Context()
+	stream, err := rpc.MatchClient.WatchRooms(ctx, &pb.WatchRoomsReq{
+		RoomId: c.Query("room_id"),
+		Uid:    uid.(int64),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Watch rooms failed", "details": err.Error()})
+		return
+	}
+
+	// 第一条事件到达前的错误（如房间不存在、无权订阅）仍以普通 JSON 返回
+	first, err := stream.Recv()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Watch rooms failed", "details": err.Error()})
+		return
+	}
+
+	events := make(chan *pb.RoomEvent, 16)
+	errs := make(chan error, 1)
+	events <- first
+	go func() {
+		for {
+			ev, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case ev := <-events:
+			c.SSEvent(strings.ToLower(ev.Type.String()), ev)
+			return true
+		case <-heartbeat.C:
+			io.WriteString(w, ": ping\n\n")
+			return true
+		case err := <-errs:
+			if err != io.EOF {
+				c.SSEvent("error", gin.H{"error": err.Error()})
+			}
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"mygame/server/gateway/dao"
+	"mygame/server/gateway/pkg/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+)
+
+var wsUpgrader = websocket.Upgrader{
+	CheckOrigin:     func(r *http.Request) bool { return true },
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+var wsDialer = &websocket.Dialer{
+	HandshakeTimeout: 5 * time.Second,
+	ReadBufferSize:   1024,
+	WriteBufferSize:  1024,
+}
+
+const (
+	wsReadDeadline  = 60 * time.Second
+	wsWriteDeadline = 10 * time.Second
+	wsPingPeriod    = 30 * time.Second
+)
+
+// wsCounter 单方向的帧数与字节数
+type wsCounter struct {
+	Frames atomic.Int64
+	Bytes  atomic.Int64
+}
+
+func (w *wsCounter) add(n int) {
+	w.Frames.Add(1)
+	w.Bytes.Add(int64(n))
+}
+
+// wsStats 代理连接的累计指标，In 为客户端 -> Game Server，Out 为 Game Server -> 客户端
+var wsStats struct {
+	Active atomic.Int64
+	Total  atomic.Int64
+	Failed atomic.Int64
+	In     wsCounter
+	Out    wsCounter
+}
+
+// HandleWSStats 代理连接指标
+func HandleWSStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"active":     wsStats.Active.Load(),
+		"total":      wsStats.Total.Load(),
+		"failed":     wsStats.Failed.Load(),
+		"frames_in":  wsStats.In.Frames.Load(),
+		"frames_out": wsStats.Out.Frames.Load(),
+		"bytes_in":   wsStats.In.Bytes.Load(),
+		"bytes_out":  wsStats.Out.Bytes.Load(),
+	})
+}
+
+// HandleGameWebSocket 客户端经 Gateway 连接 Game Server，客户端不再需要知道内部地址。
+// uid 取自已校验的 JWT，ticket 等参数原样转发给 Game Server
+func HandleGameWebSocket(c *gin.Context) {
+	uid, _ := c.Get("uid")
+	token, _ := c.Get("token")
+
+	roomID := c.Query("room_id")
+	ticket := c.Query("token")
+	if roomID == "" || ticket == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "room_id and token required"})
+		return
+	}
+
+	// 1. 查找房间所在的 Game Server
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addr, err := dao.GetRoomServer(ctx, roomID)
+	if errors.Is(err, redis.Nil) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "room not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+		return
+	}
+
+	// 2. 先连上游，握手失败时把 Game Server 的状态码原样返回
+	query := url.Values{}
+	query.Set("room_id", roomID)
+	query.Set("uid", strconv.FormatInt(uid.(int64), 10))
+	query.Set("token", ticket)
+	if c.Query("observer") == "1" {
+		query.Set("observer", "1")
+	}
+	target := url.URL{Scheme: "ws", Host: addr, Path: "/ws", RawQuery: query.Encode()}
+
+	// JWT 放在请求头中，避免出现在 Game Server 的访问日志里
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+token.(string))
+	upstream, resp, err := wsDialer.DialContext(ctx, target.String(), header)
+	if err != nil {
+		wsStats.Failed.Add(1)
+		if resp != nil {
+			c.JSON(resp.StatusCode, gin.H{"error": "game server rejected connection"})
+			return
+		}
+		log.Printf("Dial game server %s failed: %v", addr, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "game server unavailable"})
+		return
+	}
+	defer upstream.Close()
+
+	// 3. 升级客户端连接
+	client, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		wsStats.Failed.Add(1)
+		log.Println("Upgrade failed:", err)
+		upstream.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, "client upgrade failed"), time.Now().Add(wsWriteDeadline))
+		return
+	}
+	defer client.Close()
+
+	wsStats.Active.Add(1)
+	wsStats.Total.Add(1)
+	defer wsStats.Active.Add(-1)
+
+	// 4. 双向转发，任一方关闭后把关闭原因传给另一方
+	var in, out wsCounter
+	start := time.Now()
+
+	client.SetReadDeadline(time.Now().Add(wsReadDeadline))
+	client.SetPongHandler(func(string) error {
+		client.SetReadDeadline(time.Now().Add(wsReadDeadline))
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := relay(upstream, client, &in, &wsStats.In, func() {
+			client.SetReadDeadline(time.Now().Add(wsReadDeadline))
+		})
+		propagateClose(upstream, err)
+	}()
+
+	outDone := make(chan struct{})
+	go func() {
+		defer close(outDone)
+		err := relay(client, upstream, &out, &wsStats.Out, nil)
+		propagateClose(client, err)
+	}()
+
+	// Game Server 的 ping 由 upstream 自动回复；客户端侧由 Gateway 自己保活
+	pingTicker := time.NewTicker(wsPingPeriod)
+	defer pingTicker.Stop()
+loop:
+	for {
+		select {
+		case <-pingTicker.C:
+			if err := client.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteDeadline)); err != nil {
+				break loop
+			}
+		case <-done:
+			break loop
+		case <-outDone:
+			break loop
+		}
+	}
+
+	// 关闭两端，让另一个转发协程退出
+	client.Close()
+	upstream.Close()
+	<-done
+	<-outDone
+
+	log.Printf("WS proxy closed: uid=%v room=%s duration=%s in=%d frames/%d bytes out=%d frames/%d bytes",
+		uid, roomID, time.Since(start).Round(time.Millisecond),
+		in.Frames.Load(), in.Bytes.Load(), out.Frames.Load(), out.Bytes.Load())
+}
+
+// relay 把 src 的消息原样写到 dst，返回 src 的读错误
+func relay(dst, src *websocket.Conn, conn, total *wsCounter, onRead func()) error {
+	for {
+		mt, data, err := src.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if onRead != nil {
+			onRead()
+		}
+
+		dst.SetWriteDeadline(time.Now().Add(wsWriteDeadline))
+		if err := dst.WriteMessage(mt, data); err != nil {
+			return err
+		}
+		conn.add(len(data))
+		total.add(len(data))
+	}
+}
+
+// propagateClose 对端正常关闭时转发其关闭码与原因，否则视为 going away
+func propagateClose(dst *websocket.Conn, err error) {
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "peer disconnected")
+	var ce *websocket.CloseError
+	if errors.As(err, &ce) && ce.Code != websocket.CloseNoStatusReceived && ce.Code != websocket.CloseAbnormalClosure {
+		msg = websocket.FormatCloseMessage(ce.Code, ce.Text)
+	}
+	dst.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteDeadline))
+}
+
+// withGameServer 启用代理时只返回 /ws/game 路径，否则返回 Game Server 地址供客户端直连
+func withGameServer(h gin.H, ip string, port int32) gin.H {
+	if config.AppConfig.GameProxy.HideServerAddr {
+		h["ws_path"] = "/ws/game"
+		return h
+	}
+	h["server_ip"] = ip
+	h["server_port"] = port
+	return h
+}
@@ -1,17 +1,24 @@
 package middleware
 
 import (
-	"mygame/server/gateway/pkg/config"
+	"context"
+	"mygame/server/gateway/dao"
+	"mygame/server/gateway/rpc"
 	"net/http"
 	"strings"
+	"time"
 
-	"github.com/dgrijalva/jwt-go" // 或使用 golang-jwt/jwt
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+		// 浏览器的 WebSocket 无法设置请求头，允许通过 access_token 参数携带
+		if authHeader == "" && c.IsWebsocket() && c.Query("access_token") != "" {
+			authHeader = "Bearer " + c.Query("access_token")
+		}
 		if authHeader == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
 			return
@@ -24,9 +31,8 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(config.AppConfig.JWT.Secret), nil
-		})
+		// 按 kid 取 JWKS 中的公钥验签
+		token, err := jwt.Parse(tokenString, rpc.JWKS.Keyfunc)
 
 		if err != nil || !token.Valid {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
@@ -41,10 +47,31 @@ func AuthMiddleware() gin.HandlerFunc {
 
 		// 将 UserID 注入 Context
 		// 注意：JSON解析出来的数字通常是 float64
-		if uid, ok := claims["uid"].(float64); ok {
-			c.Set("uid", int64(uid))
+		uid, _ := claims["uid"].(float64)
+		jti, _ := claims["jti"].(string)
+		// iat 只精确到秒，吊销判断用毫秒的 iat_ms；旧 token 按所在秒的起点处理
+		iatMs, ok := claims["iat_ms"].(float64)
+		if !ok {
+			iat, _ := claims["iat"].(float64)
+			iatMs = iat * 1000
+		}
+
+		// 检查吊销名单（登出、登出所有设备）
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		revoked, err := dao.IsTokenRevoked(ctx, jti, int64(uid), int64(iatMs))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Token check unavailable"})
+			return
+		}
+		if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
+			return
 		}
 
+		c.Set("uid", int64(uid))
+		c.Set("token", tokenString)
+
 		c.Next()
 	}
 }
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/gateway/rpc"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 同一玩家的活跃上报间隔，避免每个请求都调用 User Service
+const presenceInterval = 30 * time.Second
+
+var lastPresence sync.Map // uid -> time.Time
+
+// PresenceMiddleware 登录用户的请求视为在线，续期在线状态（需放在 AuthMiddleware 之后）
+func PresenceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if v, ok := c.Get("uid"); ok {
+			uid := v.(int64)
+			now := time.Now()
+			if last, ok := lastPresence.Load(uid); !ok || now.Sub(last.(time.Time)) > presenceInterval {
+				lastPresence.Store(uid, now)
+				go touchPresence(uid)
+			}
+		}
+		c.Next()
+	}
+}
+
+func touchPresence(uid int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := rpc.UserClient.UpdatePresence(ctx, &pb.UpdatePresenceReq{Uid: uid, Touch: true}); err != nil {
+		log.Printf("Touch presence of %d failed: %v", uid, err)
+	}
+}
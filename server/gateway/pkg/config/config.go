@@ -10,11 +10,19 @@ type Config struct {
 	Server ServerConfig `mapstructure:"server"`
 	RPC    RPCConfig    `mapstructure:"rpc"`
 	JWT    JWTConfig    `mapstructure:"jwt"`
+	Redis  RedisConfig  `mapstructure:"redis"`
+
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+	GameProxy GameProxyConfig `mapstructure:"game_proxy"`
 }
 
 type ServerConfig struct {
 	Port int    `mapstructure:"port"`
 	Mode string `mapstructure:"mode"`
+
+	DebugAddr string `mapstructure:"debug_addr"` // 调试接口 (/debug/ws) 的独立监听地址，为空时不开启
+	// 只信任这些反向代理的 X-Forwarded-For / X-Real-IP（IP 或 CIDR），为空时一律使用连接的对端地址
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type RPCConfig struct {
@@ -23,7 +31,39 @@ type RPCConfig struct {
 }
 
 type JWTConfig struct {
-	Secret string `mapstructure:"secret"`
+	JWKSRefreshInterval string `mapstructure:"jwks_refresh_interval"` // 定期从 User Service 拉取公钥
+}
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type RateLimitConfig struct {
+	Enabled      bool                     `mapstructure:"enabled"`
+	Groups       map[string]RateLimitRule `mapstructure:"groups"` // 路由组名 -> 限流规则，未配置的组不限流
+	LoginLockout LoginLockoutConfig       `mapstructure:"login_lockout"`
+}
+
+// RateLimitRule 令牌桶：rate 为每秒补充的令牌数，burst 为桶容量；rate 为 0 表示该维度不限
+type RateLimitRule struct {
+	IPRate   float64 `mapstructure:"ip_rate"`
+	IPBurst  int     `mapstructure:"ip_burst"`
+	UIDRate  float64 `mapstructure:"uid_rate"`
+	UIDBurst int     `mapstructure:"uid_burst"`
+}
+
+// LoginLockoutConfig 连续登录失败后的递进锁定
+type LoginLockoutConfig struct {
+	FreeAttempts  int `mapstructure:"free_attempts"`  // 允许连续失败的次数
+	BaseSeconds   int `mapstructure:"base_seconds"`   // 首次锁定时长，之后每次失败翻倍
+	MaxSeconds    int `mapstructure:"max_seconds"`    // 最长锁定时长
+	WindowSeconds int `mapstructure:"window_seconds"` // 失败计数的保留时间
+}
+
+type GameProxyConfig struct {
+	HideServerAddr bool `mapstructure:"hide_server_addr"` // 只返回 /ws/game，不向客户端暴露 Game Server 地址
 }
 
 var AppConfig *Config
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"mygame/pkg/jwks"
+	pb "mygame/proto"
+	"mygame/server/gateway/pkg/config"
+)
+
+// JWKS User Service 的验签公钥缓存，Gateway 只持有公钥
+var JWKS *jwks.Store
+
+func InitJWKS() {
+	JWKS = jwks.NewStore(fetchJWKS)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := JWKS.Refresh(ctx); err != nil {
+		log.Fatalf("Fetch JWKS from user-service failed: %v", err)
+	}
+
+	interval, err := time.ParseDuration(config.AppConfig.JWT.JWKSRefreshInterval)
+	if err != nil || interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	JWKS.StartRefresh(interval)
+}
+
+func fetchJWKS(ctx context.Context) ([]jwks.JWK, error) {
+	resp, err := UserClient.GetJWKS(ctx, &pb.GetJWKSReq{})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]jwks.JWK, 0, len(resp.Keys))
+	for _, k := range resp.Keys {
+		keys = append(keys, jwks.JWK{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Alg: k.Alg,
+			Use: k.Use,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return keys, nil
+}
@@ -0,0 +1,41 @@
+package harness_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestLogoutAll 登出所有设备后此前签发的 access token 全部失效，之后重新登录的不受影响
+func TestLogoutAll(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	alice, err := h.Register(ctx, "logout_alice", "password123")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	other := h.NewClient()
+	if err := other.Login(ctx, "logout_alice", "password123"); err != nil {
+		t.Fatalf("login on another device: %v", err)
+	}
+
+	if err := alice.Do(ctx, http.MethodPost, "/api/auth/logout-all", nil, nil); err != nil {
+		t.Fatalf("logout-all: %v", err)
+	}
+	if _, err := alice.History(ctx); err == nil {
+		t.Fatal("token accepted after logout-all")
+	}
+	if _, err := other.History(ctx); err == nil {
+		t.Fatal("token of another device accepted after logout-all")
+	}
+
+	// 紧接着重新登录：与吊销时间同一秒签发的新 token 仍然有效
+	if err := alice.Login(ctx, "logout_alice", "password123"); err != nil {
+		t.Fatalf("login again: %v", err)
+	}
+	if _, err := alice.History(ctx); err != nil {
+		t.Fatalf("token issued after logout-all rejected: %v", err)
+	}
+}
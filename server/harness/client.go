@@ -0,0 +1,45 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"mygame/pkg/gameclient"
+	pb "mygame/proto"
+)
+
+// NewClient 指向本 Harness Gateway 的未登录客户端
+func (h *Harness) NewClient() *gameclient.Client {
+	return gameclient.New(h.GatewayURL)
+}
+
+// Register 注册并登录一个用户
+func (h *Harness) Register(ctx context.Context, username, password string) (*gameclient.Client, error) {
+	c := h.NewClient()
+	if _, err := c.Register(ctx, username, password); err != nil {
+		return nil, err
+	}
+	if err := c.Login(ctx, username, password); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// WaitForHistory 轮询 User Service，直到该玩家至少有 n 条战绩（战绩经 MQ 异步落库）
+func (h *Harness) WaitForHistory(ctx context.Context, uid int64, n int) ([]*pb.MatchRecord, error) {
+	for {
+		resp, err := h.UserClient.GetHistory(ctx, &pb.GetHistoryReq{Uid: uid, Page: 1, Limit: int32(n + 10)})
+		if err == nil && len(resp.History) >= n {
+			return resp.History, nil
+		}
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = fmt.Errorf("uid %d has %d match records, want %d", uid, len(resp.History), n)
+			}
+			return nil, fmt.Errorf("wait for history: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package harness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"mygame/pkg/gameclient"
+	pb "mygame/proto"
+)
+
+// 满蓄力时长与光柱射程，见 game-service 的 FireBeam
+const (
+	fullCharge = 550 * time.Millisecond
+	beamRange  = 800.0
+)
+
+// Fire 朝 angle 方向蓄力 hold 后释放
+func Fire(ctx context.Context, conn *gameclient.Conn, angle int32, hold time.Duration) error {
+	if err := conn.StartCharge(angle); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(hold):
+	}
+	return conn.ReleaseCharge()
+}
+
+// Hunt 追击目标直到其死亡：先在 y 轴上对齐，再靠近到射程内，满蓄力后水平开火。
+// 目标死亡返回 nil，自己先死亡返回错误
+func Hunt(ctx context.Context, conn *gameclient.Conn, target int64) error {
+	var tick int64
+	for {
+		snapshot, err := conn.NextSnapshot(ctx, tick)
+		if err != nil {
+			return fmt.Errorf("hunt %d: %v", target, err)
+		}
+		tick = snapshot.Tick
+
+		var me, them *pb.PlayerState
+		for _, p := range snapshot.Players {
+			switch p.Uid {
+			case conn.UID():
+				me = p
+			case target:
+				them = p
+			}
+		}
+		if me == nil || them == nil {
+			continue
+		}
+		if them.IsDead {
+			return nil
+		}
+		if me.IsDead {
+			return errors.New("hunter died before the target")
+		}
+
+		dx := float64(them.X - me.X)
+		dy := float64(them.Y - me.Y)
+		switch {
+		case math.Abs(dy) > 15:
+			err = conn.SendMove(0, sign(dy))
+		case math.Abs(dx) > beamRange-100:
+			err = conn.SendMove(sign(dx), 0)
+		default:
+			angle := gameclient.AngleRight
+			if dx < 0 {
+				angle = gameclient.AngleLeft
+			}
+			err = Fire(ctx, conn, angle, fullCharge)
+		}
+		if err != nil {
+			return fmt.Errorf("hunt %d: %v", target, err)
+		}
+	}
+}
+
+func sign(v float64) float32 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,13 @@
+module mygame/server/harness
+
+go 1.25.6
+
+require (
+	github.com/alicebob/miniredis/v2 v2.37.0
+	github.com/gin-gonic/gin v1.11.0
+	github.com/gorilla/websocket v1.5.1
+	google.golang.org/grpc v1.79.1
+	google.golang.org/protobuf v1.36.10
+)
+
+require github.com/yuin/gopher-lua v1.1.1 // indirect
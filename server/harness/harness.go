@@ -0,0 +1,373 @@
+// Package harness 在同一进程内启动 Gateway、User、Match、Game 四个服务，
+// 使用 miniredis、内存消息总线与内存存储，供集成测试端到端地验证
+// 注册 -> 建房 -> 对战 -> 战绩落库 的完整流程，不依赖任何外部组件。
+//
+// 各服务的配置与连接都是包级全局变量，因此一个进程只能启动一次，
+// 测试中应在 TestMain 里调用 Start 并在全部用例间共享：
+//
+//	func TestMain(m *testing.M) {
+//		h, err := harness.Start(harness.Options{})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		code := m.Run()
+//		h.Close()
+//		os.Exit(code)
+//	}
+package harness
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pb "mygame/proto"
+	gameapp "mygame/server/game-service/app"
+	gameconfig "mygame/server/game-service/pkg/config"
+	gatewayapp "mygame/server/gateway/app"
+	gatewayconfig "mygame/server/gateway/pkg/config"
+	matchapp "mygame/server/match-service/app"
+	matchconfig "mygame/server/match-service/pkg/config"
+	userapp "mygame/server/user-service/app"
+	userconfig "mygame/server/user-service/pkg/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Options 启动参数，零值即可使用
+type Options struct {
+	CountdownSeconds   int     // 开局倒计时，默认 0（立即开局）
+	MinPlayers         int     // 开局最少人数，默认 2
+	PlayerSpeed        float64 // 玩家移动速度，默认 400，加快脚本客户端的走位
+	MapSize            float64 // 地图边长，默认 2000
+	BotBackfillSeconds int     // 公开房间等待多久后补充机器人，默认 0（不补充）
+	Verbose            bool    // 输出 Gin 的访问日志
+}
+
+// Harness 已启动的全部服务
+type Harness struct {
+	// GatewayURL 形如 http://127.0.0.1:port，测试客户端只应经过 Gateway 访问
+	GatewayURL string
+	// UserClient 直连 User Service，用于断言落库结果
+	UserClient pb.UserServiceClient
+	// MatchClient 直连 Match Service
+	MatchClient pb.MatchServiceClient
+	// Redis 内存 Redis (miniredis，支持 Lua 脚本与 Streams)，可用于检查或清空状态
+	Redis *miniredis.Miniredis
+
+	dir         string
+	grpcServers []*grpc.Server
+	httpServers []*http.Server
+	conns       []*grpc.ClientConn
+	stopClock   chan struct{}
+	closeOnce   sync.Once
+}
+
+var (
+	startMu sync.Mutex
+	started bool
+)
+
+// Start 按 Redis -> User -> Match -> Gateway -> Game 的顺序启动全部服务，
+// 全部监听 127.0.0.1 的随机端口。一个进程只能调用一次
+func Start(opts Options) (*Harness, error) {
+	startMu.Lock()
+	defer startMu.Unlock()
+	if started {
+		return nil, errors.New("harness already started in this process")
+	}
+	started = true
+
+	if !opts.Verbose {
+		gin.DefaultWriter = io.Discard
+	}
+	gin.SetMode(gin.ReleaseMode)
+
+	h := &Harness{}
+	if err := h.start(opts); err != nil {
+		h.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Harness) start(opts Options) error {
+	var err error
+
+	// 1. 临时目录（签名密钥、Outbox）与内存 Redis
+	h.dir, err = os.MkdirTemp("", "mygame-harness-")
+	if err != nil {
+		return err
+	}
+	keyFile, err := writeSigningKey(h.dir)
+	if err != nil {
+		return err
+	}
+	h.Redis = miniredis.NewMiniRedis()
+	if err := h.Redis.StartAddr("127.0.0.1:0"); err != nil {
+		return err
+	}
+	h.stopClock = make(chan struct{})
+	go h.advanceRedisClock()
+
+	// 2. 预先占用全部端口，配置中互相引用的地址在启动前即可确定
+	var userLis, matchLis, gameHTTPLis, gameGRPCLis, gatewayLis net.Listener
+	for _, lis := range []*net.Listener{&userLis, &matchLis, &gameHTTPLis, &gameGRPCLis, &gatewayLis} {
+		if *lis, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+			return err
+		}
+	}
+	h.GatewayURL = "http://" + gatewayLis.Addr().String()
+
+	// 3. 各服务配置
+	setConfigs(opts, h.dir, keyFile, h.Redis.Addr(), userLis, matchLis, gameHTTPLis, gameGRPCLis, gatewayLis)
+
+	// 4. User Service
+	repo, err := userapp.Init()
+	if err != nil {
+		return err
+	}
+	h.serveGRPC(userapp.NewServer(repo), userLis)
+
+	// 5. Match Service
+	matchapp.Init()
+	h.serveGRPC(matchapp.NewServer(), matchLis)
+
+	// 6. Gateway（启动时从 User Service 拉取 JWKS）
+	gatewayapp.Init()
+	h.serveHTTP(gatewayapp.NewRouter(), gatewayLis)
+
+	// 7. Game Service（启动时从 Gateway 拉取 JWKS）
+	gameapp.Init()
+	h.serveGRPC(gameapp.NewGRPCServer(), gameGRPCLis)
+	h.serveHTTP(gameapp.NewRouter(), gameHTTPLis)
+
+	// 8. 断言用的直连客户端
+	userConn, err := h.dial(userLis.Addr().String())
+	if err != nil {
+		return err
+	}
+	h.UserClient = pb.NewUserServiceClient(userConn)
+	matchConn, err := h.dial(matchLis.Addr().String())
+	if err != nil {
+		return err
+	}
+	h.MatchClient = pb.NewMatchServiceClient(matchConn)
+
+	return h.waitReady()
+}
+
+// setConfigs 直接填充各服务的 config.AppConfig，代替从 yaml 加载
+func setConfigs(opts Options, dir, keyFile, redisAddr string, userLis, matchLis, gameHTTPLis, gameGRPCLis, gatewayLis net.Listener) {
+	countdown := opts.CountdownSeconds
+	minPlayers := opts.MinPlayers
+	if minPlayers <= 0 {
+		minPlayers = 2
+	}
+	speed := opts.PlayerSpeed
+	if speed <= 0 {
+		speed = 400
+	}
+	mapSize := opts.MapSize
+	if mapSize <= 0 {
+		mapSize = 2000
+	}
+
+	userconfig.AppConfig = &userconfig.Config{
+		Server:   userconfig.ServerConfig{Port: port(userLis)},
+		Database: userconfig.DatabaseConfig{Driver: "memory"},
+		JWT: userconfig.JWTConfig{
+			ActiveKID:             "k1",
+			Keys:                  []userconfig.JWTKeyConfig{{KID: "k1", PrivateKeyFile: keyFile}},
+			ExpireDuration:        "15m",
+			RefreshExpireDuration: "720h",
+		},
+		MQ: userconfig.MQConfig{
+			Backend:     "memory",
+			QueueName:   "game_results",
+			RetryDelays: []string{"100ms", "500ms"},
+		},
+		Redis: userconfig.RedisConfig{Addr: redisAddr},
+		Rating: userconfig.RatingConfig{
+			Season:            "S1",
+			DefaultMode:       "ffa",
+			InitialRating:     1500,
+			InitialDeviation:  350,
+			InitialVolatility: 0.06,
+			Tau:               0.5,
+		},
+		Presence: userconfig.PresenceConfig{TTLSeconds: 120},
+	}
+
+	matchconfig.AppConfig = &matchconfig.Config{
+		Server: matchconfig.ServerConfig{Port: port(matchLis)},
+		Redis:  matchconfig.RedisConfig{Addr: redisAddr},
+		RPC:    matchconfig.RPCConfig{UserServiceAddr: userLis.Addr().String()},
+		GameServers: []matchconfig.GameServerConfig{{
+			IP:       "127.0.0.1",
+			Port:     port(gameHTTPLis),
+			GrpcPort: port(gameGRPCLis),
+			Region:   "local",
+		}},
+		Match: matchconfig.MatchConfig{
+			MinPlayers:       minPlayers,
+			CountdownSeconds: countdown,
+			PartyMaxSize:     4,
+			MaxObservers:     10,
+
+			BotBackfillSeconds: opts.BotBackfillSeconds,
+		},
+	}
+
+	gatewayconfig.AppConfig = &gatewayconfig.Config{
+		Server: gatewayconfig.ServerConfig{Port: port(gatewayLis), Mode: "release"},
+		RPC: gatewayconfig.RPCConfig{
+			UserServiceAddr:  userLis.Addr().String(),
+			MatchServiceAddr: matchLis.Addr().String(),
+		},
+		JWT:   gatewayconfig.JWTConfig{JWKSRefreshInterval: "5m"},
+		Redis: gatewayconfig.RedisConfig{Addr: redisAddr},
+		// 限流依赖 Lua 脚本，内存 Redis 不支持
+		RateLimit: gatewayconfig.RateLimitConfig{
+			LoginLockout: gatewayconfig.LoginLockoutConfig{FreeAttempts: 5, BaseSeconds: 1, MaxSeconds: 60, WindowSeconds: 600},
+		},
+		GameProxy: gatewayconfig.GameProxyConfig{HideServerAddr: true},
+	}
+
+	gameconfig.AppConfig = &gameconfig.Config{
+		Server: gameconfig.ServerConfig{
+			Port:     port(gameHTTPLis),
+			GrpcPort: port(gameGRPCLis),
+			TickRate: 64,
+		},
+		MQ: gameconfig.MQConfig{
+			Backend:        "memory",
+			QueueName:      "game_results",
+			OutboxPath:     filepath.Join(dir, "outbox.log"),
+			ConfirmTimeout: "5s",
+			MaxBackoff:     "1s",
+		},
+		Redis: gameconfig.RedisConfig{Addr: redisAddr},
+		Game:  gameconfig.GameConfig{MapSize: mapSize, PlayerSpeed: speed, ViewRadius: 800},
+		Auth: gameconfig.AuthConfig{
+			JWKSURL:         "http://" + gatewayLis.Addr().String() + "/.well-known/jwks.json",
+			RefreshInterval: "5m",
+		},
+	}
+}
+
+// writeSigningKey 生成 User Service 的 Ed25519 签名密钥并写入 PEM 文件
+func writeSigningKey(dir string) (string, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, "jwt-k1.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return file, os.WriteFile(file, data, 0600)
+}
+
+func port(lis net.Listener) int {
+	return lis.Addr().(*net.TCPAddr).Port
+}
+
+func (h *Harness) serveGRPC(s *grpc.Server, lis net.Listener) {
+	h.grpcServers = append(h.grpcServers, s)
+	go s.Serve(lis)
+}
+
+func (h *Harness) serveHTTP(handler http.Handler, lis net.Listener) {
+	srv := &http.Server{Handler: handler}
+	h.httpServers = append(h.httpServers, srv)
+	go srv.Serve(lis)
+}
+
+func (h *Harness) dial(addr string) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial %s failed: %v", addr, err)
+	}
+	h.conns = append(h.conns, conn)
+	return conn, nil
+}
+
+// waitReady 等待 Gateway 能对外发布 JWKS，说明整条链路已就绪
+func (h *Harness) waitReady() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, h.GatewayURL+"/.well-known/jwks.json", nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("gateway not ready: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// Close 停止全部服务并删除临时文件。
+// advanceRedisClock miniredis 的 TTL 不会自行流逝，按真实时间推进，
+// 使房间、ticket 等带过期时间的 key 与真实 Redis 一样过期
+func (h *Harness) advanceRedisClock() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-h.stopClock:
+			return
+		case now := <-ticker.C:
+			h.Redis.FastForward(now.Sub(last))
+			last = now
+		}
+	}
+}
+
+// MQ 消费者、JWKS 刷新等后台协程随进程退出，Close 后不能再次 Start
+func (h *Harness) Close() {
+	h.closeOnce.Do(func() {
+		for _, srv := range h.httpServers {
+			srv.Close()
+		}
+		for _, s := range h.grpcServers {
+			s.Stop()
+		}
+		for _, conn := range h.conns {
+			conn.Close()
+		}
+		if h.stopClock != nil {
+			close(h.stopClock)
+		}
+		if h.Redis != nil {
+			h.Redis.Close()
+		}
+		if h.dir != "" {
+			os.RemoveAll(h.dir)
+		}
+	})
+}
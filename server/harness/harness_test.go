@@ -0,0 +1,118 @@
+package harness_test
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"mygame/pkg/gameclient"
+	pb "mygame/proto"
+	"mygame/server/harness"
+)
+
+var h *harness.Harness
+
+func TestMain(m *testing.M) {
+	var err error
+	h, err = harness.Start(harness.Options{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	code := m.Run()
+	h.Close()
+	os.Exit(code)
+}
+
+// TestMatchFlow 注册 -> 建房 -> 邀请码加入 -> WebSocket 对战 -> 战绩落库
+func TestMatchFlow(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	alice, err := h.Register(ctx, "e2e_alice", "password123")
+	if err != nil {
+		t.Fatalf("register alice: %v", err)
+	}
+	bob, err := h.Register(ctx, "e2e_bob", "password123")
+	if err != nil {
+		t.Fatalf("register bob: %v", err)
+	}
+
+	// 1. 私密房间，凭邀请码加入
+	room, err := alice.CreateRoom(ctx, gameclient.RoomOptions{Name: "e2e", MaxPlayers: 2, Visibility: "private", Mode: "ffa"})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	joined, err := bob.JoinByInvite(ctx, room.InviteCode)
+	if err != nil {
+		t.Fatalf("join by invite: %v", err)
+	}
+	if joined.ID != room.ID {
+		t.Fatalf("joined room %s, want %s", joined.ID, room.ID)
+	}
+
+	// 2. 双方连上 Game Server 后由房主开局
+	aliceConn, err := alice.Connect(ctx, room, gameclient.ConnectOptions{})
+	if err != nil {
+		t.Fatalf("alice connect: %v", err)
+	}
+	defer aliceConn.Close()
+	bobConn, err := bob.Connect(ctx, joined, gameclient.ConnectOptions{})
+	if err != nil {
+		t.Fatalf("bob connect: %v", err)
+	}
+	defer bobConn.Close()
+
+	err = aliceConn.WaitFor(ctx, func(s *pb.S2CSnapshot, _ []*pb.GameEvent) bool {
+		return s != nil && len(s.Players) == 2
+	})
+	if err != nil {
+		t.Fatalf("wait for both players: %v", err)
+	}
+	if _, err := alice.StartMatch(ctx, room.ID); err != nil {
+		t.Fatalf("start match: %v", err)
+	}
+	if _, err := aliceConn.WaitEvent(ctx, pb.GameEvent_GAME_START); err != nil {
+		t.Fatalf("wait for start: %v", err)
+	}
+
+	// 3. alice 击杀 bob，对局结束
+	if err := harness.Hunt(ctx, aliceConn, bob.UID()); err != nil {
+		t.Fatal(err)
+	}
+	over, err := bobConn.WaitEvent(ctx, pb.GameEvent_GAME_OVER)
+	if err != nil {
+		t.Fatalf("wait for game over: %v", err)
+	}
+	if over.TargetUid != alice.UID() {
+		t.Fatalf("winner %d, want alice %d", over.TargetUid, alice.UID())
+	}
+
+	// 4. 战绩经 MQ 落库
+	for _, want := range []struct {
+		uid       int64
+		placement int32
+		winner    bool
+	}{
+		{alice.UID(), 1, true},
+		{bob.UID(), 2, false},
+	} {
+		records, err := h.WaitForHistory(ctx, want.uid, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := records[0]
+		if r.MatchId != room.ID || r.Placement != want.placement || r.IsWinner != want.winner {
+			t.Errorf("uid %d record = %+v, want placement %d winner %v", want.uid, r, want.placement, want.winner)
+		}
+	}
+	// 5. 对局结束后房间停止，由 Match 销毁房间数据
+	for h.Redis.Exists("room:" + room.ID) {
+		select {
+		case <-ctx.Done():
+			t.Fatal("room was not removed after the match ended")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package harness_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"mygame/pkg/gameclient"
+	pb "mygame/proto"
+	matchconfig "mygame/server/match-service/pkg/config"
+)
+
+// 直连 Match Service 的用例使用不与注册用户冲突的 uid
+var nextUID int64 = 900000
+
+func newUID() int64 {
+	nextUID++
+	return nextUID
+}
+
+func createRoom(t *testing.T, ctx context.Context, config *pb.RoomConfig) *pb.CreateRoomResp {
+	t.Helper()
+	resp, err := h.MatchClient.CreateRoom(ctx, &pb.CreateRoomReq{Uid: newUID(), Config: config})
+	if err != nil {
+		t.Fatalf("create room %q: %v", config.RoomName, err)
+	}
+	return resp
+}
+
+func TestListRoomsByName(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ids := map[string]string{}
+	for _, name := range []string{"Alpha Squad", "beta SQUAD", "Gamma 小队"} {
+		ids[name] = createRoom(t, ctx, &pb.RoomConfig{RoomName: name, MaxPlayers: 4}).RoomId
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"squad", []string{"Alpha Squad", "beta SQUAD"}},
+		{"TA SQ", []string{"beta SQUAD"}},
+		{"alpha", []string{"Alpha Squad"}},
+		{"小队", []string{"Gamma 小队"}},
+		{"delta", nil},
+	}
+	for _, tt := range tests {
+		resp, err := h.MatchClient.ListRooms(ctx, &pb.ListRoomsReq{Name: tt.query, Limit: 100})
+		if err != nil {
+			t.Fatalf("list %q: %v", tt.query, err)
+		}
+		var got, want []string
+		for _, r := range resp.Rooms {
+			got = append(got, r.RoomId)
+		}
+		for _, name := range tt.want {
+			want = append(want, ids[name])
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if len(got) != len(want) {
+			t.Errorf("list %q = %v, want %v", tt.query, got, want)
+			continue
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("list %q = %v, want %v", tt.query, got, want)
+				break
+			}
+		}
+	}
+}
+
+// TestConcurrentJoinRespectsCapacity 并发加入同一个房间不能超出 max_players
+func TestConcurrentJoinRespectsCapacity(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	const maxPlayers, joiners = 3, 10
+	room := createRoom(t, ctx, &pb.RoomConfig{RoomName: "capacity", MaxPlayers: maxPlayers})
+
+	uids := make([]int64, joiners)
+	for i := range uids {
+		uids[i] = newUID()
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	joined := 0
+	for _, uid := range uids {
+		wg.Add(1)
+		go func(uid int64) {
+			defer wg.Done()
+			if _, err := h.MatchClient.JoinRoom(ctx, &pb.JoinRoomReq{RoomId: room.RoomId, Uid: uid}); err == nil {
+				mu.Lock()
+				joined++
+				mu.Unlock()
+			}
+		}(uid)
+	}
+	wg.Wait()
+
+	if joined != maxPlayers-1 {
+		t.Fatalf("%d players joined, want %d", joined, maxPlayers-1)
+	}
+	resp, err := h.MatchClient.ListRooms(ctx, &pb.ListRoomsReq{Name: "capacity", Limit: 10})
+	if err != nil || len(resp.Rooms) != 1 {
+		t.Fatalf("list capacity room: %v", err)
+	}
+	if got := resp.Rooms[0].CurrentPlayers; got != maxPlayers {
+		t.Fatalf("current_players = %d, want %d", got, maxPlayers)
+	}
+}
+
+// TestJoinViaFriend 凭 friend_uid 加入私密房间需要双方确为好友
+func TestJoinViaFriend(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	host, err := h.Register(ctx, "friend_host", "password123")
+	if err != nil {
+		t.Fatalf("register host: %v", err)
+	}
+	friend, err := h.Register(ctx, "friend_guest", "password123")
+	if err != nil {
+		t.Fatalf("register guest: %v", err)
+	}
+	if _, err := h.UserClient.SendFriendRequest(ctx, &pb.SendFriendRequestReq{Uid: host.UID(), TargetUid: friend.UID()}); err != nil {
+		t.Fatalf("send friend request: %v", err)
+	}
+	reqs, err := h.UserClient.GetFriendRequests(ctx, &pb.GetFriendRequestsReq{Uid: friend.UID()})
+	if err != nil || len(reqs.Incoming) != 1 {
+		t.Fatalf("get friend requests: %v", err)
+	}
+	if _, err := h.UserClient.RespondFriendRequest(ctx, &pb.RespondFriendRequestReq{Uid: friend.UID(), RequestId: reqs.Incoming[0].RequestId, Accept: true}); err != nil {
+		t.Fatalf("accept friend request: %v", err)
+	}
+
+	room, err := h.MatchClient.CreateRoom(ctx, &pb.CreateRoomReq{
+		Uid:    host.UID(),
+		Config: &pb.RoomConfig{RoomName: "friends only", MaxPlayers: 4, Visibility: pb.RoomConfig_PRIVATE},
+	})
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+
+	// 陌生人声称与房主是好友
+	if _, err := h.MatchClient.JoinRoom(ctx, &pb.JoinRoomReq{RoomId: room.RoomId, Uid: newUID(), FriendUid: host.UID()}); err == nil {
+		t.Fatal("stranger joined a private room via friend_uid")
+	}
+	if _, err := h.MatchClient.JoinRoom(ctx, &pb.JoinRoomReq{RoomId: room.RoomId, Uid: friend.UID(), FriendUid: host.UID()}); err != nil {
+		t.Fatalf("friend join: %v", err)
+	}
+}
+
+// TestObserverReservationExpires 拿到观战 ticket 却不连接的预留到期后释放名额，已连上的不受影响
+func TestObserverReservationExpires(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	watcher, err := h.Register(ctx, "observer_watcher", "password123")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	cfg := &matchconfig.AppConfig.Match
+	defer func(max, reserve int) { cfg.MaxObservers, cfg.ObserverReserveSeconds = max, reserve }(cfg.MaxObservers, cfg.ObserverReserveSeconds)
+	cfg.MaxObservers, cfg.ObserverReserveSeconds = 1, 1
+
+	room := createRoom(t, ctx, &pb.RoomConfig{RoomName: "observer reserve", MaxPlayers: 4})
+	if _, err := h.MatchClient.JoinAsObserver(ctx, &pb.JoinAsObserverReq{RoomId: room.RoomId, Uid: newUID()}); err != nil {
+		t.Fatalf("first observer: %v", err)
+	}
+	late := newUID()
+	if _, err := h.MatchClient.JoinAsObserver(ctx, &pb.JoinAsObserverReq{RoomId: room.RoomId, Uid: late}); err == nil {
+		t.Fatal("observer slots exceeded")
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := h.MatchClient.JoinAsObserver(ctx, &pb.JoinAsObserverReq{RoomId: room.RoomId, Uid: late}); err != nil {
+		t.Fatalf("slot of an unconnected observer was not released: %v", err)
+	}
+
+	// 已连上 Game Server 的观战者一直占用名额
+	watched := createRoom(t, ctx, &pb.RoomConfig{RoomName: "observer connected", MaxPlayers: 4})
+	ticket, err := watcher.Observe(ctx, watched.RoomId)
+	if err != nil {
+		t.Fatalf("observe: %v", err)
+	}
+	conn, err := watcher.Connect(ctx, ticket, gameclient.ConnectOptions{})
+	if err != nil {
+		t.Fatalf("observer connect: %v", err)
+	}
+	defer conn.Close()
+
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := h.MatchClient.JoinAsObserver(ctx, &pb.JoinAsObserverReq{RoomId: watched.RoomId, Uid: newUID()}); err == nil {
+		t.Fatal("slot of a connected observer was released")
+	}
+}
@@ -0,0 +1,8 @@
+module mygame/server/loadtest
+
+go 1.25.6
+
+require (
+	github.com/gorilla/websocket v1.5.1 // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
+)
@@ -0,0 +1,191 @@
+// loadtest 通过真实的 Gateway 与 Game Service 模拟大量并发玩家：
+// N 名虚拟玩家分布在 M 个房间中循环开局，按脚本移动与开火，
+// 统计服务端 tick 耗时分布、每个客户端的快照带宽、端到端输入延迟与各类操作的错误率，
+// 结果以 JSON 写入文件，便于在不同版本之间对比。
+//
+//	go run . -gateway http://127.0.0.1:8080 -game http://127.0.0.1:9003 -players 1000 -rooms 100 -duration 2m
+//
+// 注意：网关的 rate_limit 需要关闭或调大；账号按 <prefix>-<序号> 注册并在多次压测之间复用。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config 压测参数
+type Config struct {
+	Gateway     string        `json:"gateway"`
+	GameServers []string      `json:"game_servers,omitempty"` // 读取 /debug/ticks 的 Game Service 调试地址 (server.debug_addr)
+	Players     int           `json:"players"`
+	Rooms       int           `json:"rooms"`
+	Duration    time.Duration `json:"duration"`
+	Ramp        time.Duration `json:"ramp"`
+	Concurrency int           `json:"concurrency"` // 并发登录数
+	UserPrefix  string        `json:"user_prefix"`
+	Password    string        `json:"-"`
+	Mode        string        `json:"mode"`
+	Pattern     string        `json:"pattern"` // wander / strafe / idle
+	TurnEvery   time.Duration `json:"turn_every"`
+	FireEvery   time.Duration `json:"fire_every"`
+	ChargeHold  time.Duration `json:"charge_hold"`
+}
+
+func main() {
+	var cfg Config
+	var games, out, label string
+	flag.StringVar(&cfg.Gateway, "gateway", "http://127.0.0.1:8080", "gateway base URL")
+	flag.StringVar(&games, "game", "", "comma separated game-service debug listener (server.debug_addr) base URLs for /debug/ticks")
+	flag.IntVar(&cfg.Players, "players", 100, "number of virtual players")
+	flag.IntVar(&cfg.Rooms, "rooms", 10, "number of concurrent rooms")
+	flag.DurationVar(&cfg.Duration, "duration", time.Minute, "measurement duration")
+	flag.DurationVar(&cfg.Ramp, "ramp", 10*time.Second, "spread room start-up over this period")
+	flag.IntVar(&cfg.Concurrency, "concurrency", 32, "concurrent logins")
+	flag.StringVar(&cfg.UserPrefix, "prefix", "loadtest", "username prefix")
+	flag.StringVar(&cfg.Password, "password", "loadtest-pass", "password for all virtual players")
+	flag.StringVar(&cfg.Mode, "mode", "ffa", "game mode (ffa / tdm)")
+	flag.StringVar(&cfg.Pattern, "pattern", "wander", "movement pattern (wander / strafe / idle)")
+	flag.DurationVar(&cfg.TurnEvery, "turn-every", time.Second, "change movement direction every")
+	flag.DurationVar(&cfg.FireEvery, "fire-every", 2*time.Second, "average interval between shots, 0 disables firing")
+	flag.DurationVar(&cfg.ChargeHold, "charge", 100*time.Millisecond, "charge duration of each shot")
+	flag.StringVar(&out, "out", "loadtest-result.json", "result file")
+	flag.StringVar(&label, "label", "", "build label recorded in the result")
+	flag.Parse()
+
+	cfg.Gateway = strings.TrimSuffix(cfg.Gateway, "/")
+	for _, g := range strings.Split(games, ",") {
+		if g = strings.TrimSuffix(strings.TrimSpace(g), "/"); g != "" {
+			cfg.GameServers = append(cfg.GameServers, g)
+		}
+	}
+	if cfg.Rooms <= 0 || cfg.Players < 2*cfg.Rooms {
+		log.Fatalf("need at least 2 players per room (players=%d rooms=%d)", cfg.Players, cfg.Rooms)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := run(ctx, cfg)
+	if err != nil {
+		log.Fatalf("loadtest failed: %v", err)
+	}
+	report.Label = label
+
+	data, _ := json.MarshalIndent(report, "", "  ")
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		log.Fatalf("write %s failed: %v", out, err)
+	}
+	printSummary(report)
+	log.Printf("Result written to %s", out)
+}
+
+func run(ctx context.Context, cfg Config) (*Report, error) {
+	metrics := NewMetrics()
+
+	// 1. 并发登录全部虚拟玩家
+	log.Printf("Logging in %d players...", cfg.Players)
+	players := make([]*Player, cfg.Players)
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i := range players {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			loginCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+			defer cancel()
+			p, err := login(loginCtx, cfg, metrics, fmt.Sprintf("%s-%d", cfg.UserPrefix, i))
+			if err == nil {
+				players[i] = p
+			}
+		}(i)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	// 2. 按房间分组，登录失败的玩家不参与
+	groups := make([][]*Player, cfg.Rooms)
+	for i, p := range players {
+		if p != nil {
+			groups[i%cfg.Rooms] = append(groups[i%cfg.Rooms], p)
+		}
+	}
+
+	// 3. 清空服务端 tick 统计窗口后开始计时
+	for _, g := range cfg.GameServers {
+		if _, err := fetchTicks(ctx, g, true); err != nil {
+			log.Printf("Reset tick stats of %s failed: %v", g, err)
+		}
+	}
+	report := &Report{StartedAt: time.Now(), Config: cfg}
+	runCtx, cancel := context.WithTimeout(ctx, cfg.Duration)
+	defer cancel()
+
+	log.Printf("Running %d rooms for %v...", cfg.Rooms, cfg.Duration)
+	for i, group := range groups {
+		if len(group) < 2 {
+			log.Printf("Room %d skipped: only %d players logged in", i, len(group))
+			continue
+		}
+		wg.Add(1)
+		go func(i int, group []*Player) {
+			defer wg.Done()
+			// 在 ramp 内均匀错开各房间的开局时间
+			if cfg.Ramp > 0 {
+				select {
+				case <-runCtx.Done():
+					return
+				case <-time.After(cfg.Ramp * time.Duration(i) / time.Duration(cfg.Rooms)):
+				}
+			}
+			runRoom(runCtx, cfg, metrics, group)
+		}(i, group)
+	}
+	<-runCtx.Done()
+
+	// 4. 在断开连接前读取服务端统计，避免统计到收尾阶段
+	report.DurationSeconds = time.Since(report.StartedAt).Seconds()
+	report.ServerTicks = make(map[string]*TickSummary)
+	for _, g := range cfg.GameServers {
+		fetchCtx, cancelFetch := context.WithTimeout(context.Background(), 5*time.Second)
+		s, err := fetchTicks(fetchCtx, g, false)
+		cancelFetch()
+		if err != nil {
+			log.Printf("Fetch tick stats of %s failed: %v", g, err)
+			continue
+		}
+		report.ServerTicks[g] = s
+	}
+	wg.Wait()
+
+	metrics.Fill(report)
+	return report, nil
+}
+
+func printSummary(r *Report) {
+	fmt.Printf("duration %.0fs, %d matches, error rate %.4f%%\n", r.DurationSeconds, r.Matches, r.ErrorRate*100)
+	for g, s := range r.ServerTicks {
+		fmt.Printf("%s: %d rooms, %d players, tick p50 %.3fms p99 %.3fms max %.3fms, %d overruns / %d ticks\n",
+			g, s.Rooms, s.Players, s.DurationMs.P50, s.DurationMs.P99, s.DurationMs.Max, s.Overruns, s.Ticks)
+	}
+	fmt.Printf("snapshot bandwidth per client: p50 %.0f B/s, p99 %.0f B/s (%.1f snapshots/s)\n",
+		r.SnapshotBytesPerSec.P50, r.SnapshotBytesPerSec.P99, r.SnapshotsPerSec.Mean)
+	fmt.Printf("input latency: p50 %.1fms p90 %.1fms p99 %.1fms (%d samples, %d timeouts)\n",
+		r.InputLatencyMs.P50, r.InputLatencyMs.P90, r.InputLatencyMs.P99, r.InputLatencyMs.Count, r.InputTimeouts)
+	for name, op := range r.Operations {
+		if op.Failed > 0 {
+			fmt.Printf("  %s: %d ok, %d failed, e.g. %s\n", name, op.OK, op.Failed, op.Errors[0])
+		}
+	}
+}
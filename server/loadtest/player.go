@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"mygame/pkg/gameclient"
+	pb "mygame/proto"
+)
+
+const (
+	inputTimeout = 2 * time.Second  // 超过该时间快照仍未体现蓄力状态，视为输入丢失
+	startTimeout = 30 * time.Second // 等待开局（含倒计时）
+)
+
+// Player 一个虚拟玩家
+type Player struct {
+	Name   string
+	Client *gameclient.Client
+}
+
+// login 登录，账号不存在时先注册；账号在多次压测之间复用
+func login(ctx context.Context, cfg Config, metrics *Metrics, name string) (*Player, error) {
+	c := gameclient.New(cfg.Gateway)
+	err := c.Login(ctx, name, cfg.Password)
+	var apiErr *gameclient.APIError
+	if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized {
+		_, err = c.Register(ctx, name, cfg.Password)
+		metrics.Op("register", err)
+		if err != nil {
+			return nil, err
+		}
+		err = c.Login(ctx, name, cfg.Password)
+	}
+	metrics.Op("login", err)
+	if err != nil {
+		return nil, err
+	}
+	return &Player{Name: name, Client: c}, nil
+}
+
+// runRoom 同一组玩家循环开局直到 ctx 结束：建房 -> 入房 -> 连接 -> 开局 -> 按脚本操作直到 GAME_OVER
+func runRoom(ctx context.Context, cfg Config, metrics *Metrics, players []*Player) {
+	for ctx.Err() == nil {
+		if err := playMatch(ctx, cfg, metrics, players); err != nil && ctx.Err() == nil {
+			// 失败后稍等再开下一局，避免错误刷屏
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+func playMatch(ctx context.Context, cfg Config, metrics *Metrics, players []*Player) (err error) {
+	host := players[0]
+	opCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// 1. 房主建房，其余玩家入房
+	room, err := host.Client.CreateRoom(opCtx, gameclient.RoomOptions{
+		Name:       "loadtest",
+		MapID:      1,
+		MaxPlayers: int32(len(players)),
+		Visibility: "private",
+		Mode:       cfg.Mode,
+	})
+	metrics.Op("create_room", err)
+	if err != nil {
+		return err
+	}
+	rooms := []*gameclient.Room{room}
+	defer func() {
+		// 开局前失败时尽量退出房间，释放 Game Service 上预分配的房间
+		if err != nil {
+			for i := range rooms {
+				players[i].Client.LeaveRoom(context.Background(), room.ID)
+			}
+		}
+	}()
+	for _, p := range players[1:] {
+		var r *gameclient.Room
+		r, err = p.Client.JoinByInvite(opCtx, room.InviteCode)
+		metrics.Op("join_room", err)
+		if err != nil {
+			return err
+		}
+		rooms = append(rooms, r)
+	}
+
+	// 2. 全员连接
+	agents := make([]*agent, 0, len(players))
+	defer func() {
+		for _, a := range agents {
+			a.close(metrics)
+		}
+	}()
+	for i, p := range players {
+		a := newAgent(cfg, metrics)
+		var conn *gameclient.Conn
+		conn, err = p.Client.Connect(opCtx, rooms[i], gameclient.ConnectOptions{Handler: a.handler()})
+		metrics.Op("connect", err)
+		if err != nil {
+			return err
+		}
+		a.start(conn)
+		agents = append(agents, a)
+	}
+
+	// 3. 开局
+	_, err = host.Client.StartMatch(opCtx, room.ID)
+	metrics.Op("start_match", err)
+	if err != nil {
+		return err
+	}
+	startCtx, cancelStart := context.WithTimeout(ctx, startTimeout)
+	_, err = agents[0].conn.WaitEvent(startCtx, pb.GameEvent_GAME_START)
+	cancelStart()
+	metrics.Op("game_start", err)
+	if err != nil {
+		return err
+	}
+
+	// 4. 所有玩家按脚本操作，直到对局结束或压测结束
+	var wg sync.WaitGroup
+	for _, a := range agents {
+		wg.Add(1)
+		go func(a *agent) {
+			defer wg.Done()
+			a.play(ctx)
+		}(a)
+	}
+	wg.Wait()
+	if agents[0].gameOver() {
+		metrics.Match()
+	}
+	return nil
+}
+
+// pendingInput 等待快照确认的蓄力/松开操作
+type pendingInput struct {
+	charging bool
+	sentAt   time.Time
+}
+
+// agent 一名玩家在一局中的脚本化操作
+type agent struct {
+	cfg     Config
+	metrics *Metrics
+	rnd     *rand.Rand
+
+	conn        *gameclient.Conn
+	connectedAt time.Time
+
+	mu       sync.Mutex
+	pending  *pendingInput
+	over     bool
+	closed   bool
+	overChan chan struct{}
+}
+
+func newAgent(cfg Config, metrics *Metrics) *agent {
+	return &agent{
+		cfg:      cfg,
+		metrics:  metrics,
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		overChan: make(chan struct{}),
+	}
+}
+
+func (a *agent) start(conn *gameclient.Conn) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.conn = conn
+	a.connectedAt = time.Now()
+}
+
+// handler 在读协程中确认输入延迟并记录对局结束
+func (a *agent) handler() gameclient.Handler {
+	return gameclient.Handler{
+		OnSnapshot: func(snapshot *pb.S2CSnapshot) {
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if a.pending == nil || a.conn == nil {
+				return
+			}
+			for _, p := range snapshot.Players {
+				if p.Uid == a.conn.UID() && p.IsCharging == a.pending.charging {
+					a.metrics.Latency(time.Since(a.pending.sentAt))
+					a.pending = nil
+					return
+				}
+			}
+		},
+		OnEvent: func(event *pb.GameEvent) {
+			if event.Type != pb.GameEvent_GAME_OVER {
+				return
+			}
+			a.mu.Lock()
+			defer a.mu.Unlock()
+			if !a.over {
+				a.over = true
+				close(a.overChan)
+			}
+		},
+		OnClose: func(err error) {
+			a.mu.Lock()
+			closed := a.closed
+			a.mu.Unlock()
+			// 对局结束后服务端断开属于正常情况
+			if !closed && !a.gameOver() {
+				a.metrics.Op("disconnect", fmt.Errorf("connection lost: %v", err))
+			}
+		},
+	}
+}
+
+func (a *agent) gameOver() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.over
+}
+
+// play 每个 tick 发送一次移动，按 fire_every 周期性蓄力开火
+func (a *agent) play(ctx context.Context) {
+	ticker := time.NewTicker(a.conn.Clock().TickDuration())
+	defer ticker.Stop()
+
+	dx, dy := a.direction(0)
+	nextTurn := time.Now().Add(a.cfg.TurnEvery)
+	nextFire := time.Now().Add(a.jitter(a.cfg.FireEvery))
+	var releaseAt time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-a.overChan:
+			return
+		case <-a.conn.Done():
+			return
+		case now := <-ticker.C:
+			a.checkTimeout(now)
+
+			// 1. 移动
+			if a.cfg.Pattern != "idle" {
+				if now.After(nextTurn) {
+					dx, dy = a.direction(dx)
+					nextTurn = now.Add(a.cfg.TurnEvery)
+				}
+				a.metrics.Op("send", a.conn.SendMove(dx, dy))
+			}
+
+			// 2. 开火：死亡后服务端忽略蓄力，不再发送
+			if a.cfg.FireEvery <= 0 {
+				continue
+			}
+			if self := a.conn.Self(); self == nil || self.IsDead {
+				continue
+			}
+			if !releaseAt.IsZero() && now.After(releaseAt) {
+				a.send(false, a.conn.ReleaseCharge())
+				releaseAt = time.Time{}
+				nextFire = now.Add(a.jitter(a.cfg.FireEvery))
+			} else if releaseAt.IsZero() && now.After(nextFire) {
+				a.send(true, a.conn.StartCharge(int32(a.rnd.Intn(4))))
+				releaseAt = now.Add(a.cfg.ChargeHold)
+			}
+		}
+	}
+}
+
+// send 记录一次蓄力状态切换，等待快照确认以测量延迟
+func (a *agent) send(charging bool, err error) {
+	a.metrics.Op("send", err)
+	if err != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending != nil {
+		// 上一个操作尚未确认就被新操作覆盖，按超时计
+		a.metrics.InputTimeout()
+	}
+	a.pending = &pendingInput{charging: charging, sentAt: time.Now()}
+}
+
+func (a *agent) checkTimeout(now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending != nil && now.Sub(a.pending.sentAt) > inputTimeout {
+		a.metrics.InputTimeout()
+		a.pending = nil
+	}
+}
+
+// direction 下一个移动方向：wander 随机八方向，strafe 左右往返
+func (a *agent) direction(prevDx float32) (float32, float32) {
+	switch a.cfg.Pattern {
+	case "strafe":
+		if prevDx > 0 {
+			return -1, 0
+		}
+		return 1, 0
+	default:
+		return float32(a.rnd.Intn(3) - 1), float32(a.rnd.Intn(3) - 1)
+	}
+}
+
+// jitter 在 d 的 50%~150% 之间随机，避免同一房间的玩家同时开火
+func (a *agent) jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
+	}
+	return d/2 + time.Duration(a.rnd.Int63n(int64(d)))
+}
+
+func (a *agent) close(metrics *Metrics) {
+	if a.conn == nil {
+		return
+	}
+	a.mu.Lock()
+	a.closed = true
+	a.mu.Unlock()
+
+	stats := a.conn.Stats()
+	metrics.Connection(stats.Snapshots, stats.SnapshotBytes, time.Since(a.connectedAt))
+	a.conn.Close()
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Report 一次压测的结果，以 JSON 写入 -out，便于在不同版本之间对比
+type Report struct {
+	Label           string    `json:"label,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Config          Config    `json:"config"`
+
+	Matches int64 `json:"matches"` // 打完的对局数
+
+	// 服务端 tick 统计，key 为 Game Service 地址
+	ServerTicks map[string]*TickSummary `json:"server_ticks,omitempty"`
+
+	// 每个客户端每局的快照带宽
+	SnapshotBytesPerSec Percentiles `json:"snapshot_bytes_per_sec"`
+	SnapshotsPerSec     Percentiles `json:"snapshots_per_sec"`
+
+	// 端到端输入延迟：发出蓄力/松开到快照中 is_charging 随之变化
+	InputLatencyMs Percentiles `json:"input_latency_ms"`
+	InputTimeouts  int64       `json:"input_timeouts"`
+
+	Operations map[string]*OpStats `json:"operations"`
+	ErrorRate  float64             `json:"error_rate"`
+}
+
+// Percentiles 样本分布
+type Percentiles struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+}
+
+// TickSummary 与 game-service /debug/ticks 的响应一致
+type TickSummary struct {
+	Rooms         int     `json:"rooms"`
+	Players       int     `json:"players"`
+	Ticks         int64   `json:"ticks"`
+	Overruns      int64   `json:"overruns"`
+	Samples       int     `json:"samples"`
+	WindowSeconds float64 `json:"window_seconds"`
+	DurationMs    struct {
+		Mean float64 `json:"mean"`
+		P50  float64 `json:"p50"`
+		P90  float64 `json:"p90"`
+		P99  float64 `json:"p99"`
+		Max  float64 `json:"max"`
+	} `json:"duration_ms"`
+	IntervalMs struct {
+		Mean float64 `json:"mean"`
+		P50  float64 `json:"p50"`
+		P90  float64 `json:"p90"`
+		P99  float64 `json:"p99"`
+		Max  float64 `json:"max"`
+	} `json:"interval_ms"`
+}
+
+// OpStats 某类操作的成功/失败次数，附带少量错误样例
+type OpStats struct {
+	OK     int64    `json:"ok"`
+	Failed int64    `json:"failed"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+const maxErrorSamples = 5
+
+// Metrics 压测期间各虚拟玩家共享的统计
+type Metrics struct {
+	mu            sync.Mutex
+	ops           map[string]*OpStats
+	latencies     []float64
+	inputTimeouts int64
+	bandwidth     []float64
+	snapshotRate  []float64
+	matches       int64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{ops: make(map[string]*OpStats)}
+}
+
+// Op 记录一次操作的结果
+func (m *Metrics) Op(name string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	op, ok := m.ops[name]
+	if !ok {
+		op = &OpStats{}
+		m.ops[name] = op
+	}
+	if err == nil {
+		op.OK++
+		return
+	}
+	op.Failed++
+	if len(op.Errors) < maxErrorSamples {
+		op.Errors = append(op.Errors, err.Error())
+	}
+}
+
+func (m *Metrics) Latency(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.latencies = append(m.latencies, float64(d)/float64(time.Millisecond))
+}
+
+func (m *Metrics) InputTimeout() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inputTimeouts++
+}
+
+// Connection 一条连接在一局中的快照流量
+func (m *Metrics) Connection(snapshots, snapshotBytes int64, elapsed time.Duration) {
+	if elapsed < time.Second {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bandwidth = append(m.bandwidth, float64(snapshotBytes)/elapsed.Seconds())
+	m.snapshotRate = append(m.snapshotRate, float64(snapshots)/elapsed.Seconds())
+}
+
+func (m *Metrics) Match() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matches++
+}
+
+// Fill 把统计结果写入报告
+func (m *Metrics) Fill(r *Report) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r.Matches = m.matches
+	r.InputLatencyMs = percentiles(m.latencies)
+	r.InputTimeouts = m.inputTimeouts
+	r.SnapshotBytesPerSec = percentiles(m.bandwidth)
+	r.SnapshotsPerSec = percentiles(m.snapshotRate)
+	r.Operations = m.ops
+
+	var total, failed int64
+	for _, op := range m.ops {
+		total += op.OK + op.Failed
+		failed += op.Failed
+	}
+	if total > 0 {
+		r.ErrorRate = float64(failed) / float64(total)
+	}
+}
+
+func percentiles(samples []float64) Percentiles {
+	if len(samples) == 0 {
+		return Percentiles{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	at := func(q float64) float64 { return sorted[int(q*float64(len(sorted)-1))] }
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return Percentiles{
+		Count: len(sorted),
+		Mean:  sum / float64(len(sorted)),
+		P50:   at(0.50),
+		P90:   at(0.90),
+		P99:   at(0.99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// fetchTicks 读取 Game Service 的 tick 统计，reset 为 true 时同时清空统计窗口
+func fetchTicks(ctx context.Context, base string, reset bool) (*TickSummary, error) {
+	url := base + "/debug/ticks"
+	if reset {
+		url += "?reset=1"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	var s TickSummary
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,26 @@
+// Package app Match Service 的初始化，供 main 与进程内集成测试共用
+package app
+
+import (
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/internal/handler"
+	"mygame/server/match-service/internal/rpc"
+
+	"google.golang.org/grpc"
+)
+
+// Init 按 config.AppConfig 初始化 Redis 与 User Service 客户端，并启动机器人补充与房间回收任务
+func Init() {
+	dao.InitRedis()
+	rpc.InitUserClient()
+	go handler.StartBotBackfill()
+	go handler.StartClosedRoomCleanup()
+}
+
+// NewServer 创建注册了 MatchService 的 gRPC 服务
+func NewServer() *grpc.Server {
+	s := grpc.NewServer()
+	pb.RegisterMatchServiceServer(s, &handler.MatchService{})
+	return s
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 房间内的机器人
+//
+//	room:{id}:bots  Set: 机器人 uid（负数，房间内唯一）
+//	room:{id}       Hash 字段 bot_seq 为已分配的机器人序号，bots 为当前机器人数
+func botsKey(roomID string) string { return KeyRoomPrefix + roomID + ":bots" }
+
+// 房间 Hash 仍存在时才写入，避免与销毁房间交错时重新创建一个没有 TTL 的房间
+// KEYS: 房间 Hash；ARGV: 字段
+// nextBotSeqScript 返回递增后的序号，claimBackfillScript 返回 HSETNX 的结果；房间不存在时返回 -1
+var nextBotSeqScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return -1
+end
+return redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+`)
+
+var claimBackfillScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return -1
+end
+return redis.call('HSETNX', KEYS[1], ARGV[1], 1)
+`)
+
+// NextBotUID 为房间分配一个新的机器人 uid：-1, -2, ...
+func NextBotUID(ctx context.Context, roomID string) (int64, error) {
+	seq, err := nextBotSeqScript.Run(ctx, RDB, []string{KeyRoomPrefix + roomID}, "bot_seq").Int64()
+	if err != nil {
+		return 0, err
+	}
+	if seq < 0 {
+		return 0, ErrRoomNotFound
+	}
+	return -seq, nil
+}
+
+// AddBot 为机器人占座并记录其队伍，座位不足时返回 ErrRoomFull，返回加入后的机器人数
+func AddBot(ctx context.Context, roomID string, uid int64, team int32) (int64, error) {
+	count, err := reserveSeats(ctx, roomID, botsKey(roomID), []int64{uid})
+	if err != nil {
+		return 0, err
+	}
+	pipe := RDB.Pipeline()
+	pipe.HSet(ctx, teamsKey(roomID), strconv.FormatInt(uid, 10), team)
+	pipe.Expire(ctx, teamsKey(roomID), roomTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// RemoveBot 移除机器人，返回移除后的机器人数
+func RemoveBot(ctx context.Context, roomID string, uid int64) (int64, error) {
+	pipe := RDB.Pipeline()
+	pipe.SRem(ctx, botsKey(roomID), uid)
+	pipe.HDel(ctx, teamsKey(roomID), strconv.FormatInt(uid, 10))
+	countCmd := pipe.SCard(ctx, botsKey(roomID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return countCmd.Val(), nil
+}
+
+// GetBots 获取房间内全部机器人
+func GetBots(ctx context.Context, roomID string) ([]int64, error) {
+	vals, err := RDB.SMembers(ctx, botsKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseUIDs(vals), nil
+}
+
+// ClaimBackfill 标记房间已补充过机器人，只有第一次调用返回 true，避免多个 Match 实例重复补充
+// 房间已被销毁时返回 false
+func ClaimBackfill(ctx context.Context, roomID string) (bool, error) {
+	n, err := claimBackfillScript.Run(ctx, RDB, []string{KeyRoomPrefix + roomID}, "bot_backfilled").Int64()
+	return n == 1, err
+}
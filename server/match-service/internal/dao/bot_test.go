@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNextBotUID(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", nil)
+	for _, want := range []int64{-1, -2, -3} {
+		if uid, err := NextBotUID(ctx, "r1"); err != nil || uid != want {
+			t.Fatalf("NextBotUID = %d, %v, want %d", uid, err, want)
+		}
+	}
+
+	// 已销毁的房间不会被重新创建
+	if _, err := NextBotUID(ctx, "gone"); !errors.Is(err, ErrRoomNotFound) {
+		t.Fatalf("NextBotUID of removed room = %v, want ErrRoomNotFound", err)
+	}
+	if mr.Exists(KeyRoomPrefix + "gone") {
+		t.Fatal("removed room recreated")
+	}
+}
+
+func TestAddBot(t *testing.T) {
+	tests := []struct {
+		name    string
+		seated  []int64
+		bots    []int64
+		wantErr error
+		want    int64
+	}{
+		{name: "empty room", bots: []int64{-1, -2}, want: 2},
+		{name: "bots and players share seats", seated: []int64{1, 2, 3}, bots: []int64{-1}, want: 1},
+		{name: "room full", seated: []int64{1, 2, 3}, bots: []int64{-1, -2}, wantErr: ErrRoomFull, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setup(t)
+			saveTestRoom(t, ctx, "r1", nil)
+			if len(tt.seated) > 0 {
+				if _, err := AddMembers(ctx, "r1", tt.seated); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var err error
+			for _, uid := range tt.bots {
+				if _, err = AddBot(ctx, "r1", uid, 2); err != nil {
+					break
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddBot = %v, want %v", err, tt.wantErr)
+			}
+			if bots, _ := GetBots(ctx, "r1"); int64(len(bots)) != tt.want {
+				t.Fatalf("bots = %v, want %d", bots, tt.want)
+			}
+			// 机器人占用的座位对真人同样生效
+			if _, err := ReserveSeats(ctx, "r1", []int64{9}); errors.Is(err, ErrRoomFull) != (len(tt.seated)+int(tt.want) >= 4) {
+				t.Fatalf("reserve after bots = %v", err)
+			}
+		})
+	}
+}
+
+func TestRemoveBot(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", nil)
+	for _, uid := range []int64{-1, -2} {
+		if _, err := AddBot(ctx, "r1", uid, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n, err := RemoveBot(ctx, "r1", -1); err != nil || n != 1 {
+		t.Fatalf("RemoveBot = %d, %v, want 1", n, err)
+	}
+	teams, err := GetTeams(ctx, "r1")
+	if err != nil || len(teams) != 1 || teams[-2] != 1 {
+		t.Fatalf("teams = %v, %v, want only bot -2", teams, err)
+	}
+}
+
+func TestClaimBackfill(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", nil)
+	for _, want := range []bool{true, false} {
+		if ok, err := ClaimBackfill(ctx, "r1"); err != nil || ok != want {
+			t.Fatalf("ClaimBackfill = %v, %v, want %v", ok, err, want)
+		}
+	}
+	if ok, err := ClaimBackfill(ctx, "gone"); err != nil || ok || mr.Exists(KeyRoomPrefix+"gone") {
+		t.Fatalf("ClaimBackfill of removed room = %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,88 @@
+package dao
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 房间变化通过 Redis pub/sub 广播给所有 Match Service 实例，用于大厅实时推送
+//
+//	rooms:events  Channel: {"type": "...", "room_id": "..."}
+const KeyRoomEvents = "rooms:events"
+
+// 房间事件类型
+const (
+	RoomEventAdded    = "added"
+	RoomEventUpdated  = "updated"  // 房间信息或成员变化
+	RoomEventUnlisted = "unlisted" // 从公开列表中移除（改为私有）
+	RoomEventRemoved  = "removed"
+)
+
+type RoomEvent struct {
+	Type   string `json:"type"`
+	RoomID string `json:"room_id"`
+}
+
+// publishRoomEvent 广播房间变化，失败只记录日志，不影响写入本身
+func publishRoomEvent(ctx context.Context, eventType, roomID string) {
+	payload, _ := json.Marshal(RoomEvent{Type: eventType, RoomID: roomID})
+	if err := RDB.Publish(ctx, KeyRoomEvents, payload).Err(); err != nil {
+		log.Printf("Publish room event %s/%s failed: %v", eventType, roomID, err)
+	}
+}
+
+// SubscribeRoomEvents 订阅房间变化，事件在返回的 channel 中按发布顺序到达
+func SubscribeRoomEvents(ctx context.Context) <-chan RoomEvent {
+	ps := RDB.Subscribe(ctx, KeyRoomEvents)
+	out := make(chan RoomEvent, 256)
+	go func() {
+		defer close(out)
+		defer ps.Close()
+		for msg := range ps.Channel() {
+			var ev RoomEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &ev); err != nil {
+				log.Printf("Invalid room event %q: %v", msg.Payload, err)
+				continue
+			}
+			out <- ev
+		}
+	}()
+	return out
+}
+
+// IsListed 房间是否在公开列表中
+func IsListed(ctx context.Context, roomID string) (bool, error) {
+	return RDB.SIsMember(ctx, KeyRoomList, roomID).Result()
+}
+
+// GetListedRooms 全部公开房间详情（大厅订阅时的快照）
+func GetListedRooms(ctx context.Context) ([]map[string]string, error) {
+	ids, err := RDB.SMembers(ctx, KeyRoomList).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := RDB.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, 0, len(ids))
+	for _, id := range ids {
+		cmds = append(cmds, pipe.HGetAll(ctx, KeyRoomPrefix+id))
+	}
+	if len(ids) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	rooms := make([]map[string]string, 0, len(ids))
+	for i, cmd := range cmds {
+		if r := cmd.Val(); len(r) > 0 {
+			// Hash 中不存 room_id，补上以便转换为 RoomInfo
+			r["room_id"] = ids[i]
+			rooms = append(rooms, r)
+		}
+	}
+	return rooms, nil
+}
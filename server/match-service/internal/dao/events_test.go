@@ -0,0 +1,43 @@
+package dao
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSubscribeRoomEvents(t *testing.T) {
+	ctx := setup(t)
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := SubscribeRoomEvents(subCtx)
+	// 订阅在后台建立，等到 Redis 中出现订阅者后再写入
+	for deadline := time.Now().Add(time.Second); mr.PubSubNumSub(KeyRoomEvents)[KeyRoomEvents] == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription not established")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	saveTestRoom(t, ctx, "r1", nil)
+	if _, err := AddMember(ctx, "r1", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetRoomListed(ctx, "r1", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveRoom(ctx, "r1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{RoomEventAdded, RoomEventUpdated, RoomEventUnlisted, RoomEventRemoved} {
+		select {
+		case ev := <-events:
+			if ev.Type != want || ev.RoomID != "r1" {
+				t.Fatalf("event = %+v, want %s of r1", ev, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
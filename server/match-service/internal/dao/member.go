@@ -0,0 +1,237 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 房间成员与封禁名单
+//
+//	room:{id}:members  Set: 已加入房间的 uid
+//	room:{id}:bans     Set: 被房主封禁、不能再加入的 uid
+//	room:{id}:tickets  Hash: uid -> 该玩家连接 Game Server 的 ticket
+//	room:{id}:teams    Hash: uid -> 团队模式下的队伍编号
+func membersKey(roomID string) string { return KeyRoomPrefix + roomID + ":members" }
+func bansKey(roomID string) string    { return KeyRoomPrefix + roomID + ":bans" }
+func ticketsKey(roomID string) string { return KeyRoomPrefix + roomID + ":tickets" }
+func teamsKey(roomID string) string   { return KeyRoomPrefix + roomID + ":teams" }
+
+// AddMember 加入成员，返回加入后的成员数
+func AddMember(ctx context.Context, roomID string, uid int64) (int64, error) {
+	return AddMembers(ctx, roomID, []int64{uid})
+}
+
+// AddMembers 一次加入多名成员（整队入座），返回加入后的成员数
+func AddMembers(ctx context.Context, roomID string, uids []int64) (int64, error) {
+	members := make([]interface{}, 0, len(uids))
+	for _, uid := range uids {
+		members = append(members, uid)
+	}
+	pipe := RDB.Pipeline()
+	pipe.SAdd(ctx, membersKey(roomID), members...)
+	pipe.Expire(ctx, membersKey(roomID), roomTTL)
+	countCmd := pipe.SCard(ctx, membersKey(roomID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	publishRoomEvent(ctx, RoomEventUpdated, roomID)
+	return countCmd.Val(), nil
+}
+
+// ErrRoomFull 剩余座位不足
+var ErrRoomFull = errors.New("room is full")
+
+// ErrRoomNotFound 房间不存在或已被销毁
+var ErrRoomNotFound = errors.New("room not found")
+
+// ErrRoomNotWaiting 房间已开局或已销毁，不能再入座
+var ErrRoomNotWaiting = errors.New("room is not waiting for players")
+
+// reserveSeatsScript 在同一个脚本中校验容量并占用座位，避免并发加入时超出 max_players
+// KEYS: 房间 Hash、成员 Set、机器人 Set、占座写入的 Set（成员或机器人）
+// ARGV: TTL 秒数, uid...；已占座的 uid 不重复计数
+// 返回写入后目标 Set 的大小，-1 表示座位不足，-2 表示房间不在等待中
+var reserveSeatsScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'status') ~= 'WAITING' then
+  return -2
+end
+local max = tonumber(redis.call('HGET', KEYS[1], 'max_players')) or 0
+local taken = redis.call('SCARD', KEYS[2]) + redis.call('SCARD', KEYS[3])
+local new = 0
+for i = 2, #ARGV do
+  if redis.call('SISMEMBER', KEYS[4], ARGV[i]) == 0 then
+    new = new + 1
+  end
+end
+if taken + new > max then
+  return -1
+end
+for i = 2, #ARGV do
+  redis.call('SADD', KEYS[4], ARGV[i])
+end
+redis.call('EXPIRE', KEYS[4], ARGV[1])
+return redis.call('SCARD', KEYS[4])
+`)
+
+// reserveSeats 按 max_players 原子地占座，成功时返回 target 中的人数
+func reserveSeats(ctx context.Context, roomID, target string, uids []int64) (int64, error) {
+	args := make([]interface{}, 0, len(uids)+1)
+	args = append(args, int64(roomTTL/time.Second))
+	for _, uid := range uids {
+		args = append(args, uid)
+	}
+	keys := []string{KeyRoomPrefix + roomID, membersKey(roomID), botsKey(roomID), target}
+	n, err := reserveSeatsScript.Run(ctx, RDB, keys, args...).Int64()
+	if err != nil {
+		return 0, err
+	}
+	switch n {
+	case -1:
+		return 0, ErrRoomFull
+	case -2:
+		return 0, ErrRoomNotWaiting
+	}
+	return n, nil
+}
+
+// ReserveSeats 为加入等待中房间的玩家（整队）占座，座位不足时一个都不加入，返回加入后的成员数
+// 只占用成员 Set，随后由 AddMembers 完成入座并发布房间事件
+func ReserveSeats(ctx context.Context, roomID string, uids []int64) (int64, error) {
+	return reserveSeats(ctx, roomID, membersKey(roomID), uids)
+}
+
+// setMaxPlayersScript 等待中的房间修改容量，不能小于已占用的座位（成员与机器人）
+// KEYS: 房间 Hash、成员 Set、机器人 Set；ARGV: 新容量
+// 返回 1 表示已修改，-1 表示小于已占用座位，-2 表示房间不在等待中
+var setMaxPlayersScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'status') ~= 'WAITING' then
+  return -2
+end
+if redis.call('SCARD', KEYS[2]) + redis.call('SCARD', KEYS[3]) > tonumber(ARGV[1]) then
+  return -1
+end
+redis.call('HSET', KEYS[1], 'max_players', ARGV[1])
+return 1
+`)
+
+// ErrBelowOccupancy 新容量小于房间内已占用的座位
+var ErrBelowOccupancy = errors.New("max_players is below the number of seated players")
+
+// SetMaxPlayers 原子地校验并修改容量，避免与并发加入交错后超员
+func SetMaxPlayers(ctx context.Context, roomID string, maxPlayers int) error {
+	keys := []string{KeyRoomPrefix + roomID, membersKey(roomID), botsKey(roomID)}
+	n, err := setMaxPlayersScript.Run(ctx, RDB, keys, maxPlayers).Int64()
+	if err != nil {
+		return err
+	}
+	switch n {
+	case -1:
+		return ErrBelowOccupancy
+	case -2:
+		return ErrRoomNotWaiting
+	}
+	return nil
+}
+
+// RemoveMember 移除成员，返回移除后的成员数
+func RemoveMember(ctx context.Context, roomID string, uid int64) (int64, error) {
+	return RemoveMembers(ctx, roomID, []int64{uid})
+}
+
+// RemoveMembers 一次移除多名成员及其 ticket 与队伍，返回移除后的成员数
+func RemoveMembers(ctx context.Context, roomID string, uids []int64) (int64, error) {
+	pipe := RDB.Pipeline()
+	for _, uid := range uids {
+		pipe.SRem(ctx, membersKey(roomID), uid)
+		pipe.HDel(ctx, ticketsKey(roomID), strconv.FormatInt(uid, 10))
+		pipe.HDel(ctx, teamsKey(roomID), strconv.FormatInt(uid, 10))
+	}
+	countCmd := pipe.SCard(ctx, membersKey(roomID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	publishRoomEvent(ctx, RoomEventUpdated, roomID)
+	return countCmd.Val(), nil
+}
+
+func IsMember(ctx context.Context, roomID string, uid int64) (bool, error) {
+	return RDB.SIsMember(ctx, membersKey(roomID), uid).Result()
+}
+
+// GetMembers 获取房间全部成员
+func GetMembers(ctx context.Context, roomID string) ([]int64, error) {
+	vals, err := RDB.SMembers(ctx, membersKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseUIDs(vals), nil
+}
+
+func parseUIDs(vals []string) []int64 {
+	uids := make([]int64, 0, len(vals))
+	for _, v := range vals {
+		uid, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
+
+// SetTickets 为玩家签发各自的 ticket，Game Server 连接时按 uid 校验
+func SetTickets(ctx context.Context, roomID string, tickets map[int64]string) error {
+	fields := make(map[string]interface{}, len(tickets))
+	for uid, ticket := range tickets {
+		fields[strconv.FormatInt(uid, 10)] = ticket
+	}
+	pipe := RDB.Pipeline()
+	pipe.HSet(ctx, ticketsKey(roomID), fields)
+	pipe.Expire(ctx, ticketsKey(roomID), roomTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// SetTeams 记录团队模式下的队伍分配
+func SetTeams(ctx context.Context, roomID string, teams map[int64]int32) error {
+	fields := make(map[string]interface{}, len(teams))
+	for uid, team := range teams {
+		fields[strconv.FormatInt(uid, 10)] = team
+	}
+	pipe := RDB.Pipeline()
+	pipe.HSet(ctx, teamsKey(roomID), fields)
+	pipe.Expire(ctx, teamsKey(roomID), roomTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetTeams 读取房间的队伍分配
+func GetTeams(ctx context.Context, roomID string) (map[int64]int32, error) {
+	vals, err := RDB.HGetAll(ctx, teamsKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	teams := make(map[int64]int32, len(vals))
+	for k, v := range vals {
+		uid, _ := strconv.ParseInt(k, 10, 64)
+		team, _ := strconv.Atoi(v)
+		teams[uid] = int32(team)
+	}
+	return teams, nil
+}
+
+// BanPlayer 把玩家加入房间封禁名单
+func BanPlayer(ctx context.Context, roomID string, uid int64) error {
+	pipe := RDB.Pipeline()
+	pipe.SAdd(ctx, bansKey(roomID), uid)
+	pipe.Expire(ctx, bansKey(roomID), roomTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func IsBanned(ctx context.Context, roomID string, uid int64) (bool, error) {
+	return RDB.SIsMember(ctx, bansKey(roomID), uid).Result()
+}
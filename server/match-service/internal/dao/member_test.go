@@ -0,0 +1,152 @@
+package dao
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetMaxPlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		seated  int
+		max     int
+		wantErr error
+	}{
+		{name: "grow", seated: 3, max: 8},
+		{name: "shrink to occupancy", seated: 3, max: 3},
+		{name: "below occupancy", seated: 3, max: 2, wantErr: ErrBelowOccupancy},
+		{name: "match started", status: "PLAYING", max: 8, wantErr: ErrRoomNotWaiting},
+		{name: "room removed", status: "-", max: 8, wantErr: ErrRoomNotWaiting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setup(t)
+			switch tt.status {
+			case "-":
+			case "":
+				saveTestRoom(t, ctx, "r1", nil)
+			default:
+				saveTestRoom(t, ctx, "r1", map[string]interface{}{"status": tt.status})
+			}
+			for uid := 1; uid <= tt.seated; uid++ {
+				if _, err := AddMember(ctx, "r1", int64(uid)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := SetMaxPlayers(ctx, "r1", tt.max)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("set max_players = %v, want %v", err, tt.wantErr)
+			}
+			want := "4"
+			if tt.wantErr == nil {
+				want = strconv.Itoa(tt.max)
+			} else if tt.status == "-" {
+				want = ""
+			}
+			if got := mr.HGet(KeyRoomPrefix+"r1", "max_players"); got != want {
+				t.Fatalf("max_players = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestBanPlayer(t *testing.T) {
+	ctx := setup(t)
+	if err := BanPlayer(ctx, "r1", 7); err != nil {
+		t.Fatal(err)
+	}
+	for uid, want := range map[int64]bool{7: true, 8: false} {
+		if banned, err := IsBanned(ctx, "r1", uid); err != nil || banned != want {
+			t.Fatalf("IsBanned(%d) = %v, %v, want %v", uid, banned, err, want)
+		}
+	}
+}
+
+func TestReserveSeats(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		seated  []int64
+		uids    []int64
+		want    int64
+		wantErr error
+	}{
+		{name: "empty room", uids: []int64{1, 2}, want: 2},
+		{name: "fills last seats", seated: []int64{1, 2}, uids: []int64{3, 4}, want: 4},
+		{name: "group larger than free seats", seated: []int64{1, 2, 3}, uids: []int64{4, 5}, wantErr: ErrRoomFull},
+		{name: "already seated not counted twice", seated: []int64{1, 2, 3, 4}, uids: []int64{4}, want: 4},
+		{name: "match started", status: "PLAYING", uids: []int64{1}, wantErr: ErrRoomNotWaiting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setup(t)
+			status := tt.status
+			if status == "" {
+				status = "WAITING"
+			}
+			saveTestRoom(t, ctx, "r1", map[string]interface{}{"status": status})
+			if len(tt.seated) > 0 {
+				if _, err := AddMembers(ctx, "r1", tt.seated); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			n, err := ReserveSeats(ctx, "r1", tt.uids)
+			if !errors.Is(err, tt.wantErr) || n != tt.want {
+				t.Fatalf("reserve = %d, %v, want %d, %v", n, err, tt.want, tt.wantErr)
+			}
+			// 座位不足时整组都不加入
+			members, _ := GetMembers(ctx, "r1")
+			if tt.wantErr != nil && len(members) != len(tt.seated) {
+				t.Fatalf("members after rejected reserve = %v, want %v", members, tt.seated)
+			}
+		})
+	}
+}
+
+func TestReserveSeatsConcurrent(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", nil)
+
+	var wg sync.WaitGroup
+	var admitted atomic.Int32
+	for uid := int64(1); uid <= 20; uid++ {
+		wg.Add(1)
+		go func(uid int64) {
+			defer wg.Done()
+			if _, err := ReserveSeats(ctx, "r1", []int64{uid}); err == nil {
+				admitted.Add(1)
+			} else if !errors.Is(err, ErrRoomFull) {
+				t.Error(err)
+			}
+		}(uid)
+	}
+	wg.Wait()
+	if members, _ := GetMembers(ctx, "r1"); admitted.Load() != 4 || len(members) != 4 {
+		t.Fatalf("admitted %d players, %d members, want 4", admitted.Load(), len(members))
+	}
+}
+
+func TestRemoveMembers(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", nil)
+	if _, err := AddMembers(ctx, "r1", []int64{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetTickets(ctx, "r1", map[int64]string{1: "a", 2: "b", 3: "c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := RemoveMembers(ctx, "r1", []int64{1, 3})
+	if err != nil || n != 1 {
+		t.Fatalf("remove = %d, %v, want 1", n, err)
+	}
+	if tickets, _ := RDB.HKeys(ctx, ticketsKey("r1")).Result(); len(tickets) != 1 || tickets[0] != "2" {
+		t.Fatalf("tickets after removal = %v, want [2]", tickets)
+	}
+}
@@ -0,0 +1,78 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 观战者
+//
+//	room:{id}:observer_slots    ZSet: uid -> 预留截止时间（unix 毫秒），0 表示已连上 Game Server
+//	room:{id}:observer_tickets  Hash: uid -> 观战 ticket
+//
+// 签发 ticket 时只预留名额，Game Server 在观战连接建立时确认（ConfirmObserver），
+// 超时未确认的预留在下一次占用名额时释放。房间 Hash 中的 observers 字段记录观战人数，供大厅展示
+func observersKey(roomID string) string       { return KeyRoomPrefix + roomID + ":observer_slots" }
+func observerTicketsKey(roomID string) string { return KeyRoomPrefix + roomID + ":observer_tickets" }
+
+// ErrObserverSlotsFull 观战名额已满
+var ErrObserverSlotsFull = errors.New("observer slots are full")
+
+// addObserverScript 清理过期预留后占用名额并记录 ticket
+// KEYS: 观战 ZSet、ticket Hash、房间 Hash
+// ARGV: uid, ticket, 上限（0 不限）, 当前时间 ms, 预留截止时间 ms, TTL 秒数
+// 已预留或已连上的 uid 不重复占用，只刷新 ticket（未连上的同时延长预留）；返回观战人数，-1 表示名额已满
+var addObserverScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[1], 1, ARGV[4])
+if #expired > 0 then
+  redis.call('ZREM', KEYS[1], unpack(expired))
+  redis.call('HDEL', KEYS[2], unpack(expired))
+end
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+  local max = tonumber(ARGV[3])
+  if max > 0 and redis.call('ZCARD', KEYS[1]) >= max then
+    return -1
+  end
+  redis.call('ZADD', KEYS[1], ARGV[5], ARGV[1])
+elseif tonumber(score) ~= 0 then
+  redis.call('ZADD', KEYS[1], ARGV[5], ARGV[1])
+end
+redis.call('HSET', KEYS[2], ARGV[1], ARGV[2])
+redis.call('EXPIRE', KEYS[1], ARGV[6])
+redis.call('EXPIRE', KEYS[2], ARGV[6])
+local count = redis.call('ZCARD', KEYS[1])
+if redis.call('EXISTS', KEYS[3]) == 1 then
+  redis.call('HSET', KEYS[3], 'observers', count)
+end
+return count
+`)
+
+// AddObserver 预留一个观战名额并记录 ticket，预留在 reserve 内未被 Game Server 确认则失效，返回当前观战人数
+func AddObserver(ctx context.Context, roomID string, uid int64, ticket string, maxObservers int, reserve time.Duration) (int64, error) {
+	now := time.Now()
+	keys := []string{observersKey(roomID), observerTicketsKey(roomID), KeyRoomPrefix + roomID}
+	count, err := addObserverScript.Run(ctx, RDB, keys,
+		uid, ticket, maxObservers, now.UnixMilli(), now.Add(reserve).UnixMilli(), int64(roomTTL/time.Second)).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if count < 0 {
+		return 0, ErrObserverSlotsFull
+	}
+	publishRoomEvent(ctx, RoomEventUpdated, roomID)
+	return count, nil
+}
+
+// IsObserver 是否持有观战名额（已预留或已连上）
+func IsObserver(ctx context.Context, roomID string, uid int64) (bool, error) {
+	_, err := RDB.ZScore(ctx, observersKey(roomID), strconv.FormatInt(uid, 10)).Result()
+	if err == redis.Nil {
+		return false, nil
+	}
+	return err == nil, err
+}
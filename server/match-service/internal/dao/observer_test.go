@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAddObserver(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved float64 // 已有的 1 号观战者：0 已连上，>0 为预留截止时间（ms），<0 没有
+		uid      int64
+		max      int
+		want     int64
+		wantErr  error
+	}{
+		{name: "first observer", reserved: -1, uid: 2, max: 1, want: 1},
+		{name: "slots full", reserved: float64(time.Now().Add(time.Minute).UnixMilli()), uid: 2, max: 1, wantErr: ErrObserverSlotsFull},
+		{name: "connected observer never expires", reserved: 0, uid: 2, max: 1, wantErr: ErrObserverSlotsFull},
+		{name: "expired reservation released", reserved: 1, uid: 2, max: 1, want: 1},
+		{name: "same observer not counted twice", reserved: float64(time.Now().Add(time.Minute).UnixMilli()), uid: 1, max: 1, want: 1},
+		{name: "unlimited", reserved: 0, uid: 2, max: 0, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setup(t)
+			saveTestRoom(t, ctx, "r1", nil)
+			if tt.reserved >= 0 {
+				mr.ZAdd(observersKey("r1"), tt.reserved, "1")
+				mr.HSet(observerTicketsKey("r1"), "1", "old")
+			}
+
+			n, err := AddObserver(ctx, "r1", tt.uid, "ticket", tt.max, time.Minute)
+			if !errors.Is(err, tt.wantErr) || n != tt.want {
+				t.Fatalf("add = %d, %v, want %d, %v", n, err, tt.want, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if ok, err := IsObserver(ctx, "r1", tt.uid); err != nil || !ok {
+				t.Fatalf("IsObserver = %v, %v", ok, err)
+			}
+			if ticket := mr.HGet(observerTicketsKey("r1"), "2"); tt.uid == 2 && ticket != "ticket" {
+				t.Fatalf("ticket = %q", ticket)
+			}
+			if got := mr.HGet(KeyRoomPrefix+"r1", "observers"); got != strconv.FormatInt(tt.want, 10) {
+				t.Fatalf("observers field = %q, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddObserverRoomRemoved(t *testing.T) {
+	ctx := setup(t)
+	if _, err := AddObserver(ctx, "gone", 1, "ticket", 1, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	// 房间已销毁时只占名额，不重新创建房间 Hash
+	if mr.Exists(KeyRoomPrefix + "gone") {
+		t.Fatal("room hash recreated")
+	}
+}
@@ -0,0 +1,210 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 组队 (Redis)
+//
+//	party:{id}          Hash: leader_uid / max_size / room_id / created_at
+//	party:{id}:members  Set: 队员 uid（含队长）
+//	party:{id}:invites  Set: 已邀请、尚未加入的 uid
+//	user:{uid}:party    String: 玩家所在的队伍 ID
+//
+// 队伍与房间无关，对局结束回到大厅后仍然保留
+const (
+	KeyPartyPrefix = "party:"
+	partyTTL       = 24 * time.Hour
+)
+
+// Party 队伍状态
+type Party struct {
+	ID        string
+	LeaderUID int64
+	MaxSize   int
+	RoomID    string
+	Members   []int64
+	Invites   []int64
+}
+
+func partyKey(partyID string) string        { return KeyPartyPrefix + partyID }
+func partyMembersKey(partyID string) string { return KeyPartyPrefix + partyID + ":members" }
+func partyInvitesKey(partyID string) string { return KeyPartyPrefix + partyID + ":invites" }
+func userPartyKey(uid int64) string         { return "user:" + strconv.FormatInt(uid, 10) + ":party" }
+
+// CreateParty 创建队伍，队长自动成为队员
+func CreateParty(ctx context.Context, partyID string, leader int64, maxSize int) error {
+	pipe := RDB.TxPipeline()
+	pipe.HSet(ctx, partyKey(partyID), "leader_uid", leader, "max_size", maxSize, "room_id", "", "created_at", time.Now().Unix())
+	pipe.SAdd(ctx, partyMembersKey(partyID), leader)
+	pipe.Set(ctx, userPartyKey(leader), partyID, partyTTL)
+	expireParty(ctx, pipe, partyID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetParty 查询队伍，不存在时返回 nil
+func GetParty(ctx context.Context, partyID string) (*Party, error) {
+	pipe := RDB.Pipeline()
+	dataCmd := pipe.HGetAll(ctx, partyKey(partyID))
+	membersCmd := pipe.SMembers(ctx, partyMembersKey(partyID))
+	invitesCmd := pipe.SMembers(ctx, partyInvitesKey(partyID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+	data := dataCmd.Val()
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	leader, _ := strconv.ParseInt(data["leader_uid"], 10, 64)
+	maxSize, _ := strconv.Atoi(data["max_size"])
+	return &Party{
+		ID:        partyID,
+		LeaderUID: leader,
+		MaxSize:   maxSize,
+		RoomID:    data["room_id"],
+		Members:   parseUIDs(membersCmd.Val()),
+		Invites:   parseUIDs(invitesCmd.Val()),
+	}, nil
+}
+
+// GetUserParty 查询玩家所在队伍，不在队伍中时返回 nil
+func GetUserParty(ctx context.Context, uid int64) (*Party, error) {
+	partyID, err := RDB.Get(ctx, userPartyKey(uid)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	party, err := GetParty(ctx, partyID)
+	if err != nil || party == nil {
+		return party, err
+	}
+	// 队伍已解散或玩家已不在队中，清理残留的索引
+	for _, m := range party.Members {
+		if m == uid {
+			return party, nil
+		}
+	}
+	RDB.Del(ctx, userPartyKey(uid))
+	return nil, nil
+}
+
+// 队伍操作的错误
+var (
+	ErrPartyNotFound = errors.New("party not found")
+	ErrPartyFull     = errors.New("party is full")
+	ErrNotInvited    = errors.New("not invited to this party")
+)
+
+// partyScript 在同一个脚本中校验队伍人数并写入，避免并发邀请/加入时超出 max_size
+// KEYS: 队伍 Hash、队员 Set、邀请 Set、玩家的队伍索引
+// ARGV: 操作（invite / join）, uid, 队伍 ID, TTL 秒数
+// 返回写入后的队员数，-1 表示队伍已满，-2 表示队伍不存在，-3 表示未被邀请
+var partyScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return -2
+end
+if ARGV[1] == 'join' and redis.call('SISMEMBER', KEYS[3], ARGV[2]) == 0 then
+  return -3
+end
+local max = tonumber(redis.call('HGET', KEYS[1], 'max_size')) or 0
+if redis.call('SCARD', KEYS[2]) >= max then
+  return -1
+end
+if ARGV[1] == 'join' then
+  redis.call('SREM', KEYS[3], ARGV[2])
+  redis.call('SADD', KEYS[2], ARGV[2])
+  redis.call('SET', KEYS[4], ARGV[3], 'EX', ARGV[4])
+else
+  redis.call('SADD', KEYS[3], ARGV[2])
+end
+for i = 1, 3 do
+  redis.call('EXPIRE', KEYS[i], ARGV[4])
+end
+return redis.call('SCARD', KEYS[2])
+`)
+
+func runPartyScript(ctx context.Context, op, partyID string, uid int64) error {
+	keys := []string{partyKey(partyID), partyMembersKey(partyID), partyInvitesKey(partyID), userPartyKey(uid)}
+	n, err := partyScript.Run(ctx, RDB, keys, op, uid, partyID, int64(partyTTL/time.Second)).Int64()
+	if err != nil {
+		return err
+	}
+	switch n {
+	case -1:
+		return ErrPartyFull
+	case -2:
+		return ErrPartyNotFound
+	case -3:
+		return ErrNotInvited
+	}
+	return nil
+}
+
+// InviteToParty 记录邀请，队伍已满时返回 ErrPartyFull
+func InviteToParty(ctx context.Context, partyID string, uid int64) error {
+	return runPartyScript(ctx, "invite", partyID, uid)
+}
+
+// AddPartyMember 接受邀请加入队伍，人数校验与加入是原子的
+func AddPartyMember(ctx context.Context, partyID string, uid int64) error {
+	return runPartyScript(ctx, "join", partyID, uid)
+}
+
+// RemovePartyMember 移出队员，返回剩余人数
+func RemovePartyMember(ctx context.Context, partyID string, uid int64) (int64, error) {
+	pipe := RDB.TxPipeline()
+	pipe.SRem(ctx, partyMembersKey(partyID), uid)
+	pipe.Del(ctx, userPartyKey(uid))
+	countCmd := pipe.SCard(ctx, partyMembersKey(partyID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return countCmd.Val(), nil
+}
+
+// UpdateParty 更新队伍字段（leader_uid / room_id）并续期
+func UpdateParty(ctx context.Context, partyID string, fields map[string]interface{}) error {
+	pipe := RDB.TxPipeline()
+	pipe.HSet(ctx, partyKey(partyID), fields)
+	expireParty(ctx, pipe, partyID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TouchParty 续期队伍及队员索引
+func TouchParty(ctx context.Context, party *Party) error {
+	pipe := RDB.TxPipeline()
+	expireParty(ctx, pipe, party.ID)
+	for _, m := range party.Members {
+		pipe.Expire(ctx, userPartyKey(m), partyTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DeleteParty 解散队伍
+func DeleteParty(ctx context.Context, party *Party) error {
+	pipe := RDB.TxPipeline()
+	pipe.Del(ctx, partyKey(party.ID), partyMembersKey(party.ID), partyInvitesKey(party.ID))
+	for _, m := range party.Members {
+		pipe.Del(ctx, userPartyKey(m))
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// expireParty 续期队伍的全部 key，队员每次操作都会刷新
+func expireParty(ctx context.Context, pipe redis.Pipeliner, partyID string) {
+	pipe.Expire(ctx, partyKey(partyID), partyTTL)
+	pipe.Expire(ctx, partyMembersKey(partyID), partyTTL)
+	pipe.Expire(ctx, partyInvitesKey(partyID), partyTTL)
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPartyScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		invite  []int64
+		join    []int64
+		op      func() error
+		wantErr error
+	}{
+		{name: "invite", op: func() error { return InviteToParty(context.Background(), "p1", 2) }},
+		{name: "join invited", invite: []int64{2}, op: func() error { return AddPartyMember(context.Background(), "p1", 2) }},
+		{name: "join without invite", op: func() error { return AddPartyMember(context.Background(), "p1", 2) }, wantErr: ErrNotInvited},
+		{name: "join full party", invite: []int64{2, 3}, join: []int64{2}, op: func() error { return AddPartyMember(context.Background(), "p1", 3) }, wantErr: ErrPartyFull},
+		{name: "invite to full party", invite: []int64{2}, join: []int64{2}, op: func() error { return InviteToParty(context.Background(), "p1", 3) }, wantErr: ErrPartyFull},
+		{name: "party not found", op: func() error { return InviteToParty(context.Background(), "p2", 2) }, wantErr: ErrPartyNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := setup(t)
+			if err := CreateParty(ctx, "p1", 1, 2); err != nil {
+				t.Fatal(err)
+			}
+			for _, uid := range tt.invite {
+				mustParty(t, InviteToParty(ctx, "p1", uid))
+			}
+			for _, uid := range tt.join {
+				mustParty(t, AddPartyMember(ctx, "p1", uid))
+			}
+			if err := tt.op(); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("op = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPartyConcurrentJoin(t *testing.T) {
+	ctx := setup(t)
+	if err := CreateParty(ctx, "p1", 1, 4); err != nil {
+		t.Fatal(err)
+	}
+	for uid := int64(2); uid <= 10; uid++ {
+		mustParty(t, InviteToParty(ctx, "p1", uid))
+	}
+
+	var wg sync.WaitGroup
+	var joined atomic.Int32
+	for uid := int64(2); uid <= 10; uid++ {
+		wg.Add(1)
+		go func(uid int64) {
+			defer wg.Done()
+			if err := AddPartyMember(ctx, "p1", uid); err == nil {
+				joined.Add(1)
+			} else if !errors.Is(err, ErrPartyFull) {
+				t.Error(err)
+			}
+		}(uid)
+	}
+	wg.Wait()
+
+	party, err := GetParty(ctx, "p1")
+	if err != nil || joined.Load() != 3 || len(party.Members) != 4 {
+		t.Fatalf("joined %d, party %+v, %v, want 4 members", joined.Load(), party, err)
+	}
+	// 加入的队员都能查到自己的队伍
+	for _, uid := range party.Members {
+		if p, err := GetUserParty(ctx, uid); err != nil || p == nil || p.ID != "p1" {
+			t.Fatalf("party of %d = %+v, %v", uid, p, err)
+		}
+	}
+}
+
+func mustParty(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,7 +2,10 @@ package dao
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
 	"log"
+	"math/big"
 	"time"
 
 	"mygame/server/match-service/pkg/config"
@@ -30,63 +33,207 @@ func InitRedis() {
 
 // 键名定义
 const (
-	KeyRoomList   = "rooms:available" // Set: 存储 room_id
-	KeyRoomPrefix = "room:"           // Hash: room:{id} -> { details }
+	KeyRoomList     = "rooms:available" // Set: 存储 room_id（仅公开房间）
+	KeyRoomPrefix   = "room:"           // Hash: room:{id} -> { details }
+	KeyInvitePrefix = "invite:"         // String: invite:{code} -> room_id
+	KeyClosedRooms  = "rooms:closed"    // List: Game Server 上已停止、等待销毁的 room_id
 )
 
-// SaveRoom 创建房间
-func SaveRoom(ctx context.Context, roomID string, data map[string]interface{}) error {
+const roomTTL = 24 * time.Hour
+
+// SaveRoom 创建房间，listed 为 false 时不加入公开列表（私有房间）
+func SaveRoom(ctx context.Context, roomID string, data map[string]interface{}, listed bool) error {
 	pipe := RDB.Pipeline()
 
 	// 1. 存入房间详情
 	key := KeyRoomPrefix + roomID
 	pipe.HMSet(ctx, key, data)
-	pipe.Expire(ctx, key, 24*time.Hour) // 设置过期时间防止死数据
+	pipe.Expire(ctx, key, roomTTL) // 设置过期时间防止死数据
 
-	// 2. 加入列表
-	pipe.SAdd(ctx, KeyRoomList, roomID)
+	// 2. 加入列表并建立索引
+	if listed {
+		pipe.SAdd(ctx, KeyRoomList, roomID)
+		indexRoom(ctx, pipe, roomID, toStringMap(data))
+	}
 
-	_, err := pipe.Exec(ctx)
-	return err
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, RoomEventAdded, roomID)
+	return nil
 }
 
-// GetRoom 获取单个房间信息
-func GetRoom(ctx context.Context, roomID string) (map[string]string, error) {
-	return RDB.HGetAll(ctx, KeyRoomPrefix+roomID).Result()
+// SetRoomListed 切换房间是否出现在公开列表中
+func SetRoomListed(ctx context.Context, roomID string, listed bool) error {
+	data, err := GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	pipe := RDB.TxPipeline()
+	if listed {
+		pipe.SAdd(ctx, KeyRoomList, roomID)
+		indexRoom(ctx, pipe, roomID, data)
+	} else {
+		pipe.SRem(ctx, KeyRoomList, roomID)
+		unindexRoom(ctx, pipe, roomID, data)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return err
+	}
+	if listed {
+		publishRoomEvent(ctx, RoomEventUpdated, roomID)
+	} else {
+		publishRoomEvent(ctx, RoomEventUnlisted, roomID)
+	}
+	return nil
 }
 
-// GetAllRooms 获取所有房间列表 (高频操作，生产环境需分页或用 Scan)
-func GetAllRooms(ctx context.Context) ([]map[string]string, error) {
-	// 1. 获取所有 ID
-	ids, err := RDB.SMembers(ctx, KeyRoomList).Result()
-	if err != nil {
-		return nil, err
-	}
-
-	var rooms []map[string]string
-	// 2. 遍历获取详情 (可优化为 Pipeline)
-	// 简单实现：循环获取
-	for _, id := range ids {
-		data, err := RDB.HGetAll(ctx, KeyRoomPrefix+id).Result()
-		if err == nil && len(data) > 0 {
-			// 把 ID 也塞回去方便前端用
-			data["room_id"] = id
-			rooms = append(rooms, data)
+// 邀请码字符集：去掉了容易混淆的 0/O、1/I/L
+const inviteAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+const inviteCodeLength = 6
+
+// CreateInviteCode 为房间生成一个唯一的邀请码
+func CreateInviteCode(ctx context.Context, roomID string) (string, error) {
+	for i := 0; i < 5; i++ {
+		code, err := randomInviteCode()
+		if err != nil {
+			return "", err
+		}
+		ok, err := RDB.SetNX(ctx, KeyInvitePrefix+code, roomID, roomTTL).Result()
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return code, nil
 		}
 	}
-	return rooms, nil
+	return "", fmt.Errorf("failed to allocate invite code")
+}
+
+// ResolveInviteCode 邀请码 -> room_id，不存在时返回 redis.Nil
+func ResolveInviteCode(ctx context.Context, code string) (string, error) {
+	return RDB.Get(ctx, KeyInvitePrefix+code).Result()
+}
+
+// DeleteInviteCode 使邀请码失效
+func DeleteInviteCode(ctx context.Context, code string) error {
+	return RDB.Del(ctx, KeyInvitePrefix+code).Err()
+}
+
+func randomInviteCode() (string, error) {
+	b := make([]byte, inviteCodeLength)
+	max := big.NewInt(int64(len(inviteAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = inviteAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// GetRoom 获取单个房间信息
+func GetRoom(ctx context.Context, roomID string) (map[string]string, error) {
+	return RDB.HGetAll(ctx, KeyRoomPrefix+roomID).Result()
 }
 
 // RemoveRoom 销毁房间
 func RemoveRoom(ctx context.Context, roomID string) error {
+	data, _ := GetRoom(ctx, roomID)
+
 	pipe := RDB.Pipeline()
-	pipe.Del(ctx, KeyRoomPrefix+roomID)
+	pipe.Del(ctx, KeyRoomPrefix+roomID, membersKey(roomID), bansKey(roomID), ticketsKey(roomID), teamsKey(roomID),
+		observersKey(roomID), observerTicketsKey(roomID), botsKey(roomID))
 	pipe.SRem(ctx, KeyRoomList, roomID)
-	_, err := pipe.Exec(ctx)
-	return err
+	unindexRoom(ctx, pipe, roomID, data)
+	if code := data["invite_code"]; code != "" {
+		pipe.Del(ctx, KeyInvitePrefix+code)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, RoomEventRemoved, roomID)
+	return nil
+}
+
+// PopClosedRoom 阻塞等待 Game Server 上报的已停止房间，超时返回 redis.Nil
+func PopClosedRoom(ctx context.Context, timeout time.Duration) (string, error) {
+	res, err := RDB.BRPop(ctx, timeout, KeyClosedRooms).Result()
+	if err != nil {
+		return "", err
+	}
+	return res[1], nil
 }
 
 func UpdateRoom(ctx context.Context, roomID string, data map[string]interface{}) error {
 	key := KeyRoomPrefix + roomID
-	return RDB.HMSet(ctx, key, data).Err()
+
+	// 读取旧数据，用于刷新索引
+	pipe := RDB.Pipeline()
+	oldCmd := pipe.HGetAll(ctx, key)
+	listedCmd := pipe.SIsMember(ctx, KeyRoomList, roomID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	old := oldCmd.Val()
+
+	tx := RDB.TxPipeline()
+	tx.HMSet(ctx, key, data)
+	if listedCmd.Val() {
+		merged := make(map[string]string, len(old)+len(data))
+		for k, v := range old {
+			merged[k] = v
+		}
+		for k, v := range toStringMap(data) {
+			merged[k] = v
+		}
+		unindexRoom(ctx, tx, roomID, old)
+		indexRoom(ctx, tx, roomID, merged)
+	}
+	if _, err := tx.Exec(ctx); err != nil {
+		return err
+	}
+	publishRoomEvent(ctx, RoomEventUpdated, roomID)
+	return nil
+}
+
+// CompareAndSetStatus 仅当房间状态为 from 时改为 to，返回是否修改成功
+// 用 WATCH 保证并发的开局请求只有一个生效
+func CompareAndSetStatus(ctx context.Context, roomID, from, to string) (bool, error) {
+	key := KeyRoomPrefix + roomID
+	swapped := false
+	err := RDB.Watch(ctx, func(tx *redis.Tx) error {
+		status, err := tx.HGet(ctx, key, "status").Result()
+		if err != nil {
+			return err
+		}
+		if status != from {
+			return nil
+		}
+		listed, err := tx.SIsMember(ctx, KeyRoomList, roomID).Result()
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, "status", to)
+			if listed {
+				pipe.SRem(ctx, KeyIdxStatusPrefix+from, roomID)
+				pipe.SAdd(ctx, KeyIdxStatusPrefix+to, roomID)
+			}
+			return nil
+		})
+		if err == nil {
+			swapped = true
+		}
+		return err
+	}, key)
+	if err == redis.TxFailedErr {
+		return false, nil
+	}
+	if swapped {
+		publishRoomEvent(ctx, RoomEventUpdated, roomID)
+	}
+	return swapped, err
 }
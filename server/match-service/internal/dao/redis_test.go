@@ -0,0 +1,93 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var mr *miniredis.Miniredis
+
+func TestMain(m *testing.M) {
+	var err error
+	mr, err = miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	RDB = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	code := m.Run()
+	RDB.Close()
+	mr.Close()
+	os.Exit(code)
+}
+
+// setup 清空 Redis，每个用例从空库开始
+func setup(t *testing.T) context.Context {
+	t.Helper()
+	mr.FlushAll()
+	return context.Background()
+}
+
+// saveTestRoom 创建一个公开的等待中房间
+func saveTestRoom(t *testing.T, ctx context.Context, roomID string, fields map[string]interface{}) {
+	t.Helper()
+	data := map[string]interface{}{
+		"room_name":       roomID,
+		"status":          "WAITING",
+		"map_id":          1,
+		"max_players":     4,
+		"current_players": 0,
+		"created_at":      1,
+	}
+	for k, v := range fields {
+		data[k] = v
+	}
+	if err := SaveRoom(ctx, roomID, data, true); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInviteCode(t *testing.T) {
+	ctx := setup(t)
+
+	code, err := CreateInviteCode(ctx, "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) != inviteCodeLength || strings.Trim(code, inviteAlphabet) != "" {
+		t.Fatalf("code = %q, want %d characters from the invite alphabet", code, inviteCodeLength)
+	}
+	if roomID, err := ResolveInviteCode(ctx, code); err != nil || roomID != "r1" {
+		t.Fatalf("resolve = %q, %v", roomID, err)
+	}
+
+	if err := DeleteInviteCode(ctx, code); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResolveInviteCode(ctx, code); !errors.Is(err, redis.Nil) {
+		t.Fatalf("resolve deleted code = %v, want redis.Nil", err)
+	}
+}
+
+func TestSetRoomListed(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", nil)
+
+	tests := []struct {
+		listed bool
+	}{{false}, {true}, {false}}
+	for _, tt := range tests {
+		if err := SetRoomListed(ctx, "r1", tt.listed); err != nil {
+			t.Fatal(err)
+		}
+		if listed, err := RDB.SIsMember(ctx, KeyRoomList, "r1").Result(); err != nil || listed != tt.listed {
+			t.Fatalf("listed = %v, %v, want %v", listed, err, tt.listed)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package dao
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 房间列表的二级索引，只包含公开房间，由 SaveRoom / UpdateRoom / RemoveRoom 维护
+const (
+	KeyIdxCreated      = "rooms:idx:created" // ZSet: score = created_at
+	KeyIdxPlayers      = "rooms:idx:players" // ZSet: score = current_players
+	KeyIdxOpen         = "rooms:idx:open"    // Set: 未满员的房间
+	KeyIdxName         = "rooms:idx:name"    // ZSet(score 0): 房间名每个后缀一条 "小写后缀\x00room_id"
+	KeyIdxStatusPrefix = "rooms:idx:status:" // Set: rooms:idx:status:{status}
+	KeyIdxMapPrefix    = "rooms:idx:map:"    // Set: rooms:idx:map:{map_id}
+	keyQueryPrefix     = "rooms:query:"      // 临时查询结果
+	queryResultTTL     = 30 * time.Second
+	nameIndexRunes     = 32 // 名称索引与查询只取前 32 个字符，限制每个房间的索引条数
+)
+
+// 排序方式
+const (
+	SortNewest        = "newest"
+	SortMostPlayers   = "most_players"
+	SortFewestPlayers = "fewest_players"
+)
+
+// RoomQuery 房间列表查询条件
+type RoomQuery struct {
+	Status  string // 为空不过滤
+	MapID   int32  // 0 不过滤
+	NotFull bool
+	Name    string // 房间名子串匹配（不区分大小写）
+	SortBy  string
+	Cursor  string
+	Limit   int
+}
+
+func toInterfaces(vals []string) []interface{} {
+	out := make([]interface{}, len(vals))
+	for i, v := range vals {
+		out[i] = v
+	}
+	return out
+}
+
+func toStringMap(data map[string]interface{}) map[string]string {
+	m := make(map[string]string, len(data))
+	for k, v := range data {
+		m[k] = fmt.Sprint(v)
+	}
+	return m
+}
+
+// nameIndexMembers 房间名的每个后缀各一条索引，子串查询即转换为后缀的前缀查询 (ZRANGEBYLEX)
+func nameIndexMembers(roomID string, data map[string]string) []string {
+	name := []rune(normalizeName(data["room_name"]))
+	members := make([]string, 0, len(name))
+	for i := range name {
+		members = append(members, string(name[i:])+"\x00"+roomID)
+	}
+	return members
+}
+
+// normalizeName 小写并截断到 nameIndexRunes 个字符
+func normalizeName(name string) string {
+	runes := []rune(strings.ToLower(name))
+	if len(runes) > nameIndexRunes {
+		runes = runes[:nameIndexRunes]
+	}
+	return string(runes)
+}
+
+// indexRoom 把房间写入全部二级索引
+func indexRoom(ctx context.Context, pipe redis.Pipeliner, roomID string, data map[string]string) {
+	created, _ := strconv.ParseFloat(data["created_at"], 64)
+	cur, _ := strconv.Atoi(data["current_players"])
+	max, _ := strconv.Atoi(data["max_players"])
+	bots, _ := strconv.Atoi(data["bots"])
+
+	pipe.ZAdd(ctx, KeyIdxCreated, redis.Z{Score: created, Member: roomID})
+	pipe.ZAdd(ctx, KeyIdxPlayers, redis.Z{Score: float64(cur), Member: roomID})
+	for _, m := range nameIndexMembers(roomID, data) {
+		pipe.ZAdd(ctx, KeyIdxName, redis.Z{Score: 0, Member: m})
+	}
+	if data["status"] != "" {
+		pipe.SAdd(ctx, KeyIdxStatusPrefix+data["status"], roomID)
+	}
+	pipe.SAdd(ctx, KeyIdxMapPrefix+data["map_id"], roomID)
+	if cur+bots < max {
+		pipe.SAdd(ctx, KeyIdxOpen, roomID)
+	} else {
+		pipe.SRem(ctx, KeyIdxOpen, roomID)
+	}
+}
+
+// unindexRoom 把房间从全部二级索引中移除，data 为房间被索引时的数据
+func unindexRoom(ctx context.Context, pipe redis.Pipeliner, roomID string, data map[string]string) {
+	pipe.ZRem(ctx, KeyIdxCreated, roomID)
+	pipe.ZRem(ctx, KeyIdxPlayers, roomID)
+	pipe.SRem(ctx, KeyIdxOpen, roomID)
+	if data != nil {
+		if members := nameIndexMembers(roomID, data); len(members) > 0 {
+			pipe.ZRem(ctx, KeyIdxName, toInterfaces(members)...)
+		}
+		if data["status"] != "" {
+			pipe.SRem(ctx, KeyIdxStatusPrefix+data["status"], roomID)
+		}
+		pipe.SRem(ctx, KeyIdxMapPrefix+data["map_id"], roomID)
+	}
+}
+
+// QueryRooms 按条件查询公开房间，返回当前页房间、下一页游标与总数
+func QueryRooms(ctx context.Context, q RoomQuery) ([]map[string]string, string, int64, error) {
+	if q.Limit <= 0 || q.Limit > 100 {
+		q.Limit = 20
+	}
+
+	// 1. 选择排序索引
+	sortKey, desc := KeyIdxCreated, true
+	switch q.SortBy {
+	case SortMostPlayers:
+		sortKey = KeyIdxPlayers
+	case SortFewestPlayers:
+		sortKey, desc = KeyIdxPlayers, false
+	}
+
+	// 2. 组合过滤索引
+	var filters []string
+	if q.Status != "" {
+		filters = append(filters, KeyIdxStatusPrefix+q.Status)
+	}
+	if q.MapID != 0 {
+		filters = append(filters, KeyIdxMapPrefix+strconv.Itoa(int(q.MapID)))
+	}
+	if q.NotFull {
+		filters = append(filters, KeyIdxOpen)
+	}
+	if q.Name != "" {
+		nameKey, err := matchRoomNames(ctx, q.Name)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		filters = append(filters, nameKey)
+	}
+
+	resultKey := sortKey
+	if len(filters) > 0 {
+		// 排序索引权重 1 保留分数，过滤集合权重 0
+		resultKey = queryKey(sortKey, filters)
+		weights := make([]float64, 0, len(filters)+1)
+		weights = append(weights, 1)
+		for range filters {
+			weights = append(weights, 0)
+		}
+		pipe := RDB.Pipeline()
+		pipe.ZInterStore(ctx, resultKey, &redis.ZStore{
+			Keys:    append([]string{sortKey}, filters...),
+			Weights: weights,
+		})
+		pipe.Expire(ctx, resultKey, queryResultTTL)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, "", 0, err
+		}
+	}
+
+	// 3. 根据游标定位起始位置
+	total, err := RDB.ZCard(ctx, resultKey).Result()
+	if err != nil {
+		return nil, "", 0, err
+	}
+	start, err := cursorStart(ctx, resultKey, q.Cursor, desc)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	stop := start + int64(q.Limit) - 1
+
+	var members []redis.Z
+	if desc {
+		members, err = RDB.ZRevRangeWithScores(ctx, resultKey, start, stop).Result()
+	} else {
+		members, err = RDB.ZRangeWithScores(ctx, resultKey, start, stop).Result()
+	}
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	// 4. Pipeline 批量读取房间详情
+	pipe := RDB.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(members))
+	for i, m := range members {
+		cmds[i] = pipe.HGetAll(ctx, KeyRoomPrefix+fmt.Sprint(m.Member))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, "", 0, err
+	}
+
+	rooms := make([]map[string]string, 0, len(members))
+	var expired []string
+	for i, m := range members {
+		roomID := fmt.Sprint(m.Member)
+		data := cmds[i].Val()
+		if len(data) == 0 {
+			// 房间 Hash 已过期，顺带清理索引
+			expired = append(expired, roomID)
+			continue
+		}
+		data["room_id"] = roomID
+		rooms = append(rooms, data)
+	}
+	if len(expired) > 0 {
+		pipe := RDB.Pipeline()
+		for _, roomID := range expired {
+			pipe.SRem(ctx, KeyRoomList, roomID)
+			unindexRoom(ctx, pipe, roomID, nil)
+		}
+		pipe.Exec(ctx)
+	}
+
+	nextCursor := ""
+	if len(members) > 0 && stop+1 < total {
+		last := members[len(members)-1]
+		nextCursor = encodeCursor(last.Score, fmt.Sprint(last.Member))
+	}
+	return rooms, nextCursor, total, nil
+}
+
+// matchRoomNames 在名称索引中做不区分大小写的子串匹配，结果写入临时 Set 并返回其 key
+// 只按字典序取出以 needle 开头的后缀，不扫描整个索引
+func matchRoomNames(ctx context.Context, name string) (string, error) {
+	needle := normalizeName(name)
+	key := keyQueryPrefix + "name:" + hashKey(needle)
+
+	// UTF-8 中不会出现 0xff 字节，"[needle" 到 "[needle\xff" 恰好覆盖全部以 needle 开头的条目
+	entries, err := RDB.ZRangeByLex(ctx, KeyIdxName, &redis.ZRangeBy{
+		Min: "[" + needle,
+		Max: "[" + needle + "\xff",
+	}).Result()
+	if err != nil {
+		return "", err
+	}
+
+	var matched []interface{}
+	for _, e := range entries {
+		if i := strings.LastIndexByte(e, 0); i >= 0 {
+			matched = append(matched, e[i+1:])
+		}
+	}
+
+	pipe := RDB.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(matched) > 0 {
+		pipe.SAdd(ctx, key, matched...)
+		pipe.Expire(ctx, key, queryResultTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return key, err
+}
+
+// cursorStart 把游标转换为结果集中的起始下标
+// 游标中记录上一页最后一个房间；若该房间已不在结果中，则按其分数重新定位
+func cursorStart(ctx context.Context, key, cursor string, desc bool) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	score, roomID, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	var rank int64
+	if desc {
+		rank, err = RDB.ZRevRank(ctx, key, roomID).Result()
+	} else {
+		rank, err = RDB.ZRank(ctx, key, roomID).Result()
+	}
+	if err == nil {
+		return rank + 1, nil
+	}
+	if err != redis.Nil {
+		return 0, err
+	}
+
+	bound := strconv.FormatFloat(score, 'f', -1, 64)
+	if desc {
+		return RDB.ZCount(ctx, key, "("+bound, "+inf").Result()
+	}
+	return RDB.ZCount(ctx, key, "-inf", "("+bound).Result()
+}
+
+func encodeCursor(score float64, roomID string) string {
+	raw := strconv.FormatFloat(score, 'f', -1, 64) + "|" + roomID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (float64, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("invalid cursor")
+	}
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid cursor")
+	}
+	return score, parts[1], nil
+}
+
+func queryKey(sortKey string, filters []string) string {
+	return keyQueryPrefix + hashKey(sortKey+"|"+strings.Join(filters, "|"))
+}
+
+func hashKey(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// WaitingRooms 等待中的公开房间
+func WaitingRooms(ctx context.Context) ([]string, error) {
+	return RDB.SMembers(ctx, KeyIdxStatusPrefix+"WAITING").Result()
+}
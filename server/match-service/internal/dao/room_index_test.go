@@ -0,0 +1,142 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// roomIDs 取出查询结果中的 room_id
+func roomIDs(rooms []map[string]string) []string {
+	ids := make([]string, 0, len(rooms))
+	for _, r := range rooms {
+		ids = append(ids, r["room_id"])
+	}
+	return ids
+}
+
+// queryAll 按游标逐页读取全部结果，返回每页的 room_id
+func queryAll(t *testing.T, ctx context.Context, q RoomQuery) [][]string {
+	t.Helper()
+	var pages [][]string
+	for i := 0; i < 10; i++ {
+		rooms, next, _, err := QueryRooms(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, roomIDs(rooms))
+		if next == "" {
+			return pages
+		}
+		q.Cursor = next
+	}
+	t.Fatal("cursor never ended")
+	return nil
+}
+
+func TestQueryRooms(t *testing.T) {
+	ctx := setup(t)
+	// r1..r5 依次创建，r2 已开局，r3 已满员，r4 在 2 号地图
+	for i := 1; i <= 5; i++ {
+		fields := map[string]interface{}{"created_at": i, "current_players": i % 3}
+		switch i {
+		case 2:
+			fields["status"] = "PLAYING"
+		case 3:
+			fields["current_players"] = 4
+		case 4:
+			fields["map_id"] = 2
+		}
+		saveTestRoom(t, ctx, fmt.Sprintf("r%d", i), fields)
+	}
+	saveTestRoom(t, ctx, "Arena", map[string]interface{}{"room_name": "Desert Arena", "created_at": 0})
+
+	tests := []struct {
+		name  string
+		query RoomQuery
+		want  [][]string
+	}{
+		{
+			name:  "newest first",
+			query: RoomQuery{Limit: 2},
+			want:  [][]string{{"r5", "r4"}, {"r3", "r2"}, {"r1", "Arena"}},
+		},
+		{
+			name:  "fewest players",
+			query: RoomQuery{SortBy: SortFewestPlayers, Status: "WAITING", Limit: 3},
+			want:  [][]string{{"Arena", "r1", "r4"}, {"r5", "r3"}},
+		},
+		{
+			name:  "not full on map 1",
+			query: RoomQuery{MapID: 1, NotFull: true, Status: "WAITING"},
+			want:  [][]string{{"r5", "r1", "Arena"}},
+		},
+		{
+			name:  "name substring is case insensitive",
+			query: RoomQuery{Name: "ARE"},
+			want:  [][]string{{"Arena"}},
+		},
+		{
+			name:  "no match",
+			query: RoomQuery{Name: "nothing"},
+			want:  [][]string{{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryAll(t, ctx, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("pages = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryRoomsCursorAfterRemoval(t *testing.T) {
+	ctx := setup(t)
+	for i := 1; i <= 4; i++ {
+		saveTestRoom(t, ctx, fmt.Sprintf("r%d", i), map[string]interface{}{"created_at": i})
+	}
+
+	rooms, next, total, err := QueryRooms(ctx, RoomQuery{Limit: 2})
+	if err != nil || total != 4 || !reflect.DeepEqual(roomIDs(rooms), []string{"r4", "r3"}) {
+		t.Fatalf("first page = %v, %d, %v", roomIDs(rooms), total, err)
+	}
+
+	// 游标所指的房间已被删除时，按其分数继续，不重复也不遗漏
+	if err := RemoveRoom(ctx, "r3"); err != nil {
+		t.Fatal(err)
+	}
+	rooms, next, _, err = QueryRooms(ctx, RoomQuery{Limit: 2, Cursor: next})
+	if err != nil || next != "" || !reflect.DeepEqual(roomIDs(rooms), []string{"r2", "r1"}) {
+		t.Fatalf("second page = %v, next %q, %v", roomIDs(rooms), next, err)
+	}
+
+	if _, _, _, err := QueryRooms(ctx, RoomQuery{Cursor: "not a cursor"}); err == nil {
+		t.Fatal("invalid cursor accepted")
+	}
+}
+
+func TestUpdateRoomReindexes(t *testing.T) {
+	ctx := setup(t)
+	saveTestRoom(t, ctx, "r1", map[string]interface{}{"room_name": "Old Name"})
+
+	if err := UpdateRoom(ctx, "r1", map[string]interface{}{"room_name": "New Name", "current_players": 4}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		query RoomQuery
+		want  int
+	}{
+		{RoomQuery{Name: "old"}, 0},
+		{RoomQuery{Name: "new"}, 1},
+		{RoomQuery{NotFull: true}, 0},
+		{RoomQuery{SortBy: SortMostPlayers}, 1},
+	}
+	for _, tt := range tests {
+		rooms, _, _, err := QueryRooms(ctx, tt.query)
+		if err != nil || len(rooms) != tt.want {
+			t.Fatalf("query %+v = %v, %v, want %d rooms", tt.query, roomIDs(rooms), err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/internal/rpc"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// 房间可见性，存在 Redis 房间 Hash 的 visibility 字段中
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+func visibilityOf(cfg *pb.RoomConfig) string {
+	if cfg != nil && cfg.Visibility == pb.RoomConfig_PRIVATE {
+		return VisibilityPrivate
+	}
+	return VisibilityPublic
+}
+
+func hashRoomPassword(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return string(bytes), err
+}
+
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// checkRoomAccess 校验加入房间的权限：
+// 持有正确邀请码可直接加入；否则私有房间拒绝，设置了密码的公开房间需要密码
+func checkRoomAccess(roomData map[string]string, password, inviteCode string) error {
+	if inviteCode != "" && normalizeInviteCode(inviteCode) == roomData["invite_code"] {
+		return nil
+	}
+	if roomData["visibility"] == VisibilityPrivate {
+		// 私有房间：没有密码时只能用邀请码
+		if roomData["password_hash"] == "" {
+			return fmt.Errorf("private room requires an invite code")
+		}
+	}
+	if hash := roomData["password_hash"]; hash != "" {
+		if password == "" {
+			return fmt.Errorf("room password required")
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+			return fmt.Errorf("wrong room password")
+		}
+	}
+	return nil
+}
+
+// checkFriendAccess 通过好友加入或观战：好友需在房间内，且好友关系由 User Service 确认，
+// 不信任调用方传入的 friend_uid
+func checkFriendAccess(ctx context.Context, roomID string, uid, friendUID int64) error {
+	friends, err := rpc.AreFriends(ctx, uid, friendUID)
+	if err != nil {
+		return fmt.Errorf("verify friendship failed: %v", err)
+	}
+	if !friends {
+		return fmt.Errorf("not friends with this player")
+	}
+	inRoom, err := dao.IsMember(ctx, roomID, friendUID)
+	if err != nil {
+		return err
+	}
+	if !inRoom {
+		return fmt.Errorf("friend is not in this room")
+	}
+	return nil
+}
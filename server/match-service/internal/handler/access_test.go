@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/rpc"
+
+	"google.golang.org/grpc"
+)
+
+func TestCheckRoomAccess(t *testing.T) {
+	hash, err := hashRoomPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	public := map[string]string{"visibility": VisibilityPublic, "invite_code": "ABC234"}
+	protected := map[string]string{"visibility": VisibilityPublic, "invite_code": "ABC234", "password_hash": hash}
+	private := map[string]string{"visibility": VisibilityPrivate, "invite_code": "ABC234"}
+	privateProtected := map[string]string{"visibility": VisibilityPrivate, "invite_code": "ABC234", "password_hash": hash}
+
+	tests := []struct {
+		name       string
+		room       map[string]string
+		password   string
+		inviteCode string
+		wantErr    bool
+	}{
+		{name: "public", room: public},
+		{name: "password required", room: protected, wantErr: true},
+		{name: "wrong password", room: protected, password: "guess", wantErr: true},
+		{name: "right password", room: protected, password: "secret"},
+		{name: "invite code skips password", room: protected, inviteCode: " abc234 "},
+		{name: "wrong invite code", room: protected, inviteCode: "ZZZ999", wantErr: true},
+		{name: "private without code", room: private, wantErr: true},
+		{name: "private ignores password", room: private, password: "secret", wantErr: true},
+		{name: "private with code", room: private, inviteCode: "ABC234"},
+		{name: "private with password", room: privateProtected, password: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRoomAccess(tt.room, tt.password, tt.inviteCode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkRoomAccess = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// fakeUsers 只实现 AreFriends 的 User Service 客户端，err 不为空时模拟服务不可用
+type fakeUsers struct {
+	pb.UserServiceClient
+	friends map[[2]int64]bool
+	err     error
+}
+
+func (f *fakeUsers) AreFriends(ctx context.Context, req *pb.AreFriendsReq, opts ...grpc.CallOption) (*pb.AreFriendsResp, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.AreFriendsResp{Friends: f.friends[[2]int64{req.Uid, req.FriendUid}]}, nil
+}
+
+func TestCheckFriendAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		users     *fakeUsers
+		friendUID int64
+		wantErr   bool
+	}{
+		{name: "friend in room", users: &fakeUsers{friends: map[[2]int64]bool{{9, 1}: true}}, friendUID: 1},
+		{name: "not friends", users: &fakeUsers{}, friendUID: 1, wantErr: true},
+		{name: "friend not in room", users: &fakeUsers{friends: map[[2]int64]bool{{9, 5}: true}}, friendUID: 5, wantErr: true},
+		{name: "user service unavailable", users: &fakeUsers{err: errors.New("unavailable")}, friendUID: 1, wantErr: true},
+	}
+	defer func(c pb.UserServiceClient) { rpc.UserClient = c }(rpc.UserClient)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1, 2)
+			rpc.UserClient = tt.users
+			err := checkFriendAccess(ctx, "r1", 9, tt.friendUID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkFriendAccess = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
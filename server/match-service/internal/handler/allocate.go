@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/internal/rpc"
+	"mygame/server/match-service/pkg/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const allocateTimeout = 2 * time.Second
+
+// allocateRoom 按随机顺序尝试各台 Game Server 预分配房间，返回分配成功的服务器
+// 实际项目中这里应该去查 Redis 里的服务器负载信息
+// group 为允许连接的玩家，teams 为其队伍分配
+func allocateRoom(ctx context.Context, roomID string, hostUid int64, group []int64, teams map[int64]int32, cfg *pb.RoomConfig) (config.GameServerConfig, error) {
+	servers := config.AppConfig.GameServers
+	if len(servers) == 0 {
+		return config.GameServerConfig{}, fmt.Errorf("no available game servers")
+	}
+
+	allowedTeams := make([]int32, 0, len(group))
+	for _, uid := range group {
+		allowedTeams = append(allowedTeams, teams[uid])
+	}
+
+	for _, i := range rand.Perm(len(servers)) {
+		server := servers[i]
+		addr := fmt.Sprintf("%s:%d", server.IP, server.GrpcPort)
+		client, err := rpc.GameClient(addr)
+		if err != nil {
+			log.Printf("AllocateRoom %s on %s: %v", roomID, addr, err)
+			continue
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, allocateTimeout)
+		resp, err := client.AllocateRoom(callCtx, &pb.AllocateRoomReq{
+			RoomId:       roomID,
+			Config:       cfg,
+			MaxPlayers:   cfg.MaxPlayers,
+			AllowedUids:  group,
+			AllowedTeams: allowedTeams,
+			HostUid:      hostUid,
+			Mode:         cfg.Mode,
+
+			ObserverDelayMs: int32(config.AppConfig.Match.ObserverDelayMs),
+		})
+		cancel()
+		if err != nil {
+			log.Printf("AllocateRoom %s on %s: %v", roomID, addr, err)
+			continue
+		}
+		if !resp.Success {
+			log.Printf("AllocateRoom %s on %s rejected: %s", roomID, addr, resp.Message)
+			continue
+		}
+		return server, nil
+	}
+	return config.GameServerConfig{}, fmt.Errorf("no game server could allocate the room")
+}
+
+// admitPlayer 通知房间所在的 Game Server 放行新成员
+func admitPlayer(ctx context.Context, roomID string, roomData map[string]string, uid int64, maxPlayers int, team int32) error {
+	client, err := rpc.GameClientForRoom(roomData)
+	if err != nil {
+		return err
+	}
+	resp, err := client.AdmitPlayer(ctx, &pb.AdmitPlayerReq{
+		RoomId:     roomID,
+		Uid:        uid,
+		MaxPlayers: int32(maxPlayers),
+		Team:       team,
+	})
+	if err != nil {
+		return fmt.Errorf("game server unavailable: %v", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("game server rejected join: %s", resp.Message)
+	}
+	return nil
+}
+
+// StartClosedRoomCleanup 销毁 Game Server 上已停止的房间（对局结束或长时间无人），
+// 多个 Match 实例同时运行时每个房间只会被其中一个取出
+func StartClosedRoomCleanup() {
+	for {
+		roomID, err := dao.PopClosedRoom(context.Background(), 5*time.Second)
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			log.Printf("Pop closed room failed: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := dao.RemoveRoom(ctx, roomID); err != nil {
+			log.Printf("Remove closed room %s failed: %v", roomID, err)
+		}
+		cancel()
+	}
+}
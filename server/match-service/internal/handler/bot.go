@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/internal/rpc"
+	"mygame/server/match-service/pkg/config"
+)
+
+// AddBots 房主在等待室加入机器人，机器人与真人一样占用座位
+func (s *MatchService) AddBots(ctx context.Context, req *pb.AddBotsReq) (*pb.AddBotsResp, error) {
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found")
+	}
+	if roomHost(roomData) != req.Uid {
+		return &pb.AddBotsResp{Success: false, Message: "only room host can add bots"}, nil
+	}
+
+	count := int(req.Count)
+	if count <= 0 {
+		count = 1
+	}
+	uids, err := addBots(ctx, req.RoomId, roomData, count, req.Difficulty)
+	if err != nil {
+		return &pb.AddBotsResp{Success: false, Message: err.Error(), BotUids: uids}, nil
+	}
+	return &pb.AddBotsResp{Success: true, Message: "bots added", BotUids: uids}, nil
+}
+
+// RemoveBot 房主在等待室移除机器人，未指定时移除最后加入的一个
+func (s *MatchService) RemoveBot(ctx context.Context, req *pb.RemoveBotReq) (*pb.RemoveBotResp, error) {
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found")
+	}
+	if roomHost(roomData) != req.Uid {
+		return &pb.RemoveBotResp{Success: false, Message: "only room host can remove bots"}, nil
+	}
+	if roomData["status"] != "WAITING" {
+		return &pb.RemoveBotResp{Success: false, Message: "match already started"}, nil
+	}
+
+	// 1. 找到要移除的机器人，uid 越小加入越晚
+	bots, err := dao.GetBots(ctx, req.RoomId)
+	if err != nil {
+		return nil, err
+	}
+	target := int64(0)
+	for _, uid := range bots {
+		if (req.BotUid == 0 && uid < target) || uid == req.BotUid {
+			target = uid
+		}
+	}
+	if target == 0 {
+		return &pb.RemoveBotResp{Success: false, Message: "bot not in room"}, nil
+	}
+
+	// 2. 断开 Game Server 上的机器人并释放座位
+	notifyRemovePlayer(ctx, req.RoomId, roomData, target, "removed by host", false)
+	count, err := dao.RemoveBot(ctx, req.RoomId, target)
+	if err != nil {
+		return nil, err
+	}
+	if err := dao.UpdateRoom(ctx, req.RoomId, map[string]interface{}{"bots": count}); err != nil {
+		return nil, err
+	}
+	return &pb.RemoveBotResp{Success: true, Message: "bot removed"}, nil
+}
+
+// addBots 逐个在 Game Server 上加入机器人并占用座位，返回成功加入的机器人
+// 中途失败时已加入的机器人保留，与错误一起返回
+func addBots(ctx context.Context, roomID string, roomData map[string]string, count int, difficulty string) ([]int64, error) {
+	if roomData["status"] != "WAITING" {
+		return nil, fmt.Errorf("match already started")
+	}
+	maxPlayers, _ := strconv.Atoi(roomData["max_players"])
+	if free := maxPlayers - seatsTaken(roomData); free < count {
+		return nil, fmt.Errorf("only %d seats left", free)
+	}
+	if difficulty == "" {
+		difficulty = config.AppConfig.Match.BotDifficulty
+	}
+
+	client, err := rpc.GameClientForRoom(roomData)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := dao.GetTeams(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make([]int64, 0, count)
+	var botCount int64
+	defer func() {
+		if len(added) == 0 {
+			return
+		}
+		if err := dao.UpdateRoom(ctx, roomID, map[string]interface{}{"bots": botCount}); err != nil {
+			log.Printf("Failed to update bot count of room %s: %v", roomID, err)
+		}
+	}()
+
+	for i := 0; i < count; i++ {
+		// 1. 分配 uid 与队伍，团队模式下与真人一起平衡人数
+		uid, err := dao.NextBotUID(ctx, roomID)
+		if err != nil {
+			return added, err
+		}
+		assigned, err := assignTeams(teams, []int64{uid}, roomData["mode"], maxPlayers)
+		if err != nil {
+			return added, err
+		}
+
+		// 2. 先原子地占座，避免与同时加入的真人一起超出容量
+		count, err := dao.AddBot(ctx, roomID, uid, assigned[uid])
+		if err != nil {
+			return added, err
+		}
+
+		// 3. Game Server 加入机器人，失败时归还座位
+		resp, err := client.AddBot(ctx, &pb.AddBotReq{
+			RoomId:     roomID,
+			Uid:        uid,
+			Team:       assigned[uid],
+			Difficulty: difficulty,
+			Name:       fmt.Sprintf("Bot %d", -uid),
+		})
+		if err == nil && !resp.Success {
+			err = fmt.Errorf("game server rejected bot: %s", resp.Message)
+		} else if err != nil {
+			err = fmt.Errorf("game server unavailable: %v", err)
+		}
+		if err != nil {
+			if _, rmErr := dao.RemoveBot(ctx, roomID, uid); rmErr != nil {
+				log.Printf("Failed to release seat of bot %d in room %s: %v", uid, roomID, rmErr)
+			}
+			return added, err
+		}
+		botCount = count
+		teams[uid] = assigned[uid]
+		added = append(added, uid)
+	}
+	return added, nil
+}
+
+// StartBotBackfill 定期为等待超过 match.bot_backfill_seconds 且人数不足的公开房间补充机器人，
+// 补到开局最少人数，每个房间只补充一次；未配置时直接返回
+func StartBotBackfill() {
+	timeout := time.Duration(config.AppConfig.Match.BotBackfillSeconds) * time.Second
+	if timeout <= 0 {
+		return
+	}
+	interval := timeout / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		backfillRooms(timeout)
+	}
+}
+
+func backfillRooms(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	roomIDs, err := dao.WaitingRooms(ctx)
+	if err != nil {
+		log.Printf("Bot backfill: list waiting rooms failed: %v", err)
+		return
+	}
+	for _, roomID := range roomIDs {
+		roomData, err := dao.GetRoom(ctx, roomID)
+		if err != nil || len(roomData) == 0 || roomData["status"] != "WAITING" {
+			continue
+		}
+		createdAt, _ := strconv.ParseInt(roomData["created_at"], 10, 64)
+		if time.Since(time.Unix(createdAt, 0)) < timeout {
+			continue
+		}
+		need := minPlayers() - seatsTaken(roomData)
+		if need <= 0 || roomData["current_players"] == "0" {
+			continue
+		}
+
+		// 多个 Match 实例同时扫描时只由一个实例补充
+		if ok, err := dao.ClaimBackfill(ctx, roomID); err != nil || !ok {
+			continue
+		}
+		uids, err := addBots(ctx, roomID, roomData, need, "")
+		if err != nil {
+			log.Printf("Bot backfill of room %s: %v", roomID, err)
+		}
+		if len(uids) > 0 {
+			log.Printf("Backfilled room %s with %d bots", roomID, len(uids))
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/pkg/config"
+)
+
+// AddBot 记录加入的机器人，rejectUID 的机器人被拒绝
+func (g *fakeGame) AddBot(ctx context.Context, req *pb.AddBotReq) (*pb.AddBotResp, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if req.Uid == g.rejectUID {
+		return &pb.AddBotResp{Success: false, Message: "rejected"}, nil
+	}
+	g.bots = append(g.bots, req.Uid)
+	return &pb.AddBotResp{Success: true}, nil
+}
+
+func (g *fakeGame) botUIDs() []int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]int64(nil), g.bots...)
+}
+
+func TestAddBots(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.AddBotsReq
+		rejectUID   int64
+		wantSuccess bool
+		wantBots    []int64
+		wantCount   string
+	}{
+		{name: "add one by default", req: &pb.AddBotsReq{Uid: 1}, wantSuccess: true, wantBots: []int64{-1}, wantCount: "1"},
+		{name: "fill the room", req: &pb.AddBotsReq{Uid: 1, Count: 2}, wantSuccess: true, wantBots: []int64{-1, -2}, wantCount: "2"},
+		{name: "not enough seats", req: &pb.AddBotsReq{Uid: 1, Count: 3}},
+		{name: "not host", req: &pb.AddBotsReq{Uid: 2}},
+		{name: "game server rejects second bot", req: &pb.AddBotsReq{Uid: 1, Count: 2}, rejectUID: -2, wantBots: []int64{-1}, wantCount: "1"},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1, 2)
+			game := &fakeGame{rejectUID: tt.rejectUID}
+			startFakeGame(t, game)
+			tt.req.RoomId = "r1"
+
+			resp, err := s.AddBots(ctx, tt.req)
+			if err != nil || resp.Success != tt.wantSuccess {
+				t.Fatalf("add bots = %+v, %v, want success %v", resp, err, tt.wantSuccess)
+			}
+			if bots := game.botUIDs(); !reflect.DeepEqual(bots, tt.wantBots) {
+				t.Fatalf("game server bots = %v, want %v", bots, tt.wantBots)
+			}
+			// 被拒绝的机器人归还座位
+			if bots, _ := dao.GetBots(ctx, "r1"); len(bots) != len(tt.wantBots) {
+				t.Fatalf("seated bots = %v, want %v", bots, tt.wantBots)
+			}
+			if count := roomField(t, "bots"); count != tt.wantCount {
+				t.Fatalf("bots field = %q, want %q", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestBackfillRooms(t *testing.T) {
+	defer func() { config.AppConfig.Match.MinPlayers = 0 }()
+	config.AppConfig.Match.MinPlayers = 3
+
+	tests := []struct {
+		name     string
+		members  []int64
+		fields   map[string]string
+		wantBots int
+	}{
+		{name: "fill to min players", members: []int64{1}, wantBots: 2},
+		{name: "enough players", members: []int64{1, 2, 3}},
+		{name: "empty room"},
+		{name: "waiting briefly", members: []int64{1}, fields: map[string]string{"created_at": strconv.FormatInt(time.Now().Unix(), 10)}},
+		{name: "already started", members: []int64{1}, fields: map[string]string{"status": "PLAYING"}},
+		{name: "claimed by another instance", members: []int64{1}, fields: map[string]string{"bot_backfilled": "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seedRoom(t, 1, tt.members...)
+			game := &fakeGame{}
+			startFakeGame(t, game)
+			for k, v := range tt.fields {
+				mr.HSet(dao.KeyRoomPrefix+"r1", k, v)
+			}
+
+			// 每个房间只补充一次
+			backfillRooms(time.Minute)
+			backfillRooms(time.Minute)
+			if bots := game.botUIDs(); len(bots) != tt.wantBots {
+				t.Fatalf("backfilled bots = %v, want %d", bots, tt.wantBots)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/internal/rpc"
+)
+
+// roomHost 当前房主，旧房间没有 host_uid 时以创建者为准
+func roomHost(roomData map[string]string) int64 {
+	hostUid, _ := strconv.ParseInt(roomData["host_uid"], 10, 64)
+	if hostUid == 0 {
+		hostUid, _ = strconv.ParseInt(roomData["creator_uid"], 10, 64)
+	}
+	return hostUid
+}
+
+// notifyRemovePlayer 通知 Game Server 断开玩家；房间尚未在 Game 中运行时忽略
+func notifyRemovePlayer(ctx context.Context, roomID string, roomData map[string]string, uid int64, reason string, ban bool) {
+	client, err := rpc.GameClientForRoom(roomData)
+	if err != nil {
+		log.Printf("RemovePlayer %d from room %s: %v", uid, roomID, err)
+		return
+	}
+	_, err = client.RemovePlayer(ctx, &pb.RemovePlayerReq{RoomId: roomID, Uid: uid, Reason: reason, Ban: ban})
+	if err != nil {
+		log.Printf("RemovePlayer %d from room %s: %v", uid, roomID, err)
+	}
+}
+
+// notifyTransferHost 通知 Game Server 房主变化
+func notifyTransferHost(ctx context.Context, roomID string, roomData map[string]string, uid int64) {
+	client, err := rpc.GameClientForRoom(roomData)
+	if err != nil {
+		log.Printf("TransferHost of room %s: %v", roomID, err)
+		return
+	}
+	_, err = client.TransferHost(ctx, &pb.GameTransferHostReq{RoomId: roomID, NewHostUid: uid})
+	if err != nil {
+		log.Printf("TransferHost of room %s: %v", roomID, err)
+	}
+}
+
+// LeaveRoom 玩家主动离开房间，房主离开时自动转移房主，最后一人离开时解散房间
+func (s *MatchService) LeaveRoom(ctx context.Context, req *pb.LeaveRoomReq) (*pb.LeaveRoomResp, error) {
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found")
+	}
+
+	isMember, err := dao.IsMember(ctx, req.RoomId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return &pb.LeaveRoomResp{Success: false, Message: "not in room"}, nil
+	}
+
+	// 1. 移除成员
+	count, err := dao.RemoveMember(ctx, req.RoomId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 房间空了直接解散
+	if count == 0 {
+		notifyRemovePlayer(ctx, req.RoomId, roomData, req.Uid, "left", false)
+		if err := dao.RemoveRoom(ctx, req.RoomId); err != nil {
+			return nil, err
+		}
+		return &pb.LeaveRoomResp{Success: true, Message: "room closed"}, nil
+	}
+
+	// 3. 房主离开，转移给剩余成员中的一人
+	hostUid := roomHost(roomData)
+	updateFields := map[string]interface{}{"current_players": count}
+	if hostUid == req.Uid {
+		members, err := dao.GetMembers(ctx, req.RoomId)
+		if err != nil {
+			return nil, err
+		}
+		if len(members) > 0 {
+			hostUid = members[0]
+			updateFields["host_uid"] = hostUid
+		}
+	}
+	if err := dao.UpdateRoom(ctx, req.RoomId, updateFields); err != nil {
+		return nil, err
+	}
+
+	// 4. 同步到 Game Server：先转移房主，再断开离开的玩家，避免 Game 自行挑选房主
+	if _, ok := updateFields["host_uid"]; ok {
+		notifyTransferHost(ctx, req.RoomId, roomData, hostUid)
+	}
+	notifyRemovePlayer(ctx, req.RoomId, roomData, req.Uid, "left", false)
+
+	return &pb.LeaveRoomResp{Success: true, Message: "left room", HostUid: hostUid}, nil
+}
+
+// KickPlayer 房主把玩家移出房间，ban 为 true 时禁止其再次加入
+func (s *MatchService) KickPlayer(ctx context.Context, req *pb.KickPlayerReq) (*pb.KickPlayerResp, error) {
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found")
+	}
+
+	if roomHost(roomData) != req.Uid {
+		return &pb.KickPlayerResp{Success: false, Message: "only room host can kick players"}, nil
+	}
+	if req.TargetUid == req.Uid {
+		return &pb.KickPlayerResp{Success: false, Message: "cannot kick yourself"}, nil
+	}
+
+	isMember, err := dao.IsMember(ctx, req.RoomId, req.TargetUid)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember && !req.Ban {
+		return &pb.KickPlayerResp{Success: false, Message: "player not in room"}, nil
+	}
+
+	// 1. 先写封禁名单，保证被踢玩家无法抢在移除之前重新加入
+	if req.Ban {
+		if err := dao.BanPlayer(ctx, req.RoomId, req.TargetUid); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. 移除成员并更新人数
+	if isMember {
+		count, err := dao.RemoveMember(ctx, req.RoomId, req.TargetUid)
+		if err != nil {
+			return nil, err
+		}
+		if err := dao.UpdateRoom(ctx, req.RoomId, map[string]interface{}{"current_players": count}); err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 断开 Game 中的连接
+	reason := req.Reason
+	if reason == "" {
+		reason = "kicked by host"
+	}
+	notifyRemovePlayer(ctx, req.RoomId, roomData, req.TargetUid, reason, req.Ban)
+
+	return &pb.KickPlayerResp{Success: true, Message: "player kicked"}, nil
+}
+
+// TransferHost 房主把房主身份转移给房间内的另一名玩家
+func (s *MatchService) TransferHost(ctx context.Context, req *pb.TransferHostReq) (*pb.TransferHostResp, error) {
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found")
+	}
+
+	if roomHost(roomData) != req.Uid {
+		return &pb.TransferHostResp{Success: false, Message: "only room host can transfer host"}, nil
+	}
+	if req.NewHostUid == req.Uid {
+		return &pb.TransferHostResp{Success: false, Message: "already room host"}, nil
+	}
+
+	isMember, err := dao.IsMember(ctx, req.RoomId, req.NewHostUid)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return &pb.TransferHostResp{Success: false, Message: "player not in room"}, nil
+	}
+
+	if err := dao.UpdateRoom(ctx, req.RoomId, map[string]interface{}{"host_uid": req.NewHostUid}); err != nil {
+		return nil, err
+	}
+	notifyTransferHost(ctx, req.RoomId, roomData, req.NewHostUid)
+
+	return &pb.TransferHostResp{Success: true, Message: "host transferred"}, nil
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/pkg/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var mr *miniredis.Miniredis
+
+func TestMain(m *testing.M) {
+	var err error
+	mr, err = miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	dao.RDB = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	config.AppConfig = &config.Config{}
+	code := m.Run()
+	dao.RDB.Close()
+	mr.Close()
+	os.Exit(code)
+}
+
+// seedRoom 清空 Redis 后创建一个等待中的房间，host 为房主，members 为房间成员（含房主）
+// 房间没有 Game Server 的 gRPC 地址，通知 Game 的调用只记录日志
+func seedRoom(t *testing.T, host int64, members ...int64) context.Context {
+	t.Helper()
+	mr.FlushAll()
+	ctx := context.Background()
+	err := dao.SaveRoom(ctx, "r1", map[string]interface{}{
+		"room_name":       "test",
+		"status":          "WAITING",
+		"map_id":          1,
+		"max_players":     4,
+		"current_players": len(members),
+		"host_uid":        host,
+		"created_at":      1,
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, uid := range members {
+		if _, err := dao.AddMember(ctx, "r1", uid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ctx
+}
+
+func roomField(t *testing.T, field string) string {
+	t.Helper()
+	return mr.HGet(dao.KeyRoomPrefix+"r1", field)
+}
+
+func TestLeaveRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		members     []int64
+		uid         int64
+		wantSuccess bool
+		wantHost    string
+		wantPlayers string
+		wantClosed  bool
+	}{
+		{name: "member leaves", members: []int64{1, 2, 3}, uid: 2, wantSuccess: true, wantHost: "1", wantPlayers: "2"},
+		{name: "host leaves", members: []int64{1, 2}, uid: 1, wantSuccess: true, wantHost: "2", wantPlayers: "1"},
+		{name: "last player closes room", members: []int64{1}, uid: 1, wantSuccess: true, wantClosed: true},
+		{name: "not in room", members: []int64{1}, uid: 9, wantHost: "1", wantPlayers: "1"},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, tt.members...)
+			resp, err := s.LeaveRoom(ctx, &pb.LeaveRoomReq{RoomId: "r1", Uid: tt.uid})
+			if err != nil || resp.Success != tt.wantSuccess {
+				t.Fatalf("leave = %+v, %v, want success %v", resp, err, tt.wantSuccess)
+			}
+			if tt.wantClosed {
+				if mr.Exists(dao.KeyRoomPrefix + "r1") {
+					t.Fatal("empty room not removed")
+				}
+				return
+			}
+			if host, players := roomField(t, "host_uid"), roomField(t, "current_players"); host != tt.wantHost || players != tt.wantPlayers {
+				t.Fatalf("host %s, players %s, want %s, %s", host, players, tt.wantHost, tt.wantPlayers)
+			}
+		})
+	}
+}
+
+func TestKickPlayer(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *pb.KickPlayerReq
+		wantSuccess bool
+		wantPlayers string
+		wantBanned  bool
+	}{
+		{name: "kick", req: &pb.KickPlayerReq{Uid: 1, TargetUid: 2}, wantSuccess: true, wantPlayers: "2"},
+		{name: "kick and ban", req: &pb.KickPlayerReq{Uid: 1, TargetUid: 2, Ban: true}, wantSuccess: true, wantPlayers: "2", wantBanned: true},
+		{name: "ban player not in room", req: &pb.KickPlayerReq{Uid: 1, TargetUid: 9, Ban: true}, wantSuccess: true, wantPlayers: "3", wantBanned: true},
+		{name: "kick player not in room", req: &pb.KickPlayerReq{Uid: 1, TargetUid: 9}, wantPlayers: "3"},
+		{name: "not host", req: &pb.KickPlayerReq{Uid: 2, TargetUid: 3}, wantPlayers: "3"},
+		{name: "kick yourself", req: &pb.KickPlayerReq{Uid: 1, TargetUid: 1}, wantPlayers: "3"},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1, 2, 3)
+			tt.req.RoomId = "r1"
+			resp, err := s.KickPlayer(ctx, tt.req)
+			if err != nil || resp.Success != tt.wantSuccess {
+				t.Fatalf("kick = %+v, %v, want success %v", resp, err, tt.wantSuccess)
+			}
+			if players := roomField(t, "current_players"); players != tt.wantPlayers {
+				t.Fatalf("players = %s, want %s", players, tt.wantPlayers)
+			}
+			if banned, _ := dao.IsBanned(ctx, "r1", tt.req.TargetUid); banned != tt.wantBanned {
+				t.Fatalf("banned = %v, want %v", banned, tt.wantBanned)
+			}
+		})
+	}
+}
+
+func TestTransferHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		uid         int64
+		newHost     int64
+		wantSuccess bool
+		wantHost    string
+	}{
+		{name: "transfer", uid: 1, newHost: 2, wantSuccess: true, wantHost: "2"},
+		{name: "not host", uid: 2, newHost: 2, wantHost: "1"},
+		{name: "already host", uid: 1, newHost: 1, wantHost: "1"},
+		{name: "not in room", uid: 1, newHost: 9, wantHost: "1"},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1, 2)
+			resp, err := s.TransferHost(ctx, &pb.TransferHostReq{RoomId: "r1", Uid: tt.uid, NewHostUid: tt.newHost})
+			if err != nil || resp.Success != tt.wantSuccess {
+				t.Fatalf("transfer = %+v, %v, want success %v", resp, err, tt.wantSuccess)
+			}
+			if host := roomField(t, "host_uid"); host != tt.wantHost {
+				t.Fatalf("host = %s, want %s", host, tt.wantHost)
+			}
+		})
+	}
+}
@@ -2,16 +2,16 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"time"
 
 	pb "mygame/proto"
 	"mygame/server/match-service/internal/dao"
-	"mygame/server/match-service/pkg/config"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 )
 
 type MatchService struct {
@@ -20,17 +20,13 @@ type MatchService struct {
 
 // CreateRoom 创建房间
 func (s *MatchService) CreateRoom(ctx context.Context, req *pb.CreateRoomReq) (*pb.CreateRoomResp, error) {
-	// 1. 简单的调度算法：随机选择一个 Game Server
-	// 实际项目中这里应该去查 Redis 里的服务器负载信息
-	servers := config.AppConfig.GameServers
-	if len(servers) == 0 {
-		return nil, fmt.Errorf("no available game servers")
-	}
-	targetServer := servers[rand.Intn(len(servers))]
-
-	// 2. 生成房间 ID 和 Token
+	// 1. 生成房间 ID；在队伍中时整队一起入座
 	roomID := uuid.New().String()
-	token := uuid.New().String()
+
+	group, party, err := seatGroup(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
 
 	roomName := "Room " + roomID[:8]
 	if req.Config != nil && req.Config.RoomName != "" {
@@ -42,66 +38,183 @@ func (s *MatchService) CreateRoom(ctx context.Context, req *pb.CreateRoomReq) (*
 		maxPlayers = req.Config.MaxPlayers
 	}
 
-	// 3. 保存到 Redis
-	err := dao.SaveRoom(ctx, roomID, map[string]interface{}{
-		"room_name":       roomName,
-		"max_players":     maxPlayers,
-		"current_players": 0,
-		"status":          "WAITING",
-		"server_ip":       targetServer.IP,
-		"server_port":     targetServer.Port,
-		"token":           token,
-		"creator_uid":     req.Uid,
-		"created_at":      time.Now().Unix(),
+	if int(maxPlayers) < len(group) {
+		return nil, fmt.Errorf("room is too small for the party")
+	}
+
+	mapID := int32(0)
+	if req.Config != nil {
+		mapID = req.Config.MapId
+	}
+
+	mode, err := resolveMode(req.GetConfig().GetMode())
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMaxPlayers(mode, int(maxPlayers)); err != nil {
+		return nil, err
+	}
+	teams, err := assignTeams(nil, group, mode, int(maxPlayers))
+	if err != nil {
+		return nil, err
+	}
+
+	visibility := visibilityOf(req.Config)
+	passwordHash := ""
+	if req.Config != nil && req.Config.Password != "" {
+		hash, err := hashRoomPassword(req.Config.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hash
+	}
+
+	// 2. 在 Game Server 上预分配房间，失败时换下一台
+	targetServer, err := allocateRoom(ctx, roomID, req.Uid, group, teams, &pb.RoomConfig{
+		RoomName:   roomName,
+		MapId:      mapID,
+		MaxPlayers: maxPlayers,
+		Visibility: req.GetConfig().GetVisibility(),
+		Mode:       mode,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 4. 返回给 Gateway -> Client
+	// 3. 生成邀请码
+	inviteCode, err := dao.CreateInviteCode(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 保存到 Redis，私有房间不进入公开列表
+	err = dao.SaveRoom(ctx, roomID, map[string]interface{}{
+		"room_name":        roomName,
+		"max_players":      maxPlayers,
+		"current_players":  len(group),
+		"status":           "WAITING",
+		"server_ip":        targetServer.IP,
+		"server_port":      targetServer.Port,
+		"server_grpc_port": targetServer.GrpcPort,
+		"creator_uid":      req.Uid,
+		"created_at":       time.Now().Unix(),
+		"host_uid":         req.Uid,
+		"map_id":           mapID,
+		"mode":             mode,
+		"server_region":    targetServer.Region,
+		"visibility":       visibility,
+		"password_hash":    passwordHash,
+		"invite_code":      inviteCode,
+	}, visibility == VisibilityPublic)
+	if err != nil {
+		dao.DeleteInviteCode(ctx, inviteCode)
+		return nil, err
+	}
+	tickets, _, err := seatMembers(ctx, roomID, group, teams)
+	if err != nil {
+		dao.RemoveRoom(ctx, roomID)
+		return nil, err
+	}
+	if party != nil {
+		dao.UpdateParty(ctx, party.ID, map[string]interface{}{"room_id": roomID})
+	}
+
+	// 5. 返回给 Gateway -> Client
 	return &pb.CreateRoomResp{
 		RoomId:     roomID,
+		RoomName:   roomName,
 		ServerIp:   targetServer.IP,
 		ServerPort: int32(targetServer.Port),
-		RoomToken:  token, // Client 拿着这个去连 WS
+		RoomToken:  tickets[req.Uid], // Client 拿着这个去连 WS
+		InviteCode: inviteCode,
+		Tickets:    toRoomTickets(group, tickets, teams),
 	}, nil
 }
 
-// ListRooms 获取列表
+// ListRooms 获取列表（过滤、排序、游标分页）
 func (s *MatchService) ListRooms(ctx context.Context, req *pb.ListRoomsReq) (*pb.ListRoomsResp, error) {
-	data, err := dao.GetAllRooms(ctx)
+	sortBy := dao.SortNewest
+	switch req.SortBy {
+	case pb.ListRoomsReq_MOST_PLAYERS:
+		sortBy = dao.SortMostPlayers
+	case pb.ListRoomsReq_FEWEST_PLAYERS:
+		sortBy = dao.SortFewestPlayers
+	}
+
+	data, nextCursor, total, err := dao.QueryRooms(ctx, dao.RoomQuery{
+		Status:  req.Status,
+		MapID:   req.MapId,
+		NotFull: req.NotFull,
+		Name:    req.Name,
+		SortBy:  sortBy,
+		Cursor:  req.Cursor,
+		Limit:   int(req.Limit),
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var pbRooms []*pb.RoomInfo
+	pbRooms := make([]*pb.RoomInfo, 0, len(data))
 	for _, r := range data {
-		// Redis HGetAll 返回的是 string，需要转换
-		cur, _ := strconv.Atoi(r["current_players"])
-		max, _ := strconv.Atoi(r["max_players"])
-
-		pbRooms = append(pbRooms, &pb.RoomInfo{
-			RoomId:         r["room_id"],
-			CurrentPlayers: int32(cur),
-			MaxPlayers:     int32(max),
-			Status:         r["status"],
-		})
+		pbRooms = append(pbRooms, toRoomInfo(r))
 	}
 
-	return &pb.ListRoomsResp{Rooms: pbRooms}, nil
+	return &pb.ListRoomsResp{Rooms: pbRooms, NextCursor: nextCursor, Total: total}, nil
+}
+
+// toRoomInfo Redis Hash -> RoomInfo，HGetAll 返回的是 string，需要转换
+func toRoomInfo(r map[string]string) *pb.RoomInfo {
+	cur, _ := strconv.Atoi(r["current_players"])
+	max, _ := strconv.Atoi(r["max_players"])
+	mapID, _ := strconv.Atoi(r["map_id"])
+	createdAt, _ := strconv.ParseInt(r["created_at"], 10, 64)
+	observers, _ := strconv.Atoi(r["observers"])
+	bots, _ := strconv.Atoi(r["bots"])
+
+	return &pb.RoomInfo{
+		RoomId:         r["room_id"],
+		RoomName:       r["room_name"],
+		CurrentPlayers: int32(cur),
+		MaxPlayers:     int32(max),
+		Status:         r["status"],
+		HasPassword:    r["password_hash"] != "",
+		HostUid:        roomHost(r),
+		MapId:          int32(mapID),
+		CreatedAt:      createdAt,
+		ServerRegion:   r["server_region"],
+		ServerAddr:     r["server_ip"] + ":" + r["server_port"],
+		Mode:           r["mode"],
+		Observers:      int32(observers),
+		MaxObservers:   int32(maxObservers()),
+		Bots:           int32(bots),
+	}
 }
 
 func (s *MatchService) JoinRoom(ctx context.Context, req *pb.JoinRoomReq) (*pb.JoinRoomResp, error) {
+	// 只提供邀请码时先解析出房间 ID
+	if req.RoomId == "" && req.InviteCode != "" {
+		roomID, err := dao.ResolveInviteCode(ctx, normalizeInviteCode(req.InviteCode))
+		if err == redis.Nil {
+			return nil, fmt.Errorf("invalid invite code")
+		}
+		if err != nil {
+			return nil, err
+		}
+		req.RoomId = roomID
+	}
+
 	roomData, err := dao.GetRoom(ctx, req.RoomId)
 	if err != nil || len(roomData) == 0 {
 		return nil, fmt.Errorf("room not found or expired")
 	}
 
-	curPlayers, _ := strconv.Atoi(roomData["current_players"])
-	maxPlayers, _ := strconv.Atoi(roomData["max_players"])
-
-	if curPlayers >= maxPlayers {
-		return nil, fmt.Errorf("room is full")
+	// 通过好友加入：好友在房间内时视同持有邀请码
+	if req.FriendUid != 0 {
+		if err := checkFriendAccess(ctx, req.RoomId, req.Uid, req.FriendUid); err != nil {
+			return nil, err
+		}
+	} else if err := checkRoomAccess(roomData, req.Password, req.InviteCode); err != nil {
+		return nil, err
 	}
 
 	status, _ := roomData["status"]
@@ -109,26 +222,95 @@ func (s *MatchService) JoinRoom(ctx context.Context, req *pb.JoinRoomReq) (*pb.J
 		return nil, fmt.Errorf("room is not available, status: %s", status)
 	}
 
-	token := uuid.New().String()
-	err = dao.UpdateRoom(ctx, req.RoomId, map[string]interface{}{
-		"current_players": curPlayers + 1,
-		"token":           token,
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	port, _ := strconv.Atoi(roomData["server_port"])
 	if port == 0 {
 		return nil, fmt.Errorf("invalid server port")
 	}
-
-	return &pb.JoinRoomResp{
+	resp := &pb.JoinRoomResp{
 		RoomId:     req.RoomId,
 		ServerIp:   roomData["server_ip"],
 		ServerPort: int32(port),
-		RoomToken:  token,
-	}, nil
+	}
+
+	// 已在房间内的成员（重连，或随队长入座的队员）只重新签发自己的 ticket，不占用新的座位
+	isMember, err := dao.IsMember(ctx, req.RoomId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		tickets, err := issueTickets(ctx, req.RoomId, []int64{req.Uid})
+		if err != nil {
+			return nil, err
+		}
+		resp.RoomToken = tickets[req.Uid]
+		return resp, nil
+	}
+
+	// 1. 在队伍中时由队长带领整队入座
+	group, party, err := seatGroup(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	for _, uid := range group {
+		banned, err := dao.IsBanned(ctx, req.RoomId, uid)
+		if err != nil {
+			return nil, err
+		}
+		if banned {
+			if uid == req.Uid {
+				return nil, fmt.Errorf("you are banned from this room")
+			}
+			return nil, fmt.Errorf("a party member is banned from this room")
+		}
+	}
+
+	// 2. 一次性为全队原子地占座（容量校验与占座在同一个 Lua 脚本中），再分配到同一支队伍
+	maxPlayers, _ := strconv.Atoi(roomData["max_players"])
+	if _, err := dao.ReserveSeats(ctx, req.RoomId, group); err != nil {
+		return nil, err
+	}
+	// 之后任一步失败：撤销已放行的玩家并归还整队的座位
+	rollback := func(admitted []int64) {
+		for _, uid := range admitted {
+			notifyRemovePlayer(ctx, req.RoomId, roomData, uid, "join failed", false)
+		}
+		releaseSeats(ctx, req.RoomId, group)
+	}
+	existing, err := dao.GetTeams(ctx, req.RoomId)
+	if err != nil {
+		rollback(nil)
+		return nil, err
+	}
+	teams, err := assignTeams(existing, group, roomData["mode"], maxPlayers)
+	if err != nil {
+		rollback(nil)
+		return nil, err
+	}
+
+	// 3. Game Server 只接受放行过的玩家连接，任一队员失败则整队回滚
+	for i, uid := range group {
+		if err := admitPlayer(ctx, req.RoomId, roomData, uid, maxPlayers, teams[uid]); err != nil {
+			rollback(group[:i])
+			return nil, err
+		}
+	}
+
+	tickets, count, err := seatMembers(ctx, req.RoomId, group, teams)
+	if err != nil {
+		rollback(group)
+		return nil, err
+	}
+	if err := dao.UpdateRoom(ctx, req.RoomId, map[string]interface{}{"current_players": count}); err != nil {
+		rollback(group)
+		return nil, err
+	}
+	if party != nil {
+		dao.UpdateParty(ctx, party.ID, map[string]interface{}{"room_id": req.RoomId})
+	}
+
+	resp.RoomToken = tickets[req.Uid]
+	resp.Tickets = toRoomTickets(group, tickets, teams)
+	return resp, nil
 }
 
 func (s *MatchService) UpdateRoom(ctx context.Context, req *pb.UpdateRoomReq) (*pb.UpdateRoomResp, error) {
@@ -138,9 +320,8 @@ func (s *MatchService) UpdateRoom(ctx context.Context, req *pb.UpdateRoomReq) (*
 		return nil, fmt.Errorf("room not found")
 	}
 
-	// 验证UID是否匹配creator_uid（只有房主可以更新）
-	creatorUid, _ := strconv.ParseInt(roomData["creator_uid"], 10, 64)
-	if creatorUid != req.Uid {
+	// 只有房主可以更新
+	if roomHost(roomData) != req.Uid {
 		return &pb.UpdateRoomResp{
 			Success: false,
 			Message: "only room host can update room settings",
@@ -158,13 +339,55 @@ func (s *MatchService) UpdateRoom(ctx context.Context, req *pb.UpdateRoomReq) (*
 
 	// 构建更新字段
 	updateFields := make(map[string]interface{})
+	visibility := roomData["visibility"]
 	if req.Config != nil {
 		if req.Config.RoomName != "" {
 			updateFields["room_name"] = req.Config.RoomName
 		}
 		if req.Config.MaxPlayers > 0 {
+			if err := checkMaxPlayers(roomData["mode"], int(req.Config.MaxPlayers)); err != nil {
+				return &pb.UpdateRoomResp{Success: false, Message: err.Error()}, nil
+			}
+			// 不能小于已入座的人数（与并发加入一起在脚本中原子校验）
+			if err := dao.SetMaxPlayers(ctx, req.RoomId, int(req.Config.MaxPlayers)); err != nil {
+				if errors.Is(err, dao.ErrBelowOccupancy) || errors.Is(err, dao.ErrRoomNotWaiting) {
+					return &pb.UpdateRoomResp{Success: false, Message: err.Error()}, nil
+				}
+				return nil, err
+			}
 			updateFields["max_players"] = req.Config.MaxPlayers
 		}
+		if req.Config.MapId > 0 {
+			updateFields["map_id"] = req.Config.MapId
+		}
+		if req.Config.Visibility != pb.RoomConfig_UNSPECIFIED {
+			visibility = visibilityOf(req.Config)
+			updateFields["visibility"] = visibility
+		}
+		if req.Config.Password != "" {
+			hash, err := hashRoomPassword(req.Config.Password)
+			if err != nil {
+				return nil, err
+			}
+			updateFields["password_hash"] = hash
+		}
+	}
+	if req.ClearPassword {
+		updateFields["password_hash"] = ""
+	}
+
+	// 重新生成邀请码，旧码立即失效
+	inviteCode := roomData["invite_code"]
+	if req.RegenerateInviteCode {
+		newCode, err := dao.CreateInviteCode(ctx, req.RoomId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to regenerate invite code: %v", err)
+		}
+		if inviteCode != "" {
+			dao.DeleteInviteCode(ctx, inviteCode)
+		}
+		inviteCode = newCode
+		updateFields["invite_code"] = inviteCode
 	}
 
 	// 执行更新
@@ -173,9 +396,15 @@ func (s *MatchService) UpdateRoom(ctx context.Context, req *pb.UpdateRoomReq) (*
 			return nil, fmt.Errorf("failed to update room: %v", err)
 		}
 	}
+	if _, ok := updateFields["visibility"]; ok {
+		if err := dao.SetRoomListed(ctx, req.RoomId, visibility != VisibilityPrivate); err != nil {
+			return nil, fmt.Errorf("failed to update room visibility: %v", err)
+		}
+	}
 
 	return &pb.UpdateRoomResp{
-		Success: true,
-		Message: "room updated successfully",
+		Success:    true,
+		Message:    "room updated successfully",
+		InviteCode: inviteCode,
 	}, nil
 }
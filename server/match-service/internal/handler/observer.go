@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/pkg/config"
+)
+
+func maxObservers() int {
+	if n := config.AppConfig.Match.MaxObservers; n > 0 {
+		return n
+	}
+	return 10
+}
+
+func observerReserveTTL() time.Duration {
+	if n := config.AppConfig.Match.ObserverReserveSeconds; n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	return 30 * time.Second
+}
+
+// JoinAsObserver 以观战者身份进入房间，等待中和对局中的房间都可以观战
+func (s *MatchService) JoinAsObserver(ctx context.Context, req *pb.JoinAsObserverReq) (*pb.JoinAsObserverResp, error) {
+	// 1. 解析邀请码
+	if req.RoomId == "" && req.InviteCode != "" {
+		roomID, err := dao.ResolveInviteCode(ctx, normalizeInviteCode(req.InviteCode))
+		if err == redis.Nil {
+			return nil, fmt.Errorf("invalid invite code")
+		}
+		if err != nil {
+			return nil, err
+		}
+		req.RoomId = roomID
+	}
+
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found or expired")
+	}
+
+	// 2. 访问控制与加入房间一致：好友在房间内，或通过密码/邀请码校验
+	if req.FriendUid != 0 {
+		if err := checkFriendAccess(ctx, req.RoomId, req.Uid, req.FriendUid); err != nil {
+			return nil, err
+		}
+	} else if err := checkRoomAccess(roomData, req.Password, req.InviteCode); err != nil {
+		return nil, err
+	}
+
+	banned, err := dao.IsBanned(ctx, req.RoomId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if banned {
+		return nil, fmt.Errorf("you are banned from this room")
+	}
+
+	// 房间里的玩家不能同时观战自己的对局
+	isMember, err := dao.IsMember(ctx, req.RoomId, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return nil, fmt.Errorf("players cannot observe their own room")
+	}
+
+	port, _ := strconv.Atoi(roomData["server_port"])
+	if port == 0 {
+		return nil, fmt.Errorf("invalid server port")
+	}
+
+	// 3. 预留观战名额并签发观战 ticket，连上 Game Server 后才转为正式占用
+	ticket := uuid.New().String()
+	if _, err := dao.AddObserver(ctx, req.RoomId, req.Uid, ticket, maxObservers(), observerReserveTTL()); err != nil {
+		return nil, err
+	}
+
+	return &pb.JoinAsObserverResp{
+		RoomId:         req.RoomId,
+		ServerIp:       roomData["server_ip"],
+		ServerPort:     int32(port),
+		ObserverTicket: ticket,
+		DelayMs:        int32(config.AppConfig.Match.ObserverDelayMs),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"testing"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+)
+
+func TestJoinAsObserver(t *testing.T) {
+	tests := []struct {
+		name    string
+		uid     int64
+		private bool
+		banned  bool
+		code    string
+		wantErr bool
+	}{
+		{name: "observe", uid: 9},
+		{name: "player in room", uid: 2, wantErr: true},
+		{name: "banned", uid: 9, banned: true, wantErr: true},
+		{name: "private room without code", uid: 9, private: true, wantErr: true},
+		{name: "private room with code", uid: 9, private: true, code: "ABC234"},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1, 2)
+			mr.HSet(dao.KeyRoomPrefix+"r1", "server_ip", "127.0.0.1", "server_port", "9000", "invite_code", "ABC234")
+			if tt.private {
+				mr.HSet(dao.KeyRoomPrefix+"r1", "visibility", VisibilityPrivate)
+			}
+			if tt.banned {
+				if err := dao.BanPlayer(ctx, "r1", tt.uid); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			resp, err := s.JoinAsObserver(ctx, &pb.JoinAsObserverReq{RoomId: "r1", Uid: tt.uid, InviteCode: tt.code})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("observe = %+v, %v, wantErr %v", resp, err, tt.wantErr)
+			}
+			isObserver, _ := dao.IsObserver(ctx, "r1", tt.uid)
+			if isObserver == tt.wantErr {
+				t.Fatalf("holds observer slot = %v, want %v", isObserver, !tt.wantErr)
+			}
+			if !tt.wantErr && (resp.ObserverTicket == "" || resp.ServerPort != 9000) {
+				t.Fatalf("resp = %+v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/pkg/config"
+
+	"github.com/google/uuid"
+)
+
+func partyMaxSize() int {
+	if size := config.AppConfig.Match.PartyMaxSize; size > 0 {
+		return size
+	}
+	return 4
+}
+
+func toPartyInfo(p *dao.Party) *pb.PartyInfo {
+	if p == nil {
+		return nil
+	}
+	return &pb.PartyInfo{
+		PartyId:   p.ID,
+		LeaderUid: p.LeaderUID,
+		Members:   p.Members,
+		Invites:   p.Invites,
+		MaxSize:   int32(p.MaxSize),
+		RoomId:    p.RoomID,
+	}
+}
+
+// partyResp 返回操作结果并附带最新的队伍状态
+func partyResp(ctx context.Context, partyID, message string) (*pb.PartyResp, error) {
+	party, err := dao.GetParty(ctx, partyID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PartyResp{Success: true, Message: message, Party: toPartyInfo(party)}, nil
+}
+
+func (s *MatchService) CreateParty(ctx context.Context, req *pb.CreatePartyReq) (*pb.PartyResp, error) {
+	existing, err := dao.GetUserParty(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return &pb.PartyResp{Success: false, Message: "already in a party", Party: toPartyInfo(existing)}, nil
+	}
+
+	partyID := uuid.New().String()
+	if err := dao.CreateParty(ctx, partyID, req.Uid, partyMaxSize()); err != nil {
+		return nil, err
+	}
+	return partyResp(ctx, partyID, "party created")
+}
+
+func (s *MatchService) InviteToParty(ctx context.Context, req *pb.InviteToPartyReq) (*pb.PartyResp, error) {
+	party, err := dao.GetUserParty(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return &pb.PartyResp{Success: false, Message: "not in a party"}, nil
+	}
+	if party.LeaderUID != req.Uid {
+		return &pb.PartyResp{Success: false, Message: "only the party leader can invite"}, nil
+	}
+	if req.TargetUid == req.Uid {
+		return &pb.PartyResp{Success: false, Message: "cannot invite yourself"}, nil
+	}
+	if len(party.Members) >= party.MaxSize {
+		return &pb.PartyResp{Success: false, Message: "party is full"}, nil
+	}
+
+	if err := dao.InviteToParty(ctx, party.ID, req.TargetUid); err != nil {
+		if errors.Is(err, dao.ErrPartyFull) || errors.Is(err, dao.ErrPartyNotFound) {
+			return &pb.PartyResp{Success: false, Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return partyResp(ctx, party.ID, "invite sent")
+}
+
+func (s *MatchService) JoinParty(ctx context.Context, req *pb.JoinPartyReq) (*pb.PartyResp, error) {
+	existing, err := dao.GetUserParty(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return &pb.PartyResp{Success: false, Message: "already in a party", Party: toPartyInfo(existing)}, nil
+	}
+
+	party, err := dao.GetParty(ctx, req.PartyId)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return &pb.PartyResp{Success: false, Message: "party not found"}, nil
+	}
+
+	invited := false
+	for _, uid := range party.Invites {
+		if uid == req.Uid {
+			invited = true
+			break
+		}
+	}
+	if !invited {
+		return &pb.PartyResp{Success: false, Message: "not invited to this party"}, nil
+	}
+	if len(party.Members) >= party.MaxSize {
+		return &pb.PartyResp{Success: false, Message: "party is full"}, nil
+	}
+
+	// 上面的人数检查只是快速失败，并发加入由 AddPartyMember 原子校验
+	if err := dao.AddPartyMember(ctx, party.ID, req.Uid); err != nil {
+		if errors.Is(err, dao.ErrPartyFull) || errors.Is(err, dao.ErrPartyNotFound) || errors.Is(err, dao.ErrNotInvited) {
+			return &pb.PartyResp{Success: false, Message: err.Error()}, nil
+		}
+		return nil, err
+	}
+	return partyResp(ctx, party.ID, "joined party")
+}
+
+func (s *MatchService) LeaveParty(ctx context.Context, req *pb.LeavePartyReq) (*pb.PartyResp, error) {
+	party, err := dao.GetUserParty(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return &pb.PartyResp{Success: false, Message: "not in a party"}, nil
+	}
+
+	count, err := dao.RemovePartyMember(ctx, party.ID, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+
+	// 最后一人离开时解散，队长离开时转交给剩余队员
+	if count == 0 {
+		if err := dao.DeleteParty(ctx, party); err != nil {
+			return nil, err
+		}
+		return &pb.PartyResp{Success: true, Message: "party disbanded"}, nil
+	}
+	if party.LeaderUID == req.Uid {
+		for _, uid := range party.Members {
+			if uid != req.Uid {
+				if err := dao.UpdateParty(ctx, party.ID, map[string]interface{}{"leader_uid": uid}); err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+	return &pb.PartyResp{Success: true, Message: "left party"}, nil
+}
+
+func (s *MatchService) KickFromParty(ctx context.Context, req *pb.KickFromPartyReq) (*pb.PartyResp, error) {
+	party, err := dao.GetUserParty(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return &pb.PartyResp{Success: false, Message: "not in a party"}, nil
+	}
+	if party.LeaderUID != req.Uid {
+		return &pb.PartyResp{Success: false, Message: "only the party leader can kick"}, nil
+	}
+	if req.TargetUid == req.Uid {
+		return &pb.PartyResp{Success: false, Message: "cannot kick yourself"}, nil
+	}
+
+	inParty := false
+	for _, uid := range party.Members {
+		if uid == req.TargetUid {
+			inParty = true
+			break
+		}
+	}
+	if !inParty {
+		return &pb.PartyResp{Success: false, Message: "player not in party"}, nil
+	}
+
+	if _, err := dao.RemovePartyMember(ctx, party.ID, req.TargetUid); err != nil {
+		return nil, err
+	}
+	return partyResp(ctx, party.ID, "player kicked")
+}
+
+func (s *MatchService) GetParty(ctx context.Context, req *pb.GetPartyReq) (*pb.PartyResp, error) {
+	party, err := dao.GetUserParty(ctx, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	if party == nil {
+		return &pb.PartyResp{Success: false, Message: "not in a party"}, nil
+	}
+	// 查询也会续期，回到大厅的队伍不会过期
+	if err := dao.TouchParty(ctx, party); err != nil {
+		return nil, err
+	}
+	return &pb.PartyResp{Success: true, Party: toPartyInfo(party)}, nil
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/pkg/config"
+)
+
+func TestPartyFlow(t *testing.T) {
+	mr.FlushAll()
+	defer func(size int) { config.AppConfig.Match.PartyMaxSize = size }(config.AppConfig.Match.PartyMaxSize)
+	config.AppConfig.Match.PartyMaxSize = 2
+
+	s := &MatchService{}
+	ctx := context.Background()
+	var partyID string
+	steps := []struct {
+		name        string
+		call        func() (*pb.PartyResp, error)
+		wantSuccess bool
+		wantLeader  int64
+		wantMembers int
+	}{
+		{
+			name:        "create",
+			call:        func() (*pb.PartyResp, error) { return s.CreateParty(ctx, &pb.CreatePartyReq{Uid: 1}) },
+			wantSuccess: true, wantLeader: 1, wantMembers: 1,
+		},
+		{
+			name: "create while in a party",
+			call: func() (*pb.PartyResp, error) { return s.CreateParty(ctx, &pb.CreatePartyReq{Uid: 1}) },
+		},
+		{
+			name: "invite without a party",
+			call: func() (*pb.PartyResp, error) { return s.InviteToParty(ctx, &pb.InviteToPartyReq{Uid: 2, TargetUid: 3}) },
+		},
+		{
+			name:        "invite",
+			call:        func() (*pb.PartyResp, error) { return s.InviteToParty(ctx, &pb.InviteToPartyReq{Uid: 1, TargetUid: 2}) },
+			wantSuccess: true, wantLeader: 1, wantMembers: 1,
+		},
+		{
+			name:        "invite another",
+			call:        func() (*pb.PartyResp, error) { return s.InviteToParty(ctx, &pb.InviteToPartyReq{Uid: 1, TargetUid: 3}) },
+			wantSuccess: true, wantLeader: 1, wantMembers: 1,
+		},
+		{
+			name: "join without invite",
+			call: func() (*pb.PartyResp, error) { return s.JoinParty(ctx, &pb.JoinPartyReq{Uid: 4, PartyId: partyID}) },
+		},
+		{
+			name:        "join",
+			call:        func() (*pb.PartyResp, error) { return s.JoinParty(ctx, &pb.JoinPartyReq{Uid: 2, PartyId: partyID}) },
+			wantSuccess: true, wantLeader: 1, wantMembers: 2,
+		},
+		{
+			name: "join full party",
+			call: func() (*pb.PartyResp, error) { return s.JoinParty(ctx, &pb.JoinPartyReq{Uid: 3, PartyId: partyID}) },
+		},
+		{
+			name: "invite to full party",
+			call: func() (*pb.PartyResp, error) { return s.InviteToParty(ctx, &pb.InviteToPartyReq{Uid: 1, TargetUid: 4}) },
+		},
+		{
+			name:        "leader leaves",
+			call:        func() (*pb.PartyResp, error) { return s.LeaveParty(ctx, &pb.LeavePartyReq{Uid: 1}) },
+			wantSuccess: true,
+		},
+		{
+			name:        "leadership transferred",
+			call:        func() (*pb.PartyResp, error) { return s.GetParty(ctx, &pb.GetPartyReq{Uid: 2}) },
+			wantSuccess: true, wantLeader: 2, wantMembers: 1,
+		},
+		{
+			name:        "last member disbands",
+			call:        func() (*pb.PartyResp, error) { return s.LeaveParty(ctx, &pb.LeavePartyReq{Uid: 2}) },
+			wantSuccess: true,
+		},
+		{
+			name: "party gone",
+			call: func() (*pb.PartyResp, error) { return s.GetParty(ctx, &pb.GetPartyReq{Uid: 2}) },
+		},
+	}
+	for _, step := range steps {
+		resp, err := step.call()
+		if err != nil || resp.Success != step.wantSuccess {
+			t.Fatalf("%s: %+v, %v, want success %v", step.name, resp, err, step.wantSuccess)
+		}
+		if step.wantMembers == 0 {
+			continue
+		}
+		partyID = resp.Party.PartyId
+		if resp.Party.LeaderUid != step.wantLeader || len(resp.Party.Members) != step.wantMembers {
+			t.Fatalf("%s: party = %+v, want leader %d with %d members", step.name, resp.Party, step.wantLeader, step.wantMembers)
+		}
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Fatalf("keys left after disband: %v", keys)
+	}
+}
+
+func TestPartyJoinRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		uid         int64
+		maxPlayers  string
+		rejectUID   int64
+		wantErr     bool
+		wantMembers int
+		wantRemoved []int64
+	}{
+		{name: "party seated together", uid: 10, maxPlayers: "4", wantMembers: 3},
+		{name: "only leader chooses room", uid: 11, maxPlayers: "4", wantErr: true, wantMembers: 1},
+		{name: "not enough seats", uid: 10, maxPlayers: "2", wantErr: true, wantMembers: 1},
+		{name: "rejected member rolls back party", uid: 10, maxPlayers: "4", rejectUID: 11, wantErr: true, wantMembers: 1, wantRemoved: []int64{10}},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1)
+			game := &fakeGame{rejectUID: tt.rejectUID}
+			startFakeGame(t, game)
+			mr.HSet(dao.KeyRoomPrefix+"r1", "mode", ModeTDM, "max_players", tt.maxPlayers, "server_port", "9000")
+			if err := dao.CreateParty(ctx, "p1", 10, 4); err != nil {
+				t.Fatal(err)
+			}
+			if err := dao.InviteToParty(ctx, "p1", 11); err != nil {
+				t.Fatal(err)
+			}
+			if err := dao.AddPartyMember(ctx, "p1", 11); err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := s.JoinRoom(ctx, &pb.JoinRoomReq{RoomId: "r1", Uid: tt.uid})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("join = %+v, %v, wantErr %v", resp, err, tt.wantErr)
+			}
+			members, _ := dao.GetMembers(ctx, "r1")
+			if len(members) != tt.wantMembers || roomField(t, "current_players") != strconv.Itoa(tt.wantMembers) {
+				t.Fatalf("members = %v, current_players %s, want %d", members, roomField(t, "current_players"), tt.wantMembers)
+			}
+			if _, removed := game.players(); !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Fatalf("removed from game = %v, want %v", removed, tt.wantRemoved)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// 整队分到同一支队伍，每人各有 ticket
+			if len(resp.Tickets) != 2 || resp.Tickets[0].Team != resp.Tickets[1].Team || resp.Tickets[0].Team == 0 {
+				t.Fatalf("tickets = %v, want two teammates", resp.Tickets)
+			}
+			if party, _ := dao.GetParty(ctx, "p1"); party.RoomID != "r1" {
+				t.Fatalf("party room = %q, want r1", party.RoomID)
+			}
+		})
+	}
+}
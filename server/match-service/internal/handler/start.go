@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+	"mygame/server/match-service/internal/rpc"
+	"mygame/server/match-service/pkg/config"
+)
+
+// StartMatch 房主开局：校验人数，锁定房间后通知 Game Server 开始倒计时
+func (s *MatchService) StartMatch(ctx context.Context, req *pb.StartMatchReq) (*pb.StartMatchResp, error) {
+	roomData, err := dao.GetRoom(ctx, req.RoomId)
+	if err != nil || len(roomData) == 0 {
+		return nil, fmt.Errorf("room not found")
+	}
+
+	// 1. 校验房主与人数
+	if roomHost(roomData) != req.Uid {
+		return &pb.StartMatchResp{Success: false, Message: "only room host can start the match"}, nil
+	}
+	if roomData["status"] != "WAITING" {
+		return &pb.StartMatchResp{Success: false, Message: "match already started"}, nil
+	}
+
+	// 机器人与真人一样计入开局人数
+	if seatsTaken(roomData) < minPlayers() {
+		return &pb.StartMatchResp{
+			Success: false,
+			Message: fmt.Sprintf("need at least %d players to start", minPlayers()),
+		}, nil
+	}
+
+	// 2. 状态改为 PLAYING，JoinRoom / UpdateRoom 随之拒绝新的请求
+	ok, err := dao.CompareAndSetStatus(ctx, req.RoomId, "WAITING", "PLAYING")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &pb.StartMatchResp{Success: false, Message: "match already started"}, nil
+	}
+
+	// 3. 通知 Game Server 开始倒计时，失败时回滚状态
+	countdown := config.AppConfig.Match.CountdownSeconds
+	if err := notifyGameStart(ctx, req.RoomId, roomData, countdown); err != nil {
+		log.Printf("NotifyGameStart for room %s: %v", req.RoomId, err)
+		if _, rbErr := dao.CompareAndSetStatus(ctx, req.RoomId, "PLAYING", "WAITING"); rbErr != nil {
+			log.Printf("Failed to roll back status of room %s: %v", req.RoomId, rbErr)
+		}
+		return &pb.StartMatchResp{Success: false, Message: "game server failed to start the match"}, nil
+	}
+
+	return &pb.StartMatchResp{
+		Success:          true,
+		Message:          "match starting",
+		CountdownSeconds: int32(countdown),
+	}, nil
+}
+
+// minPlayers 开局最少人数 (match.min_players)，未配置时为 2
+func minPlayers() int {
+	if n := config.AppConfig.Match.MinPlayers; n > 0 {
+		return n
+	}
+	return 2
+}
+
+// seatsTaken 已占用的座位：真人加机器人
+func seatsTaken(roomData map[string]string) int {
+	cur, _ := strconv.Atoi(roomData["current_players"])
+	bots, _ := strconv.Atoi(roomData["bots"])
+	return cur + bots
+}
+
+func notifyGameStart(ctx context.Context, roomID string, roomData map[string]string, countdown int) error {
+	client, err := rpc.GameClientForRoom(roomData)
+	if err != nil {
+		return err
+	}
+	resp, err := client.NotifyGameStart(ctx, &pb.NotifyGameStartReq{
+		RoomId:           roomID,
+		CountdownSeconds: int32(countdown),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("room is not running on game server")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+
+	"google.golang.org/grpc"
+)
+
+// fakeGame 记录 Match 发给 Game Server 的请求，reject 为 true 时拒绝开局，rejectUID 的玩家或机器人不被放行
+type fakeGame struct {
+	pb.UnimplementedGameServiceServer
+
+	mu        sync.Mutex
+	reject    bool
+	rejectUID int64
+	starts    []*pb.NotifyGameStartReq
+	admitted  []int64
+	removed   []int64
+	bots      []int64
+}
+
+func (g *fakeGame) NotifyGameStart(ctx context.Context, req *pb.NotifyGameStartReq) (*pb.NotifyGameStartResp, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.starts = append(g.starts, req)
+	return &pb.NotifyGameStartResp{Success: !g.reject}, nil
+}
+
+func (g *fakeGame) startCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.starts)
+}
+
+func (g *fakeGame) AdmitPlayer(ctx context.Context, req *pb.AdmitPlayerReq) (*pb.AdmitPlayerResp, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if req.Uid == g.rejectUID {
+		return &pb.AdmitPlayerResp{Success: false, Message: "rejected"}, nil
+	}
+	g.admitted = append(g.admitted, req.Uid)
+	return &pb.AdmitPlayerResp{Success: true}, nil
+}
+
+func (g *fakeGame) RemovePlayer(ctx context.Context, req *pb.RemovePlayerReq) (*pb.RemovePlayerResp, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.removed = append(g.removed, req.Uid)
+	return &pb.RemovePlayerResp{Success: true}, nil
+}
+
+// players 已放行与已断开的玩家
+func (g *fakeGame) players() (admitted, removed []int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]int64(nil), g.admitted...), append([]int64(nil), g.removed...)
+}
+
+// startFakeGame 启动一个 Game Server 并把 r1 指向它
+func startFakeGame(t *testing.T, game *fakeGame) {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterGameServiceServer(srv, game)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	mr.HSet(dao.KeyRoomPrefix+"r1", "server_ip", "127.0.0.1", "server_grpc_port", strconv.Itoa(port))
+}
+
+func TestStartMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		members     []int64
+		uid         int64
+		status      string
+		reject      bool
+		wantSuccess bool
+		wantStatus  string
+		wantStarts  int
+	}{
+		{name: "start", members: []int64{1, 2}, uid: 1, wantSuccess: true, wantStatus: "PLAYING", wantStarts: 1},
+		{name: "not host", members: []int64{1, 2}, uid: 2, wantStatus: "WAITING"},
+		{name: "not enough players", members: []int64{1}, uid: 1, wantStatus: "WAITING"},
+		{name: "already started", members: []int64{1, 2}, uid: 1, status: "PLAYING", wantStatus: "PLAYING"},
+		{name: "game server rejects", members: []int64{1, 2}, uid: 1, reject: true, wantStatus: "WAITING", wantStarts: 1},
+	}
+	s := &MatchService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, tt.members...)
+			game := &fakeGame{reject: tt.reject}
+			startFakeGame(t, game)
+			if tt.status != "" {
+				mr.HSet(dao.KeyRoomPrefix+"r1", "status", tt.status)
+			}
+
+			resp, err := s.StartMatch(ctx, &pb.StartMatchReq{RoomId: "r1", Uid: tt.uid})
+			if err != nil || resp.Success != tt.wantSuccess {
+				t.Fatalf("start = %+v, %v, want success %v", resp, err, tt.wantSuccess)
+			}
+			if status := roomField(t, "status"); status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s", status, tt.wantStatus)
+			}
+			if n := game.startCount(); n != tt.wantStarts {
+				t.Fatalf("NotifyGameStart called %d times, want %d", n, tt.wantStarts)
+			}
+		})
+	}
+}
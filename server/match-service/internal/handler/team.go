@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+
+	"github.com/google/uuid"
+)
+
+// 游戏模式及其队伍数，0 表示个人混战
+const (
+	ModeFFA = "ffa"
+	ModeTDM = "tdm"
+)
+
+var modeTeams = map[string]int{
+	ModeFFA: 0,
+	ModeTDM: 2,
+}
+
+// modeMaxPlayers 各模式的房间容量上限
+var modeMaxPlayers = map[string]int{
+	ModeFFA: 16,
+	ModeTDM: 16,
+}
+
+// checkMaxPlayers 校验房间容量不超过模式上限，团队模式下每队至少一人
+func checkMaxPlayers(mode string, maxPlayers int) error {
+	if limit := modeMaxPlayers[mode]; limit > 0 && maxPlayers > limit {
+		return fmt.Errorf("max_players of mode %s cannot exceed %d", mode, limit)
+	}
+	if maxPlayers < 1 || maxPlayers < modeTeams[mode] {
+		return fmt.Errorf("max_players is too small for mode %s", mode)
+	}
+	return nil
+}
+
+// resolveMode 校验模式，为空时为个人混战
+func resolveMode(mode string) (string, error) {
+	if mode == "" {
+		return ModeFFA, nil
+	}
+	if _, ok := modeTeams[mode]; !ok {
+		return "", fmt.Errorf("unsupported mode: %s", mode)
+	}
+	return mode, nil
+}
+
+// assignTeams 为一组玩家（一支队伍或单人）分配同一个队伍编号
+// 选择剩余座位最多且能容纳整组的队伍；非团队模式全部为 0
+func assignTeams(existing map[int64]int32, group []int64, mode string, maxPlayers int) (map[int64]int32, error) {
+	teams := make(map[int64]int32, len(group))
+	teamCount := modeTeams[mode]
+	if teamCount == 0 {
+		for _, uid := range group {
+			teams[uid] = 0
+		}
+		return teams, nil
+	}
+
+	perTeam := (maxPlayers + teamCount - 1) / teamCount
+	counts := make([]int, teamCount+1)
+	for _, team := range existing {
+		if team >= 1 && int(team) <= teamCount {
+			counts[team]++
+		}
+	}
+
+	best := 0
+	for t := 1; t <= teamCount; t++ {
+		if counts[t]+len(group) > perTeam {
+			continue
+		}
+		if best == 0 || counts[t] < counts[best] {
+			best = t
+		}
+	}
+	if best == 0 {
+		return nil, fmt.Errorf("no team has enough seats for the party")
+	}
+	for _, uid := range group {
+		teams[uid] = int32(best)
+	}
+	return teams, nil
+}
+
+// seatGroup 返回需要一起入座的玩家：在队伍中时为全体队员（队长在前），否则只有自己
+// 队伍只能由队长带领创建或加入房间
+func seatGroup(ctx context.Context, uid int64) ([]int64, *dao.Party, error) {
+	party, err := dao.GetUserParty(ctx, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if party == nil {
+		return []int64{uid}, nil, nil
+	}
+	if party.LeaderUID != uid {
+		return nil, nil, fmt.Errorf("only the party leader can choose a room")
+	}
+
+	group := []int64{uid}
+	for _, m := range party.Members {
+		if m != uid {
+			group = append(group, m)
+		}
+	}
+	return group, party, nil
+}
+
+// seatMembers 整组入座：加入成员、记录队伍并签发 ticket，返回 ticket 与入座后的人数
+func seatMembers(ctx context.Context, roomID string, group []int64, teams map[int64]int32) (map[int64]string, int64, error) {
+	count, err := dao.AddMembers(ctx, roomID, group)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := dao.SetTeams(ctx, roomID, teams); err != nil {
+		return nil, 0, err
+	}
+	tickets, err := issueTickets(ctx, roomID, group)
+	if err != nil {
+		return nil, 0, err
+	}
+	return tickets, count, nil
+}
+
+// releaseSeats 入座失败时归还 ReserveSeats 占用的座位
+func releaseSeats(ctx context.Context, roomID string, group []int64) {
+	count, err := dao.RemoveMembers(ctx, roomID, group)
+	if err == nil {
+		err = dao.UpdateRoom(ctx, roomID, map[string]interface{}{"current_players": count})
+	}
+	if err != nil {
+		log.Printf("Failed to release seats of %v in room %s: %v", group, roomID, err)
+	}
+}
+
+func toRoomTickets(group []int64, tickets map[int64]string, teams map[int64]int32) []*pb.RoomTicket {
+	result := make([]*pb.RoomTicket, 0, len(group))
+	for _, uid := range group {
+		result = append(result, &pb.RoomTicket{Uid: uid, Ticket: tickets[uid], Team: teams[uid]})
+	}
+	return result
+}
+
+// issueTickets 为每名玩家签发各自的 ticket
+func issueTickets(ctx context.Context, roomID string, uids []int64) (map[int64]string, error) {
+	tickets := make(map[int64]string, len(uids))
+	for _, uid := range uids {
+		tickets[uid] = uuid.New().String()
+	}
+	if err := dao.SetTickets(ctx, roomID, tickets); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
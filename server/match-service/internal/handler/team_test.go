@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignTeams(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing map[int64]int32
+		group    []int64
+		mode     string
+		max      int
+		want     map[int64]int32
+		wantErr  bool
+	}{
+		{name: "ffa", group: []int64{1, 2}, mode: ModeFFA, max: 8, want: map[int64]int32{1: 0, 2: 0}},
+		{name: "first party", group: []int64{1, 2}, mode: ModeTDM, max: 8, want: map[int64]int32{1: 1, 2: 1}},
+		{name: "smaller team", existing: map[int64]int32{1: 1, 2: 1}, group: []int64{3}, mode: ModeTDM, max: 8, want: map[int64]int32{3: 2}},
+		{name: "party kept together", existing: map[int64]int32{1: 1, 2: 2, 3: 2}, group: []int64{4, 5, 6}, mode: ModeTDM, max: 8, want: map[int64]int32{4: 1, 5: 1, 6: 1}},
+		{name: "party too large for any team", existing: map[int64]int32{1: 1, 2: 2, 3: 2}, group: []int64{4, 5, 6, 7}, mode: ModeTDM, max: 8, wantErr: true},
+		{name: "odd capacity rounds up", existing: map[int64]int32{1: 1, 2: 1}, group: []int64{3, 4, 5}, mode: ModeTDM, max: 5, want: map[int64]int32{3: 2, 4: 2, 5: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := assignTeams(tt.existing, tt.group, tt.mode, tt.max)
+			if (err != nil) != tt.wantErr || !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("assignTeams = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMaxPlayers(t *testing.T) {
+	tests := []struct {
+		mode    string
+		max     int
+		wantErr bool
+	}{
+		{ModeFFA, 1, false},
+		{ModeFFA, 16, false},
+		{ModeFFA, 17, true},
+		{ModeFFA, 0, true},
+		{ModeTDM, 1, true},
+		{ModeTDM, 2, false},
+	}
+	for _, tt := range tests {
+		if err := checkMaxPlayers(tt.mode, tt.max); (err != nil) != tt.wantErr {
+			t.Errorf("checkMaxPlayers(%s, %d) = %v, wantErr %v", tt.mode, tt.max, err, tt.wantErr)
+		}
+	}
+}
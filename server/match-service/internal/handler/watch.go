@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+
+	"google.golang.org/grpc"
+)
+
+// 每个订阅者的缓冲，写满说明客户端消费太慢，断开后由客户端重连并重新拿快照
+const watcherBuffer = 128
+
+// roomWatcher 一个 WatchRooms 订阅；roomID 为空时订阅公开房间列表
+type roomWatcher struct {
+	roomID string
+	ch     chan *pb.RoomEvent
+}
+
+// roomHub 每个 Match Service 实例只订阅一次 Redis，查询一次房间后分发给本实例的全部订阅者
+var roomHub = struct {
+	sync.Mutex
+	once     sync.Once
+	watchers map[*roomWatcher]struct{}
+}{watchers: make(map[*roomWatcher]struct{})}
+
+func addWatcher(roomID string) *roomWatcher {
+	roomHub.once.Do(func() { go runRoomHub() })
+
+	w := &roomWatcher{roomID: roomID, ch: make(chan *pb.RoomEvent, watcherBuffer)}
+	roomHub.Lock()
+	roomHub.watchers[w] = struct{}{}
+	roomHub.Unlock()
+	return w
+}
+
+func removeWatcher(w *roomWatcher) {
+	roomHub.Lock()
+	defer roomHub.Unlock()
+	if _, ok := roomHub.watchers[w]; ok {
+		delete(roomHub.watchers, w)
+		close(w.ch)
+	}
+}
+
+func runRoomHub() {
+	for ev := range dao.SubscribeRoomEvents(context.Background()) {
+		dispatchRoomEvent(ev)
+	}
+	log.Println("Room event subscription closed")
+}
+
+// dispatchRoomEvent 把 Redis 事件转换为大厅与单房间订阅各自需要的 RoomEvent
+func dispatchRoomEvent(ev dao.RoomEvent) {
+	roomHub.Lock()
+	idle := len(roomHub.watchers) == 0
+	roomHub.Unlock()
+	if idle {
+		return
+	}
+	ctx := context.Background()
+
+	// 1. 房间仍存在时读取最新数据
+	var room *pb.RoomInfo
+	var members []int64
+	listed := false
+	if ev.Type != dao.RoomEventRemoved {
+		data, err := dao.GetRoom(ctx, ev.RoomID)
+		if err != nil {
+			log.Printf("Load room %s for event failed: %v", ev.RoomID, err)
+			return
+		}
+		if len(data) == 0 {
+			ev.Type = dao.RoomEventRemoved
+		} else {
+			data["room_id"] = ev.RoomID
+			room = toRoomInfo(data)
+			listed, _ = dao.IsListed(ctx, ev.RoomID)
+			members, _ = dao.GetMembers(ctx, ev.RoomID)
+		}
+	}
+
+	// 2. 大厅订阅只关心公开房间
+	var lobby *pb.RoomEvent
+	switch {
+	case ev.Type == dao.RoomEventRemoved || ev.Type == dao.RoomEventUnlisted:
+		lobby = &pb.RoomEvent{Type: pb.RoomEvent_REMOVED, RoomId: ev.RoomID}
+	case listed && ev.Type == dao.RoomEventAdded:
+		lobby = &pb.RoomEvent{Type: pb.RoomEvent_ADDED, RoomId: ev.RoomID, Room: room}
+	case listed:
+		lobby = &pb.RoomEvent{Type: pb.RoomEvent_UPDATED, RoomId: ev.RoomID, Room: room}
+	}
+
+	// 3. 单房间订阅带上成员列表
+	single := &pb.RoomEvent{Type: pb.RoomEvent_UPDATED, RoomId: ev.RoomID, Room: room, Members: members}
+	if ev.Type == dao.RoomEventRemoved {
+		single = &pb.RoomEvent{Type: pb.RoomEvent_REMOVED, RoomId: ev.RoomID}
+	}
+
+	roomHub.Lock()
+	defer roomHub.Unlock()
+	for w := range roomHub.watchers {
+		out := lobby
+		if w.roomID != "" {
+			if w.roomID != ev.RoomID {
+				continue
+			}
+			out = single
+		}
+		if out == nil {
+			continue
+		}
+		select {
+		case w.ch <- out:
+		default:
+			// 消费太慢，断开订阅
+			delete(roomHub.watchers, w)
+			close(w.ch)
+		}
+	}
+}
+
+// WatchRooms 推送房间变化：先发送全量快照与 SNAPSHOT_END，之后只发送增量
+func (s *MatchService) WatchRooms(req *pb.WatchRoomsReq, stream grpc.ServerStreamingServer[pb.RoomEvent]) error {
+	ctx := stream.Context()
+
+	// 1. 先注册订阅再读快照，快照期间的变化会缓冲在 channel 中，不会丢失
+	w := addWatcher(req.RoomId)
+	defer removeWatcher(w)
+
+	// 2. 发送快照
+	if req.RoomId == "" {
+		rooms, err := dao.GetListedRooms(ctx)
+		if err != nil {
+			return err
+		}
+		for _, r := range rooms {
+			if err := stream.Send(&pb.RoomEvent{Type: pb.RoomEvent_SNAPSHOT, RoomId: r["room_id"], Room: toRoomInfo(r)}); err != nil {
+				return err
+			}
+		}
+	} else {
+		data, err := dao.GetRoom(ctx, req.RoomId)
+		if err != nil || len(data) == 0 {
+			return fmt.Errorf("room not found or expired")
+		}
+		if err := checkWatchAccess(ctx, req.RoomId, req.Uid, data); err != nil {
+			return err
+		}
+		members, err := dao.GetMembers(ctx, req.RoomId)
+		if err != nil {
+			return err
+		}
+		data["room_id"] = req.RoomId
+		if err := stream.Send(&pb.RoomEvent{Type: pb.RoomEvent_SNAPSHOT, RoomId: req.RoomId, Room: toRoomInfo(data), Members: members}); err != nil {
+			return err
+		}
+	}
+	if err := stream.Send(&pb.RoomEvent{Type: pb.RoomEvent_SNAPSHOT_END}); err != nil {
+		return err
+	}
+
+	// 3. 增量推送，直到客户端断开
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.ch:
+			if !ok {
+				return fmt.Errorf("watcher too slow, reconnect to resync")
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+			if req.RoomId != "" && ev.Type == pb.RoomEvent_REMOVED {
+				return nil
+			}
+		}
+	}
+}
+
+// checkWatchAccess 公开房间任何人可订阅；私有房间只有成员与观战者可以
+func checkWatchAccess(ctx context.Context, roomID string, uid int64, data map[string]string) error {
+	if data["visibility"] != VisibilityPrivate {
+		return nil
+	}
+	if ok, err := dao.IsMember(ctx, roomID, uid); err != nil || ok {
+		return err
+	}
+	if ok, err := dao.IsObserver(ctx, roomID, uid); err != nil || ok {
+		return err
+	}
+	return fmt.Errorf("not allowed to watch this room")
+}
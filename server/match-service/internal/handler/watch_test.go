@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/internal/dao"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// testWatcher 直接注册到 roomHub，不启动 Redis 订阅，由测试调用 dispatchRoomEvent
+func testWatcher(t *testing.T, roomID string) *roomWatcher {
+	t.Helper()
+	w := &roomWatcher{roomID: roomID, ch: make(chan *pb.RoomEvent, watcherBuffer)}
+	roomHub.Lock()
+	roomHub.watchers[w] = struct{}{}
+	roomHub.Unlock()
+	t.Cleanup(func() { removeWatcher(w) })
+	return w
+}
+
+func nextEvent(w *roomWatcher) *pb.RoomEvent {
+	select {
+	case ev := <-w.ch:
+		return ev
+	default:
+		return nil
+	}
+}
+
+func TestDispatchRoomEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      dao.RoomEvent
+		private    bool
+		wantLobby  pb.RoomEvent_Type // -1 表示大厅不收到事件
+		wantSingle pb.RoomEvent_Type
+	}{
+		{name: "added", event: dao.RoomEvent{Type: dao.RoomEventAdded, RoomID: "r1"}, wantLobby: pb.RoomEvent_ADDED, wantSingle: pb.RoomEvent_UPDATED},
+		{name: "updated", event: dao.RoomEvent{Type: dao.RoomEventUpdated, RoomID: "r1"}, wantLobby: pb.RoomEvent_UPDATED, wantSingle: pb.RoomEvent_UPDATED},
+		{name: "private room hidden from lobby", event: dao.RoomEvent{Type: dao.RoomEventUpdated, RoomID: "r1"}, private: true, wantLobby: -1, wantSingle: pb.RoomEvent_UPDATED},
+		{name: "unlisted", event: dao.RoomEvent{Type: dao.RoomEventUnlisted, RoomID: "r1"}, private: true, wantLobby: pb.RoomEvent_REMOVED, wantSingle: pb.RoomEvent_UPDATED},
+		{name: "removed", event: dao.RoomEvent{Type: dao.RoomEventRemoved, RoomID: "r1"}, wantLobby: pb.RoomEvent_REMOVED, wantSingle: pb.RoomEvent_REMOVED},
+		{name: "room hash expired", event: dao.RoomEvent{Type: dao.RoomEventUpdated, RoomID: "gone"}, wantLobby: pb.RoomEvent_REMOVED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := seedRoom(t, 1, 1, 2)
+			if tt.private {
+				if err := dao.SetRoomListed(ctx, "r1", false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			lobby, single := testWatcher(t, ""), testWatcher(t, "r1")
+
+			dispatchRoomEvent(tt.event)
+
+			ev := nextEvent(lobby)
+			switch {
+			case tt.wantLobby < 0 && ev != nil:
+				t.Fatalf("lobby got %v, want nothing", ev)
+			case tt.wantLobby >= 0 && (ev == nil || ev.Type != tt.wantLobby || ev.RoomId != tt.event.RoomID):
+				t.Fatalf("lobby got %v, want %v", ev, tt.wantLobby)
+			}
+			ev = nextEvent(single)
+			if tt.event.RoomID != "r1" {
+				if ev != nil {
+					t.Fatalf("watcher of r1 got %v", ev)
+				}
+				return
+			}
+			if ev == nil || ev.Type != tt.wantSingle {
+				t.Fatalf("watcher of r1 got %v, want %v", ev, tt.wantSingle)
+			}
+			if ev.Type == pb.RoomEvent_UPDATED && (len(ev.Members) != 2 || ev.Room.RoomId != "r1") {
+				t.Fatalf("room update without members: %v", ev)
+			}
+		})
+	}
+}
+
+func TestDispatchDropsSlowWatcher(t *testing.T) {
+	seedRoom(t, 1, 1)
+	w := testWatcher(t, "r1")
+	for i := 0; i <= watcherBuffer; i++ {
+		dispatchRoomEvent(dao.RoomEvent{Type: dao.RoomEventUpdated, RoomID: "r1"})
+	}
+	for range w.ch {
+	}
+	roomHub.Lock()
+	_, ok := roomHub.watchers[w]
+	roomHub.Unlock()
+	if ok {
+		t.Fatal("slow watcher not dropped")
+	}
+}
+
+func TestWatchRooms(t *testing.T) {
+	ctx := seedRoom(t, 1, 1)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	pb.RegisterMatchServiceServer(srv, &MatchService{})
+	go srv.Serve(lis)
+	defer srv.Stop()
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	streamCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	stream, err := pb.NewMatchServiceClient(conn).WatchRooms(streamCtx, &pb.WatchRoomsReq{RoomId: "r1", Uid: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1. 快照
+	for _, want := range []pb.RoomEvent_Type{pb.RoomEvent_SNAPSHOT, pb.RoomEvent_SNAPSHOT_END} {
+		ev, err := stream.Recv()
+		if err != nil || ev.Type != want {
+			t.Fatalf("recv = %v, %v, want %v", ev, err, want)
+		}
+	}
+
+	// 2. 订阅建立后的变化逐条推送，房间销毁后结束
+	for deadline := time.Now().Add(time.Second); mr.PubSubNumSub(dao.KeyRoomEvents)[dao.KeyRoomEvents] == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("room hub not subscribed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	// 事件处理时读取房间的最新状态，所以等上一条到达后再做下一次修改
+	steps := []struct {
+		change func() error
+		want   pb.RoomEvent_Type
+	}{
+		{func() error { _, err := dao.AddMember(ctx, "r1", 2); return err }, pb.RoomEvent_UPDATED},
+		{func() error { return dao.RemoveRoom(ctx, "r1") }, pb.RoomEvent_REMOVED},
+	}
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatal(err)
+		}
+		ev, err := stream.Recv()
+		if err != nil || ev.Type != step.want {
+			t.Fatalf("recv = %v, %v, want %v", ev, err, step.want)
+		}
+	}
+	if _, err := stream.Recv(); err == nil {
+		t.Fatal("stream still open after room removed")
+	}
+}
@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/pkg/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Game Server 的 gRPC 连接，按地址缓存复用
+var (
+	gameClients = make(map[string]pb.GameServiceClient)
+	gameMu      sync.Mutex
+)
+
+// GameClient 获取指定地址的 Game Service 客户端
+func GameClient(addr string) (pb.GameServiceClient, error) {
+	gameMu.Lock()
+	defer gameMu.Unlock()
+
+	if c, ok := gameClients[addr]; ok {
+		return c, nil
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("connect game server %s: %v", addr, err)
+	}
+	c := pb.NewGameServiceClient(conn)
+	gameClients[addr] = c
+	return c, nil
+}
+
+// GameClientForRoom 根据房间数据找到房间所在 Game Server 的客户端
+// 旧房间没有记录 server_grpc_port 时，按 ip + port 回查配置
+func GameClientForRoom(roomData map[string]string) (pb.GameServiceClient, error) {
+	ip := roomData["server_ip"]
+	grpcPort, _ := strconv.Atoi(roomData["server_grpc_port"])
+	if grpcPort == 0 {
+		port, _ := strconv.Atoi(roomData["server_port"])
+		for _, s := range config.AppConfig.GameServers {
+			if s.IP == ip && s.Port == port {
+				grpcPort = s.GrpcPort
+				break
+			}
+		}
+	}
+	if grpcPort == 0 {
+		return nil, fmt.Errorf("no grpc address for game server %s", ip)
+	}
+	return GameClient(fmt.Sprintf("%s:%d", ip, grpcPort))
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"context"
+	"log"
+
+	pb "mygame/proto"
+	"mygame/server/match-service/pkg/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// UserClient User Service 客户端，用于校验好友关系
+var UserClient pb.UserServiceClient
+
+// InitUserClient 连接 User Service（grpc.Dial 不阻塞，User Service 可晚于 Match 启动）
+func InitUserClient() {
+	addr := config.AppConfig.RPC.UserServiceAddr
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Did not connect to user-service: %v", err)
+	}
+	UserClient = pb.NewUserServiceClient(conn)
+	log.Printf("Connected to User Service at %s", addr)
+}
+
+// AreFriends 两人是否为好友
+func AreFriends(ctx context.Context, uid, friendUID int64) (bool, error) {
+	resp, err := UserClient.AreFriends(ctx, &pb.AreFriendsReq{Uid: uid, FriendUid: friendUID})
+	if err != nil {
+		return false, err
+	}
+	return resp.Friends, nil
+}
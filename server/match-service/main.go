@@ -5,12 +5,8 @@ import (
 	"log"
 	"net"
 
-	pb "mygame/proto"
-	"mygame/server/match-service/internal/dao"
-	"mygame/server/match-service/internal/handler"
+	"mygame/server/match-service/app"
 	"mygame/server/match-service/pkg/config"
-
-	"google.golang.org/grpc"
 )
 
 func main() {
@@ -18,7 +14,7 @@ func main() {
 	config.InitConfig()
 
 	// 2. 初始化 Redis
-	dao.InitRedis()
+	app.Init()
 
 	// 3. 启动 gRPC 服务
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.AppConfig.Server.Port))
@@ -26,8 +22,7 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterMatchServiceServer(s, &handler.MatchService{})
+	s := app.NewServer()
 
 	log.Printf("Match Service listening on :%d", config.AppConfig.Server.Port)
 	if err := s.Serve(lis); err != nil {
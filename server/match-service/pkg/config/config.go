@@ -10,6 +10,8 @@ type Config struct {
 	Server      ServerConfig       `mapstructure:"server"`
 	Redis       RedisConfig        `mapstructure:"redis"`
 	GameServers []GameServerConfig `mapstructure:"game_servers"`
+	Match       MatchConfig        `mapstructure:"match"`
+	RPC         RPCConfig          `mapstructure:"rpc"`
 }
 
 type ServerConfig struct {
@@ -22,10 +24,28 @@ type RedisConfig struct {
 	DB       int    `mapstructure:"db"`
 }
 
+type RPCConfig struct {
+	UserServiceAddr string `mapstructure:"user_service_addr"` // 校验好友关系
+}
+
 type GameServerConfig struct {
 	IP       string `mapstructure:"ip"`
 	Port     int    `mapstructure:"port"`
 	GrpcPort int    `mapstructure:"grpc_port"`
+	Region   string `mapstructure:"region"`
+}
+
+type MatchConfig struct {
+	MinPlayers       int `mapstructure:"min_players"`       // 开局最少人数
+	CountdownSeconds int `mapstructure:"countdown_seconds"` // 开局倒计时
+	PartyMaxSize     int `mapstructure:"party_max_size"`    // 队伍人数上限
+	MaxObservers     int `mapstructure:"max_observers"`     // 每个房间的观战人数上限
+	ObserverDelayMs  int `mapstructure:"observer_delay_ms"` // 观战画面延迟，防止报点
+
+	ObserverReserveSeconds int `mapstructure:"observer_reserve_seconds"` // 观战名额签发后等待连接的时间，超时未连上即释放
+
+	BotBackfillSeconds int    `mapstructure:"bot_backfill_seconds"` // 公开房间等待超过该秒数仍不足开局人数时补充机器人，0 不启用
+	BotDifficulty      string `mapstructure:"bot_difficulty"`       // 补充与房主添加机器人的默认难度
 }
 
 var AppConfig *Config
@@ -0,0 +1,55 @@
+// Package app User Service 的初始化，供 main 与进程内集成测试共用
+package app
+
+import (
+	"fmt"
+	"log"
+
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/handler"
+	"mygame/server/user-service/internal/mq"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/repository/memrepo"
+	"mygame/server/user-service/internal/repository/sqlrepo"
+	"mygame/server/user-service/internal/service"
+	"mygame/server/user-service/pkg/config"
+
+	"google.golang.org/grpc"
+)
+
+// Init 按 config.AppConfig 加载签名密钥、打开存储并连接 Redis
+func Init() (repository.Repository, error) {
+	if err := service.InitKeys(); err != nil {
+		return nil, fmt.Errorf("load jwt keys failed: %v", err)
+	}
+	repo, err := OpenRepository(config.AppConfig.Database)
+	if err != nil {
+		return nil, fmt.Errorf("database init failed: %v", err)
+	}
+	dao.InitRedis()
+	return repo, nil
+}
+
+// OpenRepository 根据 database.driver 选择存储实现
+func OpenRepository(cfg config.DatabaseConfig) (repository.Repository, error) {
+	if cfg.Driver == "memory" {
+		log.Println("Using in-memory repository, data will be lost on restart")
+		return memrepo.New(), nil
+	}
+	driver := cfg.Driver
+	if driver == "" {
+		driver = "mysql"
+	}
+	return sqlrepo.Open(driver, cfg.DSN, cfg.AutoMigrate)
+}
+
+// NewServer 初始化 MQ、启动战绩消费者，并创建注册了 UserService 的 gRPC 服务
+func NewServer(repo repository.Repository) *grpc.Server {
+	mq.InitMQ()
+	go mq.StartConsumer(repo)
+
+	s := grpc.NewServer()
+	pb.RegisterUserServiceServer(s, handler.NewUserService(repo))
+	return s
+}
@@ -0,0 +1,231 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"mygame/server/user-service/internal/repository"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 排行榜 (Redis ZSet)
+//   lb:{board}:global            全服总榜 (wins / kills / deaths / kd)
+//   lb:{board}:season:{season}   赛季榜
+//   lb:rating:{mode}:{season}    段位分榜，只有赛季榜
+// deaths 榜不对外展示，仅用于计算 K/D
+const (
+	BoardWins   = "wins"
+	BoardKills  = "kills"
+	BoardDeaths = "deaths"
+	BoardKD     = "kd"
+	BoardRating = "rating"
+
+	keyLeaderboardPrefix = "lb:"
+)
+
+// LeaderboardKey 生成排行榜的 key，season 为空表示全服总榜
+func LeaderboardKey(board, mode, season string) string {
+	if board == BoardRating {
+		return fmt.Sprintf("%s%s:%s:%s", keyLeaderboardPrefix, board, mode, season)
+	}
+	if season == "" {
+		return fmt.Sprintf("%s%s:global", keyLeaderboardPrefix, board)
+	}
+	return fmt.Sprintf("%s%s:season:%s", keyLeaderboardPrefix, board, season)
+}
+
+// IsValidBoard 是否为可对外查询的排行榜
+func IsValidBoard(board string) bool {
+	switch board {
+	case BoardWins, BoardKills, BoardKD, BoardRating:
+		return true
+	}
+	return false
+}
+
+// LeaderboardUpdate 一名玩家在一局比赛后对排行榜的增量
+type LeaderboardUpdate struct {
+	UID    int64
+	Win    bool
+	Kills  int
+	Deaths int
+	Rating float64
+}
+
+// UpdateLeaderboards 比赛结算后更新全服榜与赛季榜
+func UpdateLeaderboards(ctx context.Context, mode, season string, updates []LeaderboardUpdate) error {
+	scopes := []string{"", season}
+
+	// 1. 累加胜场、击杀、死亡，覆盖段位分
+	pipe := RDB.Pipeline()
+	for _, u := range updates {
+		member := strconv.FormatInt(u.UID, 10)
+		for _, scope := range scopes {
+			if u.Win {
+				pipe.ZIncrBy(ctx, LeaderboardKey(BoardWins, mode, scope), 1, member)
+			}
+			pipe.ZIncrBy(ctx, LeaderboardKey(BoardKills, mode, scope), float64(u.Kills), member)
+			pipe.ZIncrBy(ctx, LeaderboardKey(BoardDeaths, mode, scope), float64(u.Deaths), member)
+		}
+		pipe.ZAdd(ctx, LeaderboardKey(BoardRating, mode, season), redis.Z{Score: u.Rating, Member: member})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	// 2. 读取累计值，重新计算 K/D
+	type kdCmds struct {
+		member string
+		scope  string
+		kills  *redis.FloatCmd
+		deaths *redis.FloatCmd
+	}
+	var reads []kdCmds
+	pipe = RDB.Pipeline()
+	for _, u := range updates {
+		member := strconv.FormatInt(u.UID, 10)
+		for _, scope := range scopes {
+			reads = append(reads, kdCmds{
+				member: member,
+				scope:  scope,
+				kills:  pipe.ZScore(ctx, LeaderboardKey(BoardKills, mode, scope), member),
+				deaths: pipe.ZScore(ctx, LeaderboardKey(BoardDeaths, mode, scope), member),
+			})
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe = RDB.Pipeline()
+	for _, r := range reads {
+		kills, _ := r.kills.Result()
+		deaths, _ := r.deaths.Result()
+		pipe.ZAdd(ctx, LeaderboardKey(BoardKD, mode, r.scope), redis.Z{Score: KDRatio(kills, deaths), Member: r.member})
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// KDRatio 击杀/死亡比，0 死亡时按 1 计算
+func KDRatio(kills, deaths float64) float64 {
+	if deaths < 1 {
+		deaths = 1
+	}
+	return kills / deaths
+}
+
+// GetLeaderboard 按名次分页读取排行榜（分数从高到低）
+func GetLeaderboard(ctx context.Context, key string, page, limit int) ([]redis.Z, int64, error) {
+	start := int64((page - 1) * limit)
+	stop := start + int64(limit) - 1
+
+	pipe := RDB.Pipeline()
+	rangeCmd := pipe.ZRevRangeWithScores(ctx, key, start, stop)
+	countCmd := pipe.ZCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+	return rangeCmd.Val(), countCmd.Val(), nil
+}
+
+// GetRank 查询玩家名次（从 0 开始）及分数，未上榜时返回 redis.Nil
+func GetRank(ctx context.Context, key string, uid int64) (int64, float64, error) {
+	member := strconv.FormatInt(uid, 10)
+
+	pipe := RDB.Pipeline()
+	rankCmd := pipe.ZRevRank(ctx, key, member)
+	scoreCmd := pipe.ZScore(ctx, key, member)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	return rankCmd.Val(), scoreCmd.Val(), nil
+}
+
+// GetLeaderboardRange 按名次区间 [start, stop] 读取排行榜
+func GetLeaderboardRange(ctx context.Context, key string, start, stop int64) ([]redis.Z, error) {
+	return RDB.ZRevRangeWithScores(ctx, key, start, stop).Result()
+}
+
+// RebuildLeaderboards 从数据库重新生成全部排行榜，用于 Redis 数据丢失后的恢复
+func RebuildLeaderboards(ctx context.Context, matches repository.MatchRepository) error {
+	boards := make(map[string][]redis.Z)
+
+	// 1. 战绩汇总：全服榜
+	addStats := func(rows []repository.StatTotals) {
+		for _, r := range rows {
+			member := strconv.FormatUint(uint64(r.UserID), 10)
+			if r.Wins > 0 {
+				key := LeaderboardKey(BoardWins, "", r.Season)
+				boards[key] = append(boards[key], redis.Z{Score: float64(r.Wins), Member: member})
+			}
+			for board, score := range map[string]float64{
+				BoardKills:  float64(r.Kills),
+				BoardDeaths: float64(r.Deaths),
+				BoardKD:     KDRatio(float64(r.Kills), float64(r.Deaths)),
+			} {
+				key := LeaderboardKey(board, "", r.Season)
+				boards[key] = append(boards[key], redis.Z{Score: score, Member: member})
+			}
+		}
+	}
+
+	global, err := matches.SumStats(false)
+	if err != nil {
+		return err
+	}
+	addStats(global)
+
+	// 2. 战绩汇总：赛季榜
+	seasonal, err := matches.SumStats(true)
+	if err != nil {
+		return err
+	}
+	addStats(seasonal)
+
+	// 3. 段位分
+	ratings, err := matches.ListRatings()
+	if err != nil {
+		return err
+	}
+	for _, r := range ratings {
+		key := LeaderboardKey(BoardRating, r.Mode, r.Season)
+		boards[key] = append(boards[key], redis.Z{Score: r.Rating, Member: strconv.FormatUint(uint64(r.UserID), 10)})
+	}
+
+	// 4. 写入临时 key 后原子替换，并清理已不存在的旧榜
+	stale := make(map[string]bool)
+	iter := RDB.Scan(ctx, 0, keyLeaderboardPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		stale[iter.Val()] = true
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	for key, members := range boards {
+		delete(stale, key)
+		tmp := "rebuild:" + key
+		pipe := RDB.Pipeline()
+		pipe.Del(ctx, tmp)
+		for i := 0; i < len(members); i += 1000 {
+			end := i + 1000
+			if end > len(members) {
+				end = len(members)
+			}
+			pipe.ZAdd(ctx, tmp, members[i:end]...)
+		}
+		pipe.Rename(ctx, tmp, key)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+	for key := range stale {
+		if err := RDB.Del(ctx, key).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
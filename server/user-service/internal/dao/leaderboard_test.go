@@ -0,0 +1,89 @@
+package dao
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLeaderboardKey(t *testing.T) {
+	tests := []struct {
+		board, mode, season string
+		want                string
+	}{
+		{BoardWins, "ffa", "", "lb:wins:global"},
+		{BoardKD, "tdm", "s1", "lb:kd:season:s1"},
+		{BoardRating, "ffa", "s1", "lb:rating:ffa:s1"},
+	}
+	for _, tt := range tests {
+		if got := LeaderboardKey(tt.board, tt.mode, tt.season); got != tt.want {
+			t.Errorf("LeaderboardKey(%q, %q, %q) = %q, want %q", tt.board, tt.mode, tt.season, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateLeaderboards(t *testing.T) {
+	ctx := setup(t)
+	matches := [][]LeaderboardUpdate{
+		{{UID: 1, Win: true, Kills: 4, Deaths: 1, Rating: 1600}, {UID: 2, Kills: 1, Deaths: 4, Rating: 1400}},
+		{{UID: 1, Kills: 2, Deaths: 3, Rating: 1550}, {UID: 3, Win: true, Kills: 3, Rating: 1650}},
+	}
+	for _, updates := range matches {
+		if err := UpdateLeaderboards(ctx, "ffa", "s1", updates); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		key   string
+		uid   int64
+		rank  int64
+		score float64
+	}{
+		{LeaderboardKey(BoardWins, "ffa", ""), 1, 1, 1}, // 同分时 member 大的在前
+		{LeaderboardKey(BoardKills, "ffa", "s1"), 1, 0, 6},
+		{LeaderboardKey(BoardKills, "ffa", "s1"), 2, 2, 1},
+		{LeaderboardKey(BoardKD, "ffa", ""), 3, 0, 3},   // 0 死亡按 1 计算
+		{LeaderboardKey(BoardKD, "ffa", ""), 1, 1, 1.5}, // 累计 6 杀 4 死
+		{LeaderboardKey(BoardRating, "ffa", "s1"), 1, 1, 1550},
+	}
+	for _, tt := range tests {
+		rank, score, err := GetRank(ctx, tt.key, tt.uid)
+		if err != nil || rank != tt.rank || score != tt.score {
+			t.Errorf("%s rank of %d = %d, %v, %v, want %d, %v", tt.key, tt.uid, rank, score, err, tt.rank, tt.score)
+		}
+	}
+
+	if _, _, err := GetRank(ctx, LeaderboardKey(BoardWins, "ffa", ""), 2); !errors.Is(err, redis.Nil) {
+		t.Fatalf("rank of player without wins = %v, want redis.Nil", err)
+	}
+}
+
+func TestGetLeaderboardPages(t *testing.T) {
+	ctx := setup(t)
+	key := LeaderboardKey(BoardKills, "", "")
+	for uid := 1; uid <= 5; uid++ {
+		mr.ZAdd(key, float64(uid*10), string(rune('0'+uid)))
+	}
+
+	tests := []struct {
+		page, limit int
+		want        []string
+	}{
+		{1, 2, []string{"5", "4"}},
+		{3, 2, []string{"1"}},
+		{4, 2, nil},
+	}
+	for _, tt := range tests {
+		entries, total, err := GetLeaderboard(ctx, key, tt.page, tt.limit)
+		if err != nil || total != 5 || len(entries) != len(tt.want) {
+			t.Fatalf("page %d = %v, %d, %v, want %v", tt.page, entries, total, err, tt.want)
+		}
+		for i, e := range entries {
+			if e.Member != tt.want[i] {
+				t.Fatalf("page %d = %v, want %v", tt.page, entries, tt.want)
+			}
+		}
+	}
+}
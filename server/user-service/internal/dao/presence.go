@@ -0,0 +1,87 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"mygame/server/user-service/pkg/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 在线状态 (Redis Hash，带 TTL，过期即离线)
+//   presence:{uid}  status / room_id / updated_at
+// Game Service 在玩家连接房间时直接写入同一个 key
+const (
+	keyPresencePrefix = "presence:"
+
+	PresenceLobby   = "lobby"
+	PresenceInQueue = "in_queue"
+	PresenceInMatch = "in_match"
+)
+
+// PresenceInfo 玩家在线状态，Status 为空表示离线
+type PresenceInfo struct {
+	Status    string
+	RoomID    string
+	UpdatedAt int64
+}
+
+func presenceKey(uid int64) string {
+	return keyPresencePrefix + strconv.FormatInt(uid, 10)
+}
+
+func presenceTTL() time.Duration {
+	if ttl := config.AppConfig.Presence.TTLSeconds; ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+	return 2 * time.Minute
+}
+
+// SetPresence 设置在线状态并刷新 TTL
+func SetPresence(ctx context.Context, uid int64, status, roomID string) error {
+	key := presenceKey(uid)
+	pipe := RDB.TxPipeline()
+	pipe.HSet(ctx, key, "status", status, "room_id", roomID, "updated_at", time.Now().Unix())
+	pipe.Expire(ctx, key, presenceTTL())
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TouchPresence 续期当前状态，没有状态时设为大厅
+func TouchPresence(ctx context.Context, uid int64) error {
+	ok, err := RDB.Expire(ctx, presenceKey(uid), presenceTTL()).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return SetPresence(ctx, uid, PresenceLobby, "")
+	}
+	return nil
+}
+
+// GetPresences 批量查询在线状态
+func GetPresences(ctx context.Context, uids []int64) (map[int64]PresenceInfo, error) {
+	pipe := RDB.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(uids))
+	for i, uid := range uids {
+		cmds[i] = pipe.HGetAll(ctx, presenceKey(uid))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	result := make(map[int64]PresenceInfo, len(uids))
+	for i, uid := range uids {
+		data := cmds[i].Val()
+		updatedAt, _ := strconv.ParseInt(data["updated_at"], 10, 64)
+		result[uid] = PresenceInfo{Status: data["status"], RoomID: data["room_id"], UpdatedAt: updatedAt}
+	}
+	return result, nil
+}
+
+// ClearPresence 标记为离线
+func ClearPresence(ctx context.Context, uid int64) error {
+	return RDB.Del(ctx, presenceKey(uid)).Err()
+}
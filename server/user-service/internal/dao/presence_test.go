@@ -0,0 +1,46 @@
+package dao
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPresence(t *testing.T) {
+	ctx := setup(t)
+	if err := SetPresence(ctx, 1, PresenceInMatch, "r1"); err != nil {
+		t.Fatal(err)
+	}
+	// 2 号没有状态，续期后进入大厅
+	if err := TouchPresence(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		uid    int64
+		status string
+		roomID string
+	}{
+		{1, PresenceInMatch, "r1"},
+		{2, PresenceLobby, ""},
+		{3, "", ""},
+	}
+	got, err := GetPresences(ctx, []int64{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		if p := got[tt.uid]; p.Status != tt.status || p.RoomID != tt.roomID {
+			t.Errorf("presence of %d = %+v, want %q in %q", tt.uid, p, tt.status, tt.roomID)
+		}
+	}
+
+	// 过期即离线，清除后立即离线
+	mr.FastForward(presenceTTL() + time.Second)
+	if err := ClearPresence(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	got, err = GetPresences(ctx, []int64{1, 2})
+	if err != nil || got[1].Status != "" || got[2].Status != "" {
+		t.Fatalf("presences after expiry = %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"mygame/server/user-service/pkg/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+var mr *miniredis.Miniredis
+
+func TestMain(m *testing.M) {
+	var err error
+	mr, err = miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	RDB = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	config.AppConfig = &config.Config{}
+	code := m.Run()
+	RDB.Close()
+	mr.Close()
+	os.Exit(code)
+}
+
+// setup 清空 Redis，每个用例从空库开始
+func setup(t *testing.T) context.Context {
+	t.Helper()
+	mr.FlushAll()
+	return context.Background()
+}
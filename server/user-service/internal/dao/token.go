@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 吊销名单（Gateway 的 AuthMiddleware 读取同样的 key）
+//
+//	auth:revoked:{jti}              String: 被吊销的 access token，TTL 为其剩余有效期
+//	auth:revoked_before_ms:{uid}    String: 该时间（unix 毫秒）及之前签发的 access token 全部失效
+const (
+	KeyRevokedTokenPrefix  = "auth:revoked:"
+	KeyRevokedBeforePrefix = "auth:revoked_before_ms:"
+)
+
+// RevokeAccessToken 把 access token 的 jti 加入吊销名单，直到它自然过期
+func RevokeAccessToken(ctx context.Context, jti string, ttl time.Duration) error {
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+	return RDB.Set(ctx, KeyRevokedTokenPrefix+jti, 1, ttl).Err()
+}
+
+// RevokeUserAccessTokens 让用户在此之前签发的 access token 全部失效，ttl 取 access token 的最长有效期
+func RevokeUserAccessTokens(ctx context.Context, uid uint, ttl time.Duration) error {
+	key := KeyRevokedBeforePrefix + strconv.FormatUint(uint64(uid), 10)
+	return RDB.Set(ctx, key, time.Now().UnixMilli(), ttl).Err()
+}
+
+// IsAccessTokenRevoked 检查 jti 吊销名单与用户级的统一失效时间，issuedAtMs 为签发时间（unix 毫秒）
+func IsAccessTokenRevoked(ctx context.Context, jti string, uid uint, issuedAtMs int64) (bool, error) {
+	pipe := RDB.Pipeline()
+	existsCmd := pipe.Exists(ctx, KeyRevokedTokenPrefix+jti)
+	beforeCmd := pipe.Get(ctx, KeyRevokedBeforePrefix+strconv.FormatUint(uint64(uid), 10))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	if jti != "" && existsCmd.Val() > 0 {
+		return true, nil
+	}
+	if before, err := beforeCmd.Int64(); err == nil && issuedAtMs <= before {
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/service"
+	"mygame/server/user-service/model"
+	"time"
+)
+
+// issueTokens 签发 access token 与属于 familyID 的 refresh token
+func (s *UserService) issueTokens(user *model.User, familyID string) (*pb.LoginResp, *model.RefreshToken, error) {
+	token, err := service.GenerateToken(user.ID, user.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	raw, hash, err := service.NewRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refresh := &model.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(service.RefreshTokenTTL()),
+	}
+	return &pb.LoginResp{
+		Token:        token,
+		Uid:          int64(user.ID),
+		Username:     user.Username,
+		RefreshToken: raw,
+		ExpiresIn:    int64(service.AccessTokenTTL().Seconds()),
+	}, refresh, nil
+}
+
+// RefreshToken 轮换 refresh token：旧 token 作废，签发新的令牌对
+func (s *UserService) RefreshToken(ctx context.Context, req *pb.RefreshTokenReq) (*pb.LoginResp, error) {
+	// 1. 查找 refresh token
+	old, err := s.Tokens.GetRefreshToken(service.HashRefreshToken(req.RefreshToken))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 已作废的 token 被再次使用，说明可能已泄露，吊销整条链
+	if old.RevokedAt != nil {
+		if err := s.Tokens.RevokeRefreshFamily(old.FamilyID); err != nil {
+			log.Printf("Failed to revoke refresh token family %s: %v", old.FamilyID, err)
+		}
+		return nil, fmt.Errorf("refresh token has been revoked")
+	}
+	if time.Now().After(old.ExpiresAt) {
+		return nil, fmt.Errorf("refresh token expired")
+	}
+
+	user, err := s.Users.GetUserByID(old.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 签发新的令牌对并作废旧 token
+	resp, next, err := s.issueTokens(user, old.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Tokens.RotateRefreshToken(old, next); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			if err := s.Tokens.RevokeRefreshFamily(old.FamilyID); err != nil {
+				log.Printf("Failed to revoke refresh token family %s: %v", old.FamilyID, err)
+			}
+			return nil, fmt.Errorf("refresh token has been revoked")
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Logout 吊销当前 access token 与 refresh token；All 为 true 时登出所有设备
+func (s *UserService) Logout(ctx context.Context, req *pb.LogoutReq) (*pb.LogoutResp, error) {
+	claims, err := service.ParseToken(req.Token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	if req.All {
+		// 1. 所有 refresh token 作废，此前签发的 access token 全部失效
+		if err := s.Tokens.RevokeUserRefreshTokens(claims.UID); err != nil {
+			return nil, err
+		}
+		if err := dao.RevokeUserAccessTokens(ctx, claims.UID, service.AccessTokenTTL()); err != nil {
+			return nil, err
+		}
+		return &pb.LogoutResp{Success: true}, nil
+	}
+
+	// 2. 当前 access token 加入吊销名单，直到它自然过期
+	ttl := time.Until(time.Unix(claims.ExpireAt, 0))
+	if err := dao.RevokeAccessToken(ctx, claims.JTI, ttl); err != nil {
+		return nil, err
+	}
+
+	// 3. 当前登录的 refresh token 链作废（只能登出自己的）
+	if req.RefreshToken != "" {
+		t, err := s.Tokens.GetRefreshToken(service.HashRefreshToken(req.RefreshToken))
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && t.UserID == claims.UID {
+			if err := s.Tokens.RevokeRefreshFamily(t.FamilyID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &pb.LogoutResp{Success: true}, nil
+}
+
+// ValidateToken 校验 access token 的签名、有效期与吊销名单
+func (s *UserService) ValidateToken(ctx context.Context, req *pb.ValidateTokenReq) (*pb.ValidateTokenResp, error) {
+	claims, err := service.ParseToken(req.Token)
+	if err != nil {
+		return &pb.ValidateTokenResp{Valid: false}, nil
+	}
+
+	revoked, err := dao.IsAccessTokenRevoked(ctx, claims.JTI, claims.UID, claims.IssuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return &pb.ValidateTokenResp{Valid: false}, nil
+	}
+	return &pb.ValidateTokenResp{
+		Valid:    true,
+		Uid:      int64(claims.UID),
+		Username: claims.Username,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/repository/memrepo"
+	"mygame/server/user-service/internal/service"
+	"mygame/server/user-service/model"
+	"mygame/server/user-service/pkg/config"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "user-service-keys")
+	if err != nil {
+		log.Fatal(err)
+	}
+	path, err := service.GenerateKeyFiles(dir, "test", "EdDSA")
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.AppConfig = &config.Config{JWT: config.JWTConfig{
+		ActiveKID: "test",
+		Keys:      []config.JWTKeyConfig{{KID: "test", PrivateKeyFile: path}},
+	}}
+	if err := service.InitKeys(); err != nil {
+		log.Fatal(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// login 创建账号并登录；密码用最低 cost 哈希，避免 bcrypt 拖慢测试
+func login(t *testing.T, s *UserService) *pb.LoginResp {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Users.CreateUser(&model.User{Username: "alice", Password: string(hash)}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := s.Login(context.Background(), &pb.LoginReq{Username: "alice", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	return resp
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	s := NewUserService(memrepo.New())
+	ctx := context.Background()
+	first := login(t, s)
+
+	second, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: first.RefreshToken})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.Token == "" {
+		t.Fatal("refresh did not issue a new token pair")
+	}
+	third, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: second.RefreshToken})
+	if err != nil || third.RefreshToken == second.RefreshToken {
+		t.Fatalf("RefreshToken with rotated token: %v", err)
+	}
+
+	if _, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: "unknown"}); err == nil {
+		t.Fatal("unknown refresh token accepted")
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	s := NewUserService(memrepo.New())
+	ctx := context.Background()
+	first := login(t, s)
+
+	second, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: first.RefreshToken})
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+
+	// 已轮换的 token 被再次使用：拒绝，并吊销整条链上仍有效的 token
+	if _, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: first.RefreshToken}); err == nil {
+		t.Fatal("reused refresh token accepted")
+	}
+	if _, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: second.RefreshToken}); err == nil {
+		t.Fatal("token of a revoked family accepted after reuse")
+	}
+
+	// 其他登录开启的链不受影响
+	other, err := s.Login(ctx, &pb.LoginReq{Username: "alice", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if _, err := s.RefreshToken(ctx, &pb.RefreshTokenReq{RefreshToken: other.RefreshToken}); err != nil {
+		t.Fatalf("RefreshToken of another family: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/mq"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListDeadLetters 查看重试耗尽的战绩消息
+func (s *UserService) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersReq) (*pb.ListDeadLettersResp, error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	letters, total, err := mq.ListDeadLetters(ctx, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "inspect dead letters failed: %v", err)
+	}
+
+	resp := &pb.ListDeadLettersResp{
+		Letters: make([]*pb.DeadLetter, 0, len(letters)),
+		Total:   int32(total),
+	}
+	for _, l := range letters {
+		resp.Letters = append(resp.Letters, &pb.DeadLetter{
+			MatchId:  l.MatchID,
+			Error:    l.Error,
+			Attempts: l.Attempts,
+			DeadAt:   l.DeadAt,
+			Body:     l.Body,
+		})
+	}
+	return resp, nil
+}
+
+// ReplayDeadLetters 将死信重新投递到战绩队列，通常在修复问题后使用
+func (s *UserService) ReplayDeadLetters(ctx context.Context, req *pb.ReplayDeadLettersReq) (*pb.ReplayDeadLettersResp, error) {
+	if !req.All && len(req.MatchIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "match_ids or all is required")
+	}
+
+	replayed, err := mq.ReplayDeadLetters(ctx, req.MatchIds, req.All)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "replay dead letters failed (%d replayed): %v", replayed, err)
+	}
+	return &pb.ReplayDeadLettersResp{Replayed: int32(replayed)}, nil
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+)
+
+func (s *UserService) SendFriendRequest(ctx context.Context, req *pb.SendFriendRequestReq) (*pb.FriendActionResp, error) {
+	uid, target := uint(req.Uid), uint(req.TargetUid)
+	if uid == target {
+		return &pb.FriendActionResp{Success: false, Message: "cannot add yourself"}, nil
+	}
+
+	// 1. 目标用户存在、未拉黑、还不是好友
+	users, err := s.Users.GetUsersByIDs([]uint{target})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return &pb.FriendActionResp{Success: false, Message: "user not found"}, nil
+	}
+	blocked, err := s.Friends.IsBlocked(uid, target)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return &pb.FriendActionResp{Success: false, Message: "cannot add this user"}, nil
+	}
+	friends, err := s.Friends.AreFriends(uid, target)
+	if err != nil {
+		return nil, err
+	}
+	if friends {
+		return &pb.FriendActionResp{Success: false, Message: "already friends"}, nil
+	}
+
+	// 2. 对方已经向自己发出申请时直接成为好友
+	reverse, err := s.Friends.GetPendingRequest(target, uid)
+	if err == nil {
+		if err := s.Friends.AcceptFriendRequest(reverse); err != nil {
+			return nil, err
+		}
+		return &pb.FriendActionResp{Success: true, Message: "friend added"}, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	// 3. 避免重复申请
+	if _, err := s.Friends.GetPendingRequest(uid, target); err == nil {
+		return &pb.FriendActionResp{Success: false, Message: "request already sent"}, nil
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.Friends.CreateFriendRequest(&model.FriendRequest{
+		FromUserID: uid,
+		ToUserID:   target,
+		Status:     model.FriendRequestPending,
+	}); err != nil {
+		return nil, err
+	}
+	return &pb.FriendActionResp{Success: true, Message: "request sent"}, nil
+}
+
+func (s *UserService) RespondFriendRequest(ctx context.Context, req *pb.RespondFriendRequestReq) (*pb.FriendActionResp, error) {
+	fr, err := s.Friends.GetFriendRequest(uint(req.RequestId))
+	if errors.Is(err, repository.ErrNotFound) {
+		return &pb.FriendActionResp{Success: false, Message: "request not found"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 只有接收方可以处理，且只能处理一次
+	if fr.ToUserID != uint(req.Uid) {
+		return &pb.FriendActionResp{Success: false, Message: "request not found"}, nil
+	}
+	if fr.Status != model.FriendRequestPending {
+		return &pb.FriendActionResp{Success: false, Message: "request already handled"}, nil
+	}
+
+	if !req.Accept {
+		if err := s.Friends.DeclineFriendRequest(fr); err != nil {
+			return nil, err
+		}
+		return &pb.FriendActionResp{Success: true, Message: "request declined"}, nil
+	}
+
+	blocked, err := s.Friends.IsBlocked(fr.FromUserID, fr.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return &pb.FriendActionResp{Success: false, Message: "cannot add this user"}, nil
+	}
+	if err := s.Friends.AcceptFriendRequest(fr); err != nil {
+		return nil, err
+	}
+	return &pb.FriendActionResp{Success: true, Message: "friend added"}, nil
+}
+
+func (s *UserService) RemoveFriend(ctx context.Context, req *pb.RemoveFriendReq) (*pb.FriendActionResp, error) {
+	friends, err := s.Friends.AreFriends(uint(req.Uid), uint(req.FriendUid))
+	if err != nil {
+		return nil, err
+	}
+	if !friends {
+		return &pb.FriendActionResp{Success: false, Message: "not friends"}, nil
+	}
+	if err := s.Friends.RemoveFriend(uint(req.Uid), uint(req.FriendUid)); err != nil {
+		return nil, err
+	}
+	return &pb.FriendActionResp{Success: true, Message: "friend removed"}, nil
+}
+
+func (s *UserService) BlockUser(ctx context.Context, req *pb.BlockUserReq) (*pb.FriendActionResp, error) {
+	if req.Uid == req.TargetUid {
+		return &pb.FriendActionResp{Success: false, Message: "cannot block yourself"}, nil
+	}
+
+	if req.Unblock {
+		if err := s.Friends.UnblockUser(uint(req.Uid), uint(req.TargetUid)); err != nil {
+			return nil, err
+		}
+		return &pb.FriendActionResp{Success: true, Message: "user unblocked"}, nil
+	}
+
+	if err := s.Friends.BlockUser(uint(req.Uid), uint(req.TargetUid)); err != nil {
+		return nil, err
+	}
+	return &pb.FriendActionResp{Success: true, Message: "user blocked"}, nil
+}
+
+func (s *UserService) GetFriends(ctx context.Context, req *pb.GetFriendsReq) (*pb.GetFriendsResp, error) {
+	friendships, err := s.Friends.GetFriends(uint(req.Uid))
+	if err != nil {
+		return nil, err
+	}
+
+	// 批量查询用户名与在线状态
+	ids := make([]uint, 0, len(friendships))
+	uids := make([]int64, 0, len(friendships))
+	for _, f := range friendships {
+		ids = append(ids, f.FriendID)
+		uids = append(uids, int64(f.FriendID))
+	}
+	users, err := s.Users.GetUsersByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Username
+	}
+	presences, err := dao.GetPresences(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	friends := make([]*pb.FriendInfo, 0, len(friendships))
+	for _, f := range friendships {
+		friends = append(friends, &pb.FriendInfo{
+			Uid:      int64(f.FriendID),
+			Username: names[f.FriendID],
+			Presence: toPresence(presences[int64(f.FriendID)]),
+			Since:    f.CreatedAt.Unix(),
+		})
+	}
+
+	blocked, err := s.Friends.GetBlocked(uint(req.Uid))
+	if err != nil {
+		return nil, err
+	}
+	blockedUids := make([]int64, 0, len(blocked))
+	for _, id := range blocked {
+		blockedUids = append(blockedUids, int64(id))
+	}
+
+	return &pb.GetFriendsResp{Friends: friends, BlockedUids: blockedUids}, nil
+}
+
+func (s *UserService) GetFriendRequests(ctx context.Context, req *pb.GetFriendRequestsReq) (*pb.GetFriendRequestsResp, error) {
+	incoming, outgoing, err := s.Friends.GetFriendRequests(uint(req.Uid))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint
+	for _, r := range append(append([]model.FriendRequest{}, incoming...), outgoing...) {
+		ids = append(ids, r.FromUserID, r.ToUserID)
+	}
+	users, err := s.Users.GetUsersByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Username
+	}
+
+	convert := func(records []model.FriendRequest) []*pb.FriendRequestInfo {
+		result := make([]*pb.FriendRequestInfo, 0, len(records))
+		for _, r := range records {
+			result = append(result, &pb.FriendRequestInfo{
+				RequestId:    int64(r.ID),
+				FromUid:      int64(r.FromUserID),
+				FromUsername: names[r.FromUserID],
+				ToUid:        int64(r.ToUserID),
+				ToUsername:   names[r.ToUserID],
+				CreatedAt:    r.CreatedAt.Unix(),
+			})
+		}
+		return result
+	}
+	return &pb.GetFriendRequestsResp{Incoming: convert(incoming), Outgoing: convert(outgoing)}, nil
+}
+
+func (s *UserService) UpdatePresence(ctx context.Context, req *pb.UpdatePresenceReq) (*pb.UpdatePresenceResp, error) {
+	var err error
+	switch {
+	case req.Touch:
+		err = dao.TouchPresence(ctx, req.Uid)
+	case req.Status == pb.Presence_OFFLINE:
+		err = dao.ClearPresence(ctx, req.Uid)
+	default:
+		err = dao.SetPresence(ctx, req.Uid, presenceStatusName(req.Status), req.RoomId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdatePresenceResp{}, nil
+}
+
+func (s *UserService) GetFriendRoom(ctx context.Context, req *pb.GetFriendRoomReq) (*pb.GetFriendRoomResp, error) {
+	friends, err := s.Friends.AreFriends(uint(req.Uid), uint(req.FriendUid))
+	if err != nil {
+		return nil, err
+	}
+	if !friends {
+		return nil, errors.New("not friends")
+	}
+
+	presences, err := dao.GetPresences(ctx, []int64{req.FriendUid})
+	if err != nil {
+		return nil, err
+	}
+	presence := toPresence(presences[req.FriendUid])
+	return &pb.GetFriendRoomResp{RoomId: presence.RoomId, Presence: presence}, nil
+}
+
+// AreFriends 供 Match Service 校验通过好友加入房间或观战的请求，不依赖 Gateway 的预先检查
+func (s *UserService) AreFriends(ctx context.Context, req *pb.AreFriendsReq) (*pb.AreFriendsResp, error) {
+	friends, err := s.Friends.AreFriends(uint(req.Uid), uint(req.FriendUid))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AreFriendsResp{Friends: friends}, nil
+}
+
+func toPresence(p dao.PresenceInfo) *pb.Presence {
+	status := pb.Presence_OFFLINE
+	switch p.Status {
+	case dao.PresenceLobby:
+		status = pb.Presence_LOBBY
+	case dao.PresenceInQueue:
+		status = pb.Presence_IN_QUEUE
+	case dao.PresenceInMatch:
+		status = pb.Presence_IN_MATCH
+	}
+	return &pb.Presence{Status: status, RoomId: p.RoomID, UpdatedAt: p.UpdatedAt}
+}
+
+func presenceStatusName(status pb.Presence_Status) string {
+	switch status {
+	case pb.Presence_IN_QUEUE:
+		return dao.PresenceInQueue
+	case pb.Presence_IN_MATCH:
+		return dao.PresenceInMatch
+	}
+	return dao.PresenceLobby
+}
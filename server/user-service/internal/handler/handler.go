@@ -2,14 +2,33 @@ package handler
 
 import (
 	"context"
+	"errors"
 	pb "mygame/proto"
-	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/repository"
 	"mygame/server/user-service/internal/service"
 	"mygame/server/user-service/model"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserService struct {
 	pb.UnimplementedUserServiceServer
+
+	Users   repository.UserRepository
+	Matches repository.MatchRepository
+	Friends repository.FriendRepository
+	Tokens  repository.TokenRepository
+}
+
+// NewUserService 所有仓库由同一个实现提供（MySQL / SQLite / 内存）
+func NewUserService(repo repository.Repository) *UserService {
+	return &UserService{
+		Users:   repo,
+		Matches: repo,
+		Friends: repo,
+		Tokens:  repo,
+	}
 }
 
 func (s *UserService) Register(ctx context.Context, req *pb.RegisterReq) (*pb.RegisterResp, error) {
@@ -24,7 +43,7 @@ func (s *UserService) Register(ctx context.Context, req *pb.RegisterReq) (*pb.Re
 		Username: req.Username,
 		Password: hashedPwd,
 	}
-	if err := dao.CreateUser(user); err != nil {
+	if err := s.Users.CreateUser(user); err != nil {
 		return nil, err // 可能是用户名重复
 	}
 
@@ -32,32 +51,37 @@ func (s *UserService) Register(ctx context.Context, req *pb.RegisterReq) (*pb.Re
 }
 
 func (s *UserService) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error) {
-	// 1. 查用户
-	user, err := dao.GetUserByUsername(req.Username)
+	// 1. 查用户；用户不存在与密码错误返回相同的错误，Gateway 据此统计失败次数
+	user, err := s.Users.GetUserByUsername(req.Username)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
+	}
 	if err != nil {
-		return nil, err // 用户不存在
+		return nil, err
 	}
 
 	// 2. 校验密码
 	if !service.CheckPasswordHash(req.Password, user.Password) {
-		return nil, err // 密码错误 (实际应返回自定义 error code)
+		return nil, status.Error(codes.Unauthenticated, "invalid username or password")
 	}
 
-	// 3. 生成 Token
-	token, err := service.GenerateToken(user.ID, user.Username)
+	// 3. 生成 Token：每次登录开启一条新的 refresh token 链
+	familyID, err := service.NewID()
 	if err != nil {
 		return nil, err
 	}
-
-	return &pb.LoginResp{
-		Token:    token,
-		Uid:      int64(user.ID),
-		Username: user.Username,
-	}, nil
+	resp, refresh, err := s.issueTokens(user, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Tokens.CreateRefreshToken(refresh); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (s *UserService) GetHistory(ctx context.Context, req *pb.GetHistoryReq) (*pb.GetHistoryResp, error) {
-	records, err := dao.GetHistory(uint(req.Uid), int(req.Page), int(req.Limit))
+	records, err := s.Matches.GetHistory(uint(req.Uid), int(req.Page), int(req.Limit))
 	if err != nil {
 		return nil, err
 	}
@@ -70,8 +94,109 @@ func (s *UserService) GetHistory(ctx context.Context, req *pb.GetHistoryReq) (*p
 			IsWinner:  r.IsWinner,
 			Kills:     int32(r.Kills),
 			Timestamp: r.Timestamp,
+			Placement: int32(r.Placement),
+			Deaths:    int32(r.Deaths),
+			MapId:     int32(r.MapID),
 		})
 	}
 
 	return &pb.GetHistoryResp{History: pbHistory}, nil
 }
+
+func (s *UserService) GetRating(ctx context.Context, req *pb.GetRatingReq) (*pb.GetRatingResp, error) {
+	mode := service.ResolveMode(req.Mode)
+	season := service.ResolveSeason(req.Season)
+
+	r, err := s.Matches.GetRating(uint(req.Uid), mode, season)
+	if errors.Is(err, repository.ErrNotFound) {
+		// 未参与过该模式/赛季，返回初始分
+		return &pb.GetRatingResp{Rating: defaultRatingInfo(req.Uid, mode, season)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetRatingResp{Rating: toRatingInfo(r)}, nil
+}
+
+func (s *UserService) BatchGetRatings(ctx context.Context, req *pb.BatchGetRatingsReq) (*pb.BatchGetRatingsResp, error) {
+	mode := service.ResolveMode(req.Mode)
+	season := service.ResolveSeason(req.Season)
+
+	uids := make([]uint, 0, len(req.Uids))
+	for _, uid := range req.Uids {
+		uids = append(uids, uint(uid))
+	}
+	records, err := s.Matches.GetRatings(uids, mode, season)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[int64]*model.PlayerRating, len(records))
+	for i := range records {
+		byUser[int64(records[i].UserID)] = &records[i]
+	}
+
+	// 按请求顺序返回，缺失的用初始分补齐
+	ratings := make([]*pb.RatingInfo, 0, len(req.Uids))
+	for _, uid := range req.Uids {
+		if r, ok := byUser[uid]; ok {
+			ratings = append(ratings, toRatingInfo(r))
+		} else {
+			ratings = append(ratings, defaultRatingInfo(uid, mode, season))
+		}
+	}
+	return &pb.BatchGetRatingsResp{Ratings: ratings}, nil
+}
+
+func (s *UserService) GetRatingHistory(ctx context.Context, req *pb.GetRatingHistoryReq) (*pb.GetRatingHistoryResp, error) {
+	page, limit := int(req.Page), int(req.Limit)
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	records, err := s.Matches.GetRatingHistory(uint(req.Uid), service.ResolveMode(req.Mode), service.ResolveSeason(req.Season), page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbHistory []*pb.RatingChange
+	for _, r := range records {
+		pbHistory = append(pbHistory, &pb.RatingChange{
+			MatchId:      r.MatchID,
+			RatingBefore: r.RatingBefore,
+			RatingAfter:  r.RatingAfter,
+			Delta:        r.Delta,
+			Placement:    int32(r.Placement),
+			Timestamp:    r.Timestamp,
+		})
+	}
+	return &pb.GetRatingHistoryResp{History: pbHistory}, nil
+}
+
+func toRatingInfo(r *model.PlayerRating) *pb.RatingInfo {
+	return &pb.RatingInfo{
+		Uid:        int64(r.UserID),
+		Mode:       r.Mode,
+		Season:     r.Season,
+		Rating:     r.Rating,
+		Deviation:  r.Deviation,
+		Volatility: r.Volatility,
+		Matches:    int32(r.Matches),
+		Wins:       int32(r.Wins),
+	}
+}
+
+func defaultRatingInfo(uid int64, mode, season string) *pb.RatingInfo {
+	initial := service.DefaultRating()
+	return &pb.RatingInfo{
+		Uid:        uid,
+		Mode:       mode,
+		Season:     season,
+		Rating:     initial.Rating,
+		Deviation:  initial.Deviation,
+		Volatility: initial.Volatility,
+	}
+}
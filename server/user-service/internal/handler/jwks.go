@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"context"
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/service"
+)
+
+// GetJWKS 返回验签公钥，Gateway 缓存后通过 /.well-known/jwks.json 对外发布
+func (s *UserService) GetJWKS(ctx context.Context, req *pb.GetJWKSReq) (*pb.GetJWKSResp, error) {
+	keys := service.PublicJWKs()
+	resp := &pb.GetJWKSResp{Keys: make([]*pb.JWK, 0, len(keys))}
+	for _, k := range keys {
+		resp.Keys = append(resp.Keys, &pb.JWK{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Alg: k.Alg,
+			Use: k.Use,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return resp, nil
+}
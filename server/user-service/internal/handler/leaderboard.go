@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/service"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func (s *UserService) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardReq) (*pb.GetLeaderboardResp, error) {
+	key, err := leaderboardKey(req.Board, req.Mode, req.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := int(req.Page), int(req.Limit)
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	members, total, err := dao.GetLeaderboard(ctx, key, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.toLeaderboardEntries(members, int64((page-1)*limit))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetLeaderboardResp{Entries: entries, Total: total}, nil
+}
+
+func (s *UserService) GetRank(ctx context.Context, req *pb.GetRankReq) (*pb.GetRankResp, error) {
+	key, err := leaderboardKey(req.Board, req.Mode, req.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	rank, score, err := dao.GetRank(ctx, key, req.Uid)
+	if err == redis.Nil {
+		// 未上榜
+		return &pb.GetRankResp{Entry: &pb.LeaderboardEntry{Uid: req.Uid}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	neighbours := int64(req.Neighbours)
+	if neighbours < 0 {
+		neighbours = 0
+	}
+	if neighbours > 50 {
+		neighbours = 50
+	}
+	start := rank - neighbours
+	if start < 0 {
+		start = 0
+	}
+
+	members, err := dao.GetLeaderboardRange(ctx, key, start, rank+neighbours)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.toLeaderboardEntries(members, start)
+	if err != nil {
+		return nil, err
+	}
+
+	self := &pb.LeaderboardEntry{Rank: rank + 1, Uid: req.Uid, Score: score}
+	for _, e := range entries {
+		if e.Uid == req.Uid {
+			self.Username = e.Username
+		}
+	}
+	return &pb.GetRankResp{Entry: self, Neighbours: entries}, nil
+}
+
+func leaderboardKey(board, mode, season string) (string, error) {
+	if !dao.IsValidBoard(board) {
+		return "", fmt.Errorf("invalid leaderboard: %s", board)
+	}
+	if board == dao.BoardRating {
+		return dao.LeaderboardKey(board, service.ResolveMode(mode), service.ResolveSeason(season)), nil
+	}
+	return dao.LeaderboardKey(board, "", season), nil
+}
+
+// toLeaderboardEntries 把 ZSet 成员转换为榜单条目并补全用户名，offset 为第一条的名次（从 0 开始）
+func (s *UserService) toLeaderboardEntries(members []redis.Z, offset int64) ([]*pb.LeaderboardEntry, error) {
+	uids := make([]uint, 0, len(members))
+	entries := make([]*pb.LeaderboardEntry, 0, len(members))
+	for i, m := range members {
+		uid, _ := strconv.ParseInt(fmt.Sprint(m.Member), 10, 64)
+		uids = append(uids, uint(uid))
+		entries = append(entries, &pb.LeaderboardEntry{
+			Rank:  offset + int64(i) + 1,
+			Uid:   uid,
+			Score: m.Score,
+		})
+	}
+
+	users, err := s.Users.GetUsersByIDs(uids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[int64(u.ID)] = u.Username
+	}
+	for _, e := range entries {
+		e.Username = names[e.Uid]
+	}
+	return entries, nil
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pb "mygame/proto"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/service"
+)
+
+// GetProfile 玩家资料与生涯统计，数据来自 MQ 消费时维护的汇总表
+func (s *UserService) GetProfile(ctx context.Context, req *pb.GetProfileReq) (*pb.GetProfileResp, error) {
+	// 1. 基本信息
+	user, err := s.Users.GetUserByID(uint(req.Uid))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &pb.PlayerProfile{
+		Uid:      int64(user.ID),
+		Username: user.Username,
+		JoinedAt: user.CreatedAt.Unix(),
+	}
+
+	// 2. 生涯汇总，没有对局记录时全部为 0
+	stats, err := s.Matches.GetStats(user.ID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		profile.TotalMatches = int32(stats.Matches)
+		profile.Wins = int32(stats.Wins)
+		profile.Kills = int32(stats.Kills)
+		profile.Deaths = int32(stats.Deaths)
+		profile.Kd = dao.KDRatio(float64(stats.Kills), float64(stats.Deaths))
+		profile.RecentForm = stats.RecentForm
+		profile.LastMatchAt = stats.LastMatchAt
+		if stats.Matches > 0 {
+			profile.WinRate = float64(stats.Wins) / float64(stats.Matches)
+		}
+
+		mapID, err := s.Matches.GetFavouriteMap(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		profile.FavouriteMapId = int32(mapID)
+	}
+
+	// 3. 当前段位分
+	mode := service.ResolveMode(req.Mode)
+	season := service.ResolveSeason(req.Season)
+	r, err := s.Matches.GetRating(user.ID, mode, season)
+	if errors.Is(err, repository.ErrNotFound) {
+		profile.Rating = defaultRatingInfo(req.Uid, mode, season)
+	} else if err != nil {
+		return nil, err
+	} else {
+		profile.Rating = toRatingInfo(r)
+	}
+
+	return &pb.GetProfileResp{Profile: profile}, nil
+}
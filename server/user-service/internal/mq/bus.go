@@ -0,0 +1,27 @@
+package mq
+
+import (
+	"fmt"
+	"mygame/pkg/messaging"
+	"mygame/pkg/messaging/memory"
+	"mygame/pkg/messaging/rabbitmq"
+	"mygame/pkg/messaging/redisstream"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/pkg/config"
+)
+
+// openBus 根据 mq.backend 创建消息总线
+//   - rabbitmq（默认）: 使用 mq.url
+//   - redis: Redis Streams，复用 redis 配置的连接
+//   - memory: 进程内总线，仅在同一进程运行全部服务时可用
+func openBus() (messaging.Bus, error) {
+	switch config.AppConfig.MQ.Backend {
+	case "", "rabbitmq":
+		return rabbitmq.New(config.AppConfig.MQ.Url), nil
+	case "redis":
+		return redisstream.New(dao.RDB), nil
+	case "memory":
+		return memory.Shared(), nil
+	}
+	return nil, fmt.Errorf("unknown mq backend %q", config.AppConfig.MQ.Backend)
+}
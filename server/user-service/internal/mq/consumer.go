@@ -1,84 +1,215 @@
 package mq
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"mygame/pkg/messaging"
 	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/service"
 	"mygame/server/user-service/model"
 	"mygame/server/user-service/pkg/config"
-
-	"github.com/streadway/amqp"
+	"time"
 )
 
-var Conn *amqp.Connection
-var Channel *amqp.Channel
+// Bus 战绩消息使用的消息总线，后端由 mq.backend 配置
+var Bus messaging.Bus
+
+// matches 战绩落库使用的仓库，由 StartConsumer 注入
+var matches repository.MatchRepository
 
 type GameResult struct {
-	MatchID   string `json:"match_id"`
-	Winner    int64  `json:"winner"`
-	Timestamp int64  `json:"timestamp"`
+	MatchID   string         `json:"match_id"`
+	Mode      string         `json:"mode"`
+	MapID     int32          `json:"map_id"`
+	Winner    int64          `json:"winner"`
+	Timestamp int64          `json:"timestamp"`
+	Players   []PlayerResult `json:"players"`
+}
+
+type PlayerResult struct {
+	UID       int64  `json:"uid"`
+	Username  string `json:"username"`
+	Placement int32  `json:"placement"`
+	Kills     int32  `json:"kills"`
+	Deaths    int32  `json:"deaths"`
+	IsWinner  bool   `json:"is_winner"`
 }
 
 func InitMQ() {
-	var err error
-	Conn, err = amqp.Dial(config.AppConfig.MQ.Url)
+	bus, err := openBus()
 	if err != nil {
-		log.Fatalf("MQ connect failed: %v", err)
+		log.Fatalf("MQ init failed: %v", err)
 	}
+	Bus = bus
+}
 
-	Channel, err = Conn.Channel()
-	if err != nil {
-		log.Fatalf("MQ channel failed: %v", err)
+// StartConsumer 阻塞消费战绩队列；失败的消息按 mq.retry_delays 延迟重试，耗尽后进入死信队列
+func StartConsumer(repo repository.MatchRepository) {
+	matches = repo
+
+	delays := make([]time.Duration, 0, len(config.AppConfig.MQ.RetryDelays))
+	for _, s := range config.AppConfig.MQ.RetryDelays {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			log.Fatalf("Invalid mq retry delay %q", s)
+		}
+		delays = append(delays, d)
 	}
 
-	_, err = Channel.QueueDeclare(
-		config.AppConfig.MQ.QueueName,
-		true, false, false, false, nil,
+	log.Printf("MQ Consumer started, waiting for messages on queue: %s", config.AppConfig.MQ.QueueName)
+
+	err := Bus.Subscribe(context.Background(), config.AppConfig.MQ.QueueName, handleMessage,
+		messaging.WithRetry(delays...),
+		messaging.WithDeadLetter(deadLetterQueue()),
 	)
 	if err != nil {
-		log.Fatalf("MQ queue declare failed: %v", err)
+		log.Fatalf("MQ consumer stopped: %v", err)
 	}
 }
 
-func StartConsumer() {
-	msgs, err := Channel.Consume(
-		config.AppConfig.MQ.QueueName,
-		"",
-		false, // auto-ack
-		false, false, false, nil,
-	)
-	if err != nil {
-		log.Fatalf("Failed to register consumer: %v", err)
+// handleMessage 幂等处理一条战绩消息，返回错误时由总线重试或转入死信队列
+func handleMessage(ctx context.Context, msg *messaging.Message) error {
+	var result GameResult
+	if err := json.Unmarshal(msg.Body, &result); err != nil {
+		// 无法解析的消息重试也不会成功，直接进入死信队列
+		return messaging.Permanent(fmt.Errorf("unmarshal failed: %v", err))
 	}
 
-	log.Printf("MQ Consumer started, waiting for messages on queue: %s", config.AppConfig.MQ.QueueName)
+	// 1. 重复投递的比赛直接确认
+	if result.MatchID != "" {
+		saved, err := matches.IsMatchSaved(result.MatchID)
+		if err == nil && saved {
+			log.Printf("Game result already saved, skip duplicate: match_id=%s", result.MatchID)
+			return nil
+		}
+	}
+
+	// 2. 落库；并发重复投递由 (match_id, user_id) 唯一索引兜底
+	err := saveGameResult(&result)
+	if err != nil && !errors.Is(err, repository.ErrMatchAlreadySaved) {
+		log.Printf("Failed to save game result %s (attempt %d): %v", result.MatchID, msg.Attempts+1, err)
+		return err
+	}
 
-	for msg := range msgs {
-		var result GameResult
-		if err := json.Unmarshal(msg.Body, &result); err != nil {
-			log.Printf("Failed to unmarshal message: %v", err)
-			msg.Nack(false, false)
-			continue
+	log.Printf("Game result saved: match_id=%s, winner=%d", result.MatchID, result.Winner)
+	return nil
+}
+
+func saveGameResult(result *GameResult) error {
+	// 兼容旧版本 Game Service：只有胜者信息，不参与段位计算
+	if len(result.Players) == 0 {
+		history := &model.MatchHistory{
+			MatchID:   result.MatchID,
+			UserID:    uint(result.Winner),
+			IsWinner:  true,
+			Kills:     0,
+			Timestamp: result.Timestamp,
 		}
+		return matches.SaveMatchResult([]*model.MatchHistory{history}, nil, nil)
+	}
 
-		if err := saveGameResult(&result); err != nil {
-			log.Printf("Failed to save game result: %v", err)
-			msg.Nack(false, true) // requeue
-			continue
+	mode := service.ResolveMode(result.Mode)
+	season := service.ResolveSeason("")
+
+	// 1. 每个参与者一条战绩
+	histories := make([]*model.MatchHistory, 0, len(result.Players))
+	uids := make([]uint, 0, len(result.Players))
+	for _, p := range result.Players {
+		histories = append(histories, &model.MatchHistory{
+			UserID:    uint(p.UID),
+			MatchID:   result.MatchID,
+			Mode:      mode,
+			Season:    season,
+			MapID:     int(result.MapID),
+			IsWinner:  p.IsWinner,
+			Placement: int(p.Placement),
+			Kills:     int(p.Kills),
+			Deaths:    int(p.Deaths),
+			Timestamp: result.Timestamp,
+		})
+		uids = append(uids, uint(p.UID))
+	}
+
+	// 2. 读取赛前段位分，新玩家使用初始分
+	existing, err := matches.GetRatings(uids, mode, season)
+	if err != nil {
+		return err
+	}
+	byUser := make(map[uint]*model.PlayerRating, len(existing))
+	for i := range existing {
+		byUser[existing[i].UserID] = &existing[i]
+	}
+
+	participants := make([]service.Participant, 0, len(result.Players))
+	ratings := make([]*model.PlayerRating, 0, len(result.Players))
+	for _, p := range result.Players {
+		r, ok := byUser[uint(p.UID)]
+		if !ok {
+			initial := service.DefaultRating()
+			r = &model.PlayerRating{
+				UserID:     uint(p.UID),
+				Mode:       mode,
+				Season:     season,
+				Rating:     initial.Rating,
+				Deviation:  initial.Deviation,
+				Volatility: initial.Volatility,
+			}
 		}
+		ratings = append(ratings, r)
+		participants = append(participants, service.Participant{
+			UID:       p.UID,
+			Placement: p.Placement,
+			Before:    service.Rating{Rating: r.Rating, Deviation: r.Deviation, Volatility: r.Volatility},
+		})
+	}
 
-		msg.Ack(false)
-		log.Printf("Game result saved: match_id=%s, winner=%d", result.MatchID, result.Winner)
+	// 3. 计算新段位分并记录变化
+	updated := service.CalculateRatings(participants)
+	changes := make([]*model.RatingHistory, 0, len(result.Players))
+	for i, p := range result.Players {
+		r := ratings[i]
+		before := r.Rating
+		r.Rating = updated[i].Rating
+		r.Deviation = updated[i].Deviation
+		r.Volatility = updated[i].Volatility
+		r.Matches++
+		if p.IsWinner {
+			r.Wins++
+		}
+		changes = append(changes, &model.RatingHistory{
+			UserID:       uint(p.UID),
+			Mode:         mode,
+			Season:       season,
+			MatchID:      result.MatchID,
+			RatingBefore: before,
+			RatingAfter:  r.Rating,
+			Delta:        r.Rating - before,
+			Placement:    int(p.Placement),
+			Timestamp:    result.Timestamp,
+		})
 	}
-}
 
-func saveGameResult(result *GameResult) error {
-	history := &model.MatchHistory{
-		MatchID:   result.MatchID,
-		UserID:    uint(result.Winner),
-		IsWinner:  true,
-		Kills:     0,
-		Timestamp: result.Timestamp,
+	if err := matches.SaveMatchResult(histories, ratings, changes); err != nil {
+		return err
+	}
+
+	// 4. 更新排行榜；MySQL 已落库，失败时可通过 rebuild-leaderboards 命令恢复
+	updates := make([]dao.LeaderboardUpdate, 0, len(result.Players))
+	for i, p := range result.Players {
+		updates = append(updates, dao.LeaderboardUpdate{
+			UID:    p.UID,
+			Win:    p.IsWinner,
+			Kills:  int(p.Kills),
+			Deaths: int(p.Deaths),
+			Rating: ratings[i].Rating,
+		})
+	}
+	if err := dao.UpdateLeaderboards(context.Background(), mode, season, updates); err != nil {
+		log.Printf("Failed to update leaderboards for match %s: %v", result.MatchID, err)
 	}
-	return dao.AddHistory(history)
+	return nil
 }
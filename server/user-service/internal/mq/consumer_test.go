@@ -0,0 +1,185 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"mygame/pkg/messaging"
+	"mygame/pkg/messaging/memory"
+	"mygame/server/user-service/internal/dao"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/repository/memrepo"
+	"mygame/server/user-service/model"
+	"mygame/server/user-service/pkg/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMain(m *testing.M) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	dao.RDB = redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	config.AppConfig = &config.Config{MQ: config.MQConfig{QueueName: "game_results"}}
+	code := m.Run()
+	dao.RDB.Close()
+	mr.Close()
+	os.Exit(code)
+}
+
+// flakyRepo 写入 broken 中的比赛时失败，模拟数据库不可用
+type flakyRepo struct {
+	repository.MatchRepository
+
+	mu     sync.Mutex
+	broken map[string]bool
+}
+
+func (r *flakyRepo) SaveMatchResult(histories []*model.MatchHistory, ratings []*model.PlayerRating, changes []*model.RatingHistory) error {
+	r.mu.Lock()
+	broken := r.broken[histories[0].MatchID]
+	r.mu.Unlock()
+	if broken {
+		return errors.New("database unavailable")
+	}
+	return r.MatchRepository.SaveMatchResult(histories, ratings, changes)
+}
+
+func (r *flakyRepo) setBroken(matchID string, broken bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.broken[matchID] = broken
+}
+
+func resultMessage(t *testing.T, matchID string) *messaging.Message {
+	t.Helper()
+	body, err := json.Marshal(GameResult{
+		MatchID:   matchID,
+		Mode:      "ffa",
+		Winner:    1,
+		Timestamp: time.Now().Unix(),
+		Players: []PlayerResult{
+			{UID: 1, Placement: 1, Kills: 3, IsWinner: true},
+			{UID: 2, Placement: 2, Deaths: 3},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &messaging.Message{ID: matchID, Body: body}
+}
+
+func TestHandleMessage(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          []byte
+		saved         bool
+		broken        bool
+		wantErr       bool
+		wantPermanent bool
+		wantSaved     bool
+	}{
+		{name: "save", wantSaved: true},
+		{name: "duplicate delivery", saved: true, wantSaved: true},
+		{name: "database unavailable", broken: true, wantErr: true},
+		{name: "malformed body", body: []byte("{"), wantErr: true, wantPermanent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &flakyRepo{MatchRepository: memrepo.New(), broken: map[string]bool{}}
+			matches = repo
+			msg := resultMessage(t, "m1")
+			if tt.body != nil {
+				msg.Body = tt.body
+			}
+			if tt.saved {
+				if err := handleMessage(context.Background(), msg); err != nil {
+					t.Fatal(err)
+				}
+			}
+			repo.setBroken("m1", tt.broken)
+
+			err := handleMessage(context.Background(), msg)
+			if (err != nil) != tt.wantErr || messaging.IsPermanent(err) != tt.wantPermanent {
+				t.Fatalf("handle = %v, wantErr %v, permanent %v", err, tt.wantErr, tt.wantPermanent)
+			}
+			if saved, _ := repo.IsMatchSaved("m1"); saved != tt.wantSaved {
+				t.Fatalf("saved = %v, want %v", saved, tt.wantSaved)
+			}
+			// 重复投递不会重复计入战绩
+			if history, _ := repo.GetHistory(1, 1, 10); tt.wantSaved && len(history) != 1 {
+				t.Fatalf("history of winner = %d entries, want 1", len(history))
+			}
+		})
+	}
+}
+
+func TestDeadLetterReplay(t *testing.T) {
+	repo := &flakyRepo{MatchRepository: memrepo.New(), broken: map[string]bool{"m1": true}}
+	matches = repo
+	bus := memory.New()
+	Bus = bus
+	defer bus.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Bus.Subscribe(ctx, config.AppConfig.MQ.QueueName, handleMessage,
+		messaging.WithRetry(10*time.Millisecond, 10*time.Millisecond),
+		messaging.WithDeadLetter(deadLetterQueue()),
+	)
+
+	// 1. m1 首次投递与两次重试都失败后进入死信队列，m2 正常落库
+	for _, id := range []string{"m1", "m2"} {
+		if err := Bus.Publish(ctx, config.AppConfig.MQ.QueueName, resultMessage(t, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var letters []DeadLetter
+	waitFor(t, func() bool {
+		var total int
+		letters, total, _ = ListDeadLetters(ctx, 10)
+		return total == 1
+	})
+	if l := letters[0]; l.MatchID != "m1" || l.Attempts != 3 || l.Error != "database unavailable" || l.DeadAt == 0 {
+		t.Fatalf("dead letter = %+v", l)
+	}
+	waitFor(t, func() bool {
+		saved, _ := repo.IsMatchSaved("m2")
+		return saved
+	})
+
+	// 2. 数据库恢复后只重放指定的比赛
+	repo.setBroken("m1", false)
+	if n, err := ReplayDeadLetters(ctx, []string{"other"}, false); err != nil || n != 0 {
+		t.Fatalf("replay other = %d, %v", n, err)
+	}
+	if n, err := ReplayDeadLetters(ctx, []string{"m1"}, false); err != nil || n != 1 {
+		t.Fatalf("replay m1 = %d, %v", n, err)
+	}
+	waitFor(t, func() bool {
+		saved, _ := repo.IsMatchSaved("m1")
+		return saved
+	})
+	if _, total, _ := ListDeadLetters(ctx, 10); total != 0 {
+		t.Fatalf("dead letters after replay = %d, want 0", total)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,67 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"mygame/pkg/messaging"
+	"mygame/server/user-service/pkg/config"
+	"strconv"
+)
+
+// DeadLetter 死信队列中的一条战绩消息
+type DeadLetter struct {
+	MatchID  string
+	Error    string
+	Attempts int32
+	DeadAt   int64
+	Body     string
+}
+
+// deadLetterQueue 死信队列名
+func deadLetterQueue() string {
+	if q := config.AppConfig.MQ.DeadLetterQueue; q != "" {
+		return q
+	}
+	return config.AppConfig.MQ.QueueName + ".dlq"
+}
+
+// ListDeadLetters 查看死信队列前 limit 条消息，不会移除消息
+func ListDeadLetters(ctx context.Context, limit int) ([]DeadLetter, int, error) {
+	msgs, total, err := Bus.Peek(ctx, deadLetterQueue(), limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	letters := make([]DeadLetter, 0, len(msgs))
+	for _, m := range msgs {
+		deadAt, _ := strconv.ParseInt(m.Headers[messaging.HeaderDeadAt], 10, 64)
+		letters = append(letters, DeadLetter{
+			MatchID:  matchIDOf(m),
+			Error:    m.Error,
+			Attempts: int32(m.Attempts),
+			DeadAt:   deadAt,
+			Body:     string(m.Body),
+		})
+	}
+	return letters, total, nil
+}
+
+// ReplayDeadLetters 将指定比赛（all 为 true 时全部）的死信重新投递到战绩队列，重试次数清零
+func ReplayDeadLetters(ctx context.Context, matchIDs []string, all bool) (int, error) {
+	wanted := make(map[string]bool, len(matchIDs))
+	for _, id := range matchIDs {
+		wanted[id] = true
+	}
+	return Bus.Replay(ctx, deadLetterQueue(), config.AppConfig.MQ.QueueName, func(m *messaging.Message) bool {
+		return all || wanted[matchIDOf(m)]
+	})
+}
+
+// matchIDOf 优先从消息体解析 match_id，兼容未设置消息 ID 的旧版本 Game Service
+func matchIDOf(m *messaging.Message) string {
+	var result GameResult
+	if err := json.Unmarshal(m.Body, &result); err == nil && result.MatchID != "" {
+		return result.MatchID
+	}
+	return m.ID
+}
@@ -0,0 +1,28 @@
+package mq
+
+import (
+	"testing"
+
+	"mygame/pkg/messaging"
+)
+
+func TestMatchIDOf(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *messaging.Message
+		want string
+	}{
+		{"match id from body", &messaging.Message{ID: "msg-1", Body: []byte(`{"match_id":"m-1"}`)}, "m-1"},
+		{"body without match id", &messaging.Message{ID: "msg-2", Body: []byte(`{}`)}, "msg-2"},
+		// 无法解析的消息仍要能在死信列表中定位
+		{"body is not a result", &messaging.Message{ID: "msg-3", Body: []byte("not json")}, "msg-3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchIDOf(tt.msg); got != tt.want {
+				t.Errorf("matchIDOf = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package memrepo
+
+import (
+	"sort"
+	"time"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+)
+
+func (r *Repo) GetFriendRequest(id uint) (*model.FriendRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if req, ok := r.requests[id]; ok {
+		c := *req
+		return &c, nil
+	}
+	return &model.FriendRequest{}, repository.ErrNotFound
+}
+
+func (r *Repo) GetPendingRequest(from, to uint) (*model.FriendRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, req := range r.sortedRequests() {
+		if req.FromUserID == from && req.ToUserID == to && req.Status == model.FriendRequestPending {
+			c := *req
+			return &c, nil
+		}
+	}
+	return &model.FriendRequest{}, repository.ErrNotFound
+}
+
+// sortedRequests 按 ID 升序，调用方需持有 mu
+func (r *Repo) sortedRequests() []*model.FriendRequest {
+	reqs := make([]*model.FriendRequest, 0, len(r.requests))
+	for _, req := range r.requests {
+		reqs = append(reqs, req)
+	}
+	sort.Slice(reqs, func(i, j int) bool { return reqs[i].ID < reqs[j].ID })
+	return reqs
+}
+
+func (r *Repo) CreateFriendRequest(req *model.FriendRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stamp(&req.Model)
+	c := *req
+	r.requests[req.ID] = &c
+	return nil
+}
+
+func (r *Repo) GetFriendRequests(uid uint) (incoming, outgoing []model.FriendRequest, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reqs := r.sortedRequests()
+	for i := len(reqs) - 1; i >= 0; i-- {
+		req := reqs[i]
+		if req.Status != model.FriendRequestPending {
+			continue
+		}
+		if req.ToUserID == uid {
+			incoming = append(incoming, *req)
+		}
+		if req.FromUserID == uid {
+			outgoing = append(outgoing, *req)
+		}
+	}
+	return incoming, outgoing, nil
+}
+
+func (r *Repo) AcceptFriendRequest(req *model.FriendRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.requests[req.ID]; ok {
+		stored.Status = model.FriendRequestAccepted
+		stored.UpdatedAt = time.Now()
+	}
+	req.Status = model.FriendRequestAccepted
+	r.addFriendship(req.FromUserID, req.ToUserID)
+	r.addFriendship(req.ToUserID, req.FromUserID)
+	return nil
+}
+
+// addFriendship 调用方需持有 mu
+func (r *Repo) addFriendship(uid, friend uint) {
+	for _, f := range r.friendships {
+		if f.UserID == uid && f.FriendID == friend {
+			return
+		}
+	}
+	f := &model.Friendship{UserID: uid, FriendID: friend}
+	r.stamp(&f.Model)
+	r.friendships = append(r.friendships, f)
+}
+
+func (r *Repo) DeclineFriendRequest(req *model.FriendRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.requests[req.ID]; ok {
+		stored.Status = model.FriendRequestDeclined
+		stored.UpdatedAt = time.Now()
+	}
+	req.Status = model.FriendRequestDeclined
+	return nil
+}
+
+func (r *Repo) AreFriends(a, b uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, f := range r.friendships {
+		if f.UserID == a && f.FriendID == b {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Repo) GetFriends(uid uint) ([]model.Friendship, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var friends []model.Friendship
+	for _, f := range r.friendships {
+		if f.UserID == uid {
+			friends = append(friends, *f)
+		}
+	}
+	return friends, nil
+}
+
+func (r *Repo) RemoveFriend(a, b uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removeFriend(a, b)
+	return nil
+}
+
+// removeFriend 调用方需持有 mu
+func (r *Repo) removeFriend(a, b uint) {
+	kept := r.friendships[:0]
+	for _, f := range r.friendships {
+		if (f.UserID == a && f.FriendID == b) || (f.UserID == b && f.FriendID == a) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	r.friendships = kept
+}
+
+func (r *Repo) BlockUser(uid, target uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exists := false
+	for _, b := range r.blocks {
+		if b.UserID == uid && b.BlockedID == target {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		b := &model.Block{UserID: uid, BlockedID: target}
+		r.stamp(&b.Model)
+		r.blocks = append(r.blocks, b)
+	}
+
+	r.removeFriend(uid, target)
+	for _, req := range r.requests {
+		between := (req.FromUserID == uid && req.ToUserID == target) || (req.FromUserID == target && req.ToUserID == uid)
+		if between && req.Status == model.FriendRequestPending {
+			req.Status = model.FriendRequestDeclined
+			req.UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (r *Repo) UnblockUser(uid, target uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.blocks[:0]
+	for _, b := range r.blocks {
+		if b.UserID == uid && b.BlockedID == target {
+			continue
+		}
+		kept = append(kept, b)
+	}
+	r.blocks = kept
+	return nil
+}
+
+func (r *Repo) IsBlocked(a, b uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, bl := range r.blocks {
+		if (bl.UserID == a && bl.BlockedID == b) || (bl.UserID == b && bl.BlockedID == a) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Repo) GetBlocked(uid uint) ([]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ids []uint
+	for _, b := range r.blocks {
+		if b.UserID == uid {
+			ids = append(ids, b.BlockedID)
+		}
+	}
+	return ids, nil
+}
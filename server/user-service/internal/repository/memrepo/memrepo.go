@@ -0,0 +1,379 @@
+// Package memrepo 进程内的 repository 实现，数据只保存在内存中，用于本地运行与测试
+package memrepo
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+// Repo 实现 repository.Repository，所有方法并发安全，返回值均为副本
+type Repo struct {
+	mu     sync.Mutex
+	nextID uint
+
+	users         map[uint]*model.User
+	histories     []*model.MatchHistory
+	ratings       []*model.PlayerRating
+	ratingHistory []*model.RatingHistory
+	stats         map[uint]*model.PlayerStats
+	mapStats      map[mapKey]*model.PlayerMapStats
+	requests      map[uint]*model.FriendRequest
+	friendships   []*model.Friendship
+	blocks        []*model.Block
+	tokens        map[uint]*model.RefreshToken
+}
+
+type mapKey struct {
+	userID uint
+	mapID  int
+}
+
+var _ repository.Repository = (*Repo)(nil)
+
+// New 创建空仓库
+func New() *Repo {
+	return &Repo{
+		users:    make(map[uint]*model.User),
+		stats:    make(map[uint]*model.PlayerStats),
+		mapStats: make(map[mapKey]*model.PlayerMapStats),
+		requests: make(map[uint]*model.FriendRequest),
+		tokens:   make(map[uint]*model.RefreshToken),
+	}
+}
+
+// stamp 为新记录分配 ID 与时间戳，调用方需持有 mu
+func (r *Repo) stamp(m *gorm.Model) {
+	r.nextID++
+	now := time.Now()
+	m.ID = r.nextID
+	m.CreatedAt = now
+	m.UpdatedAt = now
+}
+
+// page 分页区间
+func page(n, page, limit int) (int, int) {
+	start := (page - 1) * limit
+	if start < 0 {
+		start = 0
+	}
+	if start > n {
+		start = n
+	}
+	end := start + limit
+	if limit <= 0 || end > n {
+		end = n
+	}
+	return start, end
+}
+
+// ---------- 用户 ----------
+
+func (r *Repo) CreateUser(u *model.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Username == u.Username {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	r.stamp(&u.Model)
+	c := *u
+	r.users[u.ID] = &c
+	return nil
+}
+
+func (r *Repo) GetUserByID(uid uint) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if u, ok := r.users[uid]; ok {
+		c := *u
+		return &c, nil
+	}
+	return &model.User{}, repository.ErrNotFound
+}
+
+func (r *Repo) GetUserByUsername(username string) (*model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range r.users {
+		if u.Username == username {
+			c := *u
+			return &c, nil
+		}
+	}
+	return &model.User{}, repository.ErrNotFound
+}
+
+func (r *Repo) GetUsersByIDs(uids []uint) ([]model.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users := make([]model.User, 0, len(uids))
+	seen := make(map[uint]bool, len(uids))
+	for _, id := range uids {
+		if u, ok := r.users[id]; ok && !seen[id] {
+			users = append(users, *u)
+			seen[id] = true
+		}
+	}
+	return users, nil
+}
+
+// ---------- 战绩与段位分 ----------
+
+func (r *Repo) GetHistory(uid uint, pageNo, limit int) ([]model.MatchHistory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var all []model.MatchHistory
+	for i := len(r.histories) - 1; i >= 0; i-- {
+		if r.histories[i].UserID == uid {
+			all = append(all, *r.histories[i])
+		}
+	}
+	start, end := page(len(all), pageNo, limit)
+	return all[start:end], nil
+}
+
+func (r *Repo) IsMatchSaved(matchID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, h := range r.histories {
+		if h.MatchID == matchID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Repo) SaveMatchResult(histories []*model.MatchHistory, ratings []*model.PlayerRating, changes []*model.RatingHistory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 1. 先检查唯一约束，保证要么全部写入要么全部不写
+	for _, h := range histories {
+		for _, existing := range r.histories {
+			if existing.MatchID == h.MatchID && existing.UserID == h.UserID {
+				return repository.ErrMatchAlreadySaved
+			}
+		}
+	}
+
+	// 2. 战绩与生涯统计
+	for _, h := range histories {
+		r.stamp(&h.Model)
+		c := *h
+		r.histories = append(r.histories, &c)
+		r.applyStats(h)
+	}
+
+	// 3. 段位分：已存在的按 (user, mode, season) 覆盖
+	for _, rt := range ratings {
+		r.saveRating(rt)
+	}
+	for _, ch := range changes {
+		r.stamp(&ch.Model)
+		c := *ch
+		r.ratingHistory = append(r.ratingHistory, &c)
+	}
+	return nil
+}
+
+// saveRating 调用方需持有 mu
+func (r *Repo) saveRating(rt *model.PlayerRating) {
+	for _, existing := range r.ratings {
+		if existing.UserID == rt.UserID && existing.Mode == rt.Mode && existing.Season == rt.Season {
+			rt.ID = existing.ID
+			rt.CreatedAt = existing.CreatedAt
+			rt.UpdatedAt = time.Now()
+			*existing = *rt
+			return
+		}
+	}
+	r.stamp(&rt.Model)
+	c := *rt
+	r.ratings = append(r.ratings, &c)
+}
+
+// applyStats 按本局战绩增量更新生涯汇总与地图统计，调用方需持有 mu
+func (r *Repo) applyStats(h *model.MatchHistory) {
+	stats, ok := r.stats[h.UserID]
+	if !ok {
+		stats = &model.PlayerStats{UserID: h.UserID}
+		r.stamp(&stats.Model)
+		r.stats[h.UserID] = stats
+	}
+	stats.Matches++
+	stats.Kills += h.Kills
+	stats.Deaths += h.Deaths
+	result := "L"
+	if h.IsWinner {
+		stats.Wins++
+		result = "W"
+	}
+	stats.RecentForm = repository.PushForm(stats.RecentForm, result)
+	if h.Timestamp > stats.LastMatchAt {
+		stats.LastMatchAt = h.Timestamp
+	}
+	stats.UpdatedAt = time.Now()
+
+	key := mapKey{userID: h.UserID, mapID: h.MapID}
+	ms, ok := r.mapStats[key]
+	if !ok {
+		ms = &model.PlayerMapStats{UserID: h.UserID, MapID: h.MapID}
+		r.stamp(&ms.Model)
+		r.mapStats[key] = ms
+	}
+	ms.Matches++
+	if h.IsWinner {
+		ms.Wins++
+	}
+	ms.UpdatedAt = time.Now()
+}
+
+func (r *Repo) GetRating(uid uint, mode, season string) (*model.PlayerRating, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rt := range r.ratings {
+		if rt.UserID == uid && rt.Mode == mode && rt.Season == season {
+			c := *rt
+			return &c, nil
+		}
+	}
+	return &model.PlayerRating{}, repository.ErrNotFound
+}
+
+func (r *Repo) GetRatings(uids []uint, mode, season string) ([]model.PlayerRating, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[uint]bool, len(uids))
+	for _, id := range uids {
+		wanted[id] = true
+	}
+	var ratings []model.PlayerRating
+	for _, rt := range r.ratings {
+		if wanted[rt.UserID] && rt.Mode == mode && rt.Season == season {
+			ratings = append(ratings, *rt)
+		}
+	}
+	return ratings, nil
+}
+
+func (r *Repo) GetRatingHistory(uid uint, mode, season string, pageNo, limit int) ([]model.RatingHistory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var all []model.RatingHistory
+	for _, h := range r.ratingHistory {
+		if h.UserID == uid && h.Mode == mode && h.Season == season {
+			all = append(all, *h)
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Timestamp > all[j].Timestamp })
+	start, end := page(len(all), pageNo, limit)
+	return all[start:end], nil
+}
+
+func (r *Repo) ListRatings() ([]model.PlayerRating, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ratings := make([]model.PlayerRating, 0, len(r.ratings))
+	for _, rt := range r.ratings {
+		ratings = append(ratings, *rt)
+	}
+	return ratings, nil
+}
+
+func (r *Repo) GetStats(uid uint) (*model.PlayerStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if s, ok := r.stats[uid]; ok {
+		c := *s
+		return &c, nil
+	}
+	return &model.PlayerStats{}, repository.ErrNotFound
+}
+
+func (r *Repo) GetFavouriteMap(uid uint) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var best *model.PlayerMapStats
+	for key, ms := range r.mapStats {
+		if key.userID != uid {
+			continue
+		}
+		if best == nil || ms.Matches > best.Matches ||
+			(ms.Matches == best.Matches && (ms.Wins > best.Wins || (ms.Wins == best.Wins && ms.MapID < best.MapID))) {
+			best = ms
+		}
+	}
+	if best == nil {
+		return 0, nil
+	}
+	return best.MapID, nil
+}
+
+func (r *Repo) RebuildStats() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stats = make(map[uint]*model.PlayerStats)
+	r.mapStats = make(map[mapKey]*model.PlayerMapStats)
+	for _, h := range r.histories {
+		r.applyStats(h)
+	}
+	return nil
+}
+
+func (r *Repo) SumStats(seasonal bool) ([]repository.StatTotals, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	type key struct {
+		userID uint
+		season string
+	}
+	totals := make(map[key]*repository.StatTotals)
+	var order []key
+	for _, h := range r.histories {
+		k := key{userID: h.UserID}
+		if seasonal {
+			if h.Season == "" {
+				continue
+			}
+			k.season = h.Season
+		}
+		t, ok := totals[k]
+		if !ok {
+			t = &repository.StatTotals{UserID: k.userID, Season: k.season}
+			totals[k] = t
+			order = append(order, k)
+		}
+		if h.IsWinner {
+			t.Wins++
+		}
+		t.Kills += h.Kills
+		t.Deaths += h.Deaths
+	}
+
+	rows := make([]repository.StatTotals, 0, len(order))
+	for _, k := range order {
+		rows = append(rows, *totals[k])
+	}
+	return rows, nil
+}
@@ -0,0 +1,12 @@
+package memrepo
+
+import (
+	"testing"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/repository/repotest"
+)
+
+func TestRepo(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository { return New() })
+}
@@ -0,0 +1,83 @@
+package memrepo
+
+import (
+	"time"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+func (r *Repo) CreateRefreshToken(t *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.createToken(t)
+}
+
+// createToken 调用方需持有 mu
+func (r *Repo) createToken(t *model.RefreshToken) error {
+	for _, existing := range r.tokens {
+		if existing.TokenHash == t.TokenHash {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	r.stamp(&t.Model)
+	c := *t
+	r.tokens[t.ID] = &c
+	return nil
+}
+
+func (r *Repo) GetRefreshToken(hash string) (*model.RefreshToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.tokens {
+		if t.TokenHash == hash {
+			c := *t
+			return &c, nil
+		}
+	}
+	return &model.RefreshToken{}, repository.ErrNotFound
+}
+
+func (r *Repo) RotateRefreshToken(old, next *model.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tokens[old.ID]
+	if !ok || stored.RevokedAt != nil {
+		return repository.ErrRefreshTokenReused
+	}
+	now := time.Now()
+	stored.RevokedAt = &now
+	return r.createToken(next)
+}
+
+func (r *Repo) RevokeRefreshFamily(familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revoke(func(t *model.RefreshToken) bool { return t.FamilyID == familyID })
+	return nil
+}
+
+func (r *Repo) RevokeUserRefreshTokens(uid uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revoke(func(t *model.RefreshToken) bool { return t.UserID == uid })
+	return nil
+}
+
+// revoke 吊销满足条件且尚未吊销的 token，调用方需持有 mu
+func (r *Repo) revoke(match func(*model.RefreshToken) bool) {
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.RevokedAt == nil && match(t) {
+			revokedAt := now
+			t.RevokedAt = &revokedAt
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package repository User Service 的持久化接口。
+// sqlrepo 基于 gorm 实现（MySQL / SQLite），memrepo 为进程内实现，用于本地运行与测试。
+// Redis 中的排行榜、在线状态与 access token 吊销名单仍在 dao 包中。
+package repository
+
+import (
+	"errors"
+
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound 记录不存在；与 gorm.ErrRecordNotFound 相同，gorm 实现无需转换
+var ErrNotFound = gorm.ErrRecordNotFound
+
+// ErrMatchAlreadySaved 该比赛的战绩已经落库（MQ 重复投递）
+var ErrMatchAlreadySaved = errors.New("match result already saved")
+
+// ErrRefreshTokenReused 已轮换的 refresh token 被再次使用
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+// UserRepository 用户账号
+type UserRepository interface {
+	CreateUser(u *model.User) error
+	GetUserByID(uid uint) (*model.User, error)
+	GetUserByUsername(username string) (*model.User, error)
+	GetUsersByIDs(uids []uint) ([]model.User, error)
+}
+
+// MatchRepository 战绩、段位分与生涯统计
+type MatchRepository interface {
+	// GetHistory 分页查询战绩，新的在前
+	GetHistory(uid uint, page, limit int) ([]model.MatchHistory, error)
+	// IsMatchSaved 比赛战绩是否已落库，用于消费前快速去重
+	IsMatchSaved(matchID string) (bool, error)
+	// SaveMatchResult 在同一事务中写入战绩、生涯统计、段位分与段位分变化
+	// 同一 (match_id, user_id) 已存在时整体回滚并返回 ErrMatchAlreadySaved
+	SaveMatchResult(histories []*model.MatchHistory, ratings []*model.PlayerRating, changes []*model.RatingHistory) error
+
+	// GetRating 查询单个玩家的段位分，不存在时返回 ErrNotFound
+	GetRating(uid uint, mode, season string) (*model.PlayerRating, error)
+	// GetRatings 批量查询段位分，未参与过该模式/赛季的玩家不会出现在结果中
+	GetRatings(uids []uint, mode, season string) ([]model.PlayerRating, error)
+	// GetRatingHistory 分页查询段位分变化，新的在前
+	GetRatingHistory(uid uint, mode, season string, page, limit int) ([]model.RatingHistory, error)
+	// ListRatings 全部段位分，用于重建排行榜
+	ListRatings() ([]model.PlayerRating, error)
+
+	// GetStats 查询玩家生涯汇总，没有对局记录时返回 ErrNotFound
+	GetStats(uid uint) (*model.PlayerStats, error)
+	// GetFavouriteMap 对局数最多的地图，对局数相同时取胜场多的
+	GetFavouriteMap(uid uint) (int, error)
+	// RebuildStats 从战绩全量重建生涯汇总与地图统计
+	RebuildStats() error
+	// SumStats 按玩家汇总战绩；seasonal 为 true 时按 (玩家, 赛季) 汇总，跳过没有赛季的旧战绩
+	SumStats(seasonal bool) ([]StatTotals, error)
+}
+
+// StatTotals 战绩汇总，用于重建排行榜
+type StatTotals struct {
+	UserID uint
+	Season string
+	Wins   int
+	Kills  int
+	Deaths int
+}
+
+// FriendRepository 好友申请、好友关系与拉黑
+type FriendRepository interface {
+	GetFriendRequest(id uint) (*model.FriendRequest, error)
+	// GetPendingRequest 查询 from -> to 的待处理申请
+	GetPendingRequest(from, to uint) (*model.FriendRequest, error)
+	CreateFriendRequest(req *model.FriendRequest) error
+	// GetFriendRequests 查询与玩家相关的待处理申请（收到的与发出的），新的在前
+	GetFriendRequests(uid uint) (incoming, outgoing []model.FriendRequest, err error)
+	// AcceptFriendRequest 同意申请并建立双向好友关系
+	AcceptFriendRequest(req *model.FriendRequest) error
+	DeclineFriendRequest(req *model.FriendRequest) error
+	AreFriends(a, b uint) (bool, error)
+	// GetFriends 查询玩家的全部好友关系，按成为好友的先后排列
+	GetFriends(uid uint) ([]model.Friendship, error)
+	// RemoveFriend 解除双向好友关系
+	RemoveFriend(a, b uint) error
+	// BlockUser 拉黑：解除好友关系并拒绝双方之间的待处理申请
+	BlockUser(uid, target uint) error
+	UnblockUser(uid, target uint) error
+	// IsBlocked 两人之间是否存在任一方向的拉黑
+	IsBlocked(a, b uint) (bool, error)
+	// GetBlocked 查询玩家拉黑的全部用户
+	GetBlocked(uid uint) ([]uint, error)
+}
+
+// TokenRepository refresh token（只保存哈希）
+type TokenRepository interface {
+	CreateRefreshToken(t *model.RefreshToken) error
+	// GetRefreshToken 按哈希查询，不存在时返回 ErrNotFound
+	GetRefreshToken(hash string) (*model.RefreshToken, error)
+	// RotateRefreshToken 吊销旧 token 并保存新 token；旧 token 已被并发轮换时返回 ErrRefreshTokenReused
+	RotateRefreshToken(old, next *model.RefreshToken) error
+	// RevokeRefreshFamily 吊销同一次登录轮换出的全部 refresh token
+	RevokeRefreshFamily(familyID string) error
+	// RevokeUserRefreshTokens 吊销用户的全部 refresh token（登出所有设备）
+	RevokeUserRefreshTokens(uid uint) error
+}
+
+// Repository 全部持久化接口，由具体实现一次性提供
+type Repository interface {
+	UserRepository
+	MatchRepository
+	FriendRepository
+	TokenRepository
+}
+
+// PushForm 把最新一局放到最前面，只保留最近 model.RecentFormSize 局
+func PushForm(form, result string) string {
+	form = result + form
+	if len(form) > model.RecentFormSize {
+		form = form[:model.RecentFormSize]
+	}
+	return form
+}
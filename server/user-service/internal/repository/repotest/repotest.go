@@ -0,0 +1,256 @@
+// Package repotest repository.Repository 的公共行为测试，memrepo 与 sqlrepo 各自以自己的实现运行同一套用例
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+)
+
+// Run 对 open 返回的仓库运行全部用例，每个用例使用一个新的空仓库
+func Run(t *testing.T, open func(t *testing.T) repository.Repository) {
+	t.Run("Users", func(t *testing.T) { testUsers(t, open(t)) })
+	t.Run("SaveMatchResult", func(t *testing.T) { testSaveMatchResult(t, open(t)) })
+	t.Run("MatchAlreadySaved", func(t *testing.T) { testMatchAlreadySaved(t, open(t)) })
+	t.Run("RotateRefreshToken", func(t *testing.T) { testRotateRefreshToken(t, open(t)) })
+	t.Run("RevokeRefreshTokens", func(t *testing.T) { testRevokeRefreshTokens(t, open(t)) })
+	t.Run("Friends", func(t *testing.T) { testFriends(t, open(t)) })
+}
+
+func createUsers(t *testing.T, repo repository.Repository, names ...string) []uint {
+	t.Helper()
+	uids := make([]uint, 0, len(names))
+	for _, name := range names {
+		u := &model.User{Username: name, Password: "x"}
+		if err := repo.CreateUser(u); err != nil {
+			t.Fatalf("create user %s: %v", name, err)
+		}
+		uids = append(uids, u.ID)
+	}
+	return uids
+}
+
+func testUsers(t *testing.T, repo repository.Repository) {
+	uids := createUsers(t, repo, "alice", "bob")
+	if uids[0] == 0 || uids[0] == uids[1] {
+		t.Fatalf("bad ids %v", uids)
+	}
+	if err := repo.CreateUser(&model.User{Username: "alice", Password: "x"}); err == nil {
+		t.Fatal("duplicate username accepted")
+	}
+
+	u, err := repo.GetUserByUsername("bob")
+	if err != nil || u.ID != uids[1] {
+		t.Fatalf("GetUserByUsername = %+v, %v", u, err)
+	}
+	if _, err := repo.GetUserByID(9999); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("GetUserByID missing = %v, want ErrNotFound", err)
+	}
+	users, err := repo.GetUsersByIDs([]uint{uids[0], uids[1], 9999})
+	if err != nil || len(users) != 2 {
+		t.Fatalf("GetUsersByIDs = %d users, %v", len(users), err)
+	}
+}
+
+// matchResult 一局两人对战的战绩与段位分，winner 获胜
+func matchResult(matchID string, winner, loser uint) ([]*model.MatchHistory, []*model.PlayerRating, []*model.RatingHistory) {
+	now := time.Now().Unix()
+	histories := []*model.MatchHistory{
+		{UserID: winner, MatchID: matchID, Mode: "ffa", Season: "s1", MapID: 1, IsWinner: true, Placement: 1, Kills: 1, Timestamp: now},
+		{UserID: loser, MatchID: matchID, Mode: "ffa", Season: "s1", MapID: 1, Placement: 2, Deaths: 1, Timestamp: now},
+	}
+	ratings := []*model.PlayerRating{
+		{UserID: winner, Mode: "ffa", Season: "s1", Rating: 1600, Deviation: 300, Volatility: 0.06, Matches: 1, Wins: 1},
+		{UserID: loser, Mode: "ffa", Season: "s1", Rating: 1400, Deviation: 300, Volatility: 0.06, Matches: 1},
+	}
+	changes := []*model.RatingHistory{
+		{UserID: winner, Mode: "ffa", Season: "s1", MatchID: matchID, RatingBefore: 1500, RatingAfter: 1600, Delta: 100, Placement: 1, Timestamp: now},
+		{UserID: loser, Mode: "ffa", Season: "s1", MatchID: matchID, RatingBefore: 1500, RatingAfter: 1400, Delta: -100, Placement: 2, Timestamp: now},
+	}
+	return histories, ratings, changes
+}
+
+func testSaveMatchResult(t *testing.T, repo repository.Repository) {
+	uids := createUsers(t, repo, "alice", "bob")
+	if err := repo.SaveMatchResult(matchResult("m1", uids[0], uids[1])); err != nil {
+		t.Fatalf("SaveMatchResult: %v", err)
+	}
+
+	if saved, err := repo.IsMatchSaved("m1"); err != nil || !saved {
+		t.Fatalf("IsMatchSaved(m1) = %v, %v", saved, err)
+	}
+	if saved, _ := repo.IsMatchSaved("m2"); saved {
+		t.Fatal("IsMatchSaved(m2) = true")
+	}
+
+	history, err := repo.GetHistory(uids[0], 1, 10)
+	if err != nil || len(history) != 1 || !history[0].IsWinner {
+		t.Fatalf("GetHistory = %+v, %v", history, err)
+	}
+	stats, err := repo.GetStats(uids[0])
+	if err != nil || stats.Matches != 1 || stats.Wins != 1 || stats.Kills != 1 || stats.RecentForm != "W" {
+		t.Fatalf("GetStats = %+v, %v", stats, err)
+	}
+	if _, err := repo.GetStats(9999); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("GetStats missing = %v, want ErrNotFound", err)
+	}
+	rating, err := repo.GetRating(uids[1], "ffa", "s1")
+	if err != nil || rating.Rating != 1400 {
+		t.Fatalf("GetRating = %+v, %v", rating, err)
+	}
+	if changes, err := repo.GetRatingHistory(uids[1], "ffa", "s1", 1, 10); err != nil || len(changes) != 1 || changes[0].Delta != -100 {
+		t.Fatalf("GetRatingHistory = %+v, %v", changes, err)
+	}
+
+	// 第二局：在已有段位分上更新，而不是再插入一条
+	histories, ratings, changes := matchResult("m2", uids[1], uids[0])
+	for _, r := range ratings {
+		existing, err := repo.GetRating(r.UserID, r.Mode, r.Season)
+		if err != nil {
+			t.Fatalf("GetRating: %v", err)
+		}
+		r.Model = existing.Model
+	}
+	if err := repo.SaveMatchResult(histories, ratings, changes); err != nil {
+		t.Fatalf("SaveMatchResult m2: %v", err)
+	}
+	all, err := repo.ListRatings()
+	if err != nil || len(all) != 2 {
+		t.Fatalf("ListRatings = %d ratings, %v", len(all), err)
+	}
+	if stats, _ := repo.GetStats(uids[0]); stats.Matches != 2 || stats.RecentForm != "LW" {
+		t.Fatalf("GetStats after m2 = %+v", stats)
+	}
+}
+
+func testMatchAlreadySaved(t *testing.T, repo repository.Repository) {
+	uids := createUsers(t, repo, "alice", "bob", "carol")
+	if err := repo.SaveMatchResult(matchResult("m1", uids[0], uids[1])); err != nil {
+		t.Fatalf("SaveMatchResult: %v", err)
+	}
+
+	// 重复投递：包含一条已落库的 (match_id, user_id) 时整体回滚
+	histories, ratings, changes := matchResult("m1", uids[2], uids[1])
+	ratings[0].Rating = 2000
+	err := repo.SaveMatchResult(histories, ratings, changes)
+	if !errors.Is(err, repository.ErrMatchAlreadySaved) {
+		t.Fatalf("duplicate SaveMatchResult = %v, want ErrMatchAlreadySaved", err)
+	}
+
+	if history, _ := repo.GetHistory(uids[2], 1, 10); len(history) != 0 {
+		t.Fatalf("rolled back history was written: %+v", history)
+	}
+	if _, err := repo.GetRating(uids[2], "ffa", "s1"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("rolled back rating was written: %v", err)
+	}
+	if stats, _ := repo.GetStats(uids[1]); stats.Matches != 1 {
+		t.Fatalf("stats counted the duplicate: %+v", stats)
+	}
+	if changes, _ := repo.GetRatingHistory(uids[1], "ffa", "s1", 1, 10); len(changes) != 1 {
+		t.Fatalf("rating history counted the duplicate: %d entries", len(changes))
+	}
+}
+
+func newToken(uid uint, hash, family string) *model.RefreshToken {
+	return &model.RefreshToken{UserID: uid, TokenHash: hash, FamilyID: family, ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func testRotateRefreshToken(t *testing.T, repo repository.Repository) {
+	uid := createUsers(t, repo, "alice")[0]
+	first := newToken(uid, "hash-1", "family")
+	if err := repo.CreateRefreshToken(first); err != nil {
+		t.Fatalf("CreateRefreshToken: %v", err)
+	}
+
+	old, err := repo.GetRefreshToken("hash-1")
+	if err != nil {
+		t.Fatalf("GetRefreshToken: %v", err)
+	}
+	if err := repo.RotateRefreshToken(old, newToken(uid, "hash-2", "family")); err != nil {
+		t.Fatalf("RotateRefreshToken: %v", err)
+	}
+	if revoked, _ := repo.GetRefreshToken("hash-1"); revoked.RevokedAt == nil {
+		t.Fatal("rotated token not revoked")
+	}
+	if next, err := repo.GetRefreshToken("hash-2"); err != nil || next.RevokedAt != nil {
+		t.Fatalf("new token = %+v, %v", next, err)
+	}
+
+	// 并发请求拿着同一个旧 token 再次轮换：不能再签发新 token
+	err = repo.RotateRefreshToken(old, newToken(uid, "hash-3", "family"))
+	if !errors.Is(err, repository.ErrRefreshTokenReused) {
+		t.Fatalf("second rotation = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, err := repo.GetRefreshToken("hash-3"); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("token from reused rotation was saved: %v", err)
+	}
+}
+
+func testRevokeRefreshTokens(t *testing.T, repo repository.Repository) {
+	uids := createUsers(t, repo, "alice", "bob")
+	for _, tok := range []*model.RefreshToken{
+		newToken(uids[0], "a1", "fa"),
+		newToken(uids[0], "a2", "fa"),
+		newToken(uids[0], "a3", "fb"),
+		newToken(uids[1], "b1", "fc"),
+	} {
+		if err := repo.CreateRefreshToken(tok); err != nil {
+			t.Fatalf("CreateRefreshToken: %v", err)
+		}
+	}
+	revoked := func(hash string) bool {
+		tok, err := repo.GetRefreshToken(hash)
+		if err != nil {
+			t.Fatalf("GetRefreshToken %s: %v", hash, err)
+		}
+		return tok.RevokedAt != nil
+	}
+
+	if err := repo.RevokeRefreshFamily("fa"); err != nil {
+		t.Fatalf("RevokeRefreshFamily: %v", err)
+	}
+	if !revoked("a1") || !revoked("a2") || revoked("a3") {
+		t.Fatal("RevokeRefreshFamily revoked the wrong tokens")
+	}
+
+	if err := repo.RevokeUserRefreshTokens(uids[0]); err != nil {
+		t.Fatalf("RevokeUserRefreshTokens: %v", err)
+	}
+	if !revoked("a3") || revoked("b1") {
+		t.Fatal("RevokeUserRefreshTokens revoked the wrong tokens")
+	}
+}
+
+func testFriends(t *testing.T, repo repository.Repository) {
+	uids := createUsers(t, repo, "alice", "bob", "carol")
+	req := &model.FriendRequest{FromUserID: uids[0], ToUserID: uids[1], Status: model.FriendRequestPending}
+	if err := repo.CreateFriendRequest(req); err != nil {
+		t.Fatalf("CreateFriendRequest: %v", err)
+	}
+	if err := repo.AcceptFriendRequest(req); err != nil {
+		t.Fatalf("AcceptFriendRequest: %v", err)
+	}
+
+	for _, pair := range [][2]uint{{uids[0], uids[1]}, {uids[1], uids[0]}} {
+		if ok, err := repo.AreFriends(pair[0], pair[1]); err != nil || !ok {
+			t.Fatalf("AreFriends(%d, %d) = %v, %v", pair[0], pair[1], ok, err)
+		}
+	}
+	if ok, _ := repo.AreFriends(uids[0], uids[2]); ok {
+		t.Fatal("strangers are friends")
+	}
+
+	// 拉黑会解除好友关系
+	if err := repo.BlockUser(uids[1], uids[0]); err != nil {
+		t.Fatalf("BlockUser: %v", err)
+	}
+	if ok, _ := repo.AreFriends(uids[0], uids[1]); ok {
+		t.Fatal("still friends after block")
+	}
+	if blocked, _ := repo.IsBlocked(uids[0], uids[1]); !blocked {
+		t.Fatal("IsBlocked = false after block")
+	}
+}
@@ -0,0 +1,120 @@
+package sqlrepo
+
+import (
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+// GetFriendRequest 根据 ID 查询好友申请
+func (r *Repo) GetFriendRequest(id uint) (*model.FriendRequest, error) {
+	var req model.FriendRequest
+	err := r.db.First(&req, id).Error
+	return &req, err
+}
+
+// GetPendingRequest 查询 from -> to 的待处理申请
+func (r *Repo) GetPendingRequest(from, to uint) (*model.FriendRequest, error) {
+	var req model.FriendRequest
+	err := r.db.Where("from_user_id = ? AND to_user_id = ? AND status = ?", from, to, model.FriendRequestPending).
+		First(&req).Error
+	return &req, err
+}
+
+// CreateFriendRequest 创建好友申请
+func (r *Repo) CreateFriendRequest(req *model.FriendRequest) error {
+	return r.db.Create(req).Error
+}
+
+// GetFriendRequests 查询与玩家相关的待处理申请（收到的与发出的）
+func (r *Repo) GetFriendRequests(uid uint) (incoming, outgoing []model.FriendRequest, err error) {
+	err = r.db.Where("to_user_id = ? AND status = ?", uid, model.FriendRequestPending).
+		Order("created_at desc").Find(&incoming).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	err = r.db.Where("from_user_id = ? AND status = ?", uid, model.FriendRequestPending).
+		Order("created_at desc").Find(&outgoing).Error
+	return incoming, outgoing, err
+}
+
+// AcceptFriendRequest 同意申请并建立双向好友关系
+func (r *Repo) AcceptFriendRequest(req *model.FriendRequest) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(req).Update("status", model.FriendRequestAccepted).Error; err != nil {
+			return err
+		}
+		return tx.Create(&[]model.Friendship{
+			{UserID: req.FromUserID, FriendID: req.ToUserID},
+			{UserID: req.ToUserID, FriendID: req.FromUserID},
+		}).Error
+	})
+}
+
+// DeclineFriendRequest 拒绝申请
+func (r *Repo) DeclineFriendRequest(req *model.FriendRequest) error {
+	return r.db.Model(req).Update("status", model.FriendRequestDeclined).Error
+}
+
+// AreFriends 两人是否为好友
+func (r *Repo) AreFriends(a, b uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Friendship{}).Where("user_id = ? AND friend_id = ?", a, b).Count(&count).Error
+	return count > 0, err
+}
+
+// GetFriends 查询玩家的全部好友关系
+func (r *Repo) GetFriends(uid uint) ([]model.Friendship, error) {
+	var friends []model.Friendship
+	err := r.db.Where("user_id = ?", uid).Order("created_at asc").Find(&friends).Error
+	return friends, err
+}
+
+// RemoveFriend 解除双向好友关系（硬删除，以便之后重新添加）
+func (r *Repo) RemoveFriend(a, b uint) error {
+	return removeFriend(r.db, a, b)
+}
+
+func removeFriend(tx *gorm.DB, a, b uint) error {
+	return tx.Unscoped().
+		Where("(user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)", a, b, b, a).
+		Delete(&model.Friendship{}).Error
+}
+
+// BlockUser 拉黑：解除好友关系并拒绝双方之间的待处理申请
+func (r *Repo) BlockUser(uid, target uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		block := model.Block{UserID: uid, BlockedID: target}
+		if err := tx.Where(&block).FirstOrCreate(&block).Error; err != nil {
+			return err
+		}
+		if err := removeFriend(tx, uid, target); err != nil {
+			return err
+		}
+		return tx.Model(&model.FriendRequest{}).
+			Where("((from_user_id = ? AND to_user_id = ?) OR (from_user_id = ? AND to_user_id = ?)) AND status = ?",
+				uid, target, target, uid, model.FriendRequestPending).
+			Update("status", model.FriendRequestDeclined).Error
+	})
+}
+
+// UnblockUser 取消拉黑
+func (r *Repo) UnblockUser(uid, target uint) error {
+	return r.db.Unscoped().Where("user_id = ? AND blocked_id = ?", uid, target).Delete(&model.Block{}).Error
+}
+
+// IsBlocked 两人之间是否存在任一方向的拉黑
+func (r *Repo) IsBlocked(a, b uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Block{}).
+		Where("(user_id = ? AND blocked_id = ?) OR (user_id = ? AND blocked_id = ?)", a, b, b, a).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetBlocked 查询玩家拉黑的全部用户
+func (r *Repo) GetBlocked(uid uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&model.Block{}).Where("user_id = ?", uid).Pluck("blocked_id", &ids).Error
+	return ids, err
+}
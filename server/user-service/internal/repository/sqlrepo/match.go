@@ -0,0 +1,95 @@
+package sqlrepo
+
+import (
+	"errors"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+// GetHistory 分页查询战绩
+func (r *Repo) GetHistory(uid uint, page, limit int) ([]model.MatchHistory, error) {
+	var history []model.MatchHistory
+	offset := (page - 1) * limit
+	err := r.db.Where("user_id = ?", uid).
+		Order("created_at desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&history).Error
+	return history, err
+}
+
+// GetRating 查询单个玩家的段位分，不存在时返回 repository.ErrNotFound
+func (r *Repo) GetRating(uid uint, mode, season string) (*model.PlayerRating, error) {
+	var rating model.PlayerRating
+	err := r.db.Where("user_id = ? AND mode = ? AND season = ?", uid, mode, season).
+		First(&rating).Error
+	return &rating, err
+}
+
+// GetRatings 批量查询段位分，未参与过该模式/赛季的玩家不会出现在结果中
+func (r *Repo) GetRatings(uids []uint, mode, season string) ([]model.PlayerRating, error) {
+	var ratings []model.PlayerRating
+	if len(uids) == 0 {
+		return ratings, nil
+	}
+	err := r.db.Where("user_id IN ? AND mode = ? AND season = ?", uids, mode, season).
+		Find(&ratings).Error
+	return ratings, err
+}
+
+// GetRatingHistory 分页查询段位分变化
+func (r *Repo) GetRatingHistory(uid uint, mode, season string, page, limit int) ([]model.RatingHistory, error) {
+	var history []model.RatingHistory
+	offset := (page - 1) * limit
+	err := r.db.Where("user_id = ? AND mode = ? AND season = ?", uid, mode, season).
+		Order("timestamp desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&history).Error
+	return history, err
+}
+
+// ListRatings 全部段位分
+func (r *Repo) ListRatings() ([]model.PlayerRating, error) {
+	var ratings []model.PlayerRating
+	err := r.db.Find(&ratings).Error
+	return ratings, err
+}
+
+// IsMatchSaved 比赛战绩是否已落库，用于消费前快速去重
+func (r *Repo) IsMatchSaved(matchID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.MatchHistory{}).Where("match_id = ?", matchID).Count(&count).Error
+	return count > 0, err
+}
+
+// SaveMatchResult 在同一事务中写入战绩、生涯统计、段位分与段位分变化
+// 同一 (match_id, user_id) 已存在时整个事务回滚并返回 repository.ErrMatchAlreadySaved
+func (r *Repo) SaveMatchResult(histories []*model.MatchHistory, ratings []*model.PlayerRating, changes []*model.RatingHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if len(histories) > 0 {
+			if err := tx.Create(histories).Error; err != nil {
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					return repository.ErrMatchAlreadySaved
+				}
+				return err
+			}
+			if err := applyStats(tx, histories); err != nil {
+				return err
+			}
+		}
+		for _, r := range ratings {
+			if err := tx.Save(r).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes) > 0 {
+			if err := tx.Create(changes).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,406 @@
+package sqlrepo
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 迁移文件命名: migrations/<driver>/<version>_<name>.up.sql 与同名 .down.sql，
+// version 为递增整数；Go 迁移注册在 migrations.go 的 goMigrations 中，版本号与 SQL 迁移共用
+//
+//go:embed migrations
+var migrationFS embed.FS
+
+// ErrSchemaAhead 数据库已执行过本程序不认识的迁移（通常是回滚到了旧版本的程序）
+var ErrSchemaAhead = errors.New("database schema is newer than this binary")
+
+// ErrSchemaBehind 存在尚未执行的迁移
+var ErrSchemaBehind = errors.New("database schema has pending migrations")
+
+// ErrChecksumMismatch 已执行的 SQL 迁移在之后被修改过
+var ErrChecksumMismatch = errors.New("applied migration has been modified")
+
+// Migration 一次表结构或数据变更
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error // 为 nil 时不可回滚
+
+	Checksum string // up 脚本的 SHA-256，Go 迁移为空
+}
+
+// MigrationStatus 迁移执行状态
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool // 数据库中有记录，但程序中没有对应迁移
+	Modified  bool // 执行后迁移内容被修改（校验和不一致）
+}
+
+// schemaMigration 已执行的迁移
+type schemaMigration struct {
+	Version   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"type:varchar(255);not null"`
+	Checksum  string `gorm:"type:varchar(64)"` // 执行时的校验和，为空时不校验
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator 按版本号顺序执行迁移，并记录在 schema_migrations 表中
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// Migrator 加载当前驱动的全部迁移
+func (r *Repo) Migrator() (*Migrator, error) {
+	migrations, err := loadMigrations(r.driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: r.db, migrations: migrations}, nil
+}
+
+// loadMigrations 合并该驱动的 SQL 迁移与 Go 迁移，按版本号排序
+func loadMigrations(driver string) ([]Migration, error) {
+	byVersion := make(map[int64]*Migration)
+	add := func(m Migration) error {
+		if existing, ok := byVersion[m.Version]; ok && existing.Name != m.Name {
+			return fmt.Errorf("duplicate migration version %d: %s and %s", m.Version, existing.Name, m.Name)
+		}
+		if _, ok := byVersion[m.Version]; !ok {
+			byVersion[m.Version] = &Migration{Version: m.Version, Name: m.Name}
+		}
+		target := byVersion[m.Version]
+		if m.Up != nil {
+			target.Up = m.Up
+		}
+		if m.Down != nil {
+			target.Down = m.Down
+		}
+		if m.Checksum != "" {
+			target.Checksum = m.Checksum
+		}
+		return nil
+	}
+
+	// 1. SQL 迁移
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationFS, dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, e := range entries {
+		version, name, direction, ok := parseMigrationFile(e.Name())
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %s", e.Name())
+		}
+		data, err := migrationFS.ReadFile(path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m := Migration{Version: version, Name: name}
+		if direction == "up" {
+			m.Up = execSQL(string(data))
+			sum := sha256.Sum256(data)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = execSQL(string(data))
+		}
+		if err := add(m); err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Go 迁移
+	for _, m := range goMigrations {
+		if _, ok := byVersion[m.Version]; ok {
+			return nil, fmt.Errorf("duplicate migration version %d: %s", m.Version, m.Name)
+		}
+		if err := add(m); err != nil {
+			return nil, err
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d_%s has no up step", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// parseMigrationFile 解析 0001_baseline.up.sql
+func parseMigrationFile(file string) (version int64, name, direction string, ok bool) {
+	base, found := strings.CutSuffix(file, ".sql")
+	if !found {
+		return 0, "", "", false
+	}
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+	default:
+		return 0, "", "", false
+	}
+	base = strings.TrimSuffix(base, "."+direction)
+
+	prefix, name, found := strings.Cut(base, "_")
+	if !found || name == "" {
+		return 0, "", "", false
+	}
+	version, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", "", false
+	}
+	return version, name, direction, true
+}
+
+// execSQL 按语句逐条执行（MySQL 驱动默认不允许一次执行多条语句）
+func execSQL(script string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		for _, stmt := range splitStatements(script) {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return fmt.Errorf("%v\n%s", err, stmt)
+			}
+		}
+		return nil
+	}
+}
+
+// splitStatements 以行尾的分号切分语句，忽略 -- 注释行
+func splitStatements(script string) []string {
+	var stmts []string
+	var buf strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSuffix(strings.TrimSpace(buf.String()), ";"))
+			buf.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(buf.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
+
+// applied 已执行的迁移，按版本号升序
+func (m *Migrator) applied() ([]schemaMigration, error) {
+	if err := m.db.AutoMigrate(&schemaMigration{}); err != nil {
+		return nil, fmt.Errorf("create schema_migrations failed: %v", err)
+	}
+	var rows []schemaMigration
+	err := m.db.Order("version asc").Find(&rows).Error
+	return rows, err
+}
+
+// Version 当前版本（已执行的最大版本号），未执行过任何迁移时为 0
+func (m *Migrator) Version() (int64, error) {
+	rows, err := m.applied()
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	return rows[len(rows)-1].Version, nil
+}
+
+// Latest 程序内最新的迁移版本
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status 全部迁移及其执行状态，包括数据库中有记录但程序不认识的迁移
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	rows, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+
+	known := make(map[int64]bool, len(m.migrations))
+	var status []MigrationStatus
+	for _, mig := range m.migrations {
+		known[mig.Version] = true
+		s := MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if row, ok := applied[mig.Version]; ok {
+			at := row.AppliedAt
+			s.AppliedAt = &at
+			s.Modified = row.Checksum != "" && mig.Checksum != "" && row.Checksum != mig.Checksum
+		}
+		status = append(status, s)
+	}
+	for _, row := range rows {
+		if !known[row.Version] {
+			at := row.AppliedAt
+			status = append(status, MigrationStatus{Version: row.Version, Name: row.Name, AppliedAt: &at, Unknown: true})
+		}
+	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Version < status[j].Version })
+	return status, nil
+}
+
+// Check 启动前校验：数据库中存在程序不认识的迁移时返回 ErrSchemaAhead，
+// 已执行的迁移被修改过时返回 ErrChecksumMismatch，有待执行的迁移时返回 ErrSchemaBehind
+func (m *Migrator) Check() error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	for _, s := range status {
+		if s.Unknown {
+			return fmt.Errorf("%w: migration %d_%s applied but unknown (binary latest %d)", ErrSchemaAhead, s.Version, s.Name, m.Latest())
+		}
+	}
+	for _, s := range status {
+		if s.Modified {
+			return fmt.Errorf("%w: %d_%s, add a new migration instead of editing an applied one", ErrChecksumMismatch, s.Version, s.Name)
+		}
+	}
+	for _, s := range status {
+		if s.AppliedAt == nil {
+			return fmt.Errorf("%w: %d_%s not applied", ErrSchemaBehind, s.Version, s.Name)
+		}
+	}
+	return nil
+}
+
+// Up 执行全部待执行的迁移，返回执行数量
+func (m *Migrator) Up() (int, error) {
+	return m.To(m.Latest())
+}
+
+// Down 按版本号倒序回滚最近 steps 个已执行的迁移
+func (m *Migrator) Down(steps int) (int, error) {
+	rows, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	if steps > len(rows) {
+		steps = len(rows)
+	}
+	target := int64(0)
+	if steps < len(rows) {
+		target = rows[len(rows)-1-steps].Version
+	}
+	return m.To(target)
+}
+
+// To 迁移到指定版本：执行 <= version 的待执行迁移，回滚 > version 的已执行迁移
+func (m *Migrator) To(version int64) (int, error) {
+	// 1. 程序不认识的迁移无法回滚，也说明程序版本过旧
+	if err := m.Check(); err != nil && !errors.Is(err, ErrSchemaBehind) {
+		return 0, err
+	}
+	if version != 0 && !m.has(version) {
+		return 0, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	rows, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	done := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		done[row.Version] = true
+	}
+
+	// 2. 回滚范围内有不可回滚的迁移（如 baseline）时整体拒绝，不做部分回滚
+	for _, mig := range m.migrations {
+		if mig.Version > version && done[mig.Version] && mig.Down == nil {
+			return 0, fmt.Errorf("migration %d_%s is irreversible", mig.Version, mig.Name)
+		}
+	}
+
+	count := 0
+	// 3. 回滚：从新到旧
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.Version <= version || !done[mig.Version] {
+			continue
+		}
+		if err := m.run(mig, false); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	// 4. 执行：从旧到新
+	for _, mig := range m.migrations {
+		if mig.Version > version || done[mig.Version] {
+			continue
+		}
+		if err := m.run(mig, true); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (m *Migrator) has(version int64) bool {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// run 在事务中执行单个迁移并更新 schema_migrations
+// MySQL 的 DDL 会隐式提交，失败时可能留下部分变更，因此每个迁移应尽量只做一件事
+func (m *Migrator) run(mig Migration, up bool) error {
+	if !up && mig.Down == nil {
+		return fmt.Errorf("migration %d_%s is irreversible", mig.Version, mig.Name)
+	}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if up {
+			if err := mig.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, Checksum: mig.Checksum, AppliedAt: time.Now()}).Error
+		}
+		if err := mig.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, mig.Version).Error
+	})
+	if err != nil {
+		direction := "up"
+		if !up {
+			direction = "down"
+		}
+		return fmt.Errorf("migration %d_%s %s failed: %v", mig.Version, mig.Name, direction, err)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+//go:build sqlite
+
+package sqlrepo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+func connectTemp(t *testing.T) (*Repo, *Migrator) {
+	t.Helper()
+	r, err := Connect("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	m, err := r.Migrator()
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	return r, m
+}
+
+func checkVersion(t *testing.T, m *Migrator, want int64) {
+	t.Helper()
+	if v, err := m.Version(); err != nil || v != want {
+		t.Fatalf("version = %d, %v, want %d", v, err, want)
+	}
+}
+
+func TestMigrateRoundTrip(t *testing.T) {
+	r, m := connectTemp(t)
+	latest := m.Latest()
+
+	n, err := m.Up()
+	if err != nil || n != len(m.migrations) {
+		t.Fatalf("up = %d, %v, want %d", n, err, len(m.migrations))
+	}
+	checkVersion(t, m, latest)
+	if err := m.Check(); err != nil {
+		t.Fatalf("check after up: %v", err)
+	}
+
+	// 1. 回滚到 baseline：新表被删除，战绩表恢复为旧结构
+	if n, err := m.To(1); err != nil || n != len(m.migrations)-1 {
+		t.Fatalf("to 1 = %d, %v", n, err)
+	}
+	checkVersion(t, m, 1)
+	if r.db.Migrator().HasTable(&model.PlayerRating{}) || r.db.Migrator().HasTable(&model.RefreshToken{}) {
+		t.Fatal("tables created after baseline survived rollback")
+	}
+	if r.db.Migrator().HasColumn(&model.MatchHistory{}, "placement") {
+		t.Fatal("match_histories.placement survived rollback")
+	}
+	if !r.db.Migrator().HasTable(&model.User{}) {
+		t.Fatal("users table dropped by rollback")
+	}
+	if err := m.Check(); !errors.Is(err, ErrSchemaBehind) {
+		t.Fatalf("check at baseline = %v, want ErrSchemaBehind", err)
+	}
+
+	// 2. baseline 不可回滚
+	if _, err := m.Down(1); err == nil {
+		t.Fatal("baseline rolled back")
+	}
+	if _, err := m.To(0); err == nil {
+		t.Fatal("migrated to 0")
+	}
+	checkVersion(t, m, 1)
+
+	// 3. 再次执行
+	if n, err := m.Up(); err != nil || n != len(m.migrations)-1 {
+		t.Fatalf("second up = %d, %v", n, err)
+	}
+	checkVersion(t, m, latest)
+	if n, err := m.Up(); err != nil || n != 0 {
+		t.Fatalf("up when current = %d, %v", n, err)
+	}
+}
+
+func TestMigrateDownSteps(t *testing.T) {
+	_, m := connectTemp(t)
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := m.Down(2); err != nil || n != 2 {
+		t.Fatalf("down 2 = %d, %v", n, err)
+	}
+	checkVersion(t, m, m.migrations[len(m.migrations)-3].Version)
+	if _, err := m.To(99); err == nil {
+		t.Fatal("migrated to an unknown version")
+	}
+}
+
+func TestMigrateCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, r *Repo, m *Migrator)
+		want  error
+	}{
+		{
+			name:  "pending",
+			setup: func(t *testing.T, r *Repo, m *Migrator) { mustExec(t, func() error { _, err := m.To(3); return err }) },
+			want:  ErrSchemaBehind,
+		},
+		{
+			name: "unknown migration applied",
+			setup: func(t *testing.T, r *Repo, m *Migrator) {
+				mustExec(t, func() error { _, err := m.Up(); return err })
+				mustExec(t, func() error { return r.db.Create(&schemaMigration{Version: 99, Name: "from_the_future"}).Error })
+			},
+			want: ErrSchemaAhead,
+		},
+		{
+			name: "applied migration modified",
+			setup: func(t *testing.T, r *Repo, m *Migrator) {
+				mustExec(t, func() error { _, err := m.Up(); return err })
+				mustExec(t, func() error {
+					return r.db.Model(&schemaMigration{}).Where("version = ?", 3).Update("checksum", "edited").Error
+				})
+			},
+			want: ErrChecksumMismatch,
+		},
+		{
+			name: "applied before checksums were recorded",
+			setup: func(t *testing.T, r *Repo, m *Migrator) {
+				mustExec(t, func() error { _, err := m.Up(); return err })
+				mustExec(t, func() error {
+					return r.db.Model(&schemaMigration{}).Where("version = ?", 3).Update("checksum", "").Error
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, m := connectTemp(t)
+			tt.setup(t, r, m)
+			err := m.Check()
+			if tt.want == nil && err != nil || tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("check = %v, want %v", err, tt.want)
+			}
+			if tt.want == ErrSchemaAhead || tt.want == ErrChecksumMismatch {
+				if _, err := m.To(1); !errors.Is(err, tt.want) {
+					t.Fatalf("to 1 = %v, want %v", err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func mustExec(t *testing.T, f func() error) {
+	t.Helper()
+	if err := f(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 引入迁移前 AutoMigrate 建出的表结构
+type legacyUser struct {
+	gorm.Model
+	Username string `gorm:"type:varchar(32);uniqueIndex;not null"`
+	Password string `gorm:"type:varchar(100);not null"`
+}
+
+func (legacyUser) TableName() string { return "users" }
+
+type legacyMatchHistory struct {
+	gorm.Model
+	UserID    uint   `gorm:"index;not null"`
+	MatchID   string `gorm:"type:varchar(64);index"`
+	IsWinner  bool
+	Kills     int
+	Timestamp int64
+}
+
+func (legacyMatchHistory) TableName() string { return "match_histories" }
+
+func TestUpgradeLegacySchema(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "legacy.db")
+	legacy, err := Connect("sqlite", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := legacy.db.AutoMigrate(&legacyUser{}, &legacyMatchHistory{}); err != nil {
+		t.Fatal(err)
+	}
+	alice := legacyUser{Username: "alice", Password: "hash"}
+	bob := legacyUser{Username: "bob", Password: "hash"}
+	mustExec(t, func() error { return legacy.db.Create(&alice).Error })
+	mustExec(t, func() error { return legacy.db.Create(&bob).Error })
+	// 旧版消费者重复投递留下的重复战绩
+	for _, h := range []legacyMatchHistory{
+		{UserID: alice.ID, MatchID: "old", IsWinner: true, Kills: 2, Timestamp: 1},
+		{UserID: alice.ID, MatchID: "old", IsWinner: true, Kills: 2, Timestamp: 1},
+		{UserID: bob.ID, MatchID: "old", Timestamp: 1},
+	} {
+		mustExec(t, func() error { return legacy.db.Create(&h).Error })
+	}
+
+	r, err := Open("sqlite", dsn, true)
+	if err != nil {
+		t.Fatalf("open legacy database: %v", err)
+	}
+
+	// 1. 旧数据保留，重复战绩只剩一条，生涯统计已回填
+	if u, err := r.GetUserByUsername("alice"); err != nil || u.ID != alice.ID {
+		t.Fatalf("legacy user = %+v, %v", u, err)
+	}
+	history, err := r.GetHistory(alice.ID, 1, 10)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("legacy history = %+v, %v", history, err)
+	}
+	if stats, err := r.GetStats(alice.ID); err != nil || stats.Matches != 1 || stats.Kills != 2 {
+		t.Fatalf("backfilled stats = %+v, %v", stats, err)
+	}
+
+	// 2. 新结构的战绩可以写入，且按 (match_id, user_id) 幂等
+	histories := []*model.MatchHistory{
+		{UserID: alice.ID, MatchID: "new", Mode: "ffa", Season: "s1", MapID: 1, IsWinner: true, Placement: 1, Timestamp: 2},
+		{UserID: bob.ID, MatchID: "new", Mode: "ffa", Season: "s1", MapID: 1, Placement: 2, Deaths: 1, Timestamp: 2},
+	}
+	if err := r.SaveMatchResult(histories, nil, nil); err != nil {
+		t.Fatalf("save result on upgraded database: %v", err)
+	}
+	histories[0].ID, histories[1].ID = 0, 0
+	if err := r.SaveMatchResult(histories, nil, nil); !errors.Is(err, repository.ErrMatchAlreadySaved) {
+		t.Fatalf("duplicate save = %v, want ErrMatchAlreadySaved", err)
+	}
+}
@@ -0,0 +1,32 @@
+package sqlrepo
+
+import (
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+// goMigrations 无法用 SQL 表达的迁移（数据回填等），与 migrations/ 下的 SQL 迁移按版本号统一排序执行
+// 迁移一旦发布不能再修改（SQL 迁移由校验和保证），表结构变更需新增迁移，不要只改 model
+var goMigrations = []Migration{
+	{
+		// 引入生涯统计前已有战绩的库，回填 player_stats / player_map_stats（替代手动执行 rebuild-stats）
+		Version: 6,
+		Name:    "backfill_player_stats",
+		Up: func(tx *gorm.DB) error {
+			var stats, histories int64
+			if err := tx.Model(&model.PlayerStats{}).Count(&stats).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&model.MatchHistory{}).Count(&histories).Error; err != nil {
+				return err
+			}
+			if stats > 0 || histories == 0 {
+				return nil
+			}
+			return rebuildStats(tx)
+		},
+		// 只回填数据，回滚时无需处理
+		Down: func(tx *gorm.DB) error { return nil },
+	},
+}
@@ -0,0 +1,28 @@
+-- 引入迁移前 AutoMigrate 生成的表结构；已有库中表已存在时跳过，之后的变更都通过新的迁移完成
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `username` varchar(32) NOT NULL,
+  `password` varchar(100) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_users_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_users_username` (`username`)
+);
+
+CREATE TABLE IF NOT EXISTS `match_histories` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `match_id` varchar(64),
+  `is_winner` boolean,
+  `kills` bigint,
+  `timestamp` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_match_histories_deleted_at` (`deleted_at`),
+  INDEX `idx_match_histories_user_id` (`user_id`),
+  INDEX `idx_match_histories_match_id` (`match_id`)
+);
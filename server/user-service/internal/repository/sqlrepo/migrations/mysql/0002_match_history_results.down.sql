@@ -0,0 +1,8 @@
+-- 清理掉的重复战绩无法恢复
+DROP INDEX `idx_match_user` ON `match_histories`;
+DROP INDEX `idx_match_histories_season` ON `match_histories`;
+ALTER TABLE `match_histories` DROP COLUMN `deaths`;
+ALTER TABLE `match_histories` DROP COLUMN `placement`;
+ALTER TABLE `match_histories` DROP COLUMN `map_id`;
+ALTER TABLE `match_histories` DROP COLUMN `season`;
+ALTER TABLE `match_histories` DROP COLUMN `mode`;
@@ -0,0 +1,38 @@
+-- 段位分与段位分变化记录
+CREATE TABLE `player_ratings` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `mode` varchar(16) NOT NULL,
+  `season` varchar(16) NOT NULL,
+  `rating` double NOT NULL,
+  `deviation` double NOT NULL,
+  `volatility` double NOT NULL,
+  `matches` bigint,
+  `wins` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_player_ratings_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_rating_user_mode_season` (`user_id`, `mode`, `season`)
+);
+
+CREATE TABLE `rating_histories` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `mode` varchar(16),
+  `season` varchar(16),
+  `match_id` varchar(64),
+  `rating_before` double,
+  `rating_after` double,
+  `delta` double,
+  `placement` bigint,
+  `timestamp` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_rating_histories_deleted_at` (`deleted_at`),
+  INDEX `idx_rating_history_user` (`user_id`, `mode`, `season`),
+  INDEX `idx_rating_histories_match_id` (`match_id`)
+);
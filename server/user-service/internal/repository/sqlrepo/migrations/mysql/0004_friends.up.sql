@@ -0,0 +1,39 @@
+-- 好友申请、好友关系与拉黑
+CREATE TABLE `friend_requests` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `from_user_id` bigint unsigned NOT NULL,
+  `to_user_id` bigint unsigned NOT NULL,
+  `status` varchar(16) NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_friend_requests_deleted_at` (`deleted_at`),
+  INDEX `idx_friend_requests_from_user_id` (`from_user_id`),
+  INDEX `idx_friend_requests_to_user_id` (`to_user_id`),
+  INDEX `idx_friend_requests_status` (`status`)
+);
+
+CREATE TABLE `friendships` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `friend_id` bigint unsigned NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_friendships_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_friendship_pair` (`user_id`, `friend_id`)
+);
+
+CREATE TABLE `blocks` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `blocked_id` bigint unsigned NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_blocks_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_block_pair` (`user_id`, `blocked_id`)
+);
@@ -0,0 +1,31 @@
+-- 生涯统计（由 0006 回填已有战绩）
+CREATE TABLE `player_stats` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `matches` bigint,
+  `wins` bigint,
+  `kills` bigint,
+  `deaths` bigint,
+  `recent_form` varchar(16),
+  `last_match_at` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_player_stats_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_player_stats_user_id` (`user_id`)
+);
+
+CREATE TABLE `player_map_stats` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `map_id` bigint NOT NULL,
+  `matches` bigint,
+  `wins` bigint,
+  PRIMARY KEY (`id`),
+  INDEX `idx_player_map_stats_deleted_at` (`deleted_at`),
+  UNIQUE INDEX `idx_map_stats_user_map` (`user_id`, `map_id`)
+);
@@ -0,0 +1,26 @@
+-- 引入迁移前 AutoMigrate 生成的表结构；已有库中表已存在时跳过，之后的变更都通过新的迁移完成
+CREATE TABLE IF NOT EXISTS users (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  username varchar(32) NOT NULL,
+  password varchar(100) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+
+CREATE TABLE IF NOT EXISTS match_histories (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  match_id varchar(64),
+  is_winner numeric,
+  kills integer,
+  timestamp integer
+);
+CREATE INDEX IF NOT EXISTS idx_match_histories_deleted_at ON match_histories (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_match_histories_user_id ON match_histories (user_id);
+CREATE INDEX IF NOT EXISTS idx_match_histories_match_id ON match_histories (match_id);
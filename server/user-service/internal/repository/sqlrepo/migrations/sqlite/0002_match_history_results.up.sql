@@ -0,0 +1,12 @@
+-- 战绩补充模式、赛季、地图、名次与死亡数，并保证同一玩家在同一场比赛只有一条战绩
+-- 先清理重复投递产生的重复战绩（保留最早的一条），否则唯一索引无法创建
+ALTER TABLE match_histories ADD COLUMN mode varchar(16);
+ALTER TABLE match_histories ADD COLUMN season varchar(16);
+ALTER TABLE match_histories ADD COLUMN map_id integer;
+ALTER TABLE match_histories ADD COLUMN placement integer;
+ALTER TABLE match_histories ADD COLUMN deaths integer;
+CREATE INDEX idx_match_histories_season ON match_histories (season);
+DELETE FROM match_histories WHERE match_id IS NOT NULL AND id NOT IN (
+  SELECT id FROM (SELECT MIN(id) AS id FROM match_histories GROUP BY match_id, user_id) AS keep
+);
+CREATE UNIQUE INDEX idx_match_user ON match_histories (match_id, user_id);
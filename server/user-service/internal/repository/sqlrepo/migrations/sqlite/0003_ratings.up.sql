@@ -0,0 +1,36 @@
+-- 段位分与段位分变化记录
+CREATE TABLE player_ratings (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  mode varchar(16) NOT NULL,
+  season varchar(16) NOT NULL,
+  rating real NOT NULL,
+  deviation real NOT NULL,
+  volatility real NOT NULL,
+  matches integer,
+  wins integer
+);
+CREATE INDEX idx_player_ratings_deleted_at ON player_ratings (deleted_at);
+CREATE UNIQUE INDEX idx_rating_user_mode_season ON player_ratings (user_id, mode, season);
+
+CREATE TABLE rating_histories (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  mode varchar(16),
+  season varchar(16),
+  match_id varchar(64),
+  rating_before real,
+  rating_after real,
+  delta real,
+  placement integer,
+  timestamp integer
+);
+CREATE INDEX idx_rating_histories_deleted_at ON rating_histories (deleted_at);
+CREATE INDEX idx_rating_history_user ON rating_histories (user_id, mode, season);
+CREATE INDEX idx_rating_histories_match_id ON rating_histories (match_id);
@@ -0,0 +1,36 @@
+-- 好友申请、好友关系与拉黑
+CREATE TABLE friend_requests (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  from_user_id integer NOT NULL,
+  to_user_id integer NOT NULL,
+  status varchar(16) NOT NULL
+);
+CREATE INDEX idx_friend_requests_deleted_at ON friend_requests (deleted_at);
+CREATE INDEX idx_friend_requests_from_user_id ON friend_requests (from_user_id);
+CREATE INDEX idx_friend_requests_to_user_id ON friend_requests (to_user_id);
+CREATE INDEX idx_friend_requests_status ON friend_requests (status);
+
+CREATE TABLE friendships (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  friend_id integer NOT NULL
+);
+CREATE INDEX idx_friendships_deleted_at ON friendships (deleted_at);
+CREATE UNIQUE INDEX idx_friendship_pair ON friendships (user_id, friend_id);
+
+CREATE TABLE blocks (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  blocked_id integer NOT NULL
+);
+CREATE INDEX idx_blocks_deleted_at ON blocks (deleted_at);
+CREATE UNIQUE INDEX idx_block_pair ON blocks (user_id, blocked_id);
@@ -0,0 +1,2 @@
+DROP TABLE player_map_stats;
+DROP TABLE player_stats;
@@ -0,0 +1,29 @@
+-- 生涯统计（由 0006 回填已有战绩）
+CREATE TABLE player_stats (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  matches integer,
+  wins integer,
+  kills integer,
+  deaths integer,
+  recent_form varchar(16),
+  last_match_at integer
+);
+CREATE INDEX idx_player_stats_deleted_at ON player_stats (deleted_at);
+CREATE UNIQUE INDEX idx_player_stats_user_id ON player_stats (user_id);
+
+CREATE TABLE player_map_stats (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  map_id integer NOT NULL,
+  matches integer,
+  wins integer
+);
+CREATE INDEX idx_player_map_stats_deleted_at ON player_map_stats (deleted_at);
+CREATE UNIQUE INDEX idx_map_stats_user_map ON player_map_stats (user_id, map_id);
@@ -0,0 +1,16 @@
+-- refresh token（只保存哈希）
+CREATE TABLE refresh_tokens (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  created_at datetime,
+  updated_at datetime,
+  deleted_at datetime,
+  user_id integer NOT NULL,
+  token_hash char(64) NOT NULL,
+  family_id varchar(32) NOT NULL,
+  expires_at datetime NOT NULL,
+  revoked_at datetime
+);
+CREATE INDEX idx_refresh_tokens_deleted_at ON refresh_tokens (deleted_at);
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE UNIQUE INDEX idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
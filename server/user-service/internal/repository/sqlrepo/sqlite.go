@@ -0,0 +1,12 @@
+//go:build sqlite
+
+package sqlrepo
+
+import (
+	"gorm.io/driver/sqlite"
+)
+
+// 驱动依赖 cgo，以 -tags sqlite 编译时才注册
+func init() {
+	dialectors["sqlite"] = sqlite.Open
+}
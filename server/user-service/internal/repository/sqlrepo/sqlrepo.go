@@ -0,0 +1,108 @@
+// Package sqlrepo 基于 gorm 的 repository 实现，支持 MySQL 与 SQLite（需 -tags sqlite 编译）
+package sqlrepo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// dialectors 已编译进来的数据库驱动，sqlite 由 sqlite.go 在 -tags sqlite 时注册
+var dialectors = map[string]func(dsn string) gorm.Dialector{
+	"mysql": mysql.Open,
+}
+
+// Repo 实现 repository.Repository
+type Repo struct {
+	db     *gorm.DB
+	driver string
+}
+
+var _ repository.Repository = (*Repo)(nil)
+
+// Connect 只连接数据库，不校验表结构，供 migrate 子命令使用
+func Connect(driver, dsn string) (*Repo, error) {
+	open, ok := dialectors[driver]
+	if !ok {
+		if driver == "sqlite" {
+			return nil, fmt.Errorf("sqlite support is not compiled in, rebuild with -tags sqlite")
+		}
+		return nil, fmt.Errorf("unknown database driver %q", driver)
+	}
+
+	// TranslateError: 唯一索引冲突转换为 gorm.ErrDuplicatedKey，用于战绩幂等写入
+	db, err := gorm.Open(open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, fmt.Errorf("%s connect failed: %v", driver, err)
+	}
+	return &Repo{db: db, driver: driver}, nil
+}
+
+// Open 连接数据库并校验表结构版本
+// autoMigrate 为 true 时先执行待执行的迁移；数据库版本比程序新时拒绝启动
+func Open(driver, dsn string, autoMigrate bool) (*Repo, error) {
+	r, err := Connect(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	m, err := r.Migrator()
+	if err != nil {
+		return nil, err
+	}
+
+	if autoMigrate {
+		n, err := m.Up()
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			log.Printf("Applied %d database migration(s), schema version %d", n, m.Latest())
+		}
+	}
+	if err := m.Check(); err != nil {
+		if errors.Is(err, ErrSchemaBehind) {
+			return nil, fmt.Errorf("%v, run `migrate up` first", err)
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// DB 底层连接，供子命令等直接访问
+func (r *Repo) DB() *gorm.DB {
+	return r.db
+}
+
+// CreateUser 创建用户
+func (r *Repo) CreateUser(u *model.User) error {
+	return r.db.Create(u).Error
+}
+
+// GetUserByID 根据 ID 查询用户
+func (r *Repo) GetUserByID(uid uint) (*model.User, error) {
+	var user model.User
+	err := r.db.First(&user, uid).Error
+	return &user, err
+}
+
+// GetUserByUsername 根据用户名查询
+func (r *Repo) GetUserByUsername(username string) (*model.User, error) {
+	var user model.User
+	err := r.db.Where("username = ?", username).First(&user).Error
+	return &user, err
+}
+
+// GetUsersByIDs 批量查询用户
+func (r *Repo) GetUsersByIDs(uids []uint) ([]model.User, error) {
+	var users []model.User
+	if len(uids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", uids).Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,22 @@
+//go:build sqlite
+
+package sqlrepo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/internal/repository/repotest"
+)
+
+// 以 SQLite 运行：go test -tags sqlite ./internal/repository/...
+func TestRepo(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		r, err := Open("sqlite", filepath.Join(t.TempDir(), "test.db"), true)
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		return r
+	})
+}
@@ -0,0 +1,116 @@
+package sqlrepo
+
+import (
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// applyStats 按本局战绩增量更新生涯汇总与地图统计（在 SaveMatchResult 的事务中调用）
+func applyStats(tx *gorm.DB, histories []*model.MatchHistory) error {
+	for _, h := range histories {
+		// 1. 生涯汇总：行锁读取后更新，避免并发消费时丢失计数
+		var stats model.PlayerStats
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", h.UserID).
+			Attrs(model.PlayerStats{UserID: h.UserID}).
+			FirstOrCreate(&stats).Error
+		if err != nil {
+			return err
+		}
+
+		stats.Matches++
+		stats.Kills += h.Kills
+		stats.Deaths += h.Deaths
+		result := "L"
+		if h.IsWinner {
+			stats.Wins++
+			result = "W"
+		}
+		stats.RecentForm = repository.PushForm(stats.RecentForm, result)
+		if h.Timestamp > stats.LastMatchAt {
+			stats.LastMatchAt = h.Timestamp
+		}
+		if err := tx.Save(&stats).Error; err != nil {
+			return err
+		}
+
+		// 2. 地图统计
+		wins := 0
+		if h.IsWinner {
+			wins = 1
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "map_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"matches":    gorm.Expr("matches + 1"),
+				"wins":       gorm.Expr("wins + ?", wins),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}),
+		}).Create(&model.PlayerMapStats{UserID: h.UserID, MapID: h.MapID, Matches: 1, Wins: wins}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStats 查询玩家生涯汇总，没有对局记录时返回 repository.ErrNotFound
+func (r *Repo) GetStats(uid uint) (*model.PlayerStats, error) {
+	var stats model.PlayerStats
+	err := r.db.Where("user_id = ?", uid).First(&stats).Error
+	return &stats, err
+}
+
+// GetFavouriteMap 对局数最多的地图，对局数相同时取胜场多的
+func (r *Repo) GetFavouriteMap(uid uint) (int, error) {
+	var rows []model.PlayerMapStats
+	err := r.db.Where("user_id = ?", uid).
+		Order("matches desc, wins desc, map_id asc").
+		Limit(1).
+		Find(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	return rows[0].MapID, nil
+}
+
+// RebuildStats 从 match_histories 全量重建生涯汇总与地图统计（升级前已有战绩时使用）
+func (r *Repo) RebuildStats() error {
+	return r.db.Transaction(rebuildStats)
+}
+
+func rebuildStats(tx *gorm.DB) error {
+	// 1. 清空旧数据
+	if err := tx.Unscoped().Where("1 = 1").Delete(&model.PlayerStats{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("1 = 1").Delete(&model.PlayerMapStats{}).Error; err != nil {
+		return err
+	}
+
+	// 2. 按主键（即写入顺序）分批重放，保证最近战绩的顺序正确
+	var batch []*model.MatchHistory
+	return tx.Model(&model.MatchHistory{}).
+		FindInBatches(&batch, 500, func(btx *gorm.DB, _ int) error {
+			return applyStats(tx, batch)
+		}).Error
+}
+
+// SumStats 按玩家（或玩家与赛季）汇总战绩
+func (r *Repo) SumStats(seasonal bool) ([]repository.StatTotals, error) {
+	var rows []repository.StatTotals
+	q := r.db.Model(&model.MatchHistory{})
+	if seasonal {
+		q = q.Select("user_id, season, SUM(CASE WHEN is_winner THEN 1 ELSE 0 END) AS wins, SUM(kills) AS kills, SUM(deaths) AS deaths").
+			Where("season <> ''").
+			Group("user_id, season")
+	} else {
+		q = q.Select("user_id, SUM(CASE WHEN is_winner THEN 1 ELSE 0 END) AS wins, SUM(kills) AS kills, SUM(deaths) AS deaths").
+			Group("user_id")
+	}
+	err := q.Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,52 @@
+package sqlrepo
+
+import (
+	"time"
+
+	"mygame/server/user-service/internal/repository"
+	"mygame/server/user-service/model"
+
+	"gorm.io/gorm"
+)
+
+// CreateRefreshToken 保存新签发的 refresh token（只存哈希）
+func (r *Repo) CreateRefreshToken(t *model.RefreshToken) error {
+	return r.db.Create(t).Error
+}
+
+// GetRefreshToken 按哈希查询 refresh token
+func (r *Repo) GetRefreshToken(hash string) (*model.RefreshToken, error) {
+	var t model.RefreshToken
+	err := r.db.Where("token_hash = ?", hash).First(&t).Error
+	return &t, err
+}
+
+// RotateRefreshToken 吊销旧 token 并保存新 token；旧 token 已被并发轮换时返回 repository.ErrRefreshTokenReused
+func (r *Repo) RotateRefreshToken(old, next *model.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Update("revoked_at", time.Now())
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrRefreshTokenReused
+		}
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeRefreshFamily 吊销同一次登录轮换出的全部 refresh token
+func (r *Repo) RevokeRefreshFamily(familyID string) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserRefreshTokens 吊销用户的全部 refresh token（登出所有设备）
+func (r *Repo) RevokeUserRefreshTokens(uid uint) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", uid).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"mygame/pkg/jwks"
+	"mygame/server/user-service/pkg/config"
+	"os"
+	"path/filepath"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// 签名密钥：active_kid 对应的私钥用于签发，jwt.keys 中全部公钥用于验签。
+// 轮换时先把新 key 加入 keys，再切换 active_kid，旧 key 保留到其签发的 token 全部过期后移除。
+var (
+	signingKID    string
+	signingKey    crypto.PrivateKey
+	signingMethod jwt.SigningMethod
+
+	publicKeys = make(map[string]crypto.PublicKey)
+	publicJWKs []jwks.JWK
+)
+
+// InitKeys 加载 jwt.keys 中的密钥文件，算法由 key 类型决定（RSA -> RS256，Ed25519 -> EdDSA）
+func InitKeys() error {
+	cfg := config.AppConfig.JWT
+	if len(cfg.Keys) == 0 {
+		return fmt.Errorf("no jwt keys configured, generate one with `go run . gen-jwt-key <kid>`")
+	}
+
+	for _, k := range cfg.Keys {
+		var pub crypto.PublicKey
+		if k.PrivateKeyFile != "" {
+			priv, err := loadPrivateKey(k.PrivateKeyFile)
+			if err != nil {
+				return fmt.Errorf("load private key %s: %v", k.KID, err)
+			}
+			pub = priv.(interface{ Public() crypto.PublicKey }).Public()
+			if k.KID == cfg.ActiveKID {
+				signingKID = k.KID
+				signingKey = priv
+				signingMethod = methodFor(priv)
+			}
+		} else {
+			p, err := loadPublicKey(k.PublicKeyFile)
+			if err != nil {
+				return fmt.Errorf("load public key %s: %v", k.KID, err)
+			}
+			pub = p
+		}
+
+		jwk, err := jwks.FromPublicKey(k.KID, pub)
+		if err != nil {
+			return fmt.Errorf("key %s: %v", k.KID, err)
+		}
+		publicKeys[k.KID] = pub
+		publicJWKs = append(publicJWKs, jwk)
+	}
+
+	if signingKey == nil {
+		return fmt.Errorf("active_kid %q has no private key", cfg.ActiveKID)
+	}
+	return nil
+}
+
+// PublicJWKs 当前全部验签公钥
+func PublicJWKs() []jwks.JWK {
+	return publicJWKs
+}
+
+func methodFor(key crypto.PrivateKey) jwt.SigningMethod {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// keyfunc 按 kid 取公钥，并校验算法与 key 类型一致
+func keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	pub, ok := publicKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	case ed25519.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+	}
+	return pub, nil
+}
+
+func loadPrivateKey(path string) (crypto.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return jwt.ParseEdPrivateKeyFromPEM(data)
+}
+
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return jwt.ParseEdPublicKeyFromPEM(data)
+}
+
+// GenerateKeyFiles 生成新的签名密钥，写入 {dir}/{kid}.pem 与 {dir}/{kid}.pub.pem
+func GenerateKeyFiles(dir, kid, alg string) (string, error) {
+	var priv crypto.PrivateKey
+	var pub crypto.PublicKey
+	switch alg {
+	case "", "RS256":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return "", err
+		}
+		priv, pub = key, &key.PublicKey
+	case "EdDSA":
+		p, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", err
+		}
+		priv, pub = key, p
+	default:
+		return "", fmt.Errorf("unsupported algorithm %s, use RS256 or EdDSA", alg)
+	}
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return "", err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	privPath := filepath.Join(dir, kid+".pem")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600); err != nil {
+		return "", err
+	}
+	pubPath := filepath.Join(dir, kid+".pub.pem")
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o644); err != nil {
+		return "", err
+	}
+	return privPath, nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"math"
+
+	"mygame/server/user-service/pkg/config"
+)
+
+// Glicko-2 实现，参考 http://www.glicko.net/glicko/glicko2.pdf
+// 多人混战按名次拆成两两对局：名次高者记 1 分，相同名次记 0.5 分，否则记 0 分，
+// 每局比赛视为一个评分周期。
+
+const (
+	glickoScale   = 173.7178 // Glicko 与 Glicko-2 量纲间的换算系数
+	glickoEpsilon = 0.000001 // 波动率迭代的收敛阈值
+)
+
+// Rating 一名玩家在评分周期开始时的状态
+type Rating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// Participant 参与本局结算的玩家
+type Participant struct {
+	UID       int64
+	Placement int32
+	Before    Rating
+}
+
+// DefaultRating 新玩家的初始分
+func DefaultRating() Rating {
+	cfg := config.AppConfig.Rating
+	return Rating{
+		Rating:     orDefault(cfg.InitialRating, 1500),
+		Deviation:  orDefault(cfg.InitialDeviation, 350),
+		Volatility: orDefault(cfg.InitialVolatility, 0.06),
+	}
+}
+
+// CalculateRatings 根据名次计算每位参与者的新评分，返回值与 participants 一一对应
+func CalculateRatings(participants []Participant) []Rating {
+	tau := orDefault(config.AppConfig.Rating.Tau, 0.5)
+
+	results := make([]Rating, len(participants))
+	for i, p := range participants {
+		var opponents []opponent
+		for j, o := range participants {
+			if i == j {
+				continue
+			}
+			score := 0.5
+			if p.Placement < o.Placement {
+				score = 1
+			} else if p.Placement > o.Placement {
+				score = 0
+			}
+			opponents = append(opponents, opponent{rating: o.Before, score: score})
+		}
+		results[i] = updateRating(p.Before, opponents, tau)
+	}
+	return results
+}
+
+type opponent struct {
+	rating Rating
+	score  float64
+}
+
+// updateRating 单个玩家在一个评分周期内的 Glicko-2 更新
+func updateRating(r Rating, opponents []opponent, tau float64) Rating {
+	// Step 2: 转换到 Glicko-2 量纲
+	mu := (r.Rating - 1500) / glickoScale
+	phi := r.Deviation / glickoScale
+	sigma := r.Volatility
+
+	// 本周期没有对手：只增大 RD
+	if len(opponents) == 0 {
+		phiStar := math.Sqrt(phi*phi + sigma*sigma)
+		return Rating{Rating: r.Rating, Deviation: phiStar * glickoScale, Volatility: sigma}
+	}
+
+	// Step 3 & 4: 估计方差 v 与分数改进量 delta
+	var vInv, deltaSum float64
+	for _, o := range opponents {
+		muJ := (o.rating.Rating - 1500) / glickoScale
+		phiJ := o.rating.Deviation / glickoScale
+		g := 1 / math.Sqrt(1+3*phiJ*phiJ/(math.Pi*math.Pi))
+		e := 1 / (1 + math.Exp(-g*(mu-muJ)))
+		vInv += g * g * e * (1 - e)
+		deltaSum += g * (o.score - e)
+	}
+	v := 1 / vInv
+	delta := v * deltaSum
+
+	// Step 5: 迭代求解新的波动率
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		num := ex * (delta*delta - phi*phi - v - ex)
+		den := 2 * math.Pow(phi*phi+v+ex, 2)
+		return num/den - (x-a)/(tau*tau)
+	}
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > glickoEpsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	newSigma := math.Exp(A / 2)
+
+	// Step 6 & 7: 更新 RD 与评分
+	phiStar := math.Sqrt(phi*phi + newSigma*newSigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*deltaSum
+
+	// Step 8: 转换回 Glicko 量纲
+	return Rating{
+		Rating:     newMu*glickoScale + 1500,
+		Deviation:  newPhi * glickoScale,
+		Volatility: newSigma,
+	}
+}
+
+func orDefault(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// ResolveMode 为空时返回默认模式
+func ResolveMode(mode string) string {
+	if mode != "" {
+		return mode
+	}
+	if config.AppConfig.Rating.DefaultMode != "" {
+		return config.AppConfig.Rating.DefaultMode
+	}
+	return "ffa"
+}
+
+// ResolveSeason 为空时返回当前赛季
+func ResolveSeason(season string) string {
+	if season != "" {
+		return season
+	}
+	return config.AppConfig.Rating.Season
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"mygame/server/user-service/pkg/config"
+)
+
+func init() {
+	config.AppConfig = &config.Config{}
+}
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+// Glickman 论文中的算例：1500/200 的玩家赢 1400/30，输给 1550/100 与 1700/300
+func TestUpdateRatingPaperExample(t *testing.T) {
+	got := updateRating(Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}, []opponent{
+		{rating: Rating{Rating: 1400, Deviation: 30}, score: 1},
+		{rating: Rating{Rating: 1550, Deviation: 100}, score: 0},
+		{rating: Rating{Rating: 1700, Deviation: 300}, score: 0},
+	}, 0.5)
+
+	if !near(got.Rating, 1464.06, 0.01) || !near(got.Deviation, 151.52, 0.01) || !near(got.Volatility, 0.05999, 0.00001) {
+		t.Fatalf("got %+v, want 1464.06 / 151.52 / 0.05999", got)
+	}
+}
+
+func TestCalculateRatings(t *testing.T) {
+	def := DefaultRating()
+	strong := Rating{Rating: 1900, Deviation: 80, Volatility: 0.06}
+	weak := Rating{Rating: 1300, Deviation: 80, Volatility: 0.06}
+
+	tests := []struct {
+		name         string
+		participants []Participant
+		// check 校验新评分，返回值与 participants 一一对应
+		check func(t *testing.T, before []Participant, after []Rating)
+	}{
+		{
+			name:         "winner gains and loser drops by the same amount",
+			participants: []Participant{{UID: 1, Placement: 1, Before: def}, {UID: 2, Placement: 2, Before: def}},
+			check: func(t *testing.T, before []Participant, after []Rating) {
+				gain := after[0].Rating - def.Rating
+				loss := def.Rating - after[1].Rating
+				if gain <= 0 || !near(gain, loss, 1e-6) {
+					t.Errorf("gain %.4f, loss %.4f", gain, loss)
+				}
+			},
+		},
+		{
+			name:         "draw between equals changes nothing but deviation",
+			participants: []Participant{{UID: 1, Placement: 1, Before: def}, {UID: 2, Placement: 1, Before: def}},
+			check: func(t *testing.T, before []Participant, after []Rating) {
+				for i, r := range after {
+					if !near(r.Rating, def.Rating, 1e-6) {
+						t.Errorf("player %d rating %.4f, want unchanged", i, r.Rating)
+					}
+					if r.Deviation >= def.Deviation {
+						t.Errorf("player %d deviation %.4f did not shrink", i, r.Deviation)
+					}
+				}
+			},
+		},
+		{
+			name: "free-for-all ratings follow placement",
+			participants: []Participant{
+				{UID: 1, Placement: 3, Before: def},
+				{UID: 2, Placement: 1, Before: def},
+				{UID: 3, Placement: 4, Before: def},
+				{UID: 4, Placement: 2, Before: def},
+			},
+			check: func(t *testing.T, before []Participant, after []Rating) {
+				// 名次 1 > 2 > 3 > 4
+				order := []int{1, 3, 0, 2}
+				for i := 1; i < len(order); i++ {
+					if after[order[i-1]].Rating <= after[order[i]].Rating {
+						t.Errorf("placement %d rated %.2f, not above placement %d at %.2f",
+							i, after[order[i-1]].Rating, i+1, after[order[i]].Rating)
+					}
+				}
+			},
+		},
+		{
+			name:         "upset moves ratings more than the expected result",
+			participants: []Participant{{UID: 1, Placement: 2, Before: strong}, {UID: 2, Placement: 1, Before: weak}},
+			check: func(t *testing.T, before []Participant, after []Rating) {
+				expected := CalculateRatings([]Participant{{UID: 1, Placement: 1, Before: strong}, {UID: 2, Placement: 2, Before: weak}})
+				upsetGain := after[1].Rating - weak.Rating
+				expectedGain := expected[0].Rating - strong.Rating
+				if upsetGain <= expectedGain {
+					t.Errorf("upset gain %.2f not above expected gain %.2f", upsetGain, expectedGain)
+				}
+			},
+		},
+		{
+			name:         "no opponents only grows deviation",
+			participants: []Participant{{UID: 1, Placement: 1, Before: def}},
+			check: func(t *testing.T, before []Participant, after []Rating) {
+				if after[0].Rating != def.Rating || after[0].Deviation <= def.Deviation {
+					t.Errorf("got %+v", after[0])
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := CalculateRatings(tt.participants)
+			if len(after) != len(tt.participants) {
+				t.Fatalf("got %d ratings for %d participants", len(after), len(tt.participants))
+			}
+			tt.check(t, tt.participants, after)
+		})
+	}
+}
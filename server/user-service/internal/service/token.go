@@ -0,0 +1,125 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mygame/server/user-service/pkg/config"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+)
+
+// AccessClaims access token 中携带的信息
+type AccessClaims struct {
+	UID      uint
+	Username string
+	JTI      string // 吊销名单按 jti 记录
+	IssuedAt int64  // unix 毫秒，与用户级吊销时间比较
+	ExpireAt int64
+}
+
+// AccessTokenTTL access token 有效期（jwt.expire_duration）
+func AccessTokenTTL() time.Duration {
+	if d, err := time.ParseDuration(config.AppConfig.JWT.ExpireDuration); err == nil && d > 0 {
+		return d
+	}
+	return defaultAccessTTL
+}
+
+// RefreshTokenTTL refresh token 有效期（jwt.refresh_expire_duration）
+func RefreshTokenTTL() time.Duration {
+	if d, err := time.ParseDuration(config.AppConfig.JWT.RefreshExpireDuration); err == nil && d > 0 {
+		return d
+	}
+	return defaultRefreshTTL
+}
+
+// GenerateToken 生成短期 access token (JWT)，用 active_kid 对应的私钥签名
+func GenerateToken(uid uint, username string) (string, error) {
+	jti, err := NewID()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+
+	claims := jwt.MapClaims{
+		"uid":      uid,
+		"username": username,
+		"jti":      jti,
+		"iat":      now.Unix(),
+		"iat_ms":   now.UnixMilli(), // iat 只精确到秒，吊销判断用毫秒
+		"exp":      now.Add(AccessTokenTTL()).Unix(),
+	}
+
+	token := jwt.NewWithClaims(signingMethod, claims)
+	token.Header["kid"] = signingKID
+	return token.SignedString(signingKey)
+}
+
+// ParseToken 校验签名与过期时间，不检查吊销名单
+func ParseToken(tokenString string) (*AccessClaims, error) {
+	token, err := jwt.Parse(tokenString, keyfunc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	// JSON 解析出来的数字是 float64
+	uid, _ := claims["uid"].(float64)
+	username, _ := claims["username"].(string)
+	jti, _ := claims["jti"].(string)
+	iatMs, ok := claims["iat_ms"].(float64)
+	if !ok {
+		// 旧 token 没有 iat_ms，按所在秒的起点处理
+		iat, _ := claims["iat"].(float64)
+		iatMs = iat * 1000
+	}
+	exp, _ := claims["exp"].(float64)
+	if uid == 0 {
+		return nil, fmt.Errorf("invalid claims")
+	}
+	return &AccessClaims{
+		UID:      uint(uid),
+		Username: username,
+		JTI:      jti,
+		IssuedAt: int64(iatMs),
+		ExpireAt: int64(exp),
+	}, nil
+}
+
+// NewRefreshToken 生成随机 refresh token，返回明文（只下发给客户端）与哈希（落库）
+func NewRefreshToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	raw := base64.RawURLEncoding.EncodeToString(buf)
+	return raw, HashRefreshToken(raw), nil
+}
+
+// HashRefreshToken refresh token 本身是高熵随机串，SHA-256 即可，无需 bcrypt
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewID 生成随机 ID，用于 jti 与 refresh token 的 family
+func NewID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
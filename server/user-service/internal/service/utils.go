@@ -1,10 +1,6 @@
 package service
 
 import (
-	"mygame/server/user-service/pkg/config"
-	"time"
-
-	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -19,17 +15,3 @@ func CheckPasswordHash(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
-
-// GenerateToken 生成 JWT
-func GenerateToken(uid uint, username string) (string, error) {
-	duration, _ := time.ParseDuration(config.AppConfig.JWT.ExpireDuration)
-
-	claims := jwt.MapClaims{
-		"uid":      uid,
-		"username": username,
-		"exp":      time.Now().Add(duration).Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(config.AppConfig.JWT.Secret))
-}
@@ -1,43 +1,79 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"os"
 
-	pb "mygame/proto"
+	"mygame/server/user-service/app"
 	"mygame/server/user-service/internal/dao"
-	"mygame/server/user-service/internal/handler"
-	"mygame/server/user-service/internal/mq"
+	"mygame/server/user-service/internal/service"
 	"mygame/server/user-service/pkg/config"
-
-	"google.golang.org/grpc"
 )
 
 func main() {
 	// 1. 加载配置
 	config.InitConfig()
 
-	// 2. 初始化数据库
-	// 构建 DSN: username:password@tcp(host:port)/database?charset=utf8mb4&parseTime=True&loc=Local
-	// 目前配置只包含端口、用户名、密码和数据库名，使用本地地址 127.0.0.1
-	mysqlCfg := config.AppConfig.MySQL
-	dsn := fmt.Sprintf("%s:%s@tcp(127.0.0.1:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		mysqlCfg.Username, mysqlCfg.Password, mysqlCfg.Port, mysqlCfg.Database)
-	dao.InitMySQL(dsn)
+	// 子命令：生成 JWT 签名密钥
+	//   go run . gen-jwt-key <kid> [RS256|EdDSA]
+	if len(os.Args) > 2 && os.Args[1] == "gen-jwt-key" {
+		alg := ""
+		if len(os.Args) > 3 {
+			alg = os.Args[3]
+		}
+		path, err := service.GenerateKeyFiles("keys", os.Args[2], alg)
+		if err != nil {
+			log.Fatalf("Generate jwt key failed: %v", err)
+		}
+		log.Printf("JWT key written to %s, add it to jwt.keys in config.yaml", path)
+		return
+	}
+
+	// 子命令：数据库迁移
+	//   go run . migrate status | up | down [n] | to <version>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(config.AppConfig.Database, os.Args[2:]); err != nil {
+			log.Fatalf("Migrate failed: %v", err)
+		}
+		return
+	}
+
+	// 2. 加载签名密钥，初始化数据库与 Redis
+	repo, err := app.Init()
+	if err != nil {
+		log.Fatalf("User Service init failed: %v", err)
+	}
 
-	// 3. 初始化 MQ 并启动 Consumer
-	mq.InitMQ()
-	go mq.StartConsumer()
+	// 子命令：从数据库重建 Redis 排行榜（Redis 被清空后使用）
+	//   go run . rebuild-leaderboards
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-leaderboards" {
+		if err := dao.RebuildLeaderboards(context.Background(), repo); err != nil {
+			log.Fatalf("Rebuild leaderboards failed: %v", err)
+		}
+		log.Println("Leaderboards rebuilt successfully")
+		return
+	}
+
+	// 子命令：从 match_histories 重建生涯统计（升级前已有战绩时使用）
+	//   go run . rebuild-stats
+	if len(os.Args) > 1 && os.Args[1] == "rebuild-stats" {
+		if err := repo.RebuildStats(); err != nil {
+			log.Fatalf("Rebuild stats failed: %v", err)
+		}
+		log.Println("Player stats rebuilt successfully")
+		return
+	}
 
-	// 4. 启动 gRPC 服务
+	// 3. 启动 gRPC 服务（同时初始化 MQ 并启动 Consumer）
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", config.AppConfig.Server.Port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
-	pb.RegisterUserServiceServer(s, &handler.UserService{})
+	s := app.NewServer(repo)
 
 	log.Printf("User Service listening on :%d", config.AppConfig.Server.Port)
 	if err := s.Serve(lis); err != nil {
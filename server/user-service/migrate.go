@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"mygame/server/user-service/internal/repository/sqlrepo"
+	"mygame/server/user-service/pkg/config"
+)
+
+// runMigrate 处理 migrate 子命令
+//
+//	go run . migrate status      查看迁移状态
+//	go run . migrate up          执行全部待执行的迁移
+//	go run . migrate down [n]    回滚最近 n 个迁移，默认 1
+//	go run . migrate to <ver>    迁移到指定版本（baseline 不可回滚，最低为 1）
+func runMigrate(cfg config.DatabaseConfig, args []string) error {
+	if cfg.Driver == "memory" {
+		return fmt.Errorf("memory repository has no schema to migrate")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate status | up | down [n] | to <version>")
+	}
+	driver := cfg.Driver
+	if driver == "" {
+		driver = "mysql"
+	}
+
+	// 1. 只连接，不做版本校验，否则版本不一致时无法修复
+	repo, err := sqlrepo.Connect(driver, cfg.DSN)
+	if err != nil {
+		return err
+	}
+	m, err := repo.Migrator()
+	if err != nil {
+		return err
+	}
+
+	// 2. 执行子命令
+	var n int
+	switch args[0] {
+	case "status":
+		return printMigrateStatus(m)
+	case "up":
+		n, err = m.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		n, err = m.Down(steps)
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: migrate to <version>")
+		}
+		version, perr := strconv.ParseInt(args[1], 10, 64)
+		if perr != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		n, err = m.To(version)
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	log.Printf("%d migration(s) executed, schema version %d (latest %d)", n, version, m.Latest())
+	return nil
+}
+
+func printMigrateStatus(m *sqlrepo.Migrator) error {
+	status, err := m.Status()
+	if err != nil {
+		return err
+	}
+	for _, s := range status {
+		state := "pending"
+		if s.AppliedAt != nil {
+			state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if s.Unknown {
+			state += " (unknown to this binary)"
+		}
+		if s.Modified {
+			state += " (modified after being applied)"
+		}
+		fmt.Printf("%4d  %-32s %s\n", s.Version, s.Name, state)
+	}
+	return nil
+}
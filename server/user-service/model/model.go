@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -12,13 +14,109 @@ type User struct {
 
 type MatchHistory struct {
 	gorm.Model
-	UserID    uint   `gorm:"index;not null"`
-	MatchID   string `gorm:"type:varchar(64);index"` // 比赛UUID
+	UserID    uint   `gorm:"index;not null;uniqueIndex:idx_match_user,priority:2"`
+	MatchID   string `gorm:"type:varchar(64);index;uniqueIndex:idx_match_user,priority:1"` // 比赛UUID，与 UserID 唯一，保证重复投递只落库一次
+	Mode      string `gorm:"type:varchar(16)"`
+	Season    string `gorm:"type:varchar(16);index"`
+	MapID     int
 	IsWinner  bool
+	Placement int // 名次，1 为第一名
 	Kills     int
+	Deaths    int
 	Timestamp int64
 }
 
+// PlayerRating 玩家在某模式、某赛季下的段位分 (Glicko-2)
+type PlayerRating struct {
+	gorm.Model
+	UserID     uint    `gorm:"uniqueIndex:idx_rating_user_mode_season;not null"`
+	Mode       string  `gorm:"type:varchar(16);uniqueIndex:idx_rating_user_mode_season;not null"`
+	Season     string  `gorm:"type:varchar(16);uniqueIndex:idx_rating_user_mode_season;not null"`
+	Rating     float64 `gorm:"not null"`
+	Deviation  float64 `gorm:"not null"` // RD
+	Volatility float64 `gorm:"not null"`
+	Matches    int
+	Wins       int
+}
+
+// RatingHistory 每局比赛后的段位分变化记录
+type RatingHistory struct {
+	gorm.Model
+	UserID       uint   `gorm:"index:idx_rating_history_user;not null"`
+	Mode         string `gorm:"type:varchar(16);index:idx_rating_history_user"`
+	Season       string `gorm:"type:varchar(16);index:idx_rating_history_user"`
+	MatchID      string `gorm:"type:varchar(64);index"`
+	RatingBefore float64
+	RatingAfter  float64
+	Delta        float64
+	Placement    int
+	Timestamp    int64
+}
+
+// RecentFormSize 生涯统计中保留的最近对局数
+const RecentFormSize = 10
+
+// PlayerStats 玩家生涯汇总，MQ 消费时增量更新，避免每次扫描 match_histories
+type PlayerStats struct {
+	gorm.Model
+	UserID      uint `gorm:"uniqueIndex;not null"`
+	Matches     int
+	Wins        int
+	Kills       int
+	Deaths      int
+	RecentForm  string `gorm:"type:varchar(16)"` // 最近对局胜负 (W/L)，新的在前
+	LastMatchAt int64
+}
+
+// PlayerMapStats 玩家在每张地图上的对局数，用于计算常玩地图
+type PlayerMapStats struct {
+	gorm.Model
+	UserID  uint `gorm:"uniqueIndex:idx_map_stats_user_map;not null"`
+	MapID   int  `gorm:"uniqueIndex:idx_map_stats_user_map;not null"`
+	Matches int
+	Wins    int
+}
+
+// RefreshToken 只保存哈希；同一次登录轮换出的 token 属于同一个 Family，
+// 已轮换的 token 被再次使用时视为泄露，整条链一起吊销
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"index;not null"`
+	TokenHash string    `gorm:"type:char(64);uniqueIndex;not null"`
+	FamilyID  string    `gorm:"type:varchar(32);index;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+}
+
+// 好友申请状态
+const (
+	FriendRequestPending  = "pending"
+	FriendRequestAccepted = "accepted"
+	FriendRequestDeclined = "declined"
+)
+
+// FriendRequest 好友申请
+type FriendRequest struct {
+	gorm.Model
+	FromUserID uint   `gorm:"index;not null"`
+	ToUserID   uint   `gorm:"index;not null"`
+	Status     string `gorm:"type:varchar(16);index;not null"`
+}
+
+// Friendship 好友关系，双向各存一条
+type Friendship struct {
+	gorm.Model
+	UserID   uint `gorm:"uniqueIndex:idx_friendship_pair;not null"`
+	FriendID uint `gorm:"uniqueIndex:idx_friendship_pair;not null"`
+}
+
+// Block 拉黑关系，UserID 拉黑了 BlockedID
+type Block struct {
+	gorm.Model
+	UserID    uint `gorm:"uniqueIndex:idx_block_pair;not null"`
+	BlockedID uint `gorm:"uniqueIndex:idx_block_pair;not null"`
+}
+
 // 初始化 DB
 func InitDB(dsn string) (*gorm.DB, error) {
 	// 这里仅定义结构，实际连接逻辑在 main 或 dao 层
@@ -7,31 +7,64 @@ import (
 )
 
 type Config struct {
-	Server ServerConfig `mapstructure:"server"`
-	MySQL  MySQLConfig  `mapstructure:"mysql"`
-	JWT    JWTConfig    `mapstructure:"jwt"`
-	MQ     MQConfig     `mapstructure:"mq"`
+	Server   ServerConfig   `mapstructure:"server"`
+	Database DatabaseConfig `mapstructure:"database"`
+	JWT      JWTConfig      `mapstructure:"jwt"`
+	MQ       MQConfig       `mapstructure:"mq"`
+	Redis    RedisConfig    `mapstructure:"redis"`
+	Rating   RatingConfig   `mapstructure:"rating"`
+	Presence PresenceConfig `mapstructure:"presence"`
 }
 
 type ServerConfig struct {
 	Port int `mapstructure:"port"`
 }
 
-type MySQLConfig struct {
-	Port     int    `mapstructure:"port"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	Database string `mapstructure:"database"`
+type DatabaseConfig struct {
+	Driver      string `mapstructure:"driver"`       // mysql / sqlite / memory
+	DSN         string `mapstructure:"dsn"`          // 完整连接串，memory 时忽略
+	AutoMigrate bool   `mapstructure:"auto_migrate"` // 启动时执行待执行的迁移；关闭时需先运行 migrate up
 }
 
 type JWTConfig struct {
-	Secret         string `mapstructure:"secret"`
-	ExpireDuration string `mapstructure:"expire_duration"`
+	ActiveKID      string         `mapstructure:"active_kid"`      // 签发使用的 key
+	Keys           []JWTKeyConfig `mapstructure:"keys"`            // 全部验签 key，轮换期间新旧并存
+	ExpireDuration string         `mapstructure:"expire_duration"` // access token 有效期
+
+	RefreshExpireDuration string `mapstructure:"refresh_expire_duration"` // refresh token 有效期
+}
+
+type JWTKeyConfig struct {
+	KID            string `mapstructure:"kid"`
+	PrivateKeyFile string `mapstructure:"private_key_file"` // 签发 key 必须提供私钥
+	PublicKeyFile  string `mapstructure:"public_key_file"`  // 已退役、只用于验签的 key 可只提供公钥
 }
 
 type MQConfig struct {
-	Url       string `mapstructure:"url"`
-	QueueName string `mapstructure:"queue_name"`
+	Backend         string   `mapstructure:"backend"` // rabbitmq / redis / memory
+	Url             string   `mapstructure:"url"`
+	QueueName       string   `mapstructure:"queue_name"`
+	RetryDelays     []string `mapstructure:"retry_delays"`      // 每次重试前的延迟，次数用尽后进入死信队列
+	DeadLetterQueue string   `mapstructure:"dead_letter_queue"` // 为空时使用 <queue_name>.dlq
+}
+
+type RedisConfig struct {
+	Addr     string `mapstructure:"addr"`
+	Password string `mapstructure:"password"`
+	DB       int    `mapstructure:"db"`
+}
+
+type RatingConfig struct {
+	Season            string  `mapstructure:"season"`       // 当前赛季
+	DefaultMode       string  `mapstructure:"default_mode"` // 未指定模式时使用
+	InitialRating     float64 `mapstructure:"initial_rating"`
+	InitialDeviation  float64 `mapstructure:"initial_deviation"`
+	InitialVolatility float64 `mapstructure:"initial_volatility"`
+	Tau               float64 `mapstructure:"tau"` // 约束波动率变化的系统常数，通常 0.3~1.2
+}
+
+type PresenceConfig struct {
+	TTLSeconds int `mapstructure:"ttl_seconds"` // 在线状态过期时间，过期视为离线
 }
 
 var AppConfig *Config